@@ -0,0 +1,58 @@
+package notion
+
+import "context"
+
+// API is the interface of Client methods that map to Notion API endpoints. It
+// can be used to depend on an interface instead of *Client, e.g. to use the
+// in-memory fake of package notiontest in tests.
+//
+// Helpers that make multiple requests (e.g. CreatePageDeep, QueryDatabaseStream
+// and SyncDatabase) aren't part of the interface, as they're built on Client.
+type API interface {
+	// Databases
+	FindDatabaseByID(ctx context.Context, id string) (Database, error)
+	QueryDatabase(ctx context.Context, id string, query *DatabaseQuery) (DatabaseQueryResponse, error)
+	CreateDatabase(ctx context.Context, params CreateDatabaseParams) (Database, error)
+	UpdateDatabase(ctx context.Context, databaseID string, params UpdateDatabaseParams) (Database, error)
+
+	// Data sources
+	FindDataSourceByID(ctx context.Context, id string) (DataSource, error)
+	QueryDataSource(ctx context.Context, id string, query *DatabaseQuery) (DatabaseQueryResponse, error)
+	CreateDataSource(ctx context.Context, params CreateDataSourceParams) (DataSource, error)
+	UpdateDataSource(ctx context.Context, dataSourceID string, params UpdateDataSourceParams) (DataSource, error)
+
+	// Pages
+	FindPageByID(ctx context.Context, id string) (Page, error)
+	FindPageByIDWithOpts(ctx context.Context, id string, opts *FindPageByIDOpts) (Page, error)
+	CreatePage(ctx context.Context, params CreatePageParams) (Page, error)
+	UpdatePage(ctx context.Context, pageID string, params UpdatePageParams) (Page, error)
+	FindPagePropertyByID(ctx context.Context, pageID, propID string, query *PaginationQuery) (PagePropResponse, error)
+
+	// Blocks
+	FindBlockByID(ctx context.Context, blockID string) (Block, error)
+	FindBlockChildrenByID(ctx context.Context, blockID string, query *PaginationQuery) (BlockChildrenResponse, error)
+	AppendBlockChildren(ctx context.Context, blockID string, children []Block) (BlockChildrenResponse, error)
+	UpdateBlock(ctx context.Context, blockID string, block Block) (Block, error)
+	UpdateBlockWithParams(ctx context.Context, blockID string, params UpdateBlockParams) (Block, error)
+	DeleteBlock(ctx context.Context, blockID string) (Block, error)
+
+	// Users
+	FindUserByID(ctx context.Context, id string) (User, error)
+	FindCurrentUser(ctx context.Context) (User, error)
+	ListUsers(ctx context.Context, query *PaginationQuery) (ListUsersResponse, error)
+
+	// Search
+	Search(ctx context.Context, opts *SearchOpts) (SearchResponse, error)
+
+	// Comments
+	CreateComment(ctx context.Context, params CreateCommentParams) (Comment, error)
+	FindCommentsByBlockID(ctx context.Context, query FindCommentsByBlockIDQuery) (FindCommentsResponse, error)
+	FindCommentByID(ctx context.Context, id string) (Comment, error)
+
+	// File uploads
+	CreateFileUpload(ctx context.Context, file FileParam) (FileUpload, error)
+	SendFileUpload(ctx context.Context, fileUploadID string, file FileParam) (FileUpload, error)
+	FindFileUploadByID(ctx context.Context, id string) (FileUpload, error)
+}
+
+var _ API = (*Client)(nil)
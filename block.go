@@ -4,14 +4,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"time"
 )
 
 // ErrUnknownBlockType is used when encountering an unknown block type.
+//
+// Deprecated: Blocks of unknown types are decoded as UnsupportedBlock, or as
+// the type registered with RegisterBlockType.
 var ErrUnknownBlockType = errors.New("unknown block type")
 
 // Block represents content on the Notion platform.
 // See: https://developers.notion.com/reference/block
+//
+// The block types of this library also implement `interface{ InTrash() bool }`
+// and `interface{ Raw() json.RawMessage }`. These aren't part of Block, so
+// existing implementations (e.g. custom block types, see RegisterBlockType)
+// don't need them.
 type Block interface {
 	ID() string
 	Parent() Parent
@@ -34,6 +43,7 @@ type blockDTO struct {
 	LastEditedBy   *BaseUser  `json:"last_edited_by,omitempty"`
 	HasChildren    bool       `json:"has_children,omitempty"`
 	Archived       *bool      `json:"archived,omitempty"`
+	InTrash        *bool      `json:"in_trash,omitempty"`
 
 	Paragraph        *ParagraphBlock        `json:"paragraph,omitempty"`
 	Heading1         *Heading1Block         `json:"heading_1,omitempty"`
@@ -68,6 +78,27 @@ type blockDTO struct {
 	SyncedBlock      *SyncedBlock           `json:"synced_block,omitempty"`
 	Template         *TemplateBlock         `json:"template,omitempty"`
 	Unsupported      *UnsupportedBlock      `json:"unsupported,omitempty"`
+
+	// raw contains the JSON object, used for decoding registered block types.
+	raw json.RawMessage
+	// keepRaw is set when the client was created with WithRawCapture, so raw
+	// is retained in the decoded block.
+	keepRaw bool
+}
+
+func (dto *blockDTO) UnmarshalJSON(b []byte) error {
+	type blockDTOAlias blockDTO
+
+	var alias blockDTOAlias
+
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return err
+	}
+
+	*dto = blockDTO(alias)
+	dto.raw = append(json.RawMessage(nil), b...)
+
+	return nil
 }
 
 type baseBlock struct {
@@ -79,6 +110,8 @@ type baseBlock struct {
 	lastEditedBy   BaseUser
 	hasChildren    bool
 	archived       bool
+	inTrash        bool
+	raw            json.RawMessage
 }
 
 // ID returns the identifier (UUIDv4) for the block.
@@ -110,6 +143,26 @@ func (b baseBlock) Archived() bool {
 	return b.archived
 }
 
+// InTrash returns true if the block was moved to the trash.
+func (b baseBlock) InTrash() bool {
+	return b.inTrash
+}
+
+// Raw returns the JSON object of the block, as returned by the API. It's nil
+// unless the block was decoded by a client created with WithRawCapture.
+func (b baseBlock) Raw() json.RawMessage {
+	return b.raw
+}
+
+func (b *baseBlock) setRaw(raw json.RawMessage) {
+	b.raw = raw
+}
+
+// setBaseBlock is used for setting common fields of registered block types.
+func (b *baseBlock) setBaseBlock(base baseBlock) {
+	*b = base
+}
+
 func (b baseBlock) Parent() Parent {
 	return b.parent
 }
@@ -622,6 +675,10 @@ func (b ColumnListBlock) MarshalJSON() ([]byte, error) {
 type ColumnBlock struct {
 	baseBlock
 
+	// WidthRatio is the width of the column, as a ratio (between 0 and 1) of the
+	// width of the column list. Optional; columns have equal widths by default.
+	WidthRatio *float64 `json:"width_ratio,omitempty"`
+
 	Children []Block `json:"children,omitempty"`
 }
 
@@ -817,12 +874,29 @@ func (b BreadcrumbBlock) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnsupportedBlock is a block that isn't supported by the Notion API (type
+// `unsupported`), or a block of a type that's unknown to this library, e.g. a
+// newly launched block type.
 type UnsupportedBlock struct {
 	baseBlock
+
+	// Type is the block type, as returned by the API.
+	Type BlockType `json:"-"`
+
+	// Unknown contains the raw JSON value of a block type that's unknown to this
+	// library. It's keyed by the value of `Type` when encoding to JSON, so data
+	// isn't lost on round trips.
+	Unknown json.RawMessage `json:"-"`
 }
 
 // MarshalJSON implements json.Marshaler.
 func (b UnsupportedBlock) MarshalJSON() ([]byte, error) {
+	if b.Type != "" && b.Type != BlockTypeUnsupported && b.Unknown != nil {
+		return json.Marshal(map[string]json.RawMessage{
+			string(b.Type): b.Unknown,
+		})
+	}
+
 	type (
 		blockAlias UnsupportedBlock
 		dto        struct {
@@ -873,6 +947,10 @@ const (
 	BlockTypeUnsupported      BlockType = "unsupported"
 )
 
+// MaxPageSize is the maximum page size allowed by the Notion API, e.g. for
+// PaginationQuery and DatabaseQuery.
+const MaxPageSize = 100
+
 type PaginationQuery struct {
 	StartCursor string
 	PageSize    int
@@ -905,10 +983,9 @@ func (resp *BlockChildrenResponse) UnmarshalJSON(b []byte) error {
 	for i, blockDTO := range dto.Results {
 		block, err := blockDTO.Block()
 		if err != nil {
-			// Any error (even `ErrUnknownBlockType`) is explicitly returned.
-			// We don't silently drop blocks with an unknown/unmapped type,
-			// because this could lead to surprises/unexpected list behaviour
-			// for users.
+			// Any error is explicitly returned. We don't silently drop blocks
+			// that can't be parsed, because this could lead to
+			// surprises/unexpected list behaviour for users.
 			return fmt.Errorf("notion: failed to parse block (id: %q, type: %q): %w", blockDTO.ID, blockDTO.Type, err)
 		}
 		resp.Results[i] = block
@@ -923,6 +1000,10 @@ func (dto blockDTO) Block() (Block, error) {
 		hasChildren: dto.HasChildren,
 	}
 
+	if dto.keepRaw {
+		baseBlock.raw = dto.raw
+	}
+
 	if dto.Parent != nil {
 		baseBlock.parent = *dto.Parent
 	}
@@ -947,6 +1028,10 @@ func (dto blockDTO) Block() (Block, error) {
 		baseBlock.archived = *dto.Archived
 	}
 
+	if dto.InTrash != nil {
+		baseBlock.inTrash = *dto.InTrash
+	}
+
 	switch dto.Type {
 	case BlockTypeParagraph:
 		dto.Paragraph.baseBlock = baseBlock
@@ -1046,10 +1131,151 @@ func (dto blockDTO) Block() (Block, error) {
 		return dto.Template, nil
 	case BlockTypeUnsupported:
 		dto.Unsupported.baseBlock = baseBlock
+		dto.Unsupported.Type = BlockTypeUnsupported
 		return dto.Unsupported, nil
 	default:
 		// When this case is selected, the block type is supported in the Notion
 		// API, but unknown in this library.
-		return nil, ErrUnknownBlockType
+		if factory, ok := registeredBlockType(dto.Type); ok {
+			return dto.registeredBlock(factory, baseBlock)
+		}
+		return dto.unknownBlock(baseBlock)
+	}
+}
+
+// unknownBlock returns an UnsupportedBlock for a block type that's unknown to
+// this library, with the raw JSON value of the type.
+func (dto blockDTO) unknownBlock(base baseBlock) (Block, error) {
+	block := &UnsupportedBlock{baseBlock: base, Type: dto.Type}
+
+	if dto.raw == nil {
+		return block, nil
 	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(dto.raw, &fields); err != nil {
+		return nil, err
+	}
+
+	block.Unknown = fields[string(dto.Type)]
+
+	return block, nil
+}
+
+// blockPtr returns a pointer to the underlying block struct. Blocks decoded from
+// API responses are pointers already, but blocks created by users can be either.
+func blockPtr(block Block) Block {
+	v := reflect.ValueOf(block)
+	if v.Kind() == reflect.Ptr {
+		return block
+	}
+
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+
+	return ptr.Interface().(Block)
+}
+
+// cloneBlock returns a pointer to a shallow copy of a block.
+func cloneBlock(block Block) Block {
+	v := reflect.ValueOf(blockPtr(block)).Elem()
+
+	clone := reflect.New(v.Type())
+	clone.Elem().Set(v)
+
+	return clone.Interface().(Block)
+}
+
+// blockChildren returns the (populated) children of a block, if its type
+// supports children.
+func blockChildren(block Block) []Block {
+	switch b := blockPtr(block).(type) {
+	case *ParagraphBlock:
+		return b.Children
+	case *BulletedListItemBlock:
+		return b.Children
+	case *NumberedListItemBlock:
+		return b.Children
+	case *QuoteBlock:
+		return b.Children
+	case *ToggleBlock:
+		return b.Children
+	case *TemplateBlock:
+		return b.Children
+	case *Heading1Block:
+		return b.Children
+	case *Heading2Block:
+		return b.Children
+	case *Heading3Block:
+		return b.Children
+	case *ToDoBlock:
+		return b.Children
+	case *CalloutBlock:
+		return b.Children
+	case *CodeBlock:
+		return b.Children
+	case *ColumnListBlock:
+		children := make([]Block, len(b.Children))
+		for i := range b.Children {
+			children[i] = &b.Children[i]
+		}
+		return children
+	case *ColumnBlock:
+		return b.Children
+	case *TableBlock:
+		return b.Children
+	case *SyncedBlock:
+		return b.Children
+	default:
+		return nil
+	}
+}
+
+// setBlockChildren sets the children of a block (which must be a pointer), if
+// its type supports children. It returns false otherwise.
+func setBlockChildren(block Block, children []Block) bool {
+	switch b := block.(type) {
+	case *ParagraphBlock:
+		b.Children = children
+	case *BulletedListItemBlock:
+		b.Children = children
+	case *NumberedListItemBlock:
+		b.Children = children
+	case *QuoteBlock:
+		b.Children = children
+	case *ToggleBlock:
+		b.Children = children
+	case *TemplateBlock:
+		b.Children = children
+	case *Heading1Block:
+		b.Children = children
+	case *Heading2Block:
+		b.Children = children
+	case *Heading3Block:
+		b.Children = children
+	case *ToDoBlock:
+		b.Children = children
+	case *CalloutBlock:
+		b.Children = children
+	case *CodeBlock:
+		b.Children = children
+	case *ColumnListBlock:
+		b.Children = make([]ColumnBlock, 0, len(children))
+		for _, child := range children {
+			if column, ok := blockPtr(child).(*ColumnBlock); ok {
+				b.Children = append(b.Children, *column)
+			}
+		}
+	case *ColumnBlock:
+		b.Children = children
+	case *TableBlock:
+		b.Children = children
+	case *SyncedBlock:
+		b.Children = children
+	default:
+		return false
+	}
+
+	return true
 }
@@ -0,0 +1,74 @@
+package notion
+
+import (
+	"context"
+	"errors"
+)
+
+// maxAppendChildren is the maximum number of blocks in a request body for
+// appending block children.
+// See: https://developers.notion.com/reference/patch-block-children
+const maxAppendChildren = 100
+
+// AppendBlockChildrenOpts are the options for AppendBlockChildrenAll.
+type AppendBlockChildrenOpts struct {
+	// After is the ID of an existing child block, after which the children are
+	// appended. When empty, children are appended to the end of the block.
+	After string
+}
+
+// AppendBlockChildrenAll appends any number of blocks to an existing block, by
+// splitting them into batches of at most 100 blocks, appended one request at a
+// time so their order is preserved. The results of all batches are aggregated
+// into a single response.
+//
+// If a request fails, blocks of earlier batches aren't removed; these are
+// returned along with the error. See ErrCanceled for cancellation.
+func (c *Client) AppendBlockChildrenAll(ctx context.Context, blockID string, children []Block, opts *AppendBlockChildrenOpts) (BlockChildrenResponse, error) {
+	var (
+		result BlockChildrenResponse
+		after  string
+	)
+
+	if opts != nil {
+		after = opts.After
+	}
+
+	for start := 0; ; start += maxAppendChildren {
+		end := start + maxAppendChildren
+		if end > len(children) {
+			end = len(children)
+		}
+		batch := children[start:end]
+
+		if err := canceled(ctx, start, len(children)-start); err != nil {
+			return result, err
+		}
+
+		resp, err := c.appendBlockChildren(ctx, blockID, batch, after)
+		if err != nil {
+			if err := canceled(ctx, start, len(children)-start); err != nil {
+				return result, err
+			}
+			return result, err
+		}
+
+		created := resp.Results
+		// Like in AppendBlockChildrenDeep, only keep the newly created blocks.
+		if after == "" && len(created) > len(batch) {
+			created = created[len(created)-len(batch):]
+		}
+		result.Results = append(result.Results, created...)
+
+		if after != "" && end < len(children) {
+			if len(created) == 0 {
+				return result, errors.New("notion: failed to append block children: no blocks in response")
+			}
+			after = created[len(created)-1].ID()
+		}
+
+		if end == len(children) {
+			return result, nil
+		}
+	}
+}
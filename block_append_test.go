@@ -0,0 +1,188 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+type appendRequest struct {
+	after string
+	texts []string
+}
+
+// appendTransport responds to append requests with paragraph blocks, using the
+// text of each appended paragraph as its ID.
+func appendTransport(t *testing.T, requests *[]appendRequest, failAt int) *mockRoundtripper {
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		var body struct {
+			Children []struct {
+				Paragraph struct {
+					RichText []struct {
+						Text struct {
+							Content string `json:"content"`
+						} `json:"text"`
+					} `json:"rich_text"`
+				} `json:"paragraph"`
+			} `json:"children"`
+			After *string `json:"after"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		req := appendRequest{}
+		if body.After != nil {
+			req.after = *body.After
+		}
+
+		results := make([]string, len(body.Children))
+		for i, child := range body.Children {
+			text := child.Paragraph.RichText[0].Text.Content
+			req.texts = append(req.texts, text)
+			results[i] = paragraphJSON(text, false, text)
+		}
+		*requests = append(*requests, req)
+
+		if len(*requests) == failAt {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     http.StatusText(http.StatusBadRequest),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"object": "error", "status": 400, "code": "validation_error", "message": "foobar"}`)),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"object": "list", "results": [` + strings.Join(results, ",") + `], "next_cursor": null, "has_more": false}`,
+			)),
+		}, nil
+	}}
+}
+
+func appendParagraphs(n int) ([]notion.Block, []string) {
+	blocks := make([]notion.Block, n)
+	texts := make([]string, n)
+	for i := range blocks {
+		texts[i] = fmt.Sprintf("b%v", i)
+		blocks[i] = notion.ParagraphBlock{
+			RichText: []notion.RichText{{Text: &notion.Text{Content: texts[i]}}},
+		}
+	}
+	return blocks, texts
+}
+
+func TestAppendBlockChildrenAll(t *testing.T) {
+	t.Parallel()
+
+	blocks, texts := appendParagraphs(250)
+
+	tests := []struct {
+		name        string
+		opts        *notion.AppendBlockChildrenOpts
+		failAt      int
+		expRequests []appendRequest
+		expIDs      []string
+		expError    string
+	}{
+		{
+			name: "without after",
+			opts: nil,
+			expRequests: []appendRequest{
+				{texts: texts[:100]},
+				{texts: texts[100:200]},
+				{texts: texts[200:]},
+			},
+			expIDs: texts,
+		},
+		{
+			name: "with after",
+			opts: &notion.AppendBlockChildrenOpts{After: "existing"},
+			expRequests: []appendRequest{
+				{after: "existing", texts: texts[:100]},
+				{after: "b99", texts: texts[100:200]},
+				{after: "b199", texts: texts[200:]},
+			},
+			expIDs: texts,
+		},
+		{
+			name:   "failed batch",
+			opts:   nil,
+			failAt: 2,
+			expRequests: []appendRequest{
+				{texts: texts[:100]},
+				{texts: texts[100:200]},
+			},
+			expIDs:   texts[:100],
+			expError: "notion: failed to append block children: foobar (code: validation_error, status: 400)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []appendRequest
+
+			httpClient := &http.Client{Transport: appendTransport(t, &requests, tt.failAt)}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			resp, err := client.AppendBlockChildrenAll(context.Background(), "root", blocks, tt.opts)
+			if tt.expError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != "" && (err == nil || err.Error() != tt.expError) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			if diff := cmp.Diff(tt.expRequests, requests, cmp.AllowUnexported(appendRequest{})); diff != "" {
+				t.Fatalf("requests not equal (-exp, +got):\n%v", diff)
+			}
+
+			var ids []string
+			for _, block := range resp.Results {
+				ids = append(ids, block.ID())
+			}
+			if diff := cmp.Diff(tt.expIDs, ids); diff != "" {
+				t.Fatalf("block IDs not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestAppendBlockChildrenChunked(t *testing.T) {
+	t.Parallel()
+
+	blocks, texts := appendParagraphs(101)
+
+	var requests []appendRequest
+
+	httpClient := &http.Client{Transport: appendTransport(t, &requests, 0)}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	resp, err := client.AppendBlockChildren(context.Background(), "root", blocks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expRequests := []appendRequest{
+		{texts: texts[:100]},
+		{texts: texts[100:]},
+	}
+	if diff := cmp.Diff(expRequests, requests, cmp.AllowUnexported(appendRequest{})); diff != "" {
+		t.Fatalf("requests not equal (-exp, +got):\n%v", diff)
+	}
+	if len(resp.Results) != 101 {
+		t.Fatalf("expected 101 results, got %v", len(resp.Results))
+	}
+}
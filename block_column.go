@@ -0,0 +1,60 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// minColumns is the minimum number of columns of a column list.
+const minColumns = 2
+
+// NewColumn returns a column block with child blocks, for use with NewColumns.
+func NewColumn(children ...Block) ColumnBlock {
+	return ColumnBlock{Children: children}
+}
+
+// NewColumnWithRatio returns a column block with a width ratio (between 0 and
+// 1) and child blocks, for use with NewColumns.
+func NewColumnWithRatio(widthRatio float64, children ...Block) ColumnBlock {
+	return ColumnBlock{WidthRatio: &widthRatio, Children: children}
+}
+
+// NewColumns returns a column list block, for use in requests (e.g. with
+// AppendBlockChildren). It validates the columns like the API does: a column
+// list needs at least 2 columns, and every column needs at least one child
+// block. Width ratios are optional, but if set on a column, they must be set
+// on all columns, and add up to 1.
+func NewColumns(columns ...ColumnBlock) (ColumnListBlock, error) {
+	if len(columns) < minColumns {
+		return ColumnListBlock{}, fmt.Errorf("notion: column list must have at least %v columns, got %v", minColumns, len(columns))
+	}
+
+	var (
+		withRatio int
+		sum       float64
+	)
+
+	for i, column := range columns {
+		if len(column.Children) == 0 {
+			return ColumnListBlock{}, fmt.Errorf("notion: column %v must have at least one child block", i)
+		}
+		if column.WidthRatio == nil {
+			continue
+		}
+		if ratio := *column.WidthRatio; ratio <= 0 || ratio > 1 {
+			return ColumnListBlock{}, fmt.Errorf("notion: column %v has invalid width ratio %v (expected between 0 and 1)", i, ratio)
+		}
+		withRatio++
+		sum += *column.WidthRatio
+	}
+
+	if withRatio > 0 && withRatio != len(columns) {
+		return ColumnListBlock{}, errors.New("notion: width ratio must be set on all columns, or none")
+	}
+	if withRatio > 0 && math.Abs(sum-1) > 0.001 {
+		return ColumnListBlock{}, fmt.Errorf("notion: column width ratios must add up to 1, got %v", sum)
+	}
+
+	return ColumnListBlock{Children: columns}, nil
+}
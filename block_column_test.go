@@ -0,0 +1,102 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewColumns(t *testing.T) {
+	t.Parallel()
+
+	paragraph := notion.ParagraphBlock{RichText: []notion.RichText{notion.NewRichText("Foobar")}}
+
+	tests := []struct {
+		name     string
+		columns  []notion.ColumnBlock
+		expJSON  string
+		expError string
+	}{
+		{
+			name:    "equal widths",
+			columns: []notion.ColumnBlock{notion.NewColumn(paragraph), notion.NewColumn(paragraph)},
+			expJSON: `{"column_list":{"children":[` +
+				`{"column":{"children":[{"paragraph":{"rich_text":[{"type":"text","text":{"content":"Foobar"}}]}}]}},` +
+				`{"column":{"children":[{"paragraph":{"rich_text":[{"type":"text","text":{"content":"Foobar"}}]}}]}}` +
+				`]}}`,
+		},
+		{
+			name: "width ratios",
+			columns: []notion.ColumnBlock{
+				notion.NewColumnWithRatio(0.25, notion.DividerBlock{}),
+				notion.NewColumnWithRatio(0.75, notion.DividerBlock{}),
+			},
+			expJSON: `{"column_list":{"children":[` +
+				`{"column":{"width_ratio":0.25,"children":[{"divider":{}}]}},` +
+				`{"column":{"width_ratio":0.75,"children":[{"divider":{}}]}}` +
+				`]}}`,
+		},
+		{
+			name:     "single column",
+			columns:  []notion.ColumnBlock{notion.NewColumn(paragraph)},
+			expError: "notion: column list must have at least 2 columns, got 1",
+		},
+		{
+			name:     "empty column",
+			columns:  []notion.ColumnBlock{notion.NewColumn(paragraph), notion.NewColumn()},
+			expError: "notion: column 1 must have at least one child block",
+		},
+		{
+			name: "partial width ratios",
+			columns: []notion.ColumnBlock{
+				notion.NewColumnWithRatio(0.5, paragraph),
+				notion.NewColumn(paragraph),
+			},
+			expError: "notion: width ratio must be set on all columns, or none",
+		},
+		{
+			name: "invalid width ratio",
+			columns: []notion.ColumnBlock{
+				notion.NewColumnWithRatio(0, paragraph),
+				notion.NewColumnWithRatio(1, paragraph),
+			},
+			expError: "notion: column 0 has invalid width ratio 0 (expected between 0 and 1)",
+		},
+		{
+			name: "width ratios don't add up to 1",
+			columns: []notion.ColumnBlock{
+				notion.NewColumnWithRatio(0.5, paragraph),
+				notion.NewColumnWithRatio(0.6, paragraph),
+			},
+			expError: "notion: column width ratios must add up to 1, got 1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			columnList, err := notion.NewColumns(tt.columns...)
+			if tt.expError != "" {
+				if err == nil || err.Error() != tt.expError {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			b, err := json.Marshal(columnList)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expJSON, string(b)); diff != "" {
+				t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
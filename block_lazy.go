@@ -0,0 +1,80 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ChildrenLoader lazily loads block children, so tree walking code can fetch
+// children on first access, instead of fetching a full block tree upfront (see
+// FindBlockChildrenRecursive). Loaded children are cached by block ID. It's
+// safe for concurrent use.
+//
+// Example:
+//
+//	loader := notion.NewChildrenLoader(client)
+//	blocks, err := loader.ChildrenByID(ctx, pageID)
+//	for _, block := range blocks {
+//		children, err := loader.Children(ctx, block)
+//		// ...
+//	}
+type ChildrenLoader struct {
+	client *Client
+
+	mu       sync.Mutex
+	children map[string][]Block
+}
+
+// NewChildrenLoader returns a new ChildrenLoader.
+func NewChildrenLoader(client *Client) *ChildrenLoader {
+	return &ChildrenLoader{
+		client:   client,
+		children: make(map[string][]Block),
+	}
+}
+
+// Children returns the children of a block. No request is made for blocks
+// without children (see `Block.HasChildren`), or for blocks with populated
+// children. When the block is a pointer (as blocks returned by the client are)
+// and its type has a `Children` field, the field is populated, so renderers
+// like ToMarkdown include the loaded children.
+func (l *ChildrenLoader) Children(ctx context.Context, block Block) ([]Block, error) {
+	if children := blockChildren(block); len(children) > 0 {
+		return children, nil
+	}
+	if !block.HasChildren() {
+		return nil, nil
+	}
+
+	children, err := l.ChildrenByID(ctx, block.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	setBlockChildren(block, children)
+
+	return children, nil
+}
+
+// ChildrenByID returns the children of the block (or page) with ID `blockID`,
+// following pagination.
+func (l *ChildrenLoader) ChildrenByID(ctx context.Context, blockID string) ([]Block, error) {
+	l.mu.Lock()
+	children, ok := l.children[blockID]
+	l.mu.Unlock()
+	if ok {
+		return children, nil
+	}
+
+	children, err := l.client.FindAllBlockChildren(ctx, blockID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to load children of block %v: %w", blockID, err)
+	}
+
+	l.mu.Lock()
+	l.children[blockID] = children
+	l.mu.Unlock()
+
+	return children, nil
+}
@@ -0,0 +1,72 @@
+package notion_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+)
+
+func TestChildrenLoader(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	transport := blockTreeTransport(t, "")
+	requests := 0
+	countingTransport := &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		requests++
+		return transport.RoundTrip(r)
+	}}
+
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: countingTransport}))
+	loader := notion.NewChildrenLoader(client)
+
+	blocks, err := loader.ChildrenByID(ctx, "root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(blocks) != 3 || requests != 2 {
+		t.Fatalf("expected 3 blocks with 2 requests, got %v blocks with %v requests", len(blocks), requests)
+	}
+
+	// Cached.
+	if _, err := loader.ChildrenByID(ctx, "root"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	toggles, err := loader.Children(ctx, blocks[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(toggles) != 1 || toggles[0].ID() != "t1" {
+		t.Fatalf("unexpected children: %#v", toggles)
+	}
+
+	paragraphs, err := loader.Children(ctx, toggles[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paragraphs) != 1 || paragraphs[0].ID() != "p3" {
+		t.Fatalf("unexpected children: %#v", paragraphs)
+	}
+
+	// Blocks without children aren't fetched.
+	children, err := loader.Children(ctx, paragraphs[0])
+	if err != nil || children != nil {
+		t.Fatalf("expected no children, got %v (err: %v)", children, err)
+	}
+
+	if requests != 4 {
+		t.Fatalf("expected 4 requests, got %v", requests)
+	}
+
+	// Children are populated on the fetched blocks, and aren't fetched again.
+	if exp, got := "Foo\n\n<details>\n<summary></summary>\n\nBaz\n\n</details>\n", notion.ToMarkdown(blocks[:1]); exp != got {
+		t.Fatalf("markdown not equal (expected: %q, got: %q)", exp, got)
+	}
+	if _, err := loader.Children(ctx, blocks[0]); err != nil || requests != 4 {
+		t.Fatalf("expected no extra request (requests: %v, err: %v)", requests, err)
+	}
+}
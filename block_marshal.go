@@ -0,0 +1,120 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MarshalBlockFull returns the JSON encoding of a block as a complete block
+// object, like the API returns it: with its ID, parent, timestamps, users and
+// archived status, besides the type specific fields. Use it to store fetched
+// blocks without losing data; Block.MarshalJSON only emits the fields that are
+// accepted in requests. Fields that aren't set (e.g. the ID of a block that
+// wasn't fetched) are omitted.
+//
+// Use UnmarshalBlock to decode the output.
+func MarshalBlockFull(block Block) ([]byte, error) {
+	if block == nil {
+		return nil, errors.New("notion: cannot marshal nil block")
+	}
+
+	b, err := block.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if len(fields) != 1 {
+		return nil, fmt.Errorf("notion: cannot marshal block of type %T: expected a single block type field", block)
+	}
+
+	var (
+		blockType BlockType
+		value     json.RawMessage
+	)
+	for key, v := range fields {
+		blockType, value = BlockType(key), v
+	}
+
+	dto := fullBlockDTO{
+		Object:      "block",
+		ID:          block.ID(),
+		Type:        blockType,
+		HasChildren: block.HasChildren(),
+		Archived:    block.Archived(),
+	}
+
+	if trashable, ok := block.(interface{ InTrash() bool }); ok {
+		dto.InTrash = trashable.InTrash()
+	}
+
+	if parent := block.Parent(); parent.Type != "" {
+		dto.Parent = &parent
+	}
+	if t := block.CreatedTime(); !t.IsZero() {
+		dto.CreatedTime = &t
+	}
+	if user := block.CreatedBy(); user.ID != "" {
+		dto.CreatedBy = &user
+	}
+	if t := block.LastEditedTime(); !t.IsZero() {
+		dto.LastEditedTime = &t
+	}
+	if user := block.LastEditedBy(); user.ID != "" {
+		dto.LastEditedBy = &user
+	}
+
+	obj, err := json.Marshal(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := json.Marshal(blockType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Append the type specific field, keyed by block type, to the object.
+	var buf bytes.Buffer
+	buf.Write(obj[:len(obj)-1])
+	buf.WriteByte(',')
+	buf.Write(key)
+	buf.WriteByte(':')
+	buf.Write(value)
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBlock decodes a JSON block object, e.g. as returned by the API or by
+// MarshalBlockFull.
+func UnmarshalBlock(b []byte) (Block, error) {
+	var dto blockDTO
+
+	if err := json.Unmarshal(b, &dto); err != nil {
+		return nil, err
+	}
+
+	return dto.Block()
+}
+
+type fullBlockDTO struct {
+	Object         string     `json:"object"`
+	ID             string     `json:"id,omitempty"`
+	Parent         *Parent    `json:"parent,omitempty"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	CreatedBy      *BaseUser  `json:"created_by,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	LastEditedBy   *BaseUser  `json:"last_edited_by,omitempty"`
+	HasChildren    bool       `json:"has_children"`
+	Archived       bool       `json:"archived"`
+	InTrash        bool       `json:"in_trash"`
+	Type           BlockType  `json:"type"`
+}
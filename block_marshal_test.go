@@ -0,0 +1,124 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalBlockFull(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		block   notion.Block
+		expJSON string
+	}{
+		{
+			name: "fetched block",
+			block: mustUnmarshalBlock(t, `{
+				"object": "block",
+				"id": "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113",
+				"parent": {"type": "page_id", "page_id": "59833787-2cf9-4fdf-8782-e53db20768a5"},
+				"created_time": "2021-05-14T09:15:00.000Z",
+				"created_by": {"object": "user", "id": "71e95936-2737-4e11-b03d-f174f6f13087"},
+				"last_edited_time": "2021-05-14T09:16:00.000Z",
+				"last_edited_by": {"object": "user", "id": "71e95936-2737-4e11-b03d-f174f6f13087"},
+				"has_children": true,
+				"archived": true,
+				"in_trash": true,
+				"type": "to_do",
+				"to_do": {
+					"rich_text": [{"type": "text", "text": {"content": "Foobar"}, "plain_text": "Foobar"}],
+					"checked": true
+				}
+			}`),
+			expJSON: `{
+				"object": "block",
+				"id": "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113",
+				"parent": {"type": "page_id", "page_id": "59833787-2cf9-4fdf-8782-e53db20768a5"},
+				"created_time": "2021-05-14T09:15:00Z",
+				"created_by": {"id": "71e95936-2737-4e11-b03d-f174f6f13087"},
+				"last_edited_time": "2021-05-14T09:16:00Z",
+				"last_edited_by": {"id": "71e95936-2737-4e11-b03d-f174f6f13087"},
+				"has_children": true,
+				"archived": true,
+				"in_trash": true,
+				"type": "to_do",
+				"to_do": {
+					"rich_text": [{"type": "text", "text": {"content": "Foobar"}, "plain_text": "Foobar"}],
+					"checked": true
+				}
+			}`,
+		},
+		{
+			name:  "new block",
+			block: notion.DividerBlock{},
+			expJSON: `{
+				"object": "block",
+				"has_children": false,
+				"archived": false,
+				"in_trash": false,
+				"type": "divider",
+				"divider": {}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := notion.MarshalBlockFull(tt.block)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got, exp interface{}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("invalid JSON: %v (%s)", err, b)
+			}
+			if err := json.Unmarshal([]byte(tt.expJSON), &exp); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(exp, got); diff != "" {
+				t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+			}
+
+			// Decoding and encoding the output again is lossless.
+			decoded, err := notion.UnmarshalBlock(b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			roundTrip, err := notion.MarshalBlockFull(decoded)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(string(b), string(roundTrip)); diff != "" {
+				t.Fatalf("round trip JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestMarshalBlockFullNil(t *testing.T) {
+	t.Parallel()
+
+	if _, err := notion.MarshalBlockFull(nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func mustUnmarshalBlock(t *testing.T, s string) notion.Block {
+	t.Helper()
+
+	block, err := notion.UnmarshalBlock([]byte(s))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return block
+}
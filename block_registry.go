@@ -0,0 +1,96 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	blockTypesMu sync.RWMutex
+	blockTypes   = make(map[BlockType]func() Block)
+)
+
+// RegisterBlockType makes a block type, unknown to this library, available for
+// decoding. When a block of this type is found in an API response, `factory` is
+// called, and the type specific object of the block (e.g. the value of the
+// `foo` field for a block with type `foo`) is decoded into the returned block,
+// which must be a pointer.
+//
+// The returned block can embed UnsupportedBlock to get the common block fields
+// (e.g. ID, CreatedTime) populated. Note that in that case, it should implement
+// json.Marshaler itself when the block is used in requests.
+//
+// Blocks with unknown types that aren't registered are decoded as
+// UnsupportedBlock. RegisterBlockType panics if it's called twice for the
+// same block type, if factory is nil, or if the block type is built-in.
+func RegisterBlockType(blockType BlockType, factory func() Block) {
+	if factory == nil {
+		panic("notion: block type factory is nil")
+	}
+	if isBuiltinBlockType(blockType) {
+		panic(fmt.Sprintf("notion: cannot register built-in block type %q", blockType))
+	}
+
+	blockTypesMu.Lock()
+	defer blockTypesMu.Unlock()
+
+	if _, ok := blockTypes[blockType]; ok {
+		panic(fmt.Sprintf("notion: block type %q is already registered", blockType))
+	}
+
+	blockTypes[blockType] = factory
+}
+
+func registeredBlockType(blockType BlockType) (func() Block, bool) {
+	blockTypesMu.RLock()
+	defer blockTypesMu.RUnlock()
+
+	factory, ok := blockTypes[blockType]
+
+	return factory, ok
+}
+
+// isBuiltinBlockType returns true if blockDTO has a field for the block type.
+func isBuiltinBlockType(blockType BlockType) bool {
+	if blockType == "" {
+		return true
+	}
+
+	t := reflect.TypeOf(blockDTO{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == string(blockType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (dto blockDTO) registeredBlock(factory func() Block, base baseBlock) (Block, error) {
+	block := factory()
+	if block == nil || reflect.ValueOf(block).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("factory for block type %q must return a pointer, got %T", dto.Type, block)
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(dto.raw, &fields); err != nil {
+		return nil, err
+	}
+
+	if raw, ok := fields[string(dto.Type)]; ok {
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, err
+		}
+	}
+
+	if b, ok := block.(interface{ setBaseBlock(baseBlock) }); ok {
+		b.setBaseBlock(base)
+	}
+
+	return block, nil
+}
@@ -0,0 +1,238 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type experimentalBlock struct {
+	notion.UnsupportedBlock
+
+	Prompt   string            `json:"prompt"`
+	RichText []notion.RichText `json:"rich_text"`
+}
+
+func (b experimentalBlock) MarshalJSON() ([]byte, error) {
+	type blockAlias experimentalBlock
+
+	return json.Marshal(struct {
+		Experimental blockAlias `json:"experimental_block"`
+	}{blockAlias(b)})
+}
+
+func init() {
+	notion.RegisterBlockType("experimental_block", func() notion.Block {
+		return &experimentalBlock{}
+	})
+}
+
+func TestRegisterBlockType(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{
+						"object": "list",
+						"results": [
+							{
+								"object": "block",
+								"id": "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113",
+								"created_time": "2021-05-14T09:15:00.000Z",
+								"last_edited_time": "2021-05-14T09:15:00.000Z",
+								"has_children": true,
+								"type": "experimental_block",
+								"experimental_block": {
+									"prompt": "Summarize",
+									"rich_text": [
+										{
+											"type": "text",
+											"text": {
+												"content": "Foobar"
+											},
+											"plain_text": "Foobar"
+										}
+									]
+								}
+							},
+							{
+								"object": "block",
+								"id": "5e113754-eae4-4da9-96d2-675977acce99",
+								"created_time": "2021-05-14T09:15:00.000Z",
+								"last_edited_time": "2021-05-14T09:15:00.000Z",
+								"has_children": false,
+								"type": "unregistered_block",
+								"unregistered_block": {}
+							}
+						],
+						"next_cursor": null,
+						"has_more": false
+					}`,
+				)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	resp, err := client.FindBlockChildrenByID(context.Background(), "00000000-0000-0000-0000-000000000000", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := []notion.Block{
+		&experimentalBlock{
+			Prompt: "Summarize",
+			RichText: []notion.RichText{
+				{
+					Type:      notion.RichTextTypeText,
+					Text:      &notion.Text{Content: "Foobar"},
+					PlainText: "Foobar",
+				},
+			},
+		},
+		&notion.UnsupportedBlock{
+			Type:    "unregistered_block",
+			Unknown: json.RawMessage(`{}`),
+		},
+	}
+
+	if diff := cmp.Diff(exp, resp.Results, cmpopts.IgnoreUnexported(notion.UnsupportedBlock{})); diff != "" {
+		t.Fatalf("blocks not equal (-exp, +got):\n%v", diff)
+	}
+
+	block := resp.Results[0]
+	if block.ID() != "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113" {
+		t.Fatalf("id not equal (got: %v)", block.ID())
+	}
+	if !block.HasChildren() {
+		t.Fatal("expected block to have children")
+	}
+	if exp := mustParseTime(time.RFC3339, "2021-05-14T09:15:00.000Z"); block.CreatedTime() != exp {
+		t.Fatalf("createdTime not equal (expected: %v, got: %v)", exp, block.CreatedTime())
+	}
+	if resp.Results[1].ID() != "5e113754-eae4-4da9-96d2-675977acce99" {
+		t.Fatalf("id not equal (got: %v)", resp.Results[1].ID())
+	}
+}
+
+func TestRegisterBlockTypePanics(t *testing.T) {
+	t.Parallel()
+
+	factory := func() notion.Block { return &experimentalBlock{} }
+
+	tests := []struct {
+		name      string
+		blockType notion.BlockType
+		factory   func() notion.Block
+		expPanic  string
+	}{
+		{
+			name:      "nil factory",
+			blockType: "foo",
+			factory:   nil,
+			expPanic:  "notion: block type factory is nil",
+		},
+		{
+			name:      "built-in block type",
+			blockType: notion.BlockTypeParagraph,
+			factory:   factory,
+			expPanic:  `notion: cannot register built-in block type "paragraph"`,
+		},
+		{
+			name:      "already registered",
+			blockType: "experimental_block",
+			factory:   factory,
+			expPanic:  `notion: block type "experimental_block" is already registered`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				if got := recover(); got != tt.expPanic {
+					t.Fatalf("panic not equal (expected: %v, got: %v)", tt.expPanic, got)
+				}
+			}()
+
+			notion.RegisterBlockType(tt.blockType, tt.factory)
+		})
+	}
+}
+
+func TestUnsupportedBlockMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var resp notion.BlockChildrenResponse
+	err := json.Unmarshal([]byte(`{
+		"object": "list",
+		"results": [
+			{
+				"object": "block",
+				"id": "5e113754-eae4-4da9-96d2-675977acce99",
+				"type": "ai_block",
+				"ai_block": {"prompt": "Summarize"}
+			},
+			{
+				"object": "block",
+				"id": "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113",
+				"type": "unsupported",
+				"unsupported": {}
+			}
+		]
+	}`), &resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		block notion.Block
+		exp   string
+	}{
+		{
+			name:  "unknown block type",
+			block: resp.Results[0],
+			exp:   `{"ai_block":{"prompt":"Summarize"}}`,
+		},
+		{
+			name:  "unsupported block type",
+			block: resp.Results[1],
+			exp:   `{"unsupported":{}}`,
+		},
+		{
+			name:  "zero value",
+			block: notion.UnsupportedBlock{},
+			exp:   `{"unsupported":{}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(tt.block)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.exp, string(b)); diff != "" {
+				t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
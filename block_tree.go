@@ -0,0 +1,385 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// FindBlockChildrenRecursiveOpts are the options used for finding block children
+// recursively.
+type FindBlockChildrenRecursiveOpts struct {
+	// MaxDepth is the maximum number of levels of children to find. For example,
+	// with a value of 1, only direct children are returned. Zero means no limit.
+	MaxDepth int
+
+	// Concurrency is the maximum number of concurrent requests. Defaults to 1.
+	// Keep in mind the rate limits of the Notion API.
+	// See: https://developers.notion.com/reference/request-limits#rate-limits
+	Concurrency int
+}
+
+// FindBlockChildrenRecursive returns all children of a block (following
+// pagination), with the children of each block with `HasChildren()` populated
+// in their `Children` field, recursively. This includes the children of
+// toggleable headings, which are only visible in Notion when expanded. Children
+// of `child_page` and `child_database` blocks aren't fetched, as these are
+// separate pages and databases. See ErrCanceled for cancellation.
+func (c *Client) FindBlockChildrenRecursive(ctx context.Context, blockID string, opts *FindBlockChildrenRecursiveOpts) ([]Block, error) {
+	if opts == nil {
+		opts = &FindBlockChildrenRecursiveOpts{}
+	}
+	if opts.MaxDepth < 0 {
+		return nil, errors.New("notion: max depth cannot be negative")
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := &blockTreeWalker{
+		client:   c,
+		maxDepth: opts.MaxDepth,
+		sem:      make(chan struct{}, concurrency),
+		cancel:   cancel,
+		progress: progressFrom(ctx),
+	}
+
+	w.progress.emit(ProgressStarted, blockID, 0, nil)
+
+	blocks, err := w.walk(walkCtx, blockID, 1)
+	if err != nil {
+		if err := canceled(ctx, int(atomic.LoadInt64(&w.found)), -1); err != nil {
+			return blocks, err
+		}
+		// Return the error that caused cancellation of other requests, if any.
+		return nil, w.firstErr(err)
+	}
+
+	w.progress.emit(ProgressCompleted, blockID, int(atomic.LoadInt64(&w.found)), nil)
+
+	return blocks, nil
+}
+
+type blockTreeWalker struct {
+	client   *Client
+	maxDepth int
+	sem      chan struct{}
+	cancel   context.CancelFunc
+	progress *progressReporter
+
+	// found is the number of blocks found, accessed atomically.
+	found int64
+
+	mu  sync.Mutex
+	err error
+}
+
+func (w *blockTreeWalker) walk(ctx context.Context, blockID string, depth int) ([]Block, error) {
+	children, err := w.findAll(ctx, blockID)
+	if err != nil {
+		err = fmt.Errorf("notion: failed to find children of block %v: %w", blockID, err)
+		w.progress.emit(ProgressFailed, blockID, 0, err)
+		return nil, w.fail(err)
+	}
+
+	atomic.AddInt64(&w.found, int64(len(children)))
+	w.progress.emit(ProgressFetched, blockID, len(children), nil)
+
+	if w.maxDepth > 0 && depth >= w.maxDepth {
+		return children, nil
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(children))
+	)
+
+	for i, child := range children {
+		switch child.(type) {
+		case *ChildPageBlock, *ChildDatabaseBlock:
+			continue
+		}
+		if !child.HasChildren() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, child Block) {
+			defer wg.Done()
+
+			grandchildren, err := w.walk(ctx, child.ID(), depth+1)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			setBlockChildren(child, grandchildren)
+		}(i, child)
+	}
+
+	wg.Wait()
+
+	// Children are returned along with the error, as partial results are
+	// returned on cancellation.
+	for _, err := range errs {
+		if err != nil {
+			return children, err
+		}
+	}
+
+	return children, nil
+}
+
+// findAll returns all (paginated) children of a block. The number of concurrent
+// calls is limited by the semaphore.
+func (w *blockTreeWalker) findAll(ctx context.Context, blockID string) ([]Block, error) {
+	select {
+	case w.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-w.sem }()
+
+	return w.client.FindAllBlockChildren(ctx, blockID, nil)
+}
+
+// fail records the first error and cancels all pending requests.
+func (w *blockTreeWalker) fail(err error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err == nil {
+		w.err = err
+		w.cancel()
+	}
+
+	return err
+}
+
+func (w *blockTreeWalker) firstErr(err error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+	return err
+}
+
+// maxAppendDepth is the maximum depth of blocks in a request body for appending
+// block children: top level blocks, plus two levels of nesting.
+// See: https://developers.notion.com/reference/patch-block-children
+const maxAppendDepth = 3
+
+// validateBlockDepth returns an error with the path of the first block (at
+// `depth`) with children beyond maxAppendDepth. Like in truncateBlockTree, table
+// rows are ignored, as they're always sent along with their table.
+func validateBlockDepth(blocks []Block, depth int) error {
+	for i, block := range blocks {
+		children := blockChildren(block)
+		if _, isTable := blockPtr(block).(*TableBlock); len(children) == 0 || isTable {
+			continue
+		}
+		if depth >= maxAppendDepth {
+			return fmt.Errorf("block [%v]: children exceed the maximum of %v levels of nesting per request", i, maxAppendDepth-1)
+		}
+		if err := validateBlockDepth(children, depth+1); err != nil {
+			return fmt.Errorf("block [%v]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// CreatePageDeep creates a page like CreatePage, but without the limit of two
+// levels of nesting for its children. Blocks nested deeper are appended with
+// subsequent requests, like in AppendBlockChildrenDeep. If a subsequent request
+// fails, the created page is returned along with the error.
+func (c *Client) CreatePageDeep(ctx context.Context, params CreatePageParams) (Page, error) {
+	total := countBlocks(params.Children)
+
+	truncated, deferred := truncateBlockTree(params.Children, 1)
+	params.Children = truncated
+
+	page, err := c.CreatePage(ctx, params)
+	if err != nil {
+		return Page{}, err
+	}
+	if deferred == nil {
+		return page, nil
+	}
+
+	p := &appendProgress{completed: countBlocks(truncated), total: total}
+
+	children, err := c.FindAllBlockChildren(ctx, page.ID, nil)
+	if err != nil {
+		return page, fmt.Errorf("notion: failed to find children of page %v: %w", page.ID, err)
+	}
+	if err := c.appendDeferred(ctx, children, deferred, p); err != nil {
+		return page, err
+	}
+
+	return page, nil
+}
+
+// AppendBlockChildrenDeep appends blocks to an existing block, like
+// AppendBlockChildren, but without the limit of two levels of nesting per
+// request. Blocks nested deeper are appended with subsequent requests, to the
+// IDs of the newly created parent blocks. Table rows are always sent along with
+// their table, as Notion requires. The newly created (top level) blocks are
+// returned.
+//
+// The input blocks aren't modified. If a subsequent request fails, blocks that
+// were already created aren't removed. See ErrCanceled for cancellation.
+func (c *Client) AppendBlockChildrenDeep(ctx context.Context, blockID string, children []Block) ([]Block, error) {
+	p := &appendProgress{total: countBlocks(children), reporter: progressFrom(ctx)}
+
+	p.reporter.emit(ProgressStarted, blockID, 0, nil)
+
+	created, err := c.appendDeep(ctx, blockID, children, p)
+	if err != nil {
+		if err := canceled(ctx, p.completed, p.total-p.completed); err != nil {
+			return created, err
+		}
+		return nil, err
+	}
+
+	p.reporter.emit(ProgressCompleted, blockID, p.completed, nil)
+
+	return created, nil
+}
+
+// appendProgress is the number of blocks (including nested blocks) created by
+// AppendBlockChildrenDeep, out of the total number of blocks.
+type appendProgress struct {
+	completed int
+	total     int
+	reporter  *progressReporter
+}
+
+func countBlocks(blocks []Block) int {
+	n := len(blocks)
+	for _, block := range blocks {
+		n += countBlocks(blockChildren(block))
+	}
+	return n
+}
+
+func (c *Client) appendDeep(ctx context.Context, blockID string, children []Block, p *appendProgress) ([]Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	truncated, deferred := truncateBlockTree(children, 1)
+
+	resp, err := c.AppendBlockChildren(ctx, blockID, truncated)
+	if err != nil {
+		p.reporter.emit(ProgressFailed, blockID, 0, err)
+		return nil, err
+	}
+
+	n := countBlocks(truncated)
+	p.completed += n
+	p.reporter.emit(ProgressAppended, blockID, n, nil)
+
+	created := resp.Results
+	// Some API versions return all children of the parent block instead of only
+	// the newly created ones, which are last.
+	if len(created) > len(truncated) {
+		created = created[len(created)-len(truncated):]
+	}
+
+	if err := c.appendDeferred(ctx, created, deferred, p); err != nil {
+		return created, err
+	}
+
+	return created, nil
+}
+
+// deferredChildren holds the children of a block that were removed from a
+// request body, because they exceed the maximum depth.
+type deferredChildren struct {
+	// blocks are the removed children of the block itself.
+	blocks []Block
+	// nested are the deferred children of the block's children, by index.
+	nested []*deferredChildren
+}
+
+// truncateBlockTree returns copies of blocks (at `depth`) with children beyond
+// maxAppendDepth removed. The removed children are returned by block index, or
+// nil if nothing was removed.
+func truncateBlockTree(blocks []Block, depth int) ([]Block, []*deferredChildren) {
+	var (
+		truncated = make([]Block, len(blocks))
+		deferred  = make([]*deferredChildren, len(blocks))
+		anyDefer  bool
+	)
+
+	for i, block := range blocks {
+		children := blockChildren(block)
+		_, isTable := blockPtr(block).(*TableBlock)
+
+		if len(children) == 0 || isTable {
+			truncated[i] = block
+			continue
+		}
+
+		clone := cloneBlock(block)
+
+		if depth >= maxAppendDepth {
+			setBlockChildren(clone, nil)
+			deferred[i] = &deferredChildren{blocks: children}
+			anyDefer = true
+		} else {
+			truncatedChildren, nested := truncateBlockTree(children, depth+1)
+			setBlockChildren(clone, truncatedChildren)
+			if nested != nil {
+				deferred[i] = &deferredChildren{nested: nested}
+				anyDefer = true
+			}
+		}
+
+		truncated[i] = clone
+	}
+
+	if !anyDefer {
+		return truncated, nil
+	}
+
+	return truncated, deferred
+}
+
+// appendDeferred appends deferred children to newly created blocks, finding the
+// IDs of nested blocks as needed.
+func (c *Client) appendDeferred(ctx context.Context, created []Block, deferred []*deferredChildren, p *appendProgress) error {
+	for i, d := range deferred {
+		if d == nil || i >= len(created) {
+			continue
+		}
+
+		id := created[i].ID()
+
+		if len(d.blocks) > 0 {
+			if _, err := c.appendDeep(ctx, id, d.blocks, p); err != nil {
+				return fmt.Errorf("notion: failed to append nested children of block %v: %w", id, err)
+			}
+		}
+
+		if d.nested != nil {
+			children, err := c.FindAllBlockChildren(ctx, id, nil)
+			if err != nil {
+				return fmt.Errorf("notion: failed to find children of block %v: %w", id, err)
+			}
+			if err := c.appendDeferred(ctx, children, d.nested, p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,480 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func blockJSON(id, blockType string, hasChildren bool, content string) string {
+	return fmt.Sprintf(`{"object": "block", "id": %q, "type": %q, "has_children": %v, %q: %v}`, id, blockType, hasChildren, blockType, content)
+}
+
+func paragraphJSON(id string, hasChildren bool, text string) string {
+	return blockJSON(id, "paragraph", hasChildren, fmt.Sprintf(`{"rich_text": [{"type": "text", "text": {"content": %q}, "plain_text": %q}]}`, text, text))
+}
+
+func blockTreeTransport(t *testing.T, failBlockID string) *mockRoundtripper {
+	children := map[string][]string{
+		"root": {
+			paragraphJSON("p1", true, "Foo"),
+			blockJSON("cp", "child_page", true, `{"title": "Sub page"}`),
+		},
+		"root?page2": {
+			paragraphJSON("p2", false, "Bar"),
+		},
+		"p1": {
+			blockJSON("t1", "toggle", true, `{"rich_text": []}`),
+		},
+		"t1": {
+			paragraphJSON("p3", false, "Baz"),
+		},
+	}
+
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/blocks/"), "/children")
+
+		if id == failBlockID {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Status:     http.StatusText(http.StatusInternalServerError),
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{"object": "error", "status": 500, "code": "internal_server_error", "message": "Foobar"}`,
+				)),
+			}, nil
+		}
+
+		key, hasMore, nextCursor := id, false, "null"
+		if id == "root" {
+			if r.URL.Query().Get("start_cursor") == "page2" {
+				key = "root?page2"
+			} else {
+				hasMore, nextCursor = true, `"page2"`
+			}
+		}
+
+		results, ok := children[key]
+		if !ok {
+			t.Errorf("unexpected request for children of block %q", id)
+		}
+
+		body := fmt.Sprintf(`{"object": "list", "results": [%v], "has_more": %v, "next_cursor": %v}`,
+			strings.Join(results, ","), hasMore, nextCursor)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	}}
+}
+
+func TestFindBlockChildrenRecursive(t *testing.T) {
+	t.Parallel()
+
+	richText := func(s string) []notion.RichText {
+		return []notion.RichText{{Type: notion.RichTextTypeText, Text: &notion.Text{Content: s}, PlainText: s}}
+	}
+
+	tests := []struct {
+		name        string
+		opts        *notion.FindBlockChildrenRecursiveOpts
+		failBlockID string
+		expTree     []notion.Block
+		expError    string
+	}{
+		{
+			name: "full tree",
+			opts: &notion.FindBlockChildrenRecursiveOpts{Concurrency: 3},
+			expTree: []notion.Block{
+				&notion.ParagraphBlock{
+					RichText: richText("Foo"),
+					Children: []notion.Block{
+						&notion.ToggleBlock{
+							RichText: []notion.RichText{},
+							Children: []notion.Block{
+								&notion.ParagraphBlock{RichText: richText("Baz")},
+							},
+						},
+					},
+				},
+				&notion.ChildPageBlock{Title: "Sub page"},
+				&notion.ParagraphBlock{RichText: richText("Bar")},
+			},
+		},
+		{
+			name: "max depth",
+			opts: &notion.FindBlockChildrenRecursiveOpts{MaxDepth: 2},
+			expTree: []notion.Block{
+				&notion.ParagraphBlock{
+					RichText: richText("Foo"),
+					Children: []notion.Block{
+						&notion.ToggleBlock{RichText: []notion.RichText{}},
+					},
+				},
+				&notion.ChildPageBlock{Title: "Sub page"},
+				&notion.ParagraphBlock{RichText: richText("Bar")},
+			},
+		},
+		{
+			name:        "error",
+			failBlockID: "t1",
+			expError:    "notion: failed to find children of block t1: notion: failed to find block children: Foobar (code: internal_server_error, status: 500)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{Transport: blockTreeTransport(t, tt.failBlockID)}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			blocks, err := client.FindBlockChildrenRecursive(context.Background(), "root", tt.opts)
+
+			if tt.expError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != "" && (err == nil || err.Error() != tt.expError) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			opts := cmpopts.IgnoreUnexported(notion.ParagraphBlock{}, notion.ToggleBlock{}, notion.ChildPageBlock{})
+			if diff := cmp.Diff(tt.expTree, blocks, opts); diff != "" {
+				t.Fatalf("blocks not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFindBlockChildrenRecursiveToggleableHeading(t *testing.T) {
+	t.Parallel()
+
+	children := map[string]string{
+		"root": blockJSON("h1", "heading_2", true, `{"rich_text": [], "is_toggleable": true, "color": "default"}`),
+		"h1":   paragraphJSON("p1", false, "Foo"),
+	}
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/blocks/"), "/children")
+			body := fmt.Sprintf(`{"object": "list", "results": [%v], "has_more": false, "next_cursor": null}`, children[id])
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	blocks, err := client.FindBlockChildrenRecursive(context.Background(), "root", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := []notion.Block{
+		&notion.Heading2Block{
+			RichText:     []notion.RichText{},
+			Color:        notion.ColorDefault,
+			IsToggleable: true,
+			Children: []notion.Block{
+				&notion.ParagraphBlock{
+					RichText: []notion.RichText{{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "Foo"}, PlainText: "Foo"}},
+				},
+			},
+		},
+	}
+
+	opts := cmpopts.IgnoreUnexported(notion.ParagraphBlock{}, notion.Heading2Block{})
+	if diff := cmp.Diff(exp, blocks, opts); diff != "" {
+		t.Fatalf("blocks not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestFindBlockChildrenRecursiveConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu              sync.Mutex
+		inFlight, maxIn int
+		next            = blockTreeTransport(t, "")
+	)
+
+	httpClient := &http.Client{Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxIn {
+			maxIn = inFlight
+		}
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		return next.RoundTrip(r)
+	}}}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	if _, err := client.FindBlockChildrenRecursive(context.Background(), "root", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxIn != 1 {
+		t.Fatalf("expected at most 1 concurrent request, got %v", maxIn)
+	}
+}
+
+// fakeBlockStore is a minimal in-memory implementation of the block children
+// endpoints, recording the nesting depth of append requests.
+type fakeBlockStore struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	nextID   int
+	children map[string][]string
+	text     map[string]string
+	requests int
+}
+
+func (s *fakeBlockStore) RoundTrip(r *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method == http.MethodPost && r.URL.Path == "/v1/pages" {
+		return s.createPage(r)
+	}
+
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/blocks/"), "/children")
+
+	var ids []string
+
+	switch r.Method {
+	case http.MethodPatch:
+		s.requests++
+
+		var body struct {
+			Children []map[string]interface{} `json:"children"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			s.t.Fatalf("unexpected error: %v", err)
+		}
+		ids = s.create(id, body.Children, 1)
+	case http.MethodGet:
+		ids = s.children[id]
+	}
+
+	results := make([]string, len(ids))
+	for i, id := range ids {
+		results[i] = paragraphJSON(id, len(s.children[id]) > 0, s.text[id])
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     http.StatusText(http.StatusOK),
+		Body: ioutil.NopCloser(strings.NewReader(fmt.Sprintf(
+			`{"object": "list", "results": [%v], "has_more": false, "next_cursor": null}`, strings.Join(results, ","),
+		))),
+	}, nil
+}
+
+func (s *fakeBlockStore) createPage(r *http.Request) (*http.Response, error) {
+	s.requests++
+
+	var body struct {
+		Children []map[string]interface{} `json:"children"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+	s.create("page", body.Children, 1)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     http.StatusText(http.StatusOK),
+		Body: ioutil.NopCloser(strings.NewReader(
+			`{"object": "page", "id": "page", "parent": {"type": "page_id", "page_id": "parent"}, "properties": {}}`,
+		)),
+	}, nil
+}
+
+func (s *fakeBlockStore) create(parentID string, blocks []map[string]interface{}, depth int) []string {
+	if depth > 3 {
+		s.t.Errorf("request exceeds two levels of nesting")
+	}
+
+	var ids []string
+
+	for _, block := range blocks {
+		s.nextID++
+		id := fmt.Sprintf("block-%v", s.nextID)
+		ids = append(ids, id)
+		s.children[parentID] = append(s.children[parentID], id)
+
+		content := block["paragraph"].(map[string]interface{})
+		richText := content["rich_text"].([]interface{})
+		s.text[id] = richText[0].(map[string]interface{})["text"].(map[string]interface{})["content"].(string)
+
+		if children, ok := content["children"].([]interface{}); ok {
+			nested := make([]map[string]interface{}, len(children))
+			for i := range children {
+				nested[i] = children[i].(map[string]interface{})
+			}
+			s.create(id, nested, depth+1)
+		}
+	}
+
+	return ids
+}
+
+func (s *fakeBlockStore) tree(id string) string {
+	var parts []string
+	for _, childID := range s.children[id] {
+		part := s.text[childID]
+		if sub := s.tree(childID); sub != "" {
+			part += "(" + sub + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestAppendBlockChildrenDeep(t *testing.T) {
+	t.Parallel()
+
+	p := func(text string, children ...notion.Block) notion.Block {
+		return &notion.ParagraphBlock{
+			RichText: []notion.RichText{{Text: &notion.Text{Content: text}}},
+			Children: children,
+		}
+	}
+
+	input := []notion.Block{
+		p("a",
+			p("b",
+				p("c",
+					p("d",
+						p("e"),
+					),
+				),
+				p("f",
+					p("g",
+						p("h",
+							p("i"),
+						),
+					),
+				),
+			),
+		),
+		p("j"),
+	}
+
+	store := &fakeBlockStore{t: t, children: map[string][]string{}, text: map[string]string{}}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: store}))
+
+	created, err := client.AppendBlockChildrenDeep(context.Background(), "root", input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("expected 2 created blocks, got %v", len(created))
+	}
+	if exp, got := "a(b(c(d(e)),f(g(h(i))))),j", store.tree("root"); exp != got {
+		t.Fatalf("tree not equal (expected: %v, got: %v)", exp, got)
+	}
+	if store.requests != 3 {
+		t.Fatalf("expected 3 append requests, got %v", store.requests)
+	}
+
+	// The input must be left untouched.
+	if got := len(input[0].(*notion.ParagraphBlock).Children[0].(*notion.ParagraphBlock).Children); got != 2 {
+		t.Fatalf("expected input to be left untouched, got %v children", got)
+	}
+}
+
+func TestCreatePageDeep(t *testing.T) {
+	t.Parallel()
+
+	p := func(text string, children ...notion.Block) notion.Block {
+		return &notion.ParagraphBlock{
+			RichText: []notion.RichText{{Text: &notion.Text{Content: text}}},
+			Children: children,
+		}
+	}
+
+	params := notion.CreatePageParams{
+		ParentType: notion.ParentTypePage,
+		ParentID:   "parent",
+		Title:      []notion.RichText{{Text: &notion.Text{Content: "Foobar"}}},
+		Children: []notion.Block{
+			p("a", p("b", p("c", p("d")))),
+			p("e"),
+		},
+	}
+
+	store := &fakeBlockStore{t: t, children: map[string][]string{}, text: map[string]string{}}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: store}))
+
+	_, err := client.CreatePage(context.Background(), params)
+	exp := "notion: invalid page params: children (see Client.CreatePageDeep): block [0]: block [0]: block [0]: " +
+		"children exceed the maximum of 2 levels of nesting per request"
+	if err == nil || err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+
+	page, err := client.CreatePageDeep(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.ID != "page" {
+		t.Fatalf("page ID not equal (expected: page, got: %v)", page.ID)
+	}
+	if exp, got := "a(b(c(d))),e", store.tree("page"); exp != got {
+		t.Fatalf("tree not equal (expected: %v, got: %v)", exp, got)
+	}
+	if store.requests != 2 {
+		t.Fatalf("expected 2 requests, got %v", store.requests)
+	}
+}
+
+func TestAppendBlockChildrenTooDeep(t *testing.T) {
+	t.Parallel()
+
+	p := func(text string, children ...notion.Block) notion.Block {
+		return notion.ParagraphBlock{
+			RichText: []notion.RichText{{Text: &notion.Text{Content: text}}},
+			Children: children,
+		}
+	}
+
+	store := &fakeBlockStore{t: t, children: map[string][]string{}, text: map[string]string{}}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: store}))
+
+	_, err := client.AppendBlockChildren(context.Background(), "root", []notion.Block{
+		p("a", p("b", p("c"))),
+		p("d", p("e"), p("f", p("g", p("h")))),
+	})
+
+	exp := "notion: invalid block children (see AppendBlockChildrenDeep): block [1]: block [1]: block [0]: " +
+		"children exceed the maximum of 2 levels of nesting per request"
+	if err == nil || err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+	if store.requests != 0 {
+		t.Fatalf("expected no requests, got %v", store.requests)
+	}
+}
@@ -0,0 +1,180 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// UpdateBlockParams is used for updating a block with UpdateBlockWithParams.
+// At least one field should have a non-empty value.
+type UpdateBlockParams struct {
+	// Block contains the type specific fields to update, e.g. the rich text of
+	// a paragraph block. Its type must match the type of the existing block.
+	// Children can't be updated; use AppendBlockChildren instead.
+	Block Block
+
+	// Archived archives (true) or restores (false) the block.
+	Archived *bool
+
+	// InTrash moves the block to (true) or restores it from (false) the trash.
+	InTrash *bool
+}
+
+// Validate returns an error if the params can't be used to update a block.
+func (p UpdateBlockParams) Validate() error {
+	if p.Block == nil && p.Archived == nil && p.InTrash == nil {
+		return errors.New("at least one of block, archived or in trash is required")
+	}
+	if p.Block != nil {
+		if err := validateBlockUpdate(p.Block); err != nil {
+			return fieldError("Block", err)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p UpdateBlockParams) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+
+	if p.Block != nil {
+		b, err := p.Block.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Archived != nil {
+		fields["archived"], _ = json.Marshal(*p.Archived)
+	}
+	if p.InTrash != nil {
+		fields["in_trash"], _ = json.Marshal(*p.InTrash)
+	}
+
+	return json.Marshal(fields)
+}
+
+// HeadingUpdate is a partial update of a heading block, for use with
+// UpdateBlock and UpdateBlockParams. Only the fields that are set are sent, so
+// e.g. updating the color of a toggleable heading doesn't make it regular, as
+// updating with Heading1Block (which always sends `is_toggleable`) does.
+type HeadingUpdate struct {
+	baseBlock
+
+	// Level is the level (1, 2 or 3) of the heading to update, which must match
+	// its block type (`heading_1`, `heading_2` or `heading_3`). Required.
+	Level int
+
+	RichText     []RichText
+	Color        *Color
+	IsToggleable *bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u HeadingUpdate) MarshalJSON() ([]byte, error) {
+	if u.Level < 1 || u.Level > 3 {
+		return nil, fmt.Errorf("notion: invalid heading level %v (expected 1, 2 or 3)", u.Level)
+	}
+
+	type dto struct {
+		RichText     []RichText `json:"rich_text,omitempty"`
+		Color        *Color     `json:"color,omitempty"`
+		IsToggleable *bool      `json:"is_toggleable,omitempty"`
+	}
+
+	return json.Marshal(map[string]dto{
+		fmt.Sprintf("heading_%v", u.Level): {
+			RichText:     u.RichText,
+			Color:        u.Color,
+			IsToggleable: u.IsToggleable,
+		},
+	})
+}
+
+// validateBlockUpdate returns an error for blocks that the API doesn't allow
+// updating, and for fields that can't be updated.
+func validateBlockUpdate(block Block) error {
+	switch b := blockPtr(block).(type) {
+	case *ChildPageBlock:
+		return errors.New("child page blocks can't be updated (use UpdatePage to update the title)")
+	case *ChildDatabaseBlock:
+		return errors.New("child database blocks can't be updated (use UpdateDatabase to update the title)")
+	case *LinkPreviewBlock:
+		return errors.New("link preview blocks can't be updated")
+	case *ColumnListBlock, *ColumnBlock:
+		return errors.New("column list and column blocks have no fields that can be updated")
+	case *UnsupportedBlock:
+		if b.Type == "" || b.Type == BlockTypeUnsupported || b.Unknown == nil {
+			return errors.New("unsupported blocks can't be updated")
+		}
+	case *HeadingUpdate:
+		if b.Level < 1 || b.Level > 3 {
+			return fmt.Errorf("invalid heading level %v (expected 1, 2 or 3)", b.Level)
+		}
+		if b.RichText == nil && b.Color == nil && b.IsToggleable == nil {
+			return errors.New("at least one of rich text, color or is toggleable is required")
+		}
+	}
+
+	if len(blockChildren(block)) > 0 {
+		return errors.New("children can't be updated (use AppendBlockChildren)")
+	}
+
+	return validateBlock(block)
+}
+
+// UpdateBlockWithParams updates a block, like UpdateBlock, and can also archive
+// or restore it.
+// See: https://developers.notion.com/reference/update-a-block
+func (c *Client) UpdateBlockWithParams(ctx context.Context, blockID string, params UpdateBlockParams) (Block, error) {
+	if err := params.Validate(); err != nil {
+		return nil, invalidParams("block params", err)
+	}
+
+	return c.updateBlock(ctx, blockID, params)
+}
+
+func (c *Client) updateBlock(ctx context.Context, blockID string, params UpdateBlockParams) (Block, error) {
+	body := &bytes.Buffer{}
+
+	err := json.NewEncoder(body).Encode(params)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to encode body params to JSON: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPatch, "/blocks/"+blockID, body)
+	if err != nil {
+		return nil, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	c.invalidateCached(blockID)
+	if err != nil {
+		return nil, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("notion: failed to update block: %w", parseErrorResponse(res))
+	}
+
+	var dto blockDTO
+
+	err = c.decode(res.Body, &dto)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	if dto.Parent != nil {
+		c.invalidateCached(parentID(*dto.Parent))
+	}
+
+	return dto.Block()
+}
@@ -0,0 +1,265 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUpdateBlockWithParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		params      notion.UpdateBlockParams
+		expPostBody map[string]interface{}
+		expError    error
+	}{
+		{
+			name:   "archive",
+			params: notion.UpdateBlockParams{Archived: notion.BoolPtr(true)},
+			expPostBody: map[string]interface{}{
+				"archived": true,
+			},
+		},
+		{
+			name: "block and in trash",
+			params: notion.UpdateBlockParams{
+				Block: notion.ToDoBlock{
+					RichText: []notion.RichText{{Text: &notion.Text{Content: "Foobar"}}},
+					Checked:  notion.BoolPtr(true),
+				},
+				InTrash: notion.BoolPtr(false),
+			},
+			expPostBody: map[string]interface{}{
+				"to_do": map[string]interface{}{
+					"rich_text": []interface{}{
+						map[string]interface{}{
+							"text": map[string]interface{}{
+								"content": "Foobar",
+							},
+						},
+					},
+					"checked": true,
+				},
+				"in_trash": false,
+			},
+		},
+		{
+			name:     "empty params",
+			params:   notion.UpdateBlockParams{},
+			expError: errors.New("notion: invalid block params: at least one of block, archived or in trash is required"),
+		},
+		{
+			name: "block with children",
+			params: notion.UpdateBlockParams{
+				Block: notion.ToggleBlock{Children: []notion.Block{notion.DividerBlock{}}},
+			},
+			expError: errors.New("notion: invalid block params: children can't be updated (use AppendBlockChildren)"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if tt.expError != nil {
+						t.Fatal("unexpected request")
+					}
+
+					postBody := make(map[string]interface{})
+					if err := json.NewDecoder(r.Body).Decode(&postBody); err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(tt.expPostBody, postBody); diff != "" {
+						t.Errorf("post body not equal (-exp, +got):\n%v", diff)
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body: io.NopCloser(strings.NewReader(
+							`{
+								"object": "block",
+								"id": "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113",
+								"type": "divider",
+								"divider": {},
+								"archived": true
+							}`,
+						)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+			block, err := client.UpdateBlockWithParams(context.Background(), "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113", tt.params)
+
+			if tt.expError != nil {
+				if err == nil || err.Error() != tt.expError.Error() {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !block.Archived() {
+				t.Fatal("expected block to be archived")
+			}
+		})
+	}
+}
+
+func TestUpdateBlockValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		block    notion.Block
+		expError string
+	}{
+		{
+			name:     "nil block",
+			block:    nil,
+			expError: "notion: invalid block: block is nil",
+		},
+		{
+			name:     "child page",
+			block:    notion.ChildPageBlock{Title: "Foobar"},
+			expError: "notion: invalid block: child page blocks can't be updated (use UpdatePage to update the title)",
+		},
+		{
+			name:     "column",
+			block:    &notion.ColumnBlock{},
+			expError: "notion: invalid block: column list and column blocks have no fields that can be updated",
+		},
+		{
+			name:     "unsupported",
+			block:    notion.UnsupportedBlock{},
+			expError: "notion: invalid block: unsupported blocks can't be updated",
+		},
+		{
+			name: "children",
+			block: notion.ParagraphBlock{
+				Children: []notion.Block{notion.DividerBlock{}},
+			},
+			expError: "notion: invalid block: children can't be updated (use AppendBlockChildren)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					t.Fatal("unexpected request")
+					return nil, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			_, err := client.UpdateBlock(context.Background(), "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113", tt.block)
+			if err == nil || err.Error() != tt.expError {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
+
+func TestHeadingUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		update   notion.HeadingUpdate
+		expJSON  string
+		expError string
+	}{
+		{
+			name:    "toggleable",
+			update:  notion.HeadingUpdate{Level: 2, IsToggleable: notion.BoolPtr(true)},
+			expJSON: `{"heading_2":{"is_toggleable":true}}`,
+		},
+		{
+			name: "color",
+			update: notion.HeadingUpdate{
+				Level: 1,
+				Color: colorPtr(notion.ColorBlueBg),
+			},
+			expJSON: `{"heading_1":{"color":"blue_background"}}`,
+		},
+		{
+			name:     "invalid level",
+			update:   notion.HeadingUpdate{Level: 4, IsToggleable: notion.BoolPtr(true)},
+			expError: "notion: invalid block: invalid heading level 4 (expected 1, 2 or 3)",
+		},
+		{
+			name:     "no fields",
+			update:   notion.HeadingUpdate{Level: 3},
+			expError: "notion: invalid block: at least one of rich text, color or is toggleable is required",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if tt.expError != "" {
+						t.Fatal("unexpected request")
+					}
+
+					b, err := io.ReadAll(r.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(tt.expJSON, strings.TrimSpace(string(b))); diff != "" {
+						t.Errorf("post body not equal (-exp, +got):\n%v", diff)
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body: io.NopCloser(strings.NewReader(
+							`{
+								"object": "block",
+								"id": "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113",
+								"type": "heading_2",
+								"heading_2": {"rich_text": [], "is_toggleable": true, "color": "default"}
+							}`,
+						)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			_, err := client.UpdateBlock(context.Background(), "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113", tt.update)
+			if tt.expError != "" {
+				if err == nil || err.Error() != tt.expError {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func colorPtr(c notion.Color) *notion.Color {
+	return &c
+}
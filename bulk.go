@@ -0,0 +1,274 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// BulkOptions are the options used for bulk operations, like CreatePages and
+// ArchivePages.
+type BulkOptions struct {
+	// Concurrency is the maximum number of concurrent requests. Defaults to 1.
+	// Keep in mind the rate limits of the Notion API.
+	// See: https://developers.notion.com/reference/request-limits#rate-limits
+	Concurrency int
+
+	// RequestsPerSecond limits the average rate of requests of the operation.
+	// Zero means no limit. To share a limit with other operations of the
+	// client, use WithRateLimit instead.
+	RequestsPerSecond float64
+
+	// StopOnError stops the operation after the first failed item. By default,
+	// all items are processed, and failures are reported via *BulkError.
+	StopOnError bool
+
+	// Skip reports whether the item at index `i` should be skipped, e.g. to
+	// resume an earlier operation that failed or was canceled. Optional.
+	Skip func(i int) bool
+}
+
+// BulkFailure describes an item of a bulk operation that failed.
+type BulkFailure struct {
+	// Index is the index of the item in the input of the operation.
+	Index int
+	Err   error
+}
+
+// BulkError is returned by bulk operations when one or more items failed. The
+// results of other items are returned along with it.
+type BulkError struct {
+	Total int
+	// Failed are the failed items, sorted by index.
+	Failed []BulkFailure
+}
+
+// Error implements `error`.
+func (err *BulkError) Error() string {
+	first := err.Failed[0]
+	return fmt.Sprintf("notion: %v of %v items failed (first: item [%v]: %v)", len(err.Failed), err.Total, first.Index, first.Err)
+}
+
+// CreatePagesResult is the result of CreatePages.
+type CreatePagesResult struct {
+	// Pages are the created pages, by index of the params. Pages that weren't
+	// created (yet) have an empty ID.
+	Pages []Page
+}
+
+// Created returns the number of created pages.
+func (r CreatePagesResult) Created() int {
+	n := 0
+	for i := range r.Pages {
+		if r.IsCreated(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// IsCreated reports whether the page of the params at index `i` was created.
+// Use it as BulkOptions.Skip to resume:
+//
+//	result, err := client.CreatePages(ctx, params, opts)
+//	// ...
+//	opts.Skip = result.IsCreated
+//	retried, err := client.CreatePages(ctx, params, opts)
+func (r CreatePagesResult) IsCreated(i int) bool {
+	return i < len(r.Pages) && r.Pages[i].ID != ""
+}
+
+// CreatePages creates many pages concurrently, using a bounded pool of workers.
+// The result is returned along with a *BulkError when some pages couldn't be
+// created, or an *ErrCanceled when the context is canceled. Skipped pages
+// count as completed.
+func (c *Client) CreatePages(ctx context.Context, params []CreatePageParams, opts BulkOptions) (CreatePagesResult, error) {
+	result := CreatePagesResult{Pages: make([]Page, len(params))}
+
+	err := runBulk(ctx, len(params), opts, func(ctx context.Context, i int) error {
+		page, err := c.CreatePage(ctx, params[i])
+		if err != nil {
+			return err
+		}
+		result.Pages[i] = page
+		return nil
+	})
+
+	return result, err
+}
+
+// runBulk calls `fn` for each index in [0, n) that isn't skipped, with a pool
+// of workers. Each index is handled by a single worker, so `fn` can write to
+// elements of a slice without synchronization.
+func runBulk(ctx context.Context, n int, opts BulkOptions, fn func(ctx context.Context, i int) error) error {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var limiter *rateLimiter
+	if opts.RequestsPerSecond > 0 {
+		limiter = newRateLimiter(opts.RequestsPerSecond, 1)
+	}
+
+	bulkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		failed    []BulkFailure
+		completed int
+		wg        sync.WaitGroup
+		indexes   = make(chan int)
+	)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				if bulkCtx.Err() != nil {
+					continue
+				}
+				if limiter != nil {
+					if err := limiter.wait(bulkCtx); err != nil {
+						continue
+					}
+				}
+
+				err := fn(bulkCtx, i)
+				if err != nil && bulkCtx.Err() != nil {
+					// Canceled items are remaining, not failed.
+					continue
+				}
+
+				mu.Lock()
+				if err != nil {
+					failed = append(failed, BulkFailure{Index: i, Err: err})
+					if opts.StopOnError {
+						cancel()
+					}
+				} else {
+					completed++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	skipped := 0
+
+send:
+	for i := 0; i < n; i++ {
+		if opts.Skip != nil && opts.Skip(i) {
+			skipped++
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-bulkCtx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	completed += skipped
+
+	if err := canceled(ctx, completed, n-completed-len(failed)); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		sort.Slice(failed, func(i, j int) bool { return failed[i].Index < failed[j].Index })
+		return &BulkError{Total: n, Failed: failed}
+	}
+
+	return nil
+}
+
+// ArchivePagesResult is the result of ArchivePages and TrashPages.
+type ArchivePagesResult struct {
+	// Pages are the pages that matched the query. Pages that were archived (or
+	// trashed) are replaced by the updated page.
+	Pages []Page
+	// Archived is the number of pages that were archived (or trashed).
+	Archived int
+}
+
+// ArchivePages archives all pages of a database that match `query`, e.g. to
+// clear out rows matching a filter. All matching pages are queried first (so
+// archiving doesn't affect pagination), and then archived concurrently. The
+// indexes of a *BulkError refer to the result pages. To resume after a failure
+// or cancellation, call ArchivePages again: archived pages no longer match.
+func (c *Client) ArchivePages(ctx context.Context, databaseID string, query *DatabaseQuery, opts BulkOptions) (ArchivePagesResult, error) {
+	return c.updatePages(ctx, databaseID, query, opts, UpdatePageParams{Archived: BoolPtr(true)})
+}
+
+// TrashPages moves all pages of a database that match `query` to the trash.
+// It works like ArchivePages.
+func (c *Client) TrashPages(ctx context.Context, databaseID string, query *DatabaseQuery, opts BulkOptions) (ArchivePagesResult, error) {
+	return c.updatePages(ctx, databaseID, query, opts, UpdatePageParams{InTrash: BoolPtr(true)})
+}
+
+func (c *Client) updatePages(ctx context.Context, databaseID string, query *DatabaseQuery, opts BulkOptions, params UpdatePageParams) (ArchivePagesResult, error) {
+	pages, err := c.queryAllPages(ctx, databaseID, query)
+	if err != nil {
+		return ArchivePagesResult{}, err
+	}
+
+	result := ArchivePagesResult{Pages: pages}
+	updated := make([]bool, len(pages))
+
+	err = runBulk(ctx, len(pages), opts, func(ctx context.Context, i int) error {
+		page, err := c.UpdatePage(ctx, pages[i].ID, params)
+		if err != nil {
+			return err
+		}
+		result.Pages[i] = page
+		updated[i] = true
+		return nil
+	})
+
+	for _, ok := range updated {
+		if ok {
+			result.Archived++
+		}
+	}
+
+	return result, err
+}
+
+// queryAllPages returns all pages of a database that match `query`.
+func (c *Client) queryAllPages(ctx context.Context, databaseID string, query *DatabaseQuery) ([]Page, error) {
+	q := DatabaseQuery{PageSize: MaxPageSize}
+	if query != nil {
+		q = *query
+		if q.PageSize == 0 {
+			q.PageSize = MaxPageSize
+		}
+	}
+
+	var pages []Page
+
+	for {
+		if err := canceled(ctx, 0, -1); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.QueryDatabase(ctx, databaseID, &q)
+		if err != nil {
+			if err := canceled(ctx, 0, -1); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+		pages = append(pages, resp.Results...)
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return pages, nil
+		}
+		q.StartCursor = *resp.NextCursor
+	}
+}
@@ -0,0 +1,372 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+// bulkPagesTransport creates pages with the title as ID, and fails for titles
+// in `fail`.
+func bulkPagesTransport(t *testing.T, requests *int32, fail map[string]bool) http.RoundTripper {
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(requests, 1)
+
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/pages" {
+			t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+		}
+
+		var params struct {
+			Properties struct {
+				Title []notion.RichText `json:"title"`
+			} `json:"properties"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		title := notion.PlainText(params.Properties.Title)
+
+		if fail[title] {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     http.StatusText(http.StatusBadRequest),
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{"object": "error", "status": 400, "code": "validation_error", "message": "Foobar"}`,
+				)),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body: ioutil.NopCloser(strings.NewReader(fmt.Sprintf(
+				`{"object": "page", "id": %q, "parent": {"type": "page_id", "page_id": "parent-id"}, "properties": {}}`, title,
+			))),
+		}, nil
+	}}
+}
+
+func bulkPageParams(titles ...string) []notion.CreatePageParams {
+	params := make([]notion.CreatePageParams, len(titles))
+	for i, title := range titles {
+		params[i] = notion.CreatePageParams{
+			ParentType: notion.ParentTypePage,
+			ParentID:   "parent-id",
+			Title:      []notion.RichText{{Text: &notion.Text{Content: title}}},
+		}
+	}
+	return params
+}
+
+func createdPageIDs(result notion.CreatePagesResult) []string {
+	ids := make([]string, len(result.Pages))
+	for i, page := range result.Pages {
+		ids[i] = page.ID
+	}
+	return ids
+}
+
+func TestCreatePages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		titles      []string
+		fail        map[string]bool
+		opts        notion.BulkOptions
+		expIDs      []string
+		expRequests int32
+		expFailed   []int
+		expError    error
+	}{
+		{
+			name:        "all pages created",
+			titles:      []string{"a", "b", "c", "d", "e"},
+			opts:        notion.BulkOptions{Concurrency: 3},
+			expIDs:      []string{"a", "b", "c", "d", "e"},
+			expRequests: 5,
+		},
+		{
+			name:        "partial failure",
+			titles:      []string{"a", "b", "c", "d", "e"},
+			fail:        map[string]bool{"b": true, "d": true},
+			opts:        notion.BulkOptions{Concurrency: 2},
+			expIDs:      []string{"a", "", "c", "", "e"},
+			expRequests: 5,
+			expFailed:   []int{1, 3},
+			expError: errors.New(
+				"notion: 2 of 5 items failed (first: item [1]: notion: failed to create page: Foobar (code: validation_error, status: 400))",
+			),
+		},
+		{
+			name:   "stop on error",
+			titles: []string{"a", "b", "c"},
+			fail:   map[string]bool{"a": true},
+			opts:   notion.BulkOptions{StopOnError: true},
+			expIDs: []string{"", "", ""},
+			// The failing page is the only request.
+			expRequests: 1,
+			expFailed:   []int{0},
+			expError: errors.New(
+				"notion: 1 of 3 items failed (first: item [0]: notion: failed to create page: Foobar (code: validation_error, status: 400))",
+			),
+		},
+		{
+			name:        "skip",
+			titles:      []string{"a", "b", "c"},
+			opts:        notion.BulkOptions{Skip: func(i int) bool { return i != 1 }},
+			expIDs:      []string{"", "b", ""},
+			expRequests: 1,
+		},
+		{
+			name:        "rate limited",
+			titles:      []string{"a", "b", "c"},
+			opts:        notion.BulkOptions{Concurrency: 3, RequestsPerSecond: 100},
+			expIDs:      []string{"a", "b", "c"},
+			expRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests int32
+			httpClient := &http.Client{Transport: bulkPagesTransport(t, &requests, tt.fail)}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			result, err := client.CreatePages(context.Background(), bulkPageParams(tt.titles...), tt.opts)
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && err == nil {
+				t.Fatalf("error not equal (expected: %v, got: nil)", tt.expError)
+			}
+			if tt.expError != nil && err != nil && tt.expError.Error() != err.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			if tt.expFailed != nil {
+				var bulkErr *notion.BulkError
+				if !errors.As(err, &bulkErr) {
+					t.Fatalf("expected *notion.BulkError, got: %T", err)
+				}
+				var failed []int
+				for _, failure := range bulkErr.Failed {
+					failed = append(failed, failure.Index)
+					if !errors.Is(failure.Err, notion.ErrValidation) {
+						t.Fatalf("unexpected failure error: %v", failure.Err)
+					}
+				}
+				if diff := cmp.Diff(tt.expFailed, failed); diff != "" {
+					t.Fatalf("failed indexes not equal (-exp, +got):\n%v", diff)
+				}
+			}
+
+			if diff := cmp.Diff(tt.expIDs, createdPageIDs(result)); diff != "" {
+				t.Fatalf("page IDs not equal (-exp, +got):\n%v", diff)
+			}
+			if requests != tt.expRequests {
+				t.Fatalf("requests not equal (expected: %v, got: %v)", tt.expRequests, requests)
+			}
+		})
+	}
+}
+
+func TestCreatePagesResume(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	fail := map[string]bool{"b": true}
+	httpClient := &http.Client{Transport: bulkPagesTransport(t, &requests, fail)}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+	params := bulkPageParams("a", "b", "c")
+
+	result, err := client.CreatePages(context.Background(), params, notion.BulkOptions{})
+	if err == nil {
+		t.Fatal("expected error, got: nil")
+	}
+	if result.Created() != 2 {
+		t.Fatalf("expected 2 created pages, got: %v", result.Created())
+	}
+
+	delete(fail, "b")
+	atomic.StoreInt32(&requests, 0)
+
+	retried, err := client.CreatePages(context.Background(), params, notion.BulkOptions{Skip: result.IsCreated})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"", "b", ""}, createdPageIDs(retried)); diff != "" {
+		t.Fatalf("page IDs not equal (-exp, +got):\n%v", diff)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request, got: %v", requests)
+	}
+}
+
+func TestCreatePagesCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int32
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if atomic.AddInt32(&requests, 1) == 2 {
+				cancel()
+				return nil, r.Context().Err()
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{"object": "page", "id": "page-id", "parent": {"type": "page_id", "page_id": "parent-id"}, "properties": {}}`,
+				)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	result, err := client.CreatePages(ctx, bulkPageParams("a", "b", "c", "d"), notion.BulkOptions{})
+
+	var canceledErr *notion.ErrCanceled
+	if !errors.As(err, &canceledErr) {
+		t.Fatalf("expected *notion.ErrCanceled, got: %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error not equal (expected: %v, got: %v)", context.Canceled, err)
+	}
+	if canceledErr.Completed != 1 || canceledErr.Remaining != 3 {
+		t.Fatalf("unexpected progress (completed: %v, remaining: %v)", canceledErr.Completed, canceledErr.Remaining)
+	}
+	if result.Created() != 1 {
+		t.Fatalf("expected 1 created page, got: %v", result.Created())
+	}
+}
+
+func TestArchivePages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		archive     func(client *notion.Client) (notion.ArchivePagesResult, error)
+		expBody     string
+		expArchived int
+		expError    error
+	}{
+		{
+			name: "archive",
+			archive: func(client *notion.Client) (notion.ArchivePagesResult, error) {
+				return client.ArchivePages(context.Background(), "db-id", &notion.DatabaseQuery{
+					Filter: &notion.DatabaseQueryFilter{
+						Property: "Done",
+						DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+							Checkbox: &notion.CheckboxDatabaseQueryFilter{Equals: notion.BoolPtr(true)},
+						},
+					},
+				}, notion.BulkOptions{Concurrency: 2})
+			},
+			expBody:     `{"archived":true}`,
+			expArchived: 2,
+			expError: errors.New(
+				"notion: 1 of 3 items failed (first: item [1]: notion: failed to update page properties: Foobar (code: object_not_found, status: 404))",
+			),
+		},
+		{
+			name: "trash",
+			archive: func(client *notion.Client) (notion.ArchivePagesResult, error) {
+				return client.TrashPages(context.Background(), "db-id", nil, notion.BulkOptions{})
+			},
+			expBody:     `{"in_trash":true}`,
+			expArchived: 2,
+			expError: errors.New(
+				"notion: 1 of 3 items failed (first: item [1]: notion: failed to update page properties: Foobar (code: object_not_found, status: 404))",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					body, _ := ioutil.ReadAll(r.Body)
+					status, respBody := http.StatusOK, ""
+
+					switch {
+					case r.Method == http.MethodPost && r.URL.Path == "/v1/databases/db-id/query":
+						var query notion.DatabaseQuery
+						if err := json.Unmarshal(body, &query); err != nil {
+							t.Errorf("failed to decode query: %v", err)
+						}
+						if query.PageSize != 100 {
+							t.Errorf("unexpected page size: %v", query.PageSize)
+						}
+						if query.StartCursor == "" {
+							respBody = `{"object": "list", "results": [{"object": "page", "id": "p1", "parent": {"type": "database_id", "database_id": "db-id"}, "properties": {}}, {"object": "page", "id": "p2", "parent": {"type": "database_id", "database_id": "db-id"}, "properties": {}}], "has_more": true, "next_cursor": "cursor"}`
+						} else {
+							respBody = `{"object": "list", "results": [{"object": "page", "id": "p3", "parent": {"type": "database_id", "database_id": "db-id"}, "properties": {}}], "has_more": false, "next_cursor": null}`
+						}
+					case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/v1/pages/"):
+						if got := strings.TrimSpace(string(body)); got != tt.expBody {
+							t.Errorf("request body not equal (expected: %v, got: %v)", tt.expBody, got)
+						}
+						id := strings.TrimPrefix(r.URL.Path, "/v1/pages/")
+						if id == "p2" {
+							status = http.StatusNotFound
+							respBody = `{"object": "error", "status": 404, "code": "object_not_found", "message": "Foobar"}`
+						} else {
+							respBody = fmt.Sprintf(`{"object": "page", "id": %q, "archived": true, "in_trash": true, "parent": {"type": "database_id", "database_id": "db-id"}, "properties": {}}`, id)
+						}
+					default:
+						t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+					}
+
+					return &http.Response{
+						StatusCode: status,
+						Status:     http.StatusText(status),
+						Body:       ioutil.NopCloser(strings.NewReader(respBody)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			result, err := tt.archive(client)
+
+			if err == nil || err.Error() != tt.expError.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+			if result.Archived != tt.expArchived {
+				t.Fatalf("archived not equal (expected: %v, got: %v)", tt.expArchived, result.Archived)
+			}
+
+			var ids []string
+			var archived []bool
+			for _, page := range result.Pages {
+				ids = append(ids, page.ID)
+				archived = append(archived, page.Archived)
+			}
+			if diff := cmp.Diff([]string{"p1", "p2", "p3"}, ids); diff != "" {
+				t.Fatalf("page IDs not equal (-exp, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff([]bool{true, false, true}, archived); diff != "" {
+				t.Fatalf("archived pages not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package notion
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores objects fetched by the client, keyed by object type and ID. The
+// object type is `page`, `database` or `block_children` (the first page of
+// children of a block, with default pagination). IDs are passed without dashes.
+//
+// Implementations decide how long entries are kept, and must be safe for
+// concurrent use. See WithCache, and WithReadCache for an in-memory cache.
+type Cache interface {
+	// Get returns the entry for an object, if cached.
+	Get(objectType, id string) (CacheEntry, bool)
+	// Set stores the entry for an object. It's called with an existing entry
+	// when the object is known to be up to date, so time based expiry should
+	// restart.
+	Set(objectType, id string, entry CacheEntry)
+	// Delete removes the entries for the objects with ID `id`, of all types.
+	Delete(id string)
+}
+
+// CacheEntry is a cached object.
+type CacheEntry struct {
+	// Value is the JSON of the object, as returned by the API.
+	Value []byte
+
+	// LastEditedTime is the `last_edited_time` of the object. It's zero for
+	// block children.
+	LastEditedTime time.Time
+
+	// FetchedAt is the time the object was fetched.
+	FetchedAt time.Time
+}
+
+// WithCache enables a read-through cache for FindPageByID, FindDatabaseByID
+// and FindBlockChildrenByID. Pages fetched with filtered properties, and block
+// children fetched with a pagination query, aren't cached.
+//
+// Cached objects are removed when they're modified via the client (e.g.
+// UpdatePage, AppendBlockChildren, UpdateBlock, DeleteBlock), including the
+// block children of their parent. Use InvalidateCache to remove objects
+// modified by others.
+//
+// The API doesn't support conditional requests, so cached pages and databases
+// are revalidated by comparing their `last_edited_time` with objects returned
+// by QueryDatabase, QueryDataSource, QueryDatabaseStream and Search: unchanged
+// entries are stored again (see Cache.Set), and edited ones are removed. This
+// way, a sync tool that queries a database for changes only fetches pages that
+// were edited. Notion rounds `last_edited_time` down to the minute, so an entry
+// is only revalidated when it was fetched at least a minute after it was last
+// edited; otherwise an edit in the same minute would go unnoticed.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithReadCache enables the read-through cache (see WithCache) with an
+// in-memory Cache, with entries expiring `ttl` after they were stored. This is
+// useful for dashboards that refetch the same pages every few seconds.
+func WithReadCache(ttl time.Duration) ClientOption {
+	return WithCache(&readCache{
+		ttl:     ttl,
+		entries: make(map[string]map[string]readCacheEntry),
+	})
+}
+
+// InvalidateCache removes cached entries for the page, database or block with
+// ID `id`, and for its block children. It's a no-op if no cache is enabled.
+// See WithCache.
+func (c *Client) InvalidateCache(id string) {
+	c.invalidateCached(id)
+}
+
+// readCache is the in-memory Cache of WithReadCache. It stores response bodies,
+// which are decoded on every hit, so callers can't modify cached values.
+type readCache struct {
+	ttl time.Duration
+
+	mu sync.Mutex
+	// entries are keyed by ID, then object type.
+	entries map[string]map[string]readCacheEntry
+	inserts int
+}
+
+// readCacheSweepInterval is the number of inserts after which expired entries
+// are removed from the read cache.
+const readCacheSweepInterval = 100
+
+type readCacheEntry struct {
+	CacheEntry
+	expires time.Time
+}
+
+func (rc *readCache) Get(objectType, id string) (CacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[id][objectType]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	if !time.Now().Before(entry.expires) {
+		rc.delete(objectType, id)
+		return CacheEntry{}, false
+	}
+
+	return entry.CacheEntry, true
+}
+
+func (rc *readCache) Set(objectType, id string, entry CacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := time.Now()
+
+	// Periodically remove expired entries, so the cache doesn't grow with
+	// entries that aren't requested again. Other expired entries are removed
+	// when requested.
+	rc.inserts++
+	if rc.inserts%readCacheSweepInterval == 0 {
+		for id, byType := range rc.entries {
+			for objectType, entry := range byType {
+				if !now.Before(entry.expires) {
+					rc.delete(objectType, id)
+				}
+			}
+		}
+	}
+
+	if rc.entries[id] == nil {
+		rc.entries[id] = make(map[string]readCacheEntry)
+	}
+	rc.entries[id][objectType] = readCacheEntry{CacheEntry: entry, expires: now.Add(rc.ttl)}
+}
+
+func (rc *readCache) Delete(id string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.entries, id)
+}
+
+func (rc *readCache) delete(objectType, id string) {
+	delete(rc.entries[id], objectType)
+	if len(rc.entries[id]) == 0 {
+		delete(rc.entries, id)
+	}
+}
+
+// editTimeResolution is the precision of `last_edited_time` values.
+const editTimeResolution = time.Minute
+
+// normalizeID returns an ID without dashes, as Notion IDs can be used with and
+// without dashes.
+func normalizeID(id string) string {
+	return strings.ReplaceAll(id, "-", "")
+}
+
+// findCached decodes the cached object into `v`, if any.
+func (c *Client) findCached(objectType, id string, v interface{}) bool {
+	if c.cache == nil {
+		return false
+	}
+
+	entry, ok := c.cache.Get(objectType, normalizeID(id))
+	if !ok {
+		return false
+	}
+
+	return c.unmarshal(entry.Value, v) == nil
+}
+
+// decodeCached decodes a response body into `v`, and stores it in the cache
+// (if enabled).
+func (c *Client) decodeCached(objectType, id string, r io.Reader, v interface{}) error {
+	if c.cache == nil {
+		return c.decode(r, v)
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if err := c.unmarshal(body, v); err != nil {
+		return err
+	}
+
+	entry := CacheEntry{Value: body, FetchedAt: time.Now()}
+	switch v := v.(type) {
+	case *Page:
+		entry.LastEditedTime = v.LastEditedTime
+	case *Database:
+		entry.LastEditedTime = v.LastEditedTime
+	}
+
+	c.cache.Set(objectType, normalizeID(id), entry)
+
+	return nil
+}
+
+// revalidateCached stores the cached object again if it wasn't edited since it
+// was fetched, or removes it otherwise. See WithCache.
+func (c *Client) revalidateCached(objectType, id string, lastEdited time.Time) {
+	if c.cache == nil {
+		return
+	}
+
+	id = normalizeID(id)
+
+	entry, ok := c.cache.Get(objectType, id)
+	if !ok || entry.LastEditedTime.IsZero() {
+		return
+	}
+
+	if !entry.LastEditedTime.Equal(lastEdited) {
+		c.cache.Delete(id)
+		return
+	}
+	if entry.FetchedAt.Sub(entry.LastEditedTime) < editTimeResolution {
+		return
+	}
+
+	c.cache.Set(objectType, id, entry)
+}
+
+// revalidateCachedPages revalidates the cached entries of pages.
+func (c *Client) revalidateCachedPages(pages []Page) {
+	for _, page := range pages {
+		c.revalidateCached("page", page.ID, page.LastEditedTime)
+	}
+}
+
+// revalidateCachedDatabases revalidates the cached entries of databases.
+func (c *Client) revalidateCachedDatabases(dbs []Database) {
+	for _, db := range dbs {
+		c.revalidateCached("database", db.ID, db.LastEditedTime)
+	}
+}
+
+// invalidateCached removes cached objects (and block children) by ID.
+func (c *Client) invalidateCached(ids ...string) {
+	if c.cache == nil {
+		return
+	}
+
+	for _, id := range ids {
+		if id != "" {
+			c.cache.Delete(normalizeID(id))
+		}
+	}
+}
+
+// parentID returns the ID of a parent page or block, or an empty string.
+func parentID(parent Parent) string {
+	if parent.PageID != "" {
+		return parent.PageID
+	}
+	return parent.BlockID
+}
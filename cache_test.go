@@ -0,0 +1,348 @@
+package notion_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// cacheTransport responds to page and block requests, counting requests per
+// method and path.
+func cacheTransport(requests map[string]int) *mockRoundtripper {
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		requests[r.Method+" "+r.URL.Path]++
+
+		body := `{"object": "list", "results": [], "has_more": false, "next_cursor": null}`
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1/pages/"):
+			body = `{"object": "page", "id": "page-id", "parent": {"type": "page_id", "page_id": "parent-id"}, "properties": {"title": {"id": "title", "type": "title", "title": []}}}`
+		case r.Method == http.MethodDelete:
+			body = `{"object": "block", "id": "block-id", "parent": {"type": "page_id", "page_id": "page-id"}, "type": "divider", "divider": {}}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	}}
+}
+
+func TestReadCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	requests := map[string]int{}
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: cacheTransport(requests)}),
+		notion.WithReadCache(time.Minute),
+	)
+
+	findPage := func() {
+		t.Helper()
+		page, err := client.FindPageByID(ctx, "page-id")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if page.ID != "page-id" {
+			t.Fatalf("unexpected page ID: %v", page.ID)
+		}
+	}
+	findChildren := func(query *notion.PaginationQuery) {
+		t.Helper()
+		if _, err := client.FindBlockChildrenByID(ctx, "page-id", query); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	assertRequests := func(key string, exp int) {
+		t.Helper()
+		if got := requests[key]; got != exp {
+			t.Fatalf("requests for %q not equal (expected: %v, got: %v)", key, exp, got)
+		}
+	}
+
+	findPage()
+	findPage()
+	assertRequests("GET /v1/pages/page-id", 1)
+
+	findChildren(nil)
+	findChildren(nil)
+	findChildren(&notion.PaginationQuery{StartCursor: "cursor"})
+	assertRequests("GET /v1/blocks/page-id/children", 2)
+
+	// Updating a page invalidates the page and its block children.
+	if _, err := client.UpdatePage(ctx, "page-id", notion.UpdatePageParams{Archived: notion.BoolPtr(false)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	findPage()
+	findChildren(nil)
+	assertRequests("GET /v1/pages/page-id", 2)
+	assertRequests("GET /v1/blocks/page-id/children", 3)
+
+	// Deleting a block invalidates the block children of its parent.
+	if _, err := client.DeleteBlock(ctx, "block-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	findChildren(nil)
+	assertRequests("GET /v1/blocks/page-id/children", 4)
+
+	// Appending block children invalidates the block children.
+	if _, err := client.AppendBlockChildren(ctx, "page-id", []notion.Block{notion.DividerBlock{}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	findChildren(nil)
+	assertRequests("GET /v1/blocks/page-id/children", 5)
+
+	// Explicit invalidation.
+	findPage()
+	findPage()
+	assertRequests("GET /v1/pages/page-id", 3)
+	client.InvalidateCache("page-id")
+	findPage()
+	assertRequests("GET /v1/pages/page-id", 4)
+}
+
+func TestReadCacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	requests := map[string]int{}
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: cacheTransport(requests)}),
+		notion.WithReadCache(time.Millisecond),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.FindPageByID(context.Background(), "page-id"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := requests["GET /v1/pages/page-id"]; got != 2 {
+		t.Fatalf("expected 2 requests, got %v", got)
+	}
+}
+
+func TestReadCacheIDFormats(t *testing.T) {
+	t.Parallel()
+
+	requests := map[string]int{}
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: cacheTransport(requests)}),
+		notion.WithReadCache(time.Minute),
+	)
+
+	// IDs with and without dashes share a cache entry.
+	for _, id := range []string{"b0668f48-8d66-4733-9bdb-2f82215707f7", "b0668f488d6647339bdb2f82215707f7"} {
+		if _, err := client.FindBlockChildrenByID(context.Background(), id, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := requests["GET /v1/blocks/b0668f48-8d66-4733-9bdb-2f82215707f7/children"]; got != 1 {
+		t.Fatalf("expected 1 request, got %v", got)
+	}
+}
+
+// revalidationTransport serves a page and a database, and a query response with
+// the page, edited at `*lastEdited`. Requests are counted by method and path.
+func revalidationTransport(mu *sync.Mutex, lastEdited *string, requests map[string]int) http.RoundTripper {
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		key := r.Method + " " + r.URL.Path
+		requests[key]++
+
+		page := `{"object": "page", "id": "page-id", "last_edited_time": "` + *lastEdited + `", "parent": {"type": "database_id", "database_id": "db-id"}, "properties": {}}`
+
+		var body string
+		switch key {
+		case "GET /v1/pages/page-id", "PATCH /v1/pages/page-id":
+			body = page
+		case "GET /v1/databases/db-id":
+			body = `{"object": "database", "id": "db-id", "last_edited_time": "2021-05-19T18:34:00.000Z"}`
+		case "POST /v1/databases/db-id/query":
+			body = `{"object": "list", "results": [` + page + `], "has_more": false, "next_cursor": null}`
+		default:
+			body = `{"object": "error", "status": 404, "code": "object_not_found", "message": "Not found."}`
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     http.StatusText(http.StatusNotFound),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	}}
+}
+
+// mapCache is a Cache without expiry, which counts stores per key.
+type mapCache struct {
+	mu      sync.Mutex
+	entries map[string]notion.CacheEntry
+	sets    map[string]int
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{entries: make(map[string]notion.CacheEntry), sets: make(map[string]int)}
+}
+
+func (mc *mapCache) Get(objectType, id string) (notion.CacheEntry, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry, ok := mc.entries[objectType+"/"+id]
+	return entry, ok
+}
+
+func (mc *mapCache) Set(objectType, id string, entry notion.CacheEntry) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.entries[objectType+"/"+id] = entry
+	mc.sets[objectType+"/"+id]++
+}
+
+func (mc *mapCache) Delete(id string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for key := range mc.entries {
+		if strings.HasSuffix(key, "/"+id) {
+			delete(mc.entries, key)
+		}
+	}
+}
+
+func (mc *mapCache) setCount(key string) int {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return mc.sets[key]
+}
+
+func TestWithCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu         sync.Mutex
+		lastEdited = "2021-05-19T18:34:00.000Z"
+		requests   = make(map[string]int)
+	)
+
+	ctx := context.Background()
+	cache := newMapCache()
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: revalidationTransport(&mu, &lastEdited, requests)}),
+		notion.WithCache(cache),
+	)
+
+	findPage := func(expRequests int) {
+		t.Helper()
+
+		page, err := client.FindPageByID(ctx, "page-id")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if page.ID != "page-id" {
+			t.Fatalf("page ID not equal (expected: page-id, got: %v)", page.ID)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if got := requests["GET /v1/pages/page-id"]; got != expRequests {
+			t.Fatalf("requests not equal (expected: %v, got: %v)", expRequests, got)
+		}
+	}
+
+	query := func() {
+		t.Helper()
+
+		if _, err := client.QueryDatabase(ctx, "db-id", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	assertSets := func(exp int) {
+		t.Helper()
+
+		if got := cache.setCount("page/pageid"); got != exp {
+			t.Fatalf("stores not equal (expected: %v, got: %v)", exp, got)
+		}
+	}
+
+	// Fetched, then served from the cache.
+	findPage(1)
+	findPage(1)
+	assertSets(1)
+
+	// Stored again by a query with an unchanged `last_edited_time`.
+	query()
+	assertSets(2)
+	findPage(1)
+
+	// Removed by a query with a newer `last_edited_time`.
+	mu.Lock()
+	lastEdited = "2021-05-20T09:00:00.000Z"
+	mu.Unlock()
+	query()
+	findPage(2)
+
+	// Removed when updated via the client.
+	if _, err := client.UpdatePage(ctx, "page-id", notion.UpdatePageParams{Archived: notion.BoolPtr(false)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	findPage(3)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.FindDatabaseByID(ctx, "db-id"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if got := requests["GET /v1/databases/db-id"]; got != 1 {
+		t.Fatalf("database requests not equal (expected: 1, got: %v)", got)
+	}
+}
+
+func TestWithCacheRecentlyEdited(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu         sync.Mutex
+		lastEdited = time.Now().UTC().Truncate(time.Minute).Format(time.RFC3339)
+		requests   = make(map[string]int)
+	)
+
+	ctx := context.Background()
+	cache := newMapCache()
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: revalidationTransport(&mu, &lastEdited, requests)}),
+		notion.WithCache(cache),
+	)
+
+	if _, err := client.FindPageByID(ctx, "page-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Edits within the minute of `last_edited_time` can't be detected, so the
+	// entry isn't revalidated.
+	if _, err := client.QueryDatabase(ctx, "db-id", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := cache.setCount("page/pageid"); got != 1 {
+		t.Fatalf("stores not equal (expected: 1, got: %v)", got)
+	}
+}
@@ -0,0 +1,67 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrCanceled is returned by helpers that make multiple requests, when their
+// context is canceled (or its deadline is exceeded) before all requests are
+// done. These helpers check the context between requests, and return their
+// partial results along with this error, so callers can resume work:
+//
+//   - AppendBlockChildrenAll returns the created blocks. Completed and
+//     Remaining are numbers of children, so the remaining children are
+//     `children[Completed:]`, to be appended after the last created block.
+//   - AppendBlockChildrenDeep returns the created top level blocks. Completed
+//     and Remaining are numbers of blocks, including nested blocks.
+//   - FindBlockChildrenRecursive returns the blocks found so far, which can
+//     have incomplete children. Completed is the number of blocks found.
+//   - CountPages returns the number of pages counted so far, which is also
+//     Completed.
+//   - RecentlyEdited returns the objects found so far. Completed is the number
+//     of objects.
+//   - SearchPages and SearchDatabases return the objects found so far.
+//     Completed is the number of objects.
+//   - Repository.List returns the values found so far. Completed is the number
+//     of values.
+//   - CreatePages returns the created pages. Completed and Remaining are
+//     numbers of pages; see CreatePagesResult.IsCreated to resume.
+//   - ArchivePages and TrashPages return the matching pages, or no pages when
+//     canceled while querying. Completed and Remaining are numbers of pages.
+//   - SyncDatabase returns the pages found so far, and their high-water mark.
+//     Completed is the number of pages.
+//   - ListAllUsers, ListAllComments, QueryDatabaseAllPages and
+//     FindAllBlockChildren return the items found so far. Completed is the
+//     number of items.
+//
+// Remaining is -1 when unknown. Use errors.As to access the fields, and
+// errors.Is with context.Canceled or context.DeadlineExceeded to find the
+// cause.
+type ErrCanceled struct {
+	Completed int
+	Remaining int
+
+	// Err is the error of the context.
+	Err error
+}
+
+// Error implements `error`.
+func (err *ErrCanceled) Error() string {
+	if err.Remaining < 0 {
+		return fmt.Sprintf("notion: canceled after %v completed: %v", err.Completed, err.Err)
+	}
+	return fmt.Sprintf("notion: canceled after %v completed, %v remaining: %v", err.Completed, err.Remaining, err.Err)
+}
+
+func (err *ErrCanceled) Unwrap() error {
+	return err.Err
+}
+
+// canceled returns an *ErrCanceled if ctx is done, else nil.
+func canceled(ctx context.Context, completed, remaining int) error {
+	if err := ctx.Err(); err != nil {
+		return &ErrCanceled{Completed: completed, Remaining: remaining, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// cancelTransport cancels a context after `n` requests were made via the
+// underlying transport. Requests made after cancellation fail.
+type cancelTransport struct {
+	transport http.RoundTripper
+	cancel    context.CancelFunc
+	n         int
+
+	mu       sync.Mutex
+	requests int
+}
+
+func (c *cancelTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := r.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.transport.RoundTrip(r)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	if c.requests == c.n {
+		c.cancel()
+	}
+
+	return resp, err
+}
+
+func newCancelClient(transport http.RoundTripper, n int) (*notion.Client, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	httpClient := &http.Client{Transport: &cancelTransport{transport: transport, cancel: cancel, n: n}}
+	return notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient)), ctx
+}
+
+func assertCanceled(t *testing.T, err error, expCompleted, expRemaining int) {
+	t.Helper()
+
+	var canceledErr *notion.ErrCanceled
+	if !errors.As(err, &canceledErr) {
+		t.Fatalf("expected *notion.ErrCanceled, got: %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error to wrap context.Canceled, got: %v", err)
+	}
+	if canceledErr.Completed != expCompleted {
+		t.Errorf("completed not equal (expected: %v, got: %v)", expCompleted, canceledErr.Completed)
+	}
+	if canceledErr.Remaining != expRemaining {
+		t.Errorf("remaining not equal (expected: %v, got: %v)", expRemaining, canceledErr.Remaining)
+	}
+}
+
+func TestAppendBlockChildrenAllCanceled(t *testing.T) {
+	t.Parallel()
+
+	var requests []appendRequest
+	client, ctx := newCancelClient(appendTransport(t, &requests, 0), 1)
+	children, _ := appendParagraphs(250)
+
+	resp, err := client.AppendBlockChildrenAll(ctx, "parent", children, nil)
+	assertCanceled(t, err, 100, 150)
+
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %v", len(requests))
+	}
+	if len(resp.Results) != 100 {
+		t.Fatalf("expected 100 results, got %v", len(resp.Results))
+	}
+	if exp, got := "b99", resp.Results[99].ID(); exp != got {
+		t.Fatalf("last block ID not equal (expected: %v, got: %v)", exp, got)
+	}
+}
+
+func TestAppendBlockChildrenDeepCanceled(t *testing.T) {
+	t.Parallel()
+
+	p := func(text string, children ...notion.Block) notion.Block {
+		return &notion.ParagraphBlock{
+			RichText: []notion.RichText{{Text: &notion.Text{Content: text}}},
+			Children: children,
+		}
+	}
+
+	input := []notion.Block{
+		p("a", p("b", p("c", p("d", p("e"))))),
+		p("f"),
+	}
+
+	store := &fakeBlockStore{t: t, children: map[string][]string{}, text: map[string]string{}}
+	client, ctx := newCancelClient(store, 1)
+
+	created, err := client.AppendBlockChildrenDeep(ctx, "root", input)
+	assertCanceled(t, err, 4, 2)
+
+	if len(created) != 2 {
+		t.Fatalf("expected 2 created blocks, got %v", len(created))
+	}
+	if exp, got := "a(b(c)),f", store.tree("root"); exp != got {
+		t.Fatalf("tree not equal (expected: %v, got: %v)", exp, got)
+	}
+}
+
+func TestFindBlockChildrenRecursiveCanceled(t *testing.T) {
+	t.Parallel()
+
+	// Cancel after both pages of root children were fetched.
+	client, ctx := newCancelClient(blockTreeTransport(t, ""), 2)
+
+	blocks, err := client.FindBlockChildrenRecursive(ctx, "root", nil)
+	assertCanceled(t, err, 3, -1)
+
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %v", len(blocks))
+	}
+}
+
+func TestCountPagesCanceled(t *testing.T) {
+	t.Parallel()
+
+	transport := &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		page := `{"object": "page", "parent": {"type": "database_id", "database_id": "db"}, "properties": {"Name": {"id": "title", "type": "title", "title": []}}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"object": "list", "results": [` + page + `,` + page + `], "has_more": true, "next_cursor": "cursor-1"}`,
+			)),
+		}, nil
+	}}
+	client, ctx := newCancelClient(transport, 1)
+
+	count, err := client.CountPages(ctx, "db", nil)
+	assertCanceled(t, err, 2, -1)
+
+	if count != 2 {
+		t.Fatalf("count not equal (expected: 2, got: %v)", count)
+	}
+}
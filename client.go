@@ -3,6 +3,7 @@ package notion
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,18 +11,38 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 )
 
 const (
-	baseURL       = "https://api.notion.com/v1"
-	apiVersion    = "2022-06-28"
-	clientVersion = "0.0.0"
+	defaultBaseURL = "https://api.notion.com/v1"
+	apiVersion     = "2022-06-28"
+	clientVersion  = "0.0.0"
 )
 
 // Client is used for HTTP requests to the Notion API.
 type Client struct {
 	apiKey     string
+	apiVersion string
+	baseURL    string
 	httpClient *http.Client
+
+	disableRedirects bool
+	tlsConfig        *tls.Config
+	debugWriter      io.Writer
+	dumpRequests     *bool
+	dumping          atomic.Bool
+	requestHooks     []func(*http.Request)
+	responseHooks    []func(*http.Response)
+	logger           Logger
+	cache            Cache
+	strictValidation bool
+	timeout          time.Duration
+	rateLimiter      *rateLimiter
+	captureRaw       bool
+	middlewares      []Middleware
 }
 
 // ClientOption is used to override default client behavior.
@@ -31,6 +52,8 @@ type ClientOption func(*Client)
 func NewClient(apiKey string, opts ...ClientOption) *Client {
 	c := &Client{
 		apiKey:     apiKey,
+		apiVersion: apiVersion,
+		baseURL:    defaultBaseURL,
 		httpClient: http.DefaultClient,
 	}
 
@@ -38,6 +61,8 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 		opt(c)
 	}
 
+	c.httpClient = c.wrapHTTPClient(c.httpClient)
+
 	return c
 }
 
@@ -48,14 +73,54 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithAPIVersion overrides the default Notion API version, sent via the
+// `Notion-Version` header. Types in this package are modeled after the default
+// version. For older versions, request bodies are reshaped where fields were
+// renamed (e.g. `text` to `rich_text` in filters and blocks), so existing code
+// keeps working while upgrading.
+// See: https://developers.notion.com/reference/versioning
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// WithBaseURL overrides the base URL of the Notion API (including the version
+// path), e.g. `http://localhost:8080/v1` for an API-compatible mock server, or
+// the URL of a proxy or gateway. The API key is only sent to the origin of the
+// base URL. If the URL isn't absolute, requests fail.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithoutRedirects disables following HTTP redirects. When the Notion API (or
+// a proxy in between) responds with a redirect, the request fails with an error
+// that wraps ErrRedirectsDisabled.
+func WithoutRedirects() ClientOption {
+	return func(c *Client) {
+		c.disableRedirects = true
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for connections to the Notion
+// API, e.g. to trust the CA of a TLS-intercepting proxy via `RootCAs`. The
+// transport of the http.Client (or http.DefaultTransport) is cloned, so it's
+// not modified. The transport must be an *http.Transport, else requests fail.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = config
+	}
+}
+
 func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequestWithContext(ctx, method, baseURL+url, body)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", c.apiKey))
-	req.Header.Set("Notion-Version", apiVersion)
+	req.Header.Set("Notion-Version", c.apiVersion)
 	req.Header.Set("User-Agent", "go-notion/"+clientVersion)
 
 	if body != nil {
@@ -68,6 +133,10 @@ func (c *Client) newRequest(ctx context.Context, method, url string, body io.Rea
 // FindDatabaseByID fetches a database by ID.
 // See: https://developers.notion.com/reference/get-database
 func (c *Client) FindDatabaseByID(ctx context.Context, id string) (db Database, err error) {
+	if c.findCached("database", id, &db) {
+		return db, nil
+	}
+
 	req, err := c.newRequest(ctx, http.MethodGet, "/databases/"+id, nil)
 	if err != nil {
 		return Database{}, fmt.Errorf("notion: invalid request: %w", err)
@@ -75,7 +144,7 @@ func (c *Client) FindDatabaseByID(ctx context.Context, id string) (db Database,
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return Database{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return Database{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -83,7 +152,7 @@ func (c *Client) FindDatabaseByID(ctx context.Context, id string) (db Database,
 		return Database{}, fmt.Errorf("notion: failed to find database: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&db)
+	err = c.decodeCached("database", id, res.Body, &db)
 	if err != nil {
 		return Database{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -108,9 +177,13 @@ func (c *Client) QueryDatabase(ctx context.Context, id string, query *DatabaseQu
 		return DatabaseQueryResponse{}, fmt.Errorf("notion: invalid request: %w", err)
 	}
 
+	if query != nil && len(query.FilterProperties) > 0 {
+		req.URL.RawQuery = url.Values{"filter_properties": query.FilterProperties}.Encode()
+	}
+
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return DatabaseQueryResponse{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return DatabaseQueryResponse{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -118,10 +191,11 @@ func (c *Client) QueryDatabase(ctx context.Context, id string, query *DatabaseQu
 		return DatabaseQueryResponse{}, fmt.Errorf("notion: failed to query database: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = c.decode(res.Body, &result)
 	if err != nil {
 		return DatabaseQueryResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
+	c.revalidateCachedPages(result.Results)
 
 	return result, nil
 }
@@ -130,7 +204,7 @@ func (c *Client) QueryDatabase(ctx context.Context, id string, query *DatabaseQu
 // See: https://developers.notion.com/reference/create-a-database
 func (c *Client) CreateDatabase(ctx context.Context, params CreateDatabaseParams) (db Database, err error) {
 	if err := params.Validate(); err != nil {
-		return Database{}, fmt.Errorf("notion: invalid database params: %w", err)
+		return Database{}, invalidParams("database params", err)
 	}
 
 	body := &bytes.Buffer{}
@@ -147,7 +221,7 @@ func (c *Client) CreateDatabase(ctx context.Context, params CreateDatabaseParams
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return Database{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return Database{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -155,7 +229,7 @@ func (c *Client) CreateDatabase(ctx context.Context, params CreateDatabaseParams
 		return Database{}, fmt.Errorf("notion: failed to create database: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&db)
+	err = c.decode(res.Body, &db)
 	if err != nil {
 		return Database{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -167,7 +241,7 @@ func (c *Client) CreateDatabase(ctx context.Context, params CreateDatabaseParams
 // See: https://developers.notion.com/reference/update-a-database
 func (c *Client) UpdateDatabase(ctx context.Context, databaseID string, params UpdateDatabaseParams) (updatedDB Database, err error) {
 	if err := params.Validate(); err != nil {
-		return Database{}, fmt.Errorf("notion: invalid database params: %w", err)
+		return Database{}, invalidParams("database params", err)
 	}
 
 	body := &bytes.Buffer{}
@@ -183,8 +257,9 @@ func (c *Client) UpdateDatabase(ctx context.Context, databaseID string, params U
 	}
 
 	res, err := c.httpClient.Do(req)
+	c.invalidateCached(databaseID)
 	if err != nil {
-		return Database{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return Database{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -192,7 +267,7 @@ func (c *Client) UpdateDatabase(ctx context.Context, databaseID string, params U
 		return Database{}, fmt.Errorf("notion: failed to update database: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&updatedDB)
+	err = c.decode(res.Body, &updatedDB)
 	if err != nil {
 		return Database{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -203,14 +278,38 @@ func (c *Client) UpdateDatabase(ctx context.Context, databaseID string, params U
 // FindPageByID fetches a page by ID.
 // See: https://developers.notion.com/reference/get-page
 func (c *Client) FindPageByID(ctx context.Context, id string) (page Page, err error) {
+	return c.FindPageByIDWithOpts(ctx, id, nil)
+}
+
+// FindPageByIDOpts are the options used for fetching a page.
+type FindPageByIDOpts struct {
+	// FilterProperties are the IDs of properties to return, to reduce response
+	// sizes for pages with many properties. Empty means all properties.
+	FilterProperties []string
+}
+
+// FindPageByIDWithOpts fetches a page by ID, like FindPageByID, with options.
+// See: https://developers.notion.com/reference/get-page
+func (c *Client) FindPageByIDWithOpts(ctx context.Context, id string, opts *FindPageByIDOpts) (page Page, err error) {
+	var rawQuery string
+	if opts != nil && len(opts.FilterProperties) > 0 {
+		rawQuery = url.Values{"filter_properties": opts.FilterProperties}.Encode()
+	}
+
+	// Pages with filtered properties aren't cached.
+	if rawQuery == "" && c.findCached("page", id, &page) {
+		return page, nil
+	}
+
 	req, err := c.newRequest(ctx, http.MethodGet, "/pages/"+id, nil)
 	if err != nil {
 		return Page{}, fmt.Errorf("notion: invalid request: %w", err)
 	}
+	req.URL.RawQuery = rawQuery
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return Page{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return Page{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -218,7 +317,11 @@ func (c *Client) FindPageByID(ctx context.Context, id string) (page Page, err er
 		return Page{}, fmt.Errorf("notion: failed to find page: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&page)
+	if rawQuery == "" {
+		err = c.decodeCached("page", id, res.Body, &page)
+	} else {
+		err = c.decode(res.Body, &page)
+	}
 	if err != nil {
 		return Page{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -230,7 +333,12 @@ func (c *Client) FindPageByID(ctx context.Context, id string) (page Page, err er
 // See: https://developers.notion.com/reference/post-page
 func (c *Client) CreatePage(ctx context.Context, params CreatePageParams) (page Page, err error) {
 	if err := params.Validate(); err != nil {
-		return Page{}, fmt.Errorf("notion: invalid page params: %w", err)
+		return Page{}, invalidParams("page params", err)
+	}
+	if c.strictValidation {
+		if err := params.validateLimits(); err != nil {
+			return Page{}, invalidParams("page params", err)
+		}
 	}
 
 	body := &bytes.Buffer{}
@@ -244,10 +352,15 @@ func (c *Client) CreatePage(ctx context.Context, params CreatePageParams) (page
 	if err != nil {
 		return Page{}, fmt.Errorf("notion: invalid request: %w", err)
 	}
+	parentType, parentID := params.parent()
+	if parentType == ParentTypeDataSource {
+		c.setDataSourcesVersion(req)
+	}
 
 	res, err := c.httpClient.Do(req)
+	c.invalidateCached(parentID)
 	if err != nil {
-		return Page{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return Page{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -255,7 +368,7 @@ func (c *Client) CreatePage(ctx context.Context, params CreatePageParams) (page
 		return Page{}, fmt.Errorf("notion: failed to create page: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&page)
+	err = c.decode(res.Body, &page)
 	if err != nil {
 		return Page{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -267,7 +380,12 @@ func (c *Client) CreatePage(ctx context.Context, params CreatePageParams) (page
 // See: https://developers.notion.com/reference/patch-page
 func (c *Client) UpdatePage(ctx context.Context, pageID string, params UpdatePageParams) (page Page, err error) {
 	if err := params.Validate(); err != nil {
-		return Page{}, fmt.Errorf("notion: invalid page params: %w", err)
+		return Page{}, invalidParams("page params", err)
+	}
+	if c.strictValidation {
+		if err := params.validateLimits(); err != nil {
+			return Page{}, invalidParams("page params", err)
+		}
 	}
 
 	body := &bytes.Buffer{}
@@ -283,8 +401,9 @@ func (c *Client) UpdatePage(ctx context.Context, pageID string, params UpdatePag
 	}
 
 	res, err := c.httpClient.Do(req)
+	c.invalidateCached(pageID)
 	if err != nil {
-		return Page{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return Page{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -292,14 +411,28 @@ func (c *Client) UpdatePage(ctx context.Context, pageID string, params UpdatePag
 		return Page{}, fmt.Errorf("notion: failed to update page properties: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&page)
+	err = c.decode(res.Body, &page)
 	if err != nil {
 		return Page{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
 
+	// The page is listed as a block child of its parent, e.g. when archived.
+	c.invalidateCached(parentID(page.Parent))
+
 	return page, nil
 }
 
+// TrashPage moves a page to the trash, by setting `in_trash: true`. Use
+// RestorePage to restore it.
+func (c *Client) TrashPage(ctx context.Context, pageID string) (Page, error) {
+	return c.UpdatePage(ctx, pageID, UpdatePageParams{InTrash: BoolPtr(true)})
+}
+
+// RestorePage restores a page from the trash, by setting `in_trash: false`.
+func (c *Client) RestorePage(ctx context.Context, pageID string) (Page, error) {
+	return c.UpdatePage(ctx, pageID, UpdatePageParams{InTrash: BoolPtr(false)})
+}
+
 // FindBlockChildrenByID returns a list of block children for a given block ID.
 // See: https://developers.notion.com/reference/post-database-query
 func (c *Client) FindBlockChildrenByID(ctx context.Context, blockID string, query *PaginationQuery) (result BlockChildrenResponse, err error) {
@@ -319,9 +452,16 @@ func (c *Client) FindBlockChildrenByID(ctx context.Context, blockID string, quer
 		req.URL.RawQuery = q.Encode()
 	}
 
+	// Only the first page of children, with the default (maximum) page size, is
+	// cached.
+	cached := query == nil || (query.StartCursor == "" && (query.PageSize == 0 || query.PageSize == MaxPageSize))
+	if cached && c.findCached("block_children", blockID, &result) {
+		return result, nil
+	}
+
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return BlockChildrenResponse{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return BlockChildrenResponse{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -329,7 +469,11 @@ func (c *Client) FindBlockChildrenByID(ctx context.Context, blockID string, quer
 		return BlockChildrenResponse{}, fmt.Errorf("notion: failed to find block children: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&result)
+	if cached {
+		err = c.decodeCached("block_children", blockID, res.Body, &result)
+	} else {
+		err = c.decode(res.Body, &result)
+	}
 	if err != nil {
 		return BlockChildrenResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -358,7 +502,7 @@ func (c *Client) FindPagePropertyByID(ctx context.Context, pageID, propID string
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return PagePropResponse{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return PagePropResponse{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -366,7 +510,7 @@ func (c *Client) FindPagePropertyByID(ctx context.Context, pageID, propID string
 		return PagePropResponse{}, fmt.Errorf("notion: failed to find page property: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = c.decode(res.Body, &result)
 	if err != nil {
 		return PagePropResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -375,13 +519,36 @@ func (c *Client) FindPagePropertyByID(ctx context.Context, pageID, propID string
 }
 
 // AppendBlockChildren appends child content (blocks) to an existing block.
+// When more than 100 children are passed, they are appended in batches. See
+// AppendBlockChildrenAll.
 // See: https://developers.notion.com/reference/patch-block-children
 func (c *Client) AppendBlockChildren(ctx context.Context, blockID string, children []Block) (result BlockChildrenResponse, err error) {
+	if len(children) > maxAppendChildren {
+		return c.AppendBlockChildrenAll(ctx, blockID, children, nil)
+	}
+
+	return c.appendBlockChildren(ctx, blockID, children, "")
+}
+
+func (c *Client) appendBlockChildren(ctx context.Context, blockID string, children []Block, after string) (result BlockChildrenResponse, err error) {
+	if err := validateBlocks(children); err != nil {
+		return BlockChildrenResponse{}, invalidParams("block children", err)
+	}
+	if err := validateBlockDepth(children, 1); err != nil {
+		return BlockChildrenResponse{}, invalidParams("block children (see AppendBlockChildrenDeep)", err)
+	}
+	if c.strictValidation {
+		if err := validateBlockLimits(children); err != nil {
+			return BlockChildrenResponse{}, invalidParams("block children", err)
+		}
+	}
+
 	type PostBody struct {
 		Children []Block `json:"children"`
+		After    string  `json:"after,omitempty"`
 	}
 
-	dto := PostBody{children}
+	dto := PostBody{children, after}
 	body := &bytes.Buffer{}
 
 	err = json.NewEncoder(body).Encode(dto)
@@ -395,8 +562,9 @@ func (c *Client) AppendBlockChildren(ctx context.Context, blockID string, childr
 	}
 
 	res, err := c.httpClient.Do(req)
+	c.invalidateCached(blockID)
 	if err != nil {
-		return BlockChildrenResponse{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return BlockChildrenResponse{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -404,7 +572,7 @@ func (c *Client) AppendBlockChildren(ctx context.Context, blockID string, childr
 		return BlockChildrenResponse{}, fmt.Errorf("notion: failed to append block children: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = c.decode(res.Body, &result)
 	if err != nil {
 		return BlockChildrenResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -422,7 +590,7 @@ func (c *Client) FindBlockByID(ctx context.Context, blockID string) (Block, erro
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return nil, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -432,7 +600,7 @@ func (c *Client) FindBlockByID(ctx context.Context, blockID string) (Block, erro
 
 	var dto blockDTO
 
-	err = json.NewDecoder(res.Body).Decode(&dto)
+	err = c.decode(res.Body, &dto)
 	if err != nil {
 		return nil, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -440,39 +608,18 @@ func (c *Client) FindBlockByID(ctx context.Context, blockID string) (Block, erro
 	return dto.Block()
 }
 
-// UpdateBlock updates a block.
+// UpdateBlock updates a block. Block types that can't be updated (e.g. child
+// pages) and blocks with children return an error, without making a request.
 // See: https://developers.notion.com/reference/update-a-block
 func (c *Client) UpdateBlock(ctx context.Context, blockID string, block Block) (Block, error) {
-	body := &bytes.Buffer{}
-
-	err := json.NewEncoder(body).Encode(block)
-	if err != nil {
-		return nil, fmt.Errorf("notion: failed to encode body params to JSON: %w", err)
-	}
-
-	req, err := c.newRequest(ctx, http.MethodPatch, "/blocks/"+blockID, body)
-	if err != nil {
-		return nil, fmt.Errorf("notion: invalid request: %w", err)
-	}
-
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("notion: failed to make HTTP request: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("notion: failed to update block: %w", parseErrorResponse(res))
+	if block == nil {
+		return nil, invalidParams("block", errors.New("block is nil"))
 	}
-
-	var dto blockDTO
-
-	err = json.NewDecoder(res.Body).Decode(&dto)
-	if err != nil {
-		return nil, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	if err := validateBlockUpdate(block); err != nil {
+		return nil, invalidParams("block", err)
 	}
 
-	return dto.Block()
+	return c.updateBlock(ctx, blockID, UpdateBlockParams{Block: block})
 }
 
 // DeleteBlock sets `archived: true` on a (page) block object.
@@ -485,8 +632,9 @@ func (c *Client) DeleteBlock(ctx context.Context, blockID string) (Block, error)
 	}
 
 	res, err := c.httpClient.Do(req)
+	c.invalidateCached(blockID)
 	if err != nil {
-		return nil, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return nil, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -496,11 +644,15 @@ func (c *Client) DeleteBlock(ctx context.Context, blockID string) (Block, error)
 
 	var dto blockDTO
 
-	err = json.NewDecoder(res.Body).Decode(&dto)
+	err = c.decode(res.Body, &dto)
 	if err != nil {
 		return nil, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
 
+	if dto.Parent != nil {
+		c.invalidateCached(parentID(*dto.Parent))
+	}
+
 	return dto.Block()
 }
 
@@ -514,7 +666,7 @@ func (c *Client) FindUserByID(ctx context.Context, id string) (user User, err er
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return User{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return User{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -522,7 +674,7 @@ func (c *Client) FindUserByID(ctx context.Context, id string) (user User, err er
 		return User{}, fmt.Errorf("notion: failed to find user: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&user)
+	err = c.decode(res.Body, &user)
 	if err != nil {
 		return User{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -540,7 +692,7 @@ func (c *Client) FindCurrentUser(ctx context.Context) (user User, err error) {
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return User{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return User{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -548,7 +700,7 @@ func (c *Client) FindCurrentUser(ctx context.Context) (user User, err error) {
 		return User{}, fmt.Errorf("notion: failed to find current user: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&user)
+	err = c.decode(res.Body, &user)
 	if err != nil {
 		return User{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -577,7 +729,7 @@ func (c *Client) ListUsers(ctx context.Context, query *PaginationQuery) (result
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return ListUsersResponse{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return ListUsersResponse{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -585,7 +737,7 @@ func (c *Client) ListUsers(ctx context.Context, query *PaginationQuery) (result
 		return ListUsersResponse{}, fmt.Errorf("notion: failed to list users: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = c.decode(res.Body, &result)
 	if err != nil {
 		return ListUsersResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -600,6 +752,9 @@ func (c *Client) Search(ctx context.Context, opts *SearchOpts) (result SearchRes
 	body := &bytes.Buffer{}
 
 	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return SearchResponse{}, invalidParams("search options", err)
+		}
 		err = json.NewEncoder(body).Encode(opts)
 		if err != nil {
 			return SearchResponse{}, fmt.Errorf("notion: failed to encode filter to JSON: %w", err)
@@ -613,7 +768,7 @@ func (c *Client) Search(ctx context.Context, opts *SearchOpts) (result SearchRes
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return SearchResponse{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return SearchResponse{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -621,10 +776,12 @@ func (c *Client) Search(ctx context.Context, opts *SearchOpts) (result SearchRes
 		return SearchResponse{}, fmt.Errorf("notion: failed to search: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = c.decode(res.Body, &result)
 	if err != nil {
 		return SearchResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
+	c.revalidateCachedPages(result.Pages())
+	c.revalidateCachedDatabases(result.Databases())
 
 	return result, nil
 }
@@ -633,7 +790,7 @@ func (c *Client) Search(ctx context.Context, opts *SearchOpts) (result SearchRes
 // See: https://developers.notion.com/reference/create-a-comment
 func (c *Client) CreateComment(ctx context.Context, params CreateCommentParams) (comment Comment, err error) {
 	if err := params.Validate(); err != nil {
-		return Comment{}, fmt.Errorf("notion: invalid comment params: %w", err)
+		return Comment{}, invalidParams("comment params", err)
 	}
 
 	body := &bytes.Buffer{}
@@ -650,7 +807,7 @@ func (c *Client) CreateComment(ctx context.Context, params CreateCommentParams)
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return Comment{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return Comment{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -658,7 +815,7 @@ func (c *Client) CreateComment(ctx context.Context, params CreateCommentParams)
 		return Comment{}, fmt.Errorf("notion: failed to create comment: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&comment)
+	err = c.decode(res.Body, &comment)
 	if err != nil {
 		return Comment{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
@@ -666,6 +823,16 @@ func (c *Client) CreateComment(ctx context.Context, params CreateCommentParams)
 	return comment, nil
 }
 
+// CreateCommentOnBlock starts a discussion on a block (i.e. an inline comment),
+// with a comment with rich text `richText`. Use CreateComment with the returned
+// discussion ID to reply.
+func (c *Client) CreateCommentOnBlock(ctx context.Context, blockID string, richText []RichText) (Comment, error) {
+	return c.CreateComment(ctx, CreateCommentParams{
+		ParentBlockID: blockID,
+		RichText:      richText,
+	})
+}
+
 // FindCommentsByBlockID returns a list of unresolved comments by parent block
 // ID, and pagination metadata.
 // See: https://developers.notion.com/reference/retrieve-a-comment
@@ -673,15 +840,15 @@ func (c *Client) FindCommentsByBlockID(
 	ctx context.Context,
 	query FindCommentsByBlockIDQuery,
 ) (result FindCommentsResponse, err error) {
+	if query.BlockID == "" {
+		return FindCommentsResponse{}, invalidParams("comments query", fieldError("BlockID", errors.New("block ID is required")))
+	}
+
 	req, err := c.newRequest(ctx, http.MethodGet, "/comments", nil)
 	if err != nil {
 		return FindCommentsResponse{}, fmt.Errorf("notion: invalid request: %w", err)
 	}
 
-	if query.BlockID == "" {
-		return FindCommentsResponse{}, errors.New("notion: block ID query field is required")
-	}
-
 	q := url.Values{}
 	q.Set("block_id", query.BlockID)
 	if query.StartCursor != "" {
@@ -694,7 +861,7 @@ func (c *Client) FindCommentsByBlockID(
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return FindCommentsResponse{}, fmt.Errorf("notion: failed to make HTTP request: %w", err)
+		return FindCommentsResponse{}, transportError(ctx, err)
 	}
 	defer res.Body.Close()
 
@@ -702,10 +869,56 @@ func (c *Client) FindCommentsByBlockID(
 		return FindCommentsResponse{}, fmt.Errorf("notion: failed to list comments: %w", parseErrorResponse(res))
 	}
 
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = c.decode(res.Body, &result)
 	if err != nil {
 		return FindCommentsResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
 	}
 
 	return result, nil
 }
+
+// FindCommentByID fetches a comment by ID.
+// See: https://developers.notion.com/reference/retrieve-comment
+func (c *Client) FindCommentByID(ctx context.Context, id string) (comment Comment, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/comments/"+id, nil)
+	if err != nil {
+		return Comment{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return Comment{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Comment{}, fmt.Errorf("notion: failed to find comment: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &comment)
+	if err != nil {
+		return Comment{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	return comment, nil
+}
+
+// ListAllComments returns all unresolved comments by parent block ID, following
+// pagination. If a request fails, the comments found so far are returned along
+// with the error; see ErrCanceled.
+func (c *Client) ListAllComments(ctx context.Context, blockID string) ([]Comment, error) {
+	return listAll(ctx, nil, func(cursor string) ([]Comment, *string, error) {
+		resp, err := c.FindCommentsByBlockID(ctx, FindCommentsByBlockIDQuery{
+			BlockID:     blockID,
+			StartCursor: cursor,
+			PageSize:    MaxPageSize,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !resp.HasMore {
+			return resp.Results, nil, nil
+		}
+		return resp.Results, resp.NextCursor, nil
+	})
+}
@@ -278,6 +278,8 @@ func TestFindDatabaseByID(t *testing.T) {
 					"Description": notion.DatabaseProperty{
 						ID:   "J@cS",
 						Type: notion.DBPropTypeRichText,
+						// Unknown, as the API uses `rich_text` nowadays.
+						Unknown: map[string]json.RawMessage{"text": json.RawMessage(`{}`)},
 					},
 					"In stock": notion.DatabaseProperty{
 						ID:       "{xYx",
@@ -382,6 +384,11 @@ func TestFindDatabaseByID(t *testing.T) {
 					Type:   notion.ParentTypePage,
 					PageID: "b8595b75-abd1-4cad-8dfe-f935a8ef57cb",
 				},
+				PropertyOrder: []string{
+					"Name", "Description", "In stock", "Food group", "Price", "Cost of next trip",
+					"Last ordered", "Meals", "Number of meals", "Store availability", "+1",
+					"Photo",
+				},
 			},
 			expError: nil,
 		},
@@ -1186,7 +1193,8 @@ func TestCreateDatabase(t *testing.T) {
 						URL: "https://example.com/image.png",
 					},
 				},
-				IsInline: true,
+				IsInline:      true,
+				PropertyOrder: []string{"Title"},
 			},
 			expError: nil,
 		},
@@ -1511,6 +1519,8 @@ func TestUpdateDatabase(t *testing.T) {
 					"New": notion.DatabaseProperty{
 						ID:   "J@cS",
 						Type: notion.DBPropTypeRichText,
+						// Unknown, as the API uses `rich_text` nowadays.
+						Unknown: map[string]json.RawMessage{"text": json.RawMessage(`{}`)},
 					},
 				},
 				Parent: notion.Parent{
@@ -1527,7 +1537,8 @@ func TestUpdateDatabase(t *testing.T) {
 						URL: "https://example.com/image.png",
 					},
 				},
-				IsInline: true,
+				IsInline:      true,
+				PropertyOrder: []string{"Name", "New"},
 			},
 			expError: nil,
 		},
@@ -2086,6 +2097,187 @@ func TestCreatePage(t *testing.T) {
 			},
 			expError: nil,
 		},
+		{
+			name: "block parent, successful response",
+			params: notion.CreatePageParams{
+				ParentType: notion.ParentTypeBlock,
+				ParentID:   "b0668f48-8d66-4733-9bdb-2f82215707f7",
+				Title: []notion.RichText{
+					{
+						Text: &notion.Text{
+							Content: "Foobar",
+						},
+					},
+				},
+			},
+			respBody: func(_ *http.Request) io.Reader {
+				return strings.NewReader(
+					`{
+						"object": "page",
+						"id": "276ee233-e426-4ed0-9986-6b22af8550df",
+						"created_time": "2021-05-19T19:34:05.068Z",
+						"last_edited_time": "2021-05-19T19:34:05.069Z",
+						"parent": {
+							"type": "block_id",
+							"block_id": "b0668f48-8d66-4733-9bdb-2f82215707f7"
+						},
+						"archived": false,
+						"url": "https://www.notion.so/Foobar-276ee233e4264ed099866b22af8550df",
+						"properties": {
+							"title": {
+								"id": "title",
+								"type": "title",
+								"title": [
+									{
+										"type": "text",
+										"text": {
+											"content": "Foobar",
+											"link": null
+										},
+										"annotations": {
+											"bold": false,
+											"italic": false,
+											"strikethrough": false,
+											"underline": false,
+											"code": false,
+											"color": "default"
+										},
+										"plain_text": "Foobar",
+										"href": null
+									}
+								]
+							}
+						}
+					}`,
+				)
+			},
+			respStatusCode: http.StatusOK,
+			expPostBody: map[string]interface{}{
+				"parent": map[string]interface{}{
+					"block_id": "b0668f48-8d66-4733-9bdb-2f82215707f7",
+				},
+				"properties": map[string]interface{}{
+					"title": []interface{}{
+						map[string]interface{}{
+							"text": map[string]interface{}{
+								"content": "Foobar",
+							},
+						},
+					},
+				},
+			},
+			expResponse: notion.Page{
+				ID:             "276ee233-e426-4ed0-9986-6b22af8550df",
+				CreatedTime:    mustParseTime(time.RFC3339Nano, "2021-05-19T19:34:05.068Z"),
+				LastEditedTime: mustParseTime(time.RFC3339Nano, "2021-05-19T19:34:05.069Z"),
+				URL:            "https://www.notion.so/Foobar-276ee233e4264ed099866b22af8550df",
+				Parent: notion.Parent{
+					Type:    notion.ParentTypeBlock,
+					BlockID: "b0668f48-8d66-4733-9bdb-2f82215707f7",
+				},
+				Properties: notion.PageProperties{
+					Title: notion.PageTitle{
+						Title: []notion.RichText{
+							{
+								Type: notion.RichTextTypeText,
+								Text: &notion.Text{
+									Content: "Foobar",
+								},
+								Annotations: &notion.Annotations{
+									Color: notion.ColorDefault,
+								},
+								PlainText: "Foobar",
+							},
+						},
+					},
+				},
+			},
+			expError: nil,
+		},
+		{
+			name: "workspace parent, successful response",
+			params: notion.CreatePageParams{
+				ParentType: notion.ParentTypeWorkspace,
+				Title: []notion.RichText{
+					{
+						Text: &notion.Text{
+							Content: "Foobar",
+						},
+					},
+				},
+			},
+			respBody: func(_ *http.Request) io.Reader {
+				return strings.NewReader(
+					`{
+						"object": "page",
+						"id": "276ee233-e426-4ed0-9986-6b22af8550df",
+						"created_time": "2021-05-19T19:34:05.068Z",
+						"last_edited_time": "2021-05-19T19:34:05.069Z",
+						"parent": {
+							"type": "workspace",
+							"workspace": true
+						},
+						"archived": false,
+						"url": "https://www.notion.so/Foobar-276ee233e4264ed099866b22af8550df",
+						"properties": {
+							"title": {
+								"id": "title",
+								"type": "title",
+								"title": []
+							}
+						}
+					}`,
+				)
+			},
+			respStatusCode: http.StatusOK,
+			expPostBody: map[string]interface{}{
+				"parent": map[string]interface{}{
+					"type":      "workspace",
+					"workspace": true,
+				},
+				"properties": map[string]interface{}{
+					"title": []interface{}{
+						map[string]interface{}{
+							"text": map[string]interface{}{
+								"content": "Foobar",
+							},
+						},
+					},
+				},
+			},
+			expResponse: notion.Page{
+				ID:             "276ee233-e426-4ed0-9986-6b22af8550df",
+				CreatedTime:    mustParseTime(time.RFC3339Nano, "2021-05-19T19:34:05.068Z"),
+				LastEditedTime: mustParseTime(time.RFC3339Nano, "2021-05-19T19:34:05.069Z"),
+				URL:            "https://www.notion.so/Foobar-276ee233e4264ed099866b22af8550df",
+				Parent: notion.Parent{
+					Type:      notion.ParentTypeWorkspace,
+					Workspace: true,
+				},
+				Properties: notion.PageProperties{
+					Title: notion.PageTitle{
+						Title: []notion.RichText{},
+					},
+				},
+			},
+			expError: nil,
+		},
+		{
+			name: "workspace parent with parent ID error",
+			params: notion.CreatePageParams{
+				ParentType: notion.ParentTypeWorkspace,
+				ParentID:   "b0668f48-8d66-4733-9bdb-2f82215707f7",
+				Title: []notion.RichText{
+					{
+						Text: &notion.Text{
+							Content: "Foobar",
+						},
+					},
+				},
+			},
+			expResponse: notion.Page{},
+			expError:    errors.New("notion: invalid page params: parent ID must be empty when parent type is workspace"),
+		},
 		{
 			name: "error response",
 			params: notion.CreatePageParams{
@@ -2166,6 +2358,36 @@ func TestCreatePage(t *testing.T) {
 			expResponse: notion.Page{},
 			expError:    errors.New("notion: invalid page params: title is required when parent type is page"),
 		},
+		{
+			name: "block title required error",
+			params: notion.CreatePageParams{
+				ParentType: notion.ParentTypeBlock,
+				ParentID:   "b0668f48-8d66-4733-9bdb-2f82215707f7",
+			},
+			expResponse: notion.Page{},
+			expError:    errors.New("notion: invalid page params: title is required when parent type is block"),
+		},
+		{
+			name: "database properties with page parent error",
+			params: notion.CreatePageParams{
+				ParentType: notion.ParentTypePage,
+				ParentID:   "b0668f48-8d66-4733-9bdb-2f82215707f7",
+				Title: []notion.RichText{
+					{
+						Text: &notion.Text{
+							Content: "Foobar",
+						},
+					},
+				},
+				DatabasePageProperties: &notion.DatabasePageProperties{
+					"Name": notion.DatabasePageProperty{
+						Title: []notion.RichText{{Text: &notion.Text{Content: "Foobar"}}},
+					},
+				},
+			},
+			expResponse: notion.Page{},
+			expError:    errors.New("notion: invalid page params: database page properties are only allowed when parent type is database"),
+		},
 		{
 			name: "database properties required error",
 			params: notion.CreatePageParams{
@@ -2175,6 +2397,20 @@ func TestCreatePage(t *testing.T) {
 			expResponse: notion.Page{},
 			expError:    errors.New("notion: invalid page params: database page properties is required when parent type is database"),
 		},
+		{
+			name: "database property without value error",
+			params: notion.CreatePageParams{
+				ParentType: notion.ParentTypeDatabase,
+				ParentID:   "b0668f48-8d66-4733-9bdb-2f82215707f7",
+				DatabasePageProperties: &notion.DatabasePageProperties{
+					"Name": notion.DatabasePageProperty{
+						Type: notion.DBPropTypeTitle,
+					},
+				},
+			},
+			expResponse: notion.Page{},
+			expError:    errors.New(`notion: invalid page params: property "Name": no value field is set`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -2679,7 +2915,26 @@ func TestUpdatePage(t *testing.T) {
 			name:        "missing any params",
 			params:      notion.UpdatePageParams{},
 			expResponse: notion.Page{},
-			expError:    errors.New("notion: invalid page params: at least one of database page properties, archived, icon or cover is required"),
+			expError:    errors.New("notion: invalid page params: at least one of database page properties, archived, in trash, icon or cover is required"),
+		},
+		{
+			name: "database property with multiple values",
+			params: notion.UpdatePageParams{
+				DatabasePageProperties: notion.DatabasePageProperties{
+					"Phone": notion.DatabasePageProperty{
+						PhoneNumber: notion.StringPtr("+31612345678"),
+						RichText: []notion.RichText{
+							{
+								Text: &notion.Text{
+									Content: "+31612345678",
+								},
+							},
+						},
+					},
+				},
+			},
+			expResponse: notion.Page{},
+			expError:    errors.New(`notion: invalid page params: property "Phone": multiple value fields are set (rich_text, phone_number), expected exactly one`),
 		},
 	}
 
@@ -2738,6 +2993,86 @@ func TestUpdatePage(t *testing.T) {
 	}
 }
 
+func TestTrashPage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		trash       bool
+		expPostBody map[string]interface{}
+	}{
+		{
+			name:        "trash page",
+			trash:       true,
+			expPostBody: map[string]interface{}{"in_trash": true},
+		},
+		{
+			name:        "restore page",
+			trash:       false,
+			expPostBody: map[string]interface{}{"in_trash": false},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if r.Method != http.MethodPatch || r.URL.Path != "/v1/pages/cb261dc5-6c85-4767-8585-3852382fb466" {
+						t.Fatalf("unexpected request: %v %v", r.Method, r.URL.Path)
+					}
+
+					postBody := make(map[string]interface{})
+					if err := json.NewDecoder(r.Body).Decode(&postBody); err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(tt.expPostBody, postBody); diff != "" {
+						t.Fatalf("request body not equal (-exp, +got):\n%v", diff)
+					}
+
+					body := fmt.Sprintf(`{
+						"object": "page",
+						"id": "cb261dc5-6c85-4767-8585-3852382fb466",
+						"parent": {
+							"type": "workspace",
+							"workspace": true
+						},
+						"archived": %[1]v,
+						"in_trash": %[1]v,
+						"properties": {}
+					}`, tt.trash)
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			var (
+				page notion.Page
+				err  error
+			)
+			if tt.trash {
+				page, err = client.TrashPage(context.Background(), "cb261dc5-6c85-4767-8585-3852382fb466")
+			} else {
+				page, err = client.RestorePage(context.Background(), "cb261dc5-6c85-4767-8585-3852382fb466")
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if page.InTrash != tt.trash {
+				t.Fatalf("in trash not equal (expected: %v, got: %v)", tt.trash, page.InTrash)
+			}
+		})
+	}
+}
+
 func TestFindPagePropertyByID(t *testing.T) {
 	t.Parallel()
 
@@ -2916,7 +3251,8 @@ func TestFindPagePropertyByID(t *testing.T) {
 					ID:   "aBcD123",
 					Type: notion.DBPropTypeRollup,
 					Rollup: notion.RollupResult{
-						Type: notion.RollupResultTypeDate,
+						Type:     notion.RollupResultTypeDate,
+						Function: notion.RollupFunctionLatestDate,
 						Date: &notion.Date{
 							Start: mustParseDateTime("2021-10-07T14:42:00.000+00:00"),
 						},
@@ -3112,7 +3448,7 @@ func TestFindBlockChildrenById(t *testing.T) {
 							},
 						},
 					},
-					&notion.UnsupportedBlock{},
+					&notion.UnsupportedBlock{Type: notion.BlockTypeUnsupported},
 				},
 				HasMore:    true,
 				NextCursor: notion.StringPtr("A^hd"),
@@ -3170,7 +3506,8 @@ func TestFindBlockChildrenById(t *testing.T) {
 								"created_time": "2021-05-14T09:15:00.000Z",
 								"last_edited_time": "2021-05-14T09:15:00.000Z",
 								"has_children": false,
-								"type": "foobar"
+								"type": "foobar",
+								"foobar": {"foo": "bar"}
 							}
 						],
 						"next_cursor": null,
@@ -3179,7 +3516,21 @@ func TestFindBlockChildrenById(t *testing.T) {
 				)
 			},
 			respStatusCode: http.StatusOK,
-			expError:       fmt.Errorf(`notion: failed to parse HTTP response: notion: failed to parse block (id: "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113", type: "foobar"): unknown block type`),
+			expResponse: notion.BlockChildrenResponse{
+				Results: []notion.Block{
+					&notion.UnsupportedBlock{
+						Type:    "foobar",
+						Unknown: json.RawMessage(`{"foo": "bar"}`),
+					},
+				},
+			},
+			expBlockFields: []blockFields{
+				{
+					id:             "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113",
+					createdTime:    mustParseTime(time.RFC3339, "2021-05-14T09:15:00.000Z"),
+					lastEditedTime: mustParseTime(time.RFC3339, "2021-05-14T09:15:00.000Z"),
+				},
+			},
 		},
 		{
 			name: "error response",
@@ -3674,6 +4025,56 @@ func TestFindCurrentUser(t *testing.T) {
 			},
 			expError: nil,
 		},
+		{
+			name: "workspace bot with capabilities",
+			respBody: func(_ *http.Request) io.Reader {
+				return strings.NewReader(
+					`{
+						"object": "user",
+						"id": "be32e790-8292-46df-a248-b784fdf483cf",
+						"name": "Integration",
+						"avatar_url": null,
+						"type": "bot",
+						"bot": {
+							"owner": {
+								"type": "workspace",
+								"workspace": true
+							},
+							"workspace_id": "f1e2d3c4-b5a6-4789-8abc-def012345678",
+							"workspace_name": "Acme",
+							"workspace_limits": {
+								"max_file_upload_size_in_bytes": 5242880
+							},
+							"capabilities": ["read_content", "insert_comments"]
+						}
+					}`,
+				)
+			},
+			respStatusCode: http.StatusOK,
+			expUser: notion.User{
+				BaseUser: notion.BaseUser{
+					ID: "be32e790-8292-46df-a248-b784fdf483cf",
+				},
+				Type: notion.UserTypeBot,
+				Name: "Integration",
+				Bot: &notion.Bot{
+					Owner: notion.BotOwner{
+						Type:      notion.BotOwnerTypeWorkspace,
+						Workspace: true,
+					},
+					WorkspaceID:   "f1e2d3c4-b5a6-4789-8abc-def012345678",
+					WorkspaceName: "Acme",
+					WorkspaceLimits: &notion.WorkspaceLimits{
+						MaxFileUploadSizeInBytes: 5242880,
+					},
+					Capabilities: []notion.BotCapability{
+						notion.BotCapabilityReadContent,
+						notion.BotCapabilityInsertComments,
+					},
+				},
+			},
+			expError: nil,
+		},
 		{
 			name: "error response",
 			respBody: func(_ *http.Request) io.Reader {
@@ -4042,6 +4443,7 @@ func TestSearch(t *testing.T) {
 								Title: &notion.EmptyMetadata{},
 							},
 						},
+						PropertyOrder: []string{"Name"},
 					},
 					notion.Page{
 						ID:             "276ee233-e426-4ed0-9986-6b22af8550df",
@@ -4208,6 +4610,7 @@ func TestFindBlockByID(t *testing.T) {
 		expLastEditedBy   notion.BaseUser
 		expHasChildren    bool
 		expArchived       bool
+		expInTrash        bool
 		expError          error
 	}{
 		{
@@ -4234,6 +4637,7 @@ func TestFindBlockByID(t *testing.T) {
 						},
 						"has_children": true,
 						"archived": false,
+						"in_trash": true,
 						"type": "child_page",
 						"child_page": {
 							"title": "test title"
@@ -4260,6 +4664,7 @@ func TestFindBlockByID(t *testing.T) {
 			},
 			expHasChildren: true,
 			expArchived:    false,
+			expInTrash:     true,
 			expError:       nil,
 		},
 		{
@@ -4343,6 +4748,9 @@ func TestFindBlockByID(t *testing.T) {
 				if tt.expArchived != block.Archived() {
 					t.Fatalf("archived not equal (expected: %v, got: %v)", tt.expArchived, block.Archived())
 				}
+				if got := block.(interface{ InTrash() bool }).InTrash(); tt.expInTrash != got {
+					t.Fatalf("in trash not equal (expected: %v, got: %v)", tt.expInTrash, got)
+				}
 			}
 		})
 	}
@@ -4809,6 +5217,104 @@ func TestCreateComment(t *testing.T) {
 			},
 			expError: nil,
 		},
+		{
+			name: "block parent with display name, successful response",
+			params: notion.CreateCommentParams{
+				ParentBlockID: "5d4ca33c-d6b7-4675-93d9-84b70af45d1c",
+				RichText: []notion.RichText{
+					{
+						Text: &notion.Text{
+							Content: "This is an example comment.",
+						},
+					},
+				},
+				DisplayName: &notion.CommentDisplayName{
+					Type:   notion.CommentDisplayNameTypeCustom,
+					Custom: &notion.CommentDisplayNameCustom{Name: "Notion Bot"},
+				},
+			},
+			respBody: func(_ *http.Request) io.Reader {
+				return strings.NewReader(
+					`{
+						"created_by": {
+							"id": "25c9cc08-1afd-4d22-b9e6-31b0f6e7b44f",
+							"object": "user"
+						},
+						"created_time": "2022-09-04T14:15:00.000Z",
+						"discussion_id": "729d95d1-a804-4bc4-ab6a-adbb5de8c9b3",
+						"id": "ade11b15-10f1-474a-97dd-955073779f39",
+						"last_edited_time": "2022-09-04T14:15:00.000Z",
+						"object": "comment",
+						"parent": {
+							"block_id": "5d4ca33c-d6b7-4675-93d9-84b70af45d1c",
+							"type": "block_id"
+						},
+						"rich_text": [],
+						"attachments": [
+							{
+								"category": "image",
+								"file": {
+									"url": "https://example.com/image.png",
+									"expiry_time": "2022-09-04T15:15:00.000Z"
+								}
+							}
+						],
+						"display_name": {
+							"type": "custom",
+							"resolved_name": "Notion Bot"
+						}
+					}`,
+				)
+			},
+			respStatusCode: http.StatusOK,
+			expPostBody: map[string]interface{}{
+				"parent": map[string]interface{}{
+					"type":     "block_id",
+					"block_id": "5d4ca33c-d6b7-4675-93d9-84b70af45d1c",
+				},
+				"rich_text": []interface{}{
+					map[string]interface{}{
+						"text": map[string]interface{}{
+							"content": "This is an example comment.",
+						},
+					},
+				},
+				"display_name": map[string]interface{}{
+					"type": "custom",
+					"custom": map[string]interface{}{
+						"name": "Notion Bot",
+					},
+				},
+			},
+			expResponse: notion.Comment{
+				ID:             "ade11b15-10f1-474a-97dd-955073779f39",
+				DiscussionID:   "729d95d1-a804-4bc4-ab6a-adbb5de8c9b3",
+				CreatedTime:    mustParseTime(time.RFC3339Nano, "2022-09-04T14:15:00.000Z"),
+				LastEditedTime: mustParseTime(time.RFC3339Nano, "2022-09-04T14:15:00.000Z"),
+				CreatedBy: notion.BaseUser{
+					ID: "25c9cc08-1afd-4d22-b9e6-31b0f6e7b44f",
+				},
+				Parent: notion.Parent{
+					Type:    notion.ParentTypeBlock,
+					BlockID: "5d4ca33c-d6b7-4675-93d9-84b70af45d1c",
+				},
+				RichText: []notion.RichText{},
+				Attachments: []notion.CommentFile{
+					{
+						Category: "image",
+						File: notion.FileFile{
+							URL:        "https://example.com/image.png",
+							ExpiryTime: mustParseDateTime("2022-09-04T15:15:00.000Z"),
+						},
+					},
+				},
+				DisplayName: &notion.CommentDisplayName{
+					Type:         notion.CommentDisplayNameTypeCustom,
+					ResolvedName: "Notion Bot",
+				},
+			},
+			expError: nil,
+		},
 		{
 			name: "error response",
 			params: notion.CreateCommentParams{
@@ -4860,7 +5366,7 @@ func TestCreateComment(t *testing.T) {
 				},
 			},
 			expResponse: notion.Comment{},
-			expError:    errors.New("notion: invalid comment params: either parent page ID or discussion ID is required"),
+			expError:    errors.New("notion: invalid comment params: one of parent, parent page ID, parent block ID or discussion ID is required"),
 		},
 		{
 			name: "parent ID and discussion ID both non-empty error",
@@ -4876,7 +5382,23 @@ func TestCreateComment(t *testing.T) {
 				},
 			},
 			expResponse: notion.Comment{},
-			expError:    errors.New("notion: invalid comment params: parent page ID and discussion ID cannot both be non-empty"),
+			expError:    errors.New("notion: invalid comment params: only one of parent, parent page ID, parent block ID and discussion ID can be non-empty"),
+		},
+		{
+			name: "custom display name without name error",
+			params: notion.CreateCommentParams{
+				ParentBlockID: "foo",
+				RichText: []notion.RichText{
+					{
+						Text: &notion.Text{
+							Content: "This is an example comment.",
+						},
+					},
+				},
+				DisplayName: &notion.CommentDisplayName{Type: notion.CommentDisplayNameTypeCustom},
+			},
+			expResponse: notion.Comment{},
+			expError:    errors.New("notion: invalid comment params: display name custom name is required for type custom"),
 		},
 		{
 			name: "rich text zero length error",
@@ -5050,7 +5572,7 @@ func TestFindCommentsByBlockID(t *testing.T) {
 		{
 			name:     "without block ID",
 			query:    notion.FindCommentsByBlockIDQuery{},
-			expError: errors.New("notion: block ID query field is required"),
+			expError: errors.New("notion: invalid comments query: block ID is required"),
 		},
 		{
 			name: "error response",
@@ -5125,3 +5647,172 @@ func TestFindCommentsByBlockID(t *testing.T) {
 		})
 	}
 }
+
+func TestListAllComments(t *testing.T) {
+	t.Parallel()
+
+	var cursors []string
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			q := r.URL.Query()
+			if exp, got := "block-id", q.Get("block_id"); exp != got {
+				t.Fatalf("block ID not equal (expected: %v, got: %v)", exp, got)
+			}
+			if exp, got := "100", q.Get("page_size"); exp != got {
+				t.Fatalf("page size not equal (expected: %v, got: %v)", exp, got)
+			}
+			cursors = append(cursors, q.Get("start_cursor"))
+
+			body := `{"object": "list", "results": [{"object": "comment", "id": "comment-1"}], "has_more": true, "next_cursor": "cursor-2"}`
+			if q.Get("start_cursor") == "cursor-2" {
+				body = `{"object": "list", "results": [{"object": "comment", "id": "comment-2"}], "has_more": false, "next_cursor": null}`
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	comments, err := client.ListAllComments(context.Background(), "block-id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]notion.Comment{{ID: "comment-1"}, {ID: "comment-2"}}, comments); diff != "" {
+		t.Fatalf("comments not equal (-exp, +got):\n%v", diff)
+	}
+	if diff := cmp.Diff([]string{"", "cursor-2"}, cursors); diff != "" {
+		t.Fatalf("cursors not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestListAllCommentsCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := 0
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			// Cancel once the first page of results is served.
+			defer cancel()
+			requests++
+
+			body := `{"object": "list", "results": [{"object": "comment", "id": "comment-1"}], "has_more": true, "next_cursor": "cursor-2"}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	comments, err := client.ListAllComments(ctx, "block-id")
+
+	var canceledErr *notion.ErrCanceled
+	if !errors.As(err, &canceledErr) {
+		t.Fatalf("error not equal (expected: *notion.ErrCanceled, got: %v)", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error not equal (expected: %v, got: %v)", context.Canceled, err)
+	}
+	if diff := cmp.Diff([]notion.Comment{{ID: "comment-1"}}, comments); diff != "" {
+		t.Fatalf("comments not equal (-exp, +got):\n%v", diff)
+	}
+	if requests != 1 {
+		t.Fatalf("requests not equal (expected: 1, got: %v)", requests)
+	}
+}
+
+func TestFilterProperties(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		call     func(client *notion.Client) error
+		expPath  string
+		expQuery url.Values
+		expBody  string
+	}{
+		{
+			name: "query database",
+			call: func(client *notion.Client) error {
+				_, err := client.QueryDatabase(context.Background(), "db-id", &notion.DatabaseQuery{
+					PageSize:         10,
+					FilterProperties: []string{"title", "a%3Bb"},
+				})
+				return err
+			},
+			expPath:  "/v1/databases/db-id/query",
+			expQuery: url.Values{"filter_properties": {"title", "a%3Bb"}},
+			expBody:  `{"page_size":10}`,
+		},
+		{
+			name: "find page",
+			call: func(client *notion.Client) error {
+				_, err := client.FindPageByIDWithOpts(context.Background(), "page-id", &notion.FindPageByIDOpts{
+					FilterProperties: []string{"title"},
+				})
+				return err
+			},
+			expPath:  "/v1/pages/page-id",
+			expQuery: url.Values{"filter_properties": {"title"}},
+		},
+		{
+			name: "find page without options",
+			call: func(client *notion.Client) error {
+				_, err := client.FindPageByIDWithOpts(context.Background(), "page-id", nil)
+				return err
+			},
+			expPath:  "/v1/pages/page-id",
+			expQuery: url.Values{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if r.URL.Path != tt.expPath {
+						t.Fatalf("path not equal (expected: %v, got: %v)", tt.expPath, r.URL.Path)
+					}
+					if diff := cmp.Diff(tt.expQuery, r.URL.Query()); diff != "" {
+						t.Fatalf("query params not equal (-exp, +got):\n%v", diff)
+					}
+					if tt.expBody != "" {
+						body, _ := ioutil.ReadAll(r.Body)
+						if got := strings.TrimSpace(string(body)); got != tt.expBody {
+							t.Fatalf("request body not equal (expected: %v, got: %v)", tt.expBody, got)
+						}
+					}
+
+					body := `{"object": "page", "id": "page-id", "parent": {"type": "workspace", "workspace": true}, "properties": {}}`
+					if r.Method == http.MethodPost {
+						body = `{"object": "list", "results": [], "has_more": false, "next_cursor": null}`
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+)
+
+// CloneDatabaseOpts are the options used for cloning a database.
+type CloneDatabaseOpts struct {
+	// Title is the title of the new database. Defaults to the title of the
+	// source database.
+	Title []RichText
+
+	// CopyPages copies all pages of the source database, with their property
+	// values.
+	CopyPages bool
+
+	// CopyContent copies the block content of pages too. Requires CopyPages.
+	CopyContent bool
+
+	// Concurrency is the maximum number of pages copied concurrently. Defaults
+	// to 1. Keep in mind the rate limits of the Notion API.
+	// See: https://developers.notion.com/reference/request-limits#rate-limits
+	Concurrency int
+}
+
+// CloneDatabase creates a copy of a database as a child of an existing page,
+// with the same schema, and optionally with copies of its pages.
+//
+// Some things can't be copied via the API:
+//   - Relations to other databases are created as one-way (`single_property`)
+//     relations, so the related databases aren't changed. Relations to the
+//     source database itself refer to the new database, and relation values
+//     of copied pages refer to the copied pages.
+//   - Options of `status` properties, and files, icons and covers hosted by
+//     Notion (as opposed to external files) are omitted.
+//   - Computed values (e.g. `formula`, `rollup` and `created_time`) are
+//     computed anew, and blocks that can't be created (e.g. `child_page` and
+//     `child_database`) are omitted.
+//
+// Property values that are truncated in page objects (see
+// DatabasePageProperty.IsTruncated) are fetched in full with ExpandPage before
+// copying.
+//
+// If copying pages fails, the new database is returned along with the error: a
+// *BulkError, with indexes of pages of the source database (in default query
+// order), or an *ErrCanceled.
+func (c *Client) CloneDatabase(ctx context.Context, sourceDBID, targetParentPageID string, opts *CloneDatabaseOpts) (Database, error) {
+	if opts == nil {
+		opts = &CloneDatabaseOpts{}
+	}
+
+	src, err := c.FindDatabaseByID(ctx, sourceDBID)
+	if err != nil {
+		return Database{}, err
+	}
+
+	title := src.Title
+	if opts.Title != nil {
+		title = opts.Title
+	}
+
+	props, deferred := cloneDatabaseProperties(src)
+
+	db, err := c.CreateDatabase(ctx, CreateDatabaseParams{
+		ParentPageID: targetParentPageID,
+		Title:        title,
+		Description:  src.Description,
+		Properties:   props,
+		Icon:         copyableIcon(src.Icon),
+		Cover:        copyableCover(src.Cover),
+		IsInline:     src.IsInline,
+	})
+	if err != nil {
+		return Database{}, err
+	}
+
+	if len(deferred) > 0 {
+		for _, prop := range deferred {
+			if prop.Relation != nil {
+				prop.Relation.DatabaseID = db.ID
+			}
+		}
+		db, err = c.UpdateDatabase(ctx, db.ID, UpdateDatabaseParams{Properties: deferred})
+		if err != nil {
+			return Database{}, fmt.Errorf("notion: failed to add self-referencing properties: %w", err)
+		}
+	}
+
+	if !opts.CopyPages {
+		return db, nil
+	}
+
+	selfRelations := make(map[string]bool)
+	for name, prop := range deferred {
+		if prop.Type == DBPropTypeRelation {
+			selfRelations[name] = true
+		}
+	}
+
+	if err := c.clonePages(ctx, src.ID, db.ID, selfRelations, opts); err != nil {
+		return db, err
+	}
+
+	return db, nil
+}
+
+// clonePages copies all pages of a database to another database. Relations in
+// `selfRelations` are remapped to the copied pages, after all pages are copied.
+func (c *Client) clonePages(ctx context.Context, srcID, dstID string, selfRelations map[string]bool, opts *CloneDatabaseOpts) error {
+	pages, err := c.queryAllPages(ctx, srcID, nil)
+	if err != nil {
+		return err
+	}
+
+	bulkOpts := BulkOptions{Concurrency: opts.Concurrency}
+	newIDs := make([]string, len(pages))
+
+	err = runBulk(ctx, len(pages), bulkOpts, func(ctx context.Context, i int) error {
+		// Each call only writes its own index, and the remap pass below reads
+		// the expanded pages once all calls returned.
+		src, err := c.ExpandPage(ctx, pages[i])
+		if err != nil {
+			return err
+		}
+		pages[i] = src
+
+		page, err := c.copyPage(ctx, src, DatabaseParent(dstID), opts.CopyContent, selfRelations)
+		if page.ID != "" {
+			newIDs[i] = page.ID
+		}
+		return err
+	})
+	if len(selfRelations) == 0 {
+		return err
+	}
+
+	idMap := make(map[string]string, len(pages))
+	for i, page := range pages {
+		idMap[page.ID] = newIDs[i]
+	}
+
+	// Pages that were copied are remapped even if copying other pages failed,
+	// so their relations don't refer to pages of the source database. If both
+	// passes fail, the error of copying pages is returned.
+	remapErr := runBulk(ctx, len(pages), bulkOpts, func(ctx context.Context, i int) error {
+		if newIDs[i] == "" {
+			return nil
+		}
+		props := remapRelations(pages[i], selfRelations, idMap)
+		if len(props) == 0 {
+			return nil
+		}
+		_, err := c.UpdatePage(ctx, newIDs[i], UpdatePageParams{DatabasePageProperties: props})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return remapErr
+}
+
+// ClonePage creates a copy of a page, with its icon, cover, properties and
+// content (the full block tree, see CreatePageDeep), as a child of `newParent`
+// (a page, block, database, data source or the workspace). The API has no
+// endpoint for duplicating pages.
+//
+// Properties are only copied when the new parent is a database or data source,
+// in which case it should have the same schema as the database of the source
+// page (e.g. a database created with CloneDatabase); otherwise only the title
+// is copied. Like with CloneDatabase, some things can't be copied: computed
+// property values, files, icons and covers hosted by Notion, and blocks that
+// can't be created (notably `child_page` and `child_database` blocks, so
+// subpages aren't copied). If appending nested blocks fails, the new page is
+// returned along with the error.
+func (c *Client) ClonePage(ctx context.Context, pageID string, newParent Parent) (Page, error) {
+	if err := newParent.Validate(); err != nil {
+		return Page{}, invalidParams("clone page params", fieldError("Parent", err))
+	}
+
+	page, err := c.FindPageByID(ctx, pageID)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page, err = c.ExpandPage(ctx, page)
+	if err != nil {
+		return Page{}, err
+	}
+
+	return c.copyPage(ctx, page, newParent, true, nil)
+}
+
+// copyPage creates a copy of a page. Relations in `skip` aren't copied. Callers
+// should expand truncated properties of `page` first, see clonePageProperties.
+func (c *Client) copyPage(ctx context.Context, page Page, parent Parent, copyContent bool, skip map[string]bool) (Page, error) {
+	params := CreatePageParams{
+		ParentType: parent.Type,
+		ParentID:   parent.ID(),
+		Icon:       copyableIcon(page.Icon),
+		Cover:      copyableCover(page.Cover),
+	}
+
+	switch parent.Type {
+	case ParentTypeDatabase, ParentTypeDataSource:
+		params.DatabasePageProperties = clonePageProperties(page, skip)
+	case ParentTypePage, ParentTypeBlock, ParentTypeWorkspace:
+		params.Title = pageTitle(page)
+		if params.Title == nil {
+			params.Title = []RichText{}
+		}
+	default:
+		return Page{}, invalidParams("clone page params", fieldError("Parent", fmt.Errorf("unsupported parent type %q", parent.Type)))
+	}
+
+	if copyContent {
+		children, err := c.FindBlockChildrenRecursive(ctx, page.ID, &FindBlockChildrenRecursiveOpts{})
+		if err != nil {
+			return Page{}, err
+		}
+		params.Children = copyableBlocks(children)
+	}
+
+	return c.CreatePageDeep(ctx, params)
+}
+
+// cloneDatabaseProperties returns the properties of a database that can be used
+// to create a copy of it. Relations to the database itself, and rollups of
+// these, are returned separately, as they can only be added once the new
+// database exists.
+func cloneDatabaseProperties(db Database) (DatabaseProperties, map[string]*DatabaseProperty) {
+	props := make(DatabaseProperties, len(db.Properties))
+	deferred := make(map[string]*DatabaseProperty)
+
+	for name, prop := range db.Properties {
+		prop.ID = ""
+
+		switch {
+		case prop.Select != nil:
+			prop.Select = &SelectMetadata{Options: cloneSelectOptions(prop.Select.Options)}
+		case prop.MultiSelect != nil:
+			prop.MultiSelect = &SelectMetadata{Options: cloneSelectOptions(prop.MultiSelect.Options)}
+		case prop.Status != nil:
+			prop.Status = &StatusMetadata{}
+		case prop.Relation != nil:
+			prop.Relation = &RelationMetadata{
+				DatabaseID:     prop.Relation.DatabaseID,
+				Type:           RelationTypeSingleProperty,
+				SingleProperty: &SinglePropertyRelation{},
+			}
+			if prop.Relation.DatabaseID == db.ID {
+				p := prop
+				deferred[name] = &p
+				continue
+			}
+		case prop.Rollup != nil:
+			prop.Rollup = &RollupMetadata{
+				RelationPropName: prop.Rollup.RelationPropName,
+				RollupPropName:   prop.Rollup.RollupPropName,
+				Function:         prop.Rollup.Function,
+			}
+		}
+
+		props[name] = prop
+	}
+
+	for name, prop := range props {
+		if prop.Rollup == nil {
+			continue
+		}
+		if _, ok := deferred[prop.Rollup.RelationPropName]; ok {
+			p := prop
+			deferred[name] = &p
+			delete(props, name)
+		}
+	}
+
+	return props, deferred
+}
+
+func cloneSelectOptions(options []SelectOptions) []SelectOptions {
+	if options == nil {
+		return nil
+	}
+
+	cloned := make([]SelectOptions, len(options))
+	for i, option := range options {
+		cloned[i] = SelectOptions{Name: option.Name, Color: option.Color}
+	}
+	return cloned
+}
+
+// clonePageProperties returns the property values of a database page that can
+// be used to create a copy of it. Read-only properties, empty properties and
+// relations in `skip` are omitted. Truncated properties are copied as-is, so
+// `page` should be expanded (see ExpandPage) first.
+func clonePageProperties(page Page, skip map[string]bool) *DatabasePageProperties {
+	props := make(DatabasePageProperties)
+
+	for name, prop := range page.AllProperties() {
+		if prop.isReadOnly() || skip[name] {
+			continue
+		}
+
+		prop.ID = ""
+		prop.HasMore = false
+
+		switch {
+		case prop.Select != nil:
+			prop.Select = &SelectOptions{Name: prop.Select.Name}
+		case prop.Status != nil:
+			prop.Status = &SelectOptions{Name: prop.Status.Name}
+		case prop.MultiSelect != nil:
+			options := make([]SelectOptions, len(prop.MultiSelect))
+			for i, option := range prop.MultiSelect {
+				options[i] = SelectOptions{Name: option.Name}
+			}
+			prop.MultiSelect = options
+		case prop.People != nil:
+			people := make([]User, len(prop.People))
+			for i, user := range prop.People {
+				people[i] = User{BaseUser: BaseUser{ID: user.ID}}
+			}
+			prop.People = people
+		case prop.Files != nil:
+			var files []File
+			for _, file := range prop.Files {
+				if file.External != nil {
+					files = append(files, File{Name: file.Name, Type: FileTypeExternal, External: file.External})
+				}
+			}
+			prop.Files = files
+		}
+
+		if len(prop.valueFields()) == 0 {
+			continue
+		}
+
+		props[name] = prop
+	}
+
+	return &props
+}
+
+// remapRelations returns the relation properties in `names` of a page, with the
+// IDs of related pages mapped by `idMap`. Unmapped pages are omitted.
+func remapRelations(page Page, names map[string]bool, idMap map[string]string) DatabasePageProperties {
+	props := make(DatabasePageProperties)
+
+	for name, prop := range page.AllProperties() {
+		if !names[name] || len(prop.Relation) == 0 {
+			continue
+		}
+
+		var relations []Relation
+		for _, relation := range prop.Relation {
+			if id := idMap[relation.ID]; id != "" {
+				relations = append(relations, Relation{ID: id})
+			}
+		}
+		if len(relations) > 0 {
+			props[name] = DatabasePageProperty{Relation: relations}
+		}
+	}
+
+	return props
+}
+
+// copyableBlocks returns copies of blocks (recursively) that can be used to
+// create them elsewhere, omitting blocks that can't be created via the API.
+func copyableBlocks(blocks []Block) []Block {
+	var copies []Block
+
+	for _, block := range blocks {
+		switch blockPtr(block).(type) {
+		case *ChildPageBlock, *ChildDatabaseBlock, *LinkPreviewBlock, *UnsupportedBlock:
+			continue
+		}
+
+		clone := cloneBlock(block)
+		if children := blockChildren(block); children != nil {
+			setBlockChildren(clone, copyableBlocks(children))
+		}
+		copies = append(copies, clone)
+	}
+
+	return copies
+}
+
+// copyableIcon returns the icon if it can be set via the API, else nil.
+func copyableIcon(icon *Icon) *Icon {
+	if icon == nil || icon.Type == IconTypeFile {
+		return nil
+	}
+	return icon
+}
+
+// copyableCover returns the cover if it can be set via the API, else nil.
+func copyableCover(cover *Cover) *Cover {
+	if cover == nil || cover.Type != FileTypeExternal {
+		return nil
+	}
+	return cover
+}
@@ -0,0 +1,474 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCloneDatabase(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"GET /v1/databases/src-db": `{
+			"object": "database",
+			"id": "src-db",
+			"parent": {"type": "page_id", "page_id": "src-parent"},
+			"title": [{"type": "text", "text": {"content": "Tasks"}}],
+			"icon": {"type": "file", "file": {"url": "https://example.com/icon.png"}},
+			"is_inline": true,
+			"properties": {
+				"Name": {"id": "title", "name": "Name", "type": "title", "title": {}},
+				"Tags": {"id": "a", "name": "Tags", "type": "multi_select", "multi_select": {"options": [{"id": "t1", "name": "Foo", "color": "red"}]}},
+				"Status": {"id": "b", "name": "Status", "type": "status", "status": {"options": [{"id": "s1", "name": "Done", "color": "green"}], "groups": []}},
+				"Parent": {"id": "c", "name": "Parent", "type": "relation", "relation": {"database_id": "src-db", "type": "single_property", "single_property": {}}},
+				"Project": {"id": "d", "name": "Project", "type": "relation", "relation": {"database_id": "other-db", "type": "dual_property", "dual_property": {"synced_property_id": "e", "synced_property_name": "Tasks"}}},
+				"Siblings": {"id": "f", "name": "Siblings", "type": "rollup", "rollup": {"relation_property_name": "Parent", "relation_property_id": "c", "rollup_property_name": "Name", "rollup_property_id": "title", "function": "count_all"}},
+				"Created": {"id": "g", "name": "Created", "type": "created_time", "created_time": {}}
+			}
+		}`,
+		"POST /v1/databases":         `{"object": "database", "id": "new-db", "parent": {"type": "page_id", "page_id": "target"}, "properties": {}}`,
+		"PATCH /v1/databases/new-db": `{"object": "database", "id": "new-db", "parent": {"type": "page_id", "page_id": "target"}, "properties": {}}`,
+		"POST /v1/databases/src-db/query": `{
+			"object": "list",
+			"results": [
+				{
+					"object": "page",
+					"id": "p1",
+					"parent": {"type": "database_id", "database_id": "src-db"},
+					"properties": {
+						"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "First"}}]},
+						"Tags": {"id": "a", "type": "multi_select", "multi_select": [{"id": "t1", "name": "Foo", "color": "red"}]},
+						"Status": {"id": "b", "type": "status", "status": {"id": "s1", "name": "Done", "color": "green"}},
+						"Parent": {"id": "c", "type": "relation", "relation": [], "has_more": false},
+						"Project": {"id": "d", "type": "relation", "relation": [{"id": "project-id"}], "has_more": false},
+						"Siblings": {"id": "f", "type": "rollup", "rollup": {"type": "number", "number": 0, "function": "count_all"}},
+						"Created": {"id": "g", "type": "created_time", "created_time": "2021-05-19T18:34:00.000Z"}
+					}
+				},
+				{
+					"object": "page",
+					"id": "p2",
+					"parent": {"type": "database_id", "database_id": "src-db"},
+					"properties": {
+						"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Second"}}]},
+						"Parent": {"id": "c", "type": "relation", "relation": [{"id": "p1"}], "has_more": false}
+					}
+				}
+			],
+			"has_more": false,
+			"next_cursor": null
+		}`,
+		"GET /v1/blocks/p1/children": `{
+			"object": "list",
+			"results": [
+				{"object": "block", "id": "b1", "type": "paragraph", "has_children": false, "paragraph": {"rich_text": [{"type": "text", "text": {"content": "Hello"}}]}},
+				{"object": "block", "id": "b2", "type": "child_page", "has_children": true, "child_page": {"title": "Subpage"}}
+			],
+			"has_more": false,
+			"next_cursor": null
+		}`,
+		"GET /v1/blocks/p2/children": `{"object": "list", "results": [], "has_more": false, "next_cursor": null}`,
+		"PATCH /v1/pages/new-Second": `{"object": "page", "id": "new-Second", "parent": {"type": "database_id", "database_id": "new-db"}, "properties": {}}`,
+	}
+
+	var (
+		mu     sync.Mutex
+		bodies = make(map[string]interface{})
+	)
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			key := r.Method + " " + r.URL.Path
+
+			var decoded map[string]interface{}
+			if r.Body != nil {
+				body, _ := ioutil.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &decoded); err != nil && len(body) > 0 {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+			}
+
+			resp, ok := responses[key]
+			if key == "POST /v1/pages" {
+				title := decoded["properties"].(map[string]interface{})["Name"].(map[string]interface{})["title"].([]interface{})[0].(map[string]interface{})["text"].(map[string]interface{})["content"].(string)
+				key += " " + title
+				resp, ok = `{"object": "page", "id": "new-`+title+`", "parent": {"type": "database_id", "database_id": "new-db"}, "properties": {}}`, true
+			}
+			if !ok {
+				t.Errorf("unexpected request: %v", key)
+			}
+
+			mu.Lock()
+			bodies[key] = decoded
+			mu.Unlock()
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	db, err := client.CloneDatabase(context.Background(), "src-db", "target", &notion.CloneDatabaseOpts{
+		CopyPages:   true,
+		CopyContent: true,
+		Concurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if db.ID != "new-db" {
+		t.Fatalf("database ID not equal (expected: new-db, got: %v)", db.ID)
+	}
+
+	expBodies := map[string]string{
+		"POST /v1/databases": `{
+			"parent": {"type": "page_id", "page_id": "target"},
+			"title": [{"type": "text", "text": {"content": "Tasks"}}],
+			"is_inline": true,
+			"properties": {
+				"Name": {"name": "Name", "type": "title", "title": {}},
+				"Tags": {"name": "Tags", "type": "multi_select", "multi_select": {"options": [{"name": "Foo", "color": "red"}]}},
+				"Status": {"name": "Status", "type": "status", "status": {}},
+				"Project": {"name": "Project", "type": "relation", "relation": {"database_id": "other-db", "type": "single_property", "single_property": {}}},
+				"Created": {"name": "Created", "type": "created_time", "created_time": {}}
+			}
+		}`,
+		"PATCH /v1/databases/new-db": `{
+			"properties": {
+				"Parent": {"name": "Parent", "type": "relation", "relation": {"database_id": "new-db", "type": "single_property", "single_property": {}}},
+				"Siblings": {"name": "Siblings", "type": "rollup", "rollup": {"relation_property_name": "Parent", "rollup_property_name": "Name", "function": "count_all"}}
+			}
+		}`,
+		"POST /v1/pages First": `{
+			"parent": {"database_id": "new-db"},
+			"properties": {
+				"Name": {"type": "title", "title": [{"type": "text", "text": {"content": "First"}}]},
+				"Tags": {"type": "multi_select", "multi_select": [{"name": "Foo"}]},
+				"Status": {"type": "status", "status": {"name": "Done"}},
+				"Project": {"type": "relation", "relation": [{"id": "project-id"}]}
+			},
+			"children": [
+				{"paragraph": {"rich_text": [{"type": "text", "text": {"content": "Hello"}}]}}
+			]
+		}`,
+		"POST /v1/pages Second": `{
+			"parent": {"database_id": "new-db"},
+			"properties": {
+				"Name": {"type": "title", "title": [{"type": "text", "text": {"content": "Second"}}]}
+			}
+		}`,
+		"PATCH /v1/pages/new-Second": `{
+			"properties": {
+				"Parent": {"relation": [{"id": "new-First"}]}
+			}
+		}`,
+	}
+
+	for key, exp := range expBodies {
+		var expBody map[string]interface{}
+		if err := json.Unmarshal([]byte(exp), &expBody); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expBody, bodies[key]); diff != "" {
+			t.Errorf("request body of %v not equal (-exp, +got):\n%v", key, diff)
+		}
+	}
+}
+
+func TestCloneDatabasePartialFailure(t *testing.T) {
+	t.Parallel()
+
+	page := func(id, title, relations string, hasMore bool) string {
+		return `{
+			"object": "page",
+			"id": "` + id + `",
+			"parent": {"type": "database_id", "database_id": "src-db"},
+			"properties": {
+				"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "` + title + `"}}]},
+				"Parent": {"id": "c", "type": "relation", "relation": [` + relations + `], "has_more": ` + strconv.FormatBool(hasMore) + `}
+			}
+		}`
+	}
+
+	responses := map[string]string{
+		"GET /v1/databases/src-db": `{
+			"object": "database",
+			"id": "src-db",
+			"title": [{"type": "text", "text": {"content": "Tasks"}}],
+			"properties": {
+				"Name": {"id": "title", "name": "Name", "type": "title", "title": {}},
+				"Parent": {"id": "c", "name": "Parent", "type": "relation", "relation": {"database_id": "src-db", "type": "single_property", "single_property": {}}}
+			}
+		}`,
+		"POST /v1/databases":         `{"object": "database", "id": "new-db", "properties": {}}`,
+		"PATCH /v1/databases/new-db": `{"object": "database", "id": "new-db", "properties": {}}`,
+		"POST /v1/databases/src-db/query": `{
+			"object": "list",
+			"results": [` + page("p1", "First", "", false) + `, ` + page("p2", "Second", "", false) + `, ` + page("p3", "Third", `{"id": "p1"}`, true) + `],
+			"has_more": false,
+			"next_cursor": null
+		}`,
+		"GET /v1/pages/p3/properties/c": `{
+			"object": "list",
+			"results": [
+				{"object": "property_item", "type": "relation", "relation": {"id": "p1"}},
+				{"object": "property_item", "type": "relation", "relation": {"id": "p2"}}
+			],
+			"has_more": false,
+			"next_cursor": null,
+			"type": "property_item",
+			"property_item": {"id": "c", "type": "relation", "relation": {}}
+		}`,
+		"PATCH /v1/pages/new-Third": `{"object": "page", "id": "new-Third", "properties": {}}`,
+	}
+
+	var (
+		mu      sync.Mutex
+		patches = make(map[string]interface{})
+	)
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			key := r.Method + " " + r.URL.Path
+
+			var decoded map[string]interface{}
+			if r.Body != nil {
+				body, _ := ioutil.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &decoded); err != nil && len(body) > 0 {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+			}
+
+			resp, ok := responses[key]
+			if key == "POST /v1/pages" {
+				title := decoded["properties"].(map[string]interface{})["Name"].(map[string]interface{})["title"].([]interface{})[0].(map[string]interface{})["text"].(map[string]interface{})["content"].(string)
+				if title == "Second" {
+					return &http.Response{
+						StatusCode: http.StatusBadRequest,
+						Status:     http.StatusText(http.StatusBadRequest),
+						Body:       ioutil.NopCloser(strings.NewReader(`{"object": "error", "status": 400, "code": "validation_error", "message": "Foobar"}`)),
+					}, nil
+				}
+				resp, ok = `{"object": "page", "id": "new-`+title+`", "parent": {"type": "database_id", "database_id": "new-db"}, "properties": {}}`, true
+			}
+			if !ok {
+				t.Errorf("unexpected request: %v", key)
+			}
+
+			if r.Method == http.MethodPatch {
+				mu.Lock()
+				patches[key] = decoded
+				mu.Unlock()
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	db, err := client.CloneDatabase(context.Background(), "src-db", "target", &notion.CloneDatabaseOpts{CopyPages: true})
+
+	var bulkErr *notion.BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("error not a *BulkError (got: %v)", err)
+	}
+	if len(bulkErr.Failed) != 1 || bulkErr.Failed[0].Index != 1 {
+		t.Fatalf("failed items not equal (expected: [1], got: %+v)", bulkErr.Failed)
+	}
+	if db.ID != "new-db" {
+		t.Fatalf("database ID not equal (expected: new-db, got: %v)", db.ID)
+	}
+
+	// The relation of the third page is expanded, and remapped to the copied
+	// pages, even though copying the second page failed.
+	var expBody map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"properties": {"Parent": {"relation": [{"id": "new-First"}]}}}`), &expBody); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expBody, patches["PATCH /v1/pages/new-Third"]); diff != "" {
+		t.Errorf("request body not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestClonePage(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeBlockStore{
+		t: t,
+		children: map[string][]string{
+			"source": {"s1", "s4"},
+			"s1":     {"s2"},
+			"s2":     {"s3"},
+			"s3":     {"s5"},
+		},
+		text: map[string]string{"s1": "a", "s2": "b", "s3": "c", "s4": "d", "s5": "e"},
+	}
+
+	var createBody map[string]interface{}
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/v1/pages/source":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     http.StatusText(http.StatusOK),
+					Body: ioutil.NopCloser(strings.NewReader(`{
+						"object": "page",
+						"id": "source",
+						"parent": {"type": "database_id", "database_id": "db-id"},
+						"icon": {"type": "emoji", "emoji": "🚀"},
+						"cover": {"type": "file", "file": {"url": "https://example.com/cover.png"}},
+						"properties": {
+							"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Foobar"}}]},
+							"Done": {"id": "a", "type": "checkbox", "checkbox": true}
+						}
+					}`)),
+				}, nil
+			case r.Method == http.MethodPost && r.URL.Path == "/v1/pages":
+				body, _ := ioutil.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &createBody); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				r.Body = ioutil.NopCloser(strings.NewReader(string(body)))
+			}
+			return store.RoundTrip(r)
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	page, err := client.ClonePage(context.Background(), "source", notion.PageParent("parent"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.ID != "page" {
+		t.Fatalf("page ID not equal (expected: page, got: %v)", page.ID)
+	}
+
+	if exp, got := store.tree("source"), store.tree("page"); exp != got {
+		t.Fatalf("tree not equal (expected: %v, got: %v)", exp, got)
+	}
+
+	var expBody map[string]interface{}
+	err = json.Unmarshal([]byte(`{
+		"parent": {"page_id": "parent"},
+		"icon": {"type": "emoji", "emoji": "🚀"},
+		"properties": {"title": [{"type": "text", "text": {"content": "Foobar"}}]}
+	}`), &expBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(createBody, "children")
+	if diff := cmp.Diff(expBody, createBody); diff != "" {
+		t.Fatalf("request body not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestClonePageDataSourceParent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		createBody    map[string]interface{}
+		createVersion string
+	)
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			var body string
+			switch r.Method + " " + r.URL.Path {
+			case "GET /v1/pages/source":
+				body = `{
+					"object": "page",
+					"id": "source",
+					"parent": {"type": "data_source_id", "data_source_id": "ds-id", "database_id": "db-id"},
+					"properties": {
+						"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Foobar"}}]},
+						"Done": {"id": "a", "type": "checkbox", "checkbox": true}
+					}
+				}`
+			case "GET /v1/blocks/source/children":
+				body = `{"object": "list", "results": [], "has_more": false, "next_cursor": null}`
+			case "POST /v1/pages":
+				if err := json.NewDecoder(r.Body).Decode(&createBody); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				createVersion = r.Header.Get("Notion-Version")
+				body = `{"object": "page", "id": "page", "parent": {"type": "data_source_id", "data_source_id": "new-ds-id"}, "properties": {}}`
+			default:
+				t.Fatalf("unexpected request: %v %v", r.Method, r.URL.Path)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	page, err := client.ClonePage(context.Background(), "source", notion.DataSourceParent("new-ds-id"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.ID != "page" {
+		t.Fatalf("page ID not equal (expected: page, got: %v)", page.ID)
+	}
+	if exp := "2025-09-03"; createVersion != exp {
+		t.Errorf("Notion-Version not equal (expected: %v, got: %v)", exp, createVersion)
+	}
+
+	var expBody map[string]interface{}
+	err = json.Unmarshal([]byte(`{
+		"parent": {"type": "data_source_id", "data_source_id": "new-ds-id"},
+		"properties": {
+			"Name": {"type": "title", "title": [{"type": "text", "text": {"content": "Foobar"}}]},
+			"Done": {"type": "checkbox", "checkbox": true}
+		}
+	}`), &expBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expBody, createBody); diff != "" {
+		t.Fatalf("request body not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestClonePageInvalidParent(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	_, err := client.ClonePage(context.Background(), "source", notion.Parent{PageID: "parent"})
+
+	var valErr *notion.ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("expected *notion.ValidationError, got: %v", err)
+	}
+	if exp := "Parent"; valErr.Field != exp {
+		t.Fatalf("field not equal (expected: %q, got: %q)", exp, valErr.Field)
+	}
+}
@@ -3,6 +3,7 @@ package notion
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -16,48 +17,175 @@ type Comment struct {
 	CreatedTime    time.Time  `json:"created_time"`
 	LastEditedTime time.Time  `json:"last_edited_time"`
 	CreatedBy      BaseUser   `json:"created_by"`
+
+	Attachments []CommentFile       `json:"attachments,omitempty"`
+	DisplayName *CommentDisplayName `json:"display_name,omitempty"`
+}
+
+// CommentFile is a file attached to a comment.
+type CommentFile struct {
+	// Category is the kind of file, e.g. `image` or `pdf`.
+	Category string   `json:"category"`
+	File     FileFile `json:"file"`
+}
+
+type CommentDisplayNameType string
+
+const (
+	CommentDisplayNameTypeIntegration CommentDisplayNameType = "integration"
+	CommentDisplayNameTypeUser        CommentDisplayNameType = "user"
+	CommentDisplayNameTypeCustom      CommentDisplayNameType = "custom"
+)
+
+// CommentDisplayName is the name shown as the author of a comment. When
+// creating a comment, Custom must be set for type `custom`. ResolvedName is
+// only set in responses.
+type CommentDisplayName struct {
+	Type         CommentDisplayNameType    `json:"type"`
+	Custom       *CommentDisplayNameCustom `json:"custom,omitempty"`
+	ResolvedName string                    `json:"resolved_name,omitempty"`
+}
+
+type CommentDisplayNameCustom struct {
+	Name string `json:"name"`
 }
 
 // CreateCommentParams are the params used for creating a comment.
 type CreateCommentParams struct {
-	// Either ParentPageID or DiscussionID must be non-empty. Also cannot be set
-	// both at the same time.
-	ParentPageID string
-	DiscussionID string
+	// Exactly one of Parent, ParentPageID, ParentBlockID or DiscussionID must
+	// be non-empty. With a block parent, a discussion on the block is started.
+	// Parent must be of type page or block, e.g. BlockParent(id).
+	Parent        Parent
+	ParentPageID  string
+	ParentBlockID string
+	DiscussionID  string
 
 	RichText []RichText
+
+	// Attachments are files uploaded via the file upload API (see UploadFile),
+	// at most three per comment. Optional.
+	Attachments []CommentAttachment
+
+	// DisplayName overrides the author name shown for the comment. Optional,
+	// defaults to the name of the integration.
+	DisplayName *CommentDisplayName
+}
+
+// maxCommentAttachments is the maximum number of attachments per comment.
+const maxCommentAttachments = 3
+
+// CommentAttachment references an uploaded file, used for creating a comment
+// with attachments.
+type CommentAttachment struct {
+	FileUploadID string `json:"file_upload_id"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a CommentAttachment) MarshalJSON() ([]byte, error) {
+	type dto struct {
+		Type         string `json:"type"`
+		FileUploadID string `json:"file_upload_id"`
+	}
+
+	return json.Marshal(dto{
+		Type:         "file_upload",
+		FileUploadID: a.FileUploadID,
+	})
 }
 
 func (p CreateCommentParams) Validate() error {
-	if p.ParentPageID == "" && p.DiscussionID == "" {
-		return errors.New("either parent page ID or discussion ID is required")
+	var n int
+	for _, id := range []string{p.ParentPageID, p.ParentBlockID, p.DiscussionID} {
+		if id != "" {
+			n++
+		}
 	}
-	if p.ParentPageID != "" && p.DiscussionID != "" {
-		return errors.New("parent page ID and discussion ID cannot both be non-empty")
+	if p.Parent != (Parent{}) {
+		n++
+	}
+	if n == 0 {
+		return errors.New("one of parent, parent page ID, parent block ID or discussion ID is required")
+	}
+	if n > 1 {
+		return errors.New("only one of parent, parent page ID, parent block ID and discussion ID can be non-empty")
+	}
+	if p.Parent != (Parent{}) {
+		if err := p.Parent.Validate(); err != nil {
+			return fieldError("Parent", err)
+		}
+		if p.Parent.Type != ParentTypePage && p.Parent.Type != ParentTypeBlock {
+			return fieldError("Parent", fmt.Errorf("unsupported parent type %q (expected page_id or block_id)", p.Parent.Type))
+		}
 	}
 	if len(p.RichText) == 0 {
-		return errors.New("rich text is required")
+		return fieldError("RichText", errors.New("rich text is required"))
+	}
+	if err := validateRichText(p.RichText); err != nil {
+		return fieldError("RichText", err)
+	}
+	if len(p.Attachments) > maxCommentAttachments {
+		return fieldError("Attachments", fmt.Errorf("at most %v attachments are allowed (got: %v)", maxCommentAttachments, len(p.Attachments)))
+	}
+	for i, attachment := range p.Attachments {
+		if attachment.FileUploadID == "" {
+			return fieldError("Attachments", fmt.Errorf("attachment [%v]: file upload ID is required", i))
+		}
+	}
+	if p.DisplayName != nil {
+		if err := p.DisplayName.validate(); err != nil {
+			return fieldError("DisplayName", err)
+		}
 	}
 
 	return nil
 }
 
+func (dn CommentDisplayName) validate() error {
+	switch dn.Type {
+	case CommentDisplayNameTypeIntegration, CommentDisplayNameTypeUser:
+		if dn.Custom != nil {
+			return fmt.Errorf("display name custom cannot be set for type %v", dn.Type)
+		}
+	case CommentDisplayNameTypeCustom:
+		if dn.Custom == nil || dn.Custom.Name == "" {
+			return errors.New("display name custom name is required for type custom")
+		}
+	case "":
+		return errors.New("display name type is required")
+	default:
+		return fmt.Errorf("unsupported display name type %q", dn.Type)
+	}
+	return nil
+}
+
 func (p CreateCommentParams) MarshalJSON() ([]byte, error) {
 	type CreateCommentParamsDTO struct {
-		Parent       *Parent    `json:"parent,omitempty"`
-		DiscussionID string     `json:"discussion_id,omitempty"`
-		RichText     []RichText `json:"rich_text"`
+		Parent       *Parent             `json:"parent,omitempty"`
+		DiscussionID string              `json:"discussion_id,omitempty"`
+		RichText     []RichText          `json:"rich_text"`
+		Attachments  []CommentAttachment `json:"attachments,omitempty"`
+		DisplayName  *CommentDisplayName `json:"display_name,omitempty"`
 	}
 
 	dto := CreateCommentParamsDTO{
-		RichText: p.RichText,
+		RichText:    p.RichText,
+		Attachments: p.Attachments,
+		DisplayName: p.DisplayName,
 	}
-	if p.ParentPageID != "" {
+	switch {
+	case p.Parent != (Parent{}):
+		dto.Parent = &p.Parent
+	case p.ParentPageID != "":
 		dto.Parent = &Parent{
 			Type:   ParentTypePage,
 			PageID: p.ParentPageID,
 		}
-	} else {
+	case p.ParentBlockID != "":
+		dto.Parent = &Parent{
+			Type:    ParentTypeBlock,
+			BlockID: p.ParentBlockID,
+		}
+	default:
 		dto.DiscussionID = p.DiscussionID
 	}
 
@@ -0,0 +1,135 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// richTextVersion is the API version that renamed `text` to `rich_text` in
+// database query filters and block objects.
+// See: https://developers.notion.com/changelog/releasing-notion-version-2022-02-22
+const richTextVersion = "2022-02-22"
+
+// compatTransport is an http.RoundTripper that reshapes request bodies for the
+// API version set in the `Notion-Version` header, so callers can use the same
+// types regardless of the configured version.
+type compatTransport struct {
+	// basePath is the path of the base URL (e.g. `/v1`), which precedes the
+	// endpoint path of requests.
+	basePath string
+	next     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *compatTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	version := req.Header.Get("Notion-Version")
+
+	// API versions are dates, formatted as `YYYY-MM-DD`, so they can be compared
+	// lexically.
+	if req.Body == nil || req.Body == http.NoBody || version >= richTextVersion {
+		return t.next.RoundTrip(req)
+	}
+
+	shape := richTextShaper(req.Method, strings.TrimPrefix(req.URL.Path, t.basePath))
+	if shape == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		shape(v)
+		if shaped, err := json.Marshal(v); err == nil {
+			body = shaped
+		}
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	return t.next.RoundTrip(req)
+}
+
+// richTextShaper returns a func that renames `rich_text` fields to `text` in a
+// request body for the given endpoint, or nil if the endpoint is unaffected.
+func richTextShaper(method, path string) func(map[string]interface{}) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) > 0 && parts[0] == "v1" {
+		parts = parts[1:]
+	}
+
+	switch {
+	case method == http.MethodPost && len(parts) == 3 && parts[0] == "databases" && parts[2] == "query":
+		return func(body map[string]interface{}) {
+			renameFilterRichText(body["filter"])
+		}
+	case method == http.MethodPatch && len(parts) == 3 && parts[0] == "blocks" && parts[2] == "children",
+		method == http.MethodPost && len(parts) == 1 && parts[0] == "pages":
+		return func(body map[string]interface{}) {
+			renameBlocksRichText(body["children"])
+		}
+	case method == http.MethodPatch && len(parts) == 2 && parts[0] == "blocks":
+		return renameBlockRichText
+	default:
+		return nil
+	}
+}
+
+func renameFilterRichText(v interface{}) {
+	filter, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	renameKey(filter, "rich_text", "text")
+
+	for _, key := range []string{"or", "and"} {
+		if filters, ok := filter[key].([]interface{}); ok {
+			for _, f := range filters {
+				renameFilterRichText(f)
+			}
+		}
+	}
+}
+
+func renameBlocksRichText(v interface{}) {
+	blocks, ok := v.([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, b := range blocks {
+		if block, ok := b.(map[string]interface{}); ok {
+			renameBlockRichText(block)
+		}
+	}
+}
+
+// renameBlockRichText renames `rich_text` to `text` in the type specific object
+// of a block, e.g. `{"paragraph": {"rich_text": [...]}}`, including children.
+func renameBlockRichText(block map[string]interface{}) {
+	for _, v := range block {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		renameKey(obj, "rich_text", "text")
+		renameBlocksRichText(obj["children"])
+	}
+}
+
+func renameKey(m map[string]interface{}, from, to string) {
+	if v, ok := m[from]; ok {
+		delete(m, from)
+		m[to] = v
+	}
+}
@@ -1,6 +1,9 @@
 package notion
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 type Cover struct {
 	Type FileType `json:"type"`
@@ -17,6 +20,9 @@ func (cover Cover) Validate() error {
 	if cover.Type == FileTypeExternal && cover.External == nil {
 		return errors.New("cover external cannot be empty")
 	}
+	if err := validateFileExternal(cover.External); err != nil {
+		return fmt.Errorf("cover: %w", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,286 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// dataSourcesVersion is the API version that split databases into data sources.
+// Data source endpoints are always called with (at least) this version.
+// See: https://developers.notion.com/docs/upgrade-guide-2025-09-03
+const dataSourcesVersion = "2025-09-03"
+
+// DataSource is a table of pages with a schema of properties. Since API version
+// 2025-09-03, a database is a container of one or more data sources, and pages
+// are queried and created per data source.
+// See: https://developers.notion.com/reference/data-source
+type DataSource struct {
+	ID             string             `json:"id"`
+	CreatedTime    time.Time          `json:"created_time"`
+	CreatedBy      BaseUser           `json:"created_by"`
+	LastEditedTime time.Time          `json:"last_edited_time"`
+	LastEditedBy   BaseUser           `json:"last_edited_by"`
+	Title          []RichText         `json:"title"`
+	Description    []RichText         `json:"description"`
+	Properties     DatabaseProperties `json:"properties"`
+	Icon           *Icon              `json:"icon,omitempty"`
+	Archived       bool               `json:"archived"`
+	InTrash        bool               `json:"in_trash"`
+	URL            string             `json:"url"`
+
+	// Parent is the database of the data source.
+	Parent Parent `json:"parent"`
+
+	// DatabaseParent is the parent of the database of the data source, e.g. a
+	// page.
+	DatabaseParent *Parent `json:"database_parent,omitempty"`
+}
+
+// DataSourceRef is a reference to a data source of a database, as listed in
+// Database.DataSources.
+type DataSourceRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateDataSourceParams are the params used for adding a data source to an
+// existing database.
+type CreateDataSourceParams struct {
+	DatabaseID string
+	Title      []RichText
+	Properties DatabaseProperties
+	Icon       *Icon
+}
+
+// Validate validates params for creating a data source.
+func (p CreateDataSourceParams) Validate() error {
+	if p.DatabaseID == "" {
+		return fieldError("DatabaseID", errors.New("database ID is required"))
+	}
+	if p.Properties == nil {
+		return fieldError("Properties", errors.New("data source properties are required"))
+	}
+	if p.Icon != nil {
+		if err := p.Icon.Validate(); err != nil {
+			return fieldError("Icon", err)
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p CreateDataSourceParams) MarshalJSON() ([]byte, error) {
+	type dto struct {
+		Parent     Parent             `json:"parent"`
+		Title      []RichText         `json:"title,omitempty"`
+		Properties DatabaseProperties `json:"properties"`
+		Icon       *Icon              `json:"icon,omitempty"`
+	}
+
+	return json.Marshal(dto{
+		Parent: Parent{
+			Type:       ParentTypeDatabase,
+			DatabaseID: p.DatabaseID,
+		},
+		Title:      p.Title,
+		Properties: p.Properties,
+		Icon:       p.Icon,
+	})
+}
+
+// UpdateDataSourceParams are the params used for updating a data source. At
+// least one field should have a non-empty value. Properties with a nil value
+// are removed.
+type UpdateDataSourceParams struct {
+	Title      []RichText                   `json:"title,omitempty"`
+	Properties map[string]*DatabaseProperty `json:"properties,omitempty"`
+	Icon       *Icon                        `json:"icon,omitempty"`
+	Archived   *bool                        `json:"archived,omitempty"`
+	InTrash    *bool                        `json:"in_trash,omitempty"`
+}
+
+// Validate validates params for updating a data source.
+func (p UpdateDataSourceParams) Validate() error {
+	if len(p.Title) == 0 && len(p.Properties) == 0 && p.Icon == nil && p.Archived == nil && p.InTrash == nil {
+		return errors.New("at least one of title, properties, icon, archived or in trash is required")
+	}
+	if p.Icon != nil {
+		if err := p.Icon.Validate(); err != nil {
+			return fieldError("Icon", err)
+		}
+	}
+
+	return nil
+}
+
+// newDataSourceRequest returns a new request for a data source endpoint, with
+// the `Notion-Version` header set to at least the data sources version.
+func (c *Client) newDataSourceRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := c.newRequest(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setDataSourcesVersion(req)
+
+	return req, nil
+}
+
+// setDataSourcesVersion sets the `Notion-Version` header of a request to the
+// data sources version, if the client uses an older version.
+func (c *Client) setDataSourcesVersion(req *http.Request) {
+	// API versions are dates, formatted as `YYYY-MM-DD`, so they can be compared
+	// lexically.
+	if c.apiVersion < dataSourcesVersion {
+		req.Header.Set("Notion-Version", dataSourcesVersion)
+	}
+}
+
+// FindDataSourceByID fetches a data source by ID.
+// See: https://developers.notion.com/reference/retrieve-a-data-source
+func (c *Client) FindDataSourceByID(ctx context.Context, id string) (ds DataSource, err error) {
+	req, err := c.newDataSourceRequest(ctx, http.MethodGet, "/data_sources/"+id, nil)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return DataSource{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return DataSource{}, fmt.Errorf("notion: failed to find data source: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &ds)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	return ds, nil
+}
+
+// QueryDataSource returns the pages of a data source, with optional filters,
+// sorts and pagination, like QueryDatabase.
+// See: https://developers.notion.com/reference/query-a-data-source
+func (c *Client) QueryDataSource(ctx context.Context, id string, query *DatabaseQuery) (result DatabaseQueryResponse, err error) {
+	body := &bytes.Buffer{}
+
+	if query != nil {
+		err = json.NewEncoder(body).Encode(query)
+		if err != nil {
+			return DatabaseQueryResponse{}, fmt.Errorf("notion: failed to encode filter to JSON: %w", err)
+		}
+	}
+
+	req, err := c.newDataSourceRequest(ctx, http.MethodPost, fmt.Sprintf("/data_sources/%v/query", id), body)
+	if err != nil {
+		return DatabaseQueryResponse{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	if query != nil && len(query.FilterProperties) > 0 {
+		req.URL.RawQuery = url.Values{"filter_properties": query.FilterProperties}.Encode()
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return DatabaseQueryResponse{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return DatabaseQueryResponse{}, fmt.Errorf("notion: failed to query data source: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &result)
+	if err != nil {
+		return DatabaseQueryResponse{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+	c.revalidateCachedPages(result.Results)
+
+	return result, nil
+}
+
+// CreateDataSource adds a data source to an existing database.
+// See: https://developers.notion.com/reference/create-a-data-source
+func (c *Client) CreateDataSource(ctx context.Context, params CreateDataSourceParams) (ds DataSource, err error) {
+	if err := params.Validate(); err != nil {
+		return DataSource{}, invalidParams("data source params", err)
+	}
+
+	body := &bytes.Buffer{}
+
+	err = json.NewEncoder(body).Encode(params)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: failed to encode body params to JSON: %w", err)
+	}
+
+	req, err := c.newDataSourceRequest(ctx, http.MethodPost, "/data_sources", body)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return DataSource{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return DataSource{}, fmt.Errorf("notion: failed to create data source: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &ds)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	return ds, nil
+}
+
+// UpdateDataSource updates a data source.
+// See: https://developers.notion.com/reference/update-a-data-source
+func (c *Client) UpdateDataSource(ctx context.Context, dataSourceID string, params UpdateDataSourceParams) (ds DataSource, err error) {
+	if err := params.Validate(); err != nil {
+		return DataSource{}, invalidParams("data source params", err)
+	}
+
+	body := &bytes.Buffer{}
+
+	err = json.NewEncoder(body).Encode(params)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: failed to encode body params to JSON: %w", err)
+	}
+
+	req, err := c.newDataSourceRequest(ctx, http.MethodPatch, "/data_sources/"+dataSourceID, body)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return DataSource{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return DataSource{}, fmt.Errorf("notion: failed to update data source: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &ds)
+	if err != nil {
+		return DataSource{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	return ds, nil
+}
@@ -0,0 +1,453 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+const dataSourceJSON = `{
+	"object": "data_source",
+	"id": "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+	"created_time": "2025-09-03T09:00:00.000Z",
+	"created_by": {"object": "user", "id": "71e95936-2737-4e11-b03d-f174f6f13087"},
+	"last_edited_time": "2025-09-03T09:01:00.000Z",
+	"last_edited_by": {"object": "user", "id": "71e95936-2737-4e11-b03d-f174f6f13087"},
+	"title": [{"type": "text", "text": {"content": "Tasks"}, "plain_text": "Tasks"}],
+	"description": [],
+	"properties": {
+		"Name": {"id": "title", "name": "Name", "type": "title", "title": {}}
+	},
+	"parent": {"type": "database_id", "database_id": "668d797c-76fa-4934-9b05-ad288df2d136"},
+	"database_parent": {"type": "page_id", "page_id": "b8595b75-abd1-4cad-8dfe-f935a8ef57cb"},
+	"archived": false,
+	"in_trash": false,
+	"url": "https://www.notion.so/bc1211cae3f14939ae345260b16f627c"
+}`
+
+func TestFindDataSourceByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		apiVersion string
+		expVersion string
+	}{
+		{
+			name:       "default API version",
+			expVersion: "2025-09-03",
+		},
+		{
+			name:       "newer API version",
+			apiVersion: "2026-01-01",
+			expVersion: "2026-01-01",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if exp := "/v1/data_sources/bc1211ca-e3f1-4939-ae34-5260b16f627c"; r.URL.Path != exp {
+						t.Errorf("path not equal (expected: %v, got: %v)", exp, r.URL.Path)
+					}
+					if got := r.Header.Get("Notion-Version"); got != tt.expVersion {
+						t.Errorf("Notion-Version not equal (expected: %v, got: %v)", tt.expVersion, got)
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       io.NopCloser(strings.NewReader(dataSourceJSON)),
+					}, nil
+				}},
+			}
+			opts := []notion.ClientOption{notion.WithHTTPClient(httpClient)}
+			if tt.apiVersion != "" {
+				opts = append(opts, notion.WithAPIVersion(tt.apiVersion))
+			}
+			client := notion.NewClient("secret-api-key", opts...)
+
+			ds, err := client.FindDataSourceByID(context.Background(), "bc1211ca-e3f1-4939-ae34-5260b16f627c")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			exp := notion.DataSource{
+				ID:             "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+				CreatedTime:    mustParseTime(time.RFC3339Nano, "2025-09-03T09:00:00.000Z"),
+				CreatedBy:      notion.BaseUser{ID: "71e95936-2737-4e11-b03d-f174f6f13087"},
+				LastEditedTime: mustParseTime(time.RFC3339Nano, "2025-09-03T09:01:00.000Z"),
+				LastEditedBy:   notion.BaseUser{ID: "71e95936-2737-4e11-b03d-f174f6f13087"},
+				Title: []notion.RichText{
+					{
+						Type:      notion.RichTextTypeText,
+						Text:      &notion.Text{Content: "Tasks"},
+						PlainText: "Tasks",
+					},
+				},
+				Description: []notion.RichText{},
+				Properties: notion.DatabaseProperties{
+					"Name": {
+						ID:    "title",
+						Name:  "Name",
+						Type:  notion.DBPropTypeTitle,
+						Title: &notion.EmptyMetadata{},
+					},
+				},
+				Parent: notion.Parent{
+					Type:       notion.ParentTypeDatabase,
+					DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136",
+				},
+				DatabaseParent: &notion.Parent{
+					Type:   notion.ParentTypePage,
+					PageID: "b8595b75-abd1-4cad-8dfe-f935a8ef57cb",
+				},
+				URL: "https://www.notion.so/bc1211cae3f14939ae345260b16f627c",
+			}
+
+			if diff := cmp.Diff(exp, ds); diff != "" {
+				t.Fatalf("data source not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestQueryDataSource(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if exp := "/v1/data_sources/bc1211ca-e3f1-4939-ae34-5260b16f627c/query"; r.URL.Path != exp {
+				t.Errorf("path not equal (expected: %v, got: %v)", exp, r.URL.Path)
+			}
+			if exp := "filter_properties=title"; r.URL.RawQuery != exp {
+				t.Errorf("query not equal (expected: %v, got: %v)", exp, r.URL.RawQuery)
+			}
+			if got := r.Header.Get("Notion-Version"); got != "2025-09-03" {
+				t.Errorf("Notion-Version not equal (expected: 2025-09-03, got: %v)", got)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body: io.NopCloser(strings.NewReader(
+					`{
+						"object": "list",
+						"results": [
+							{
+								"object": "page",
+								"id": "7c6b1c95-de50-45ca-94e6-af1d9fd295ab",
+								"parent": {
+									"type": "data_source_id",
+									"data_source_id": "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+									"database_id": "668d797c-76fa-4934-9b05-ad288df2d136"
+								},
+								"properties": {
+									"Name": {"id": "title", "type": "title", "title": []}
+								}
+							}
+						],
+						"next_cursor": null,
+						"has_more": false
+					}`,
+				)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	resp, err := client.QueryDataSource(context.Background(), "bc1211ca-e3f1-4939-ae34-5260b16f627c", &notion.DatabaseQuery{
+		FilterProperties: []string{"title"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := notion.Parent{
+		Type:         notion.ParentTypeDataSource,
+		DataSourceID: "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+		DatabaseID:   "668d797c-76fa-4934-9b05-ad288df2d136",
+	}
+	if len(resp.Results) != 1 {
+		t.Fatalf("expected 1 result, got: %v", len(resp.Results))
+	}
+	if diff := cmp.Diff(exp, resp.Results[0].Parent); diff != "" {
+		t.Fatalf("parent not equal (-exp, +got):\n%v", diff)
+	}
+	if _, ok := resp.Results[0].Properties.(notion.DatabasePageProperties); !ok {
+		t.Fatalf("expected database page properties, got: %T", resp.Results[0].Properties)
+	}
+}
+
+func TestCreateDataSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		params      notion.CreateDataSourceParams
+		expPostBody map[string]interface{}
+		expError    error
+	}{
+		{
+			name: "successful response",
+			params: notion.CreateDataSourceParams{
+				DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136",
+				Title:      []notion.RichText{{Text: &notion.Text{Content: "Tasks"}}},
+				Properties: notion.DatabaseProperties{
+					"Name": {Type: notion.DBPropTypeTitle, Title: &notion.EmptyMetadata{}},
+				},
+			},
+			expPostBody: map[string]interface{}{
+				"parent": map[string]interface{}{
+					"type":        "database_id",
+					"database_id": "668d797c-76fa-4934-9b05-ad288df2d136",
+				},
+				"title": []interface{}{
+					map[string]interface{}{
+						"text": map[string]interface{}{
+							"content": "Tasks",
+						},
+					},
+				},
+				"properties": map[string]interface{}{
+					"Name": map[string]interface{}{
+						"type":  "title",
+						"title": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			name: "missing database ID",
+			params: notion.CreateDataSourceParams{
+				Properties: notion.DatabaseProperties{},
+			},
+			expError: errors.New("notion: invalid data source params: database ID is required"),
+		},
+		{
+			name: "missing properties",
+			params: notion.CreateDataSourceParams{
+				DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136",
+			},
+			expError: errors.New("notion: invalid data source params: data source properties are required"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if tt.expError != nil {
+						t.Fatal("unexpected request")
+					}
+					if exp := "/v1/data_sources"; r.URL.Path != exp {
+						t.Errorf("path not equal (expected: %v, got: %v)", exp, r.URL.Path)
+					}
+
+					postBody := make(map[string]interface{})
+					if err := json.NewDecoder(r.Body).Decode(&postBody); err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(tt.expPostBody, postBody); diff != "" {
+						t.Errorf("post body not equal (-exp, +got):\n%v", diff)
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       io.NopCloser(strings.NewReader(dataSourceJSON)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			ds, err := client.CreateDataSource(context.Background(), tt.params)
+			if tt.expError != nil {
+				if err == nil || err.Error() != tt.expError.Error() {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ds.ID != "bc1211ca-e3f1-4939-ae34-5260b16f627c" {
+				t.Fatalf("unexpected data source ID: %v", ds.ID)
+			}
+		})
+	}
+}
+
+func TestUpdateDataSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		params      notion.UpdateDataSourceParams
+		expPostBody map[string]interface{}
+		expError    error
+	}{
+		{
+			name: "remove property and trash",
+			params: notion.UpdateDataSourceParams{
+				Properties: map[string]*notion.DatabaseProperty{
+					"Status": nil,
+				},
+				InTrash: notion.BoolPtr(true),
+			},
+			expPostBody: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"Status": nil,
+				},
+				"in_trash": true,
+			},
+		},
+		{
+			name:     "empty params",
+			params:   notion.UpdateDataSourceParams{},
+			expError: errors.New("notion: invalid data source params: at least one of title, properties, icon, archived or in trash is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if tt.expError != nil {
+						t.Fatal("unexpected request")
+					}
+					if r.Method != http.MethodPatch {
+						t.Errorf("method not equal (expected: PATCH, got: %v)", r.Method)
+					}
+
+					postBody := make(map[string]interface{})
+					if err := json.NewDecoder(r.Body).Decode(&postBody); err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(tt.expPostBody, postBody); diff != "" {
+						t.Errorf("post body not equal (-exp, +got):\n%v", diff)
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       io.NopCloser(strings.NewReader(dataSourceJSON)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			_, err := client.UpdateDataSource(context.Background(), "bc1211ca-e3f1-4939-ae34-5260b16f627c", tt.params)
+			if tt.expError != nil {
+				if err == nil || err.Error() != tt.expError.Error() {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreatePageWithDataSourceParent(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if got := r.Header.Get("Notion-Version"); got != "2025-09-03" {
+				t.Errorf("Notion-Version not equal (expected: 2025-09-03, got: %v)", got)
+			}
+
+			postBody := make(map[string]interface{})
+			if err := json.NewDecoder(r.Body).Decode(&postBody); err != nil {
+				t.Fatal(err)
+			}
+			expParent := map[string]interface{}{
+				"type":           "data_source_id",
+				"data_source_id": "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+			}
+			if diff := cmp.Diff(expParent, postBody["parent"]); diff != "" {
+				t.Errorf("parent not equal (-exp, +got):\n%v", diff)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body: io.NopCloser(strings.NewReader(
+					`{
+						"object": "page",
+						"id": "7c6b1c95-de50-45ca-94e6-af1d9fd295ab",
+						"parent": {
+							"type": "data_source_id",
+							"data_source_id": "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+							"database_id": "668d797c-76fa-4934-9b05-ad288df2d136"
+						},
+						"properties": {}
+					}`,
+				)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	_, err := client.CreatePage(context.Background(), notion.CreatePageParams{
+		ParentType: notion.ParentTypeDataSource,
+		ParentID:   "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+		DatabasePageProperties: &notion.DatabasePageProperties{
+			"Name": {Title: []notion.RichText{{Text: &notion.Text{Content: "Foobar"}}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.CreatePage(context.Background(), notion.CreatePageParams{
+		ParentType: notion.ParentTypeDataSource,
+		ParentID:   "bc1211ca-e3f1-4939-ae34-5260b16f627c",
+	})
+	expError := "notion: invalid page params: database page properties is required when parent type is data source"
+	if err == nil || err.Error() != expError {
+		t.Fatalf("error not equal (expected: %v, got: %v)", expError, err)
+	}
+}
+
+func TestDatabaseDataSources(t *testing.T) {
+	t.Parallel()
+
+	var db notion.Database
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"id": "668d797c-76fa-4934-9b05-ad288df2d136",
+		"data_sources": [
+			{"id": "bc1211ca-e3f1-4939-ae34-5260b16f627c", "name": "Tasks"}
+		]
+	}`), &db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := []notion.DataSourceRef{{ID: "bc1211ca-e3f1-4939-ae34-5260b16f627c", Name: "Tasks"}}
+	if diff := cmp.Diff(exp, db.DataSources); diff != "" {
+		t.Fatalf("data sources not equal (-exp, +got):\n%v", diff)
+	}
+}
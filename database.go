@@ -1,8 +1,13 @@
 package notion
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -23,6 +28,117 @@ type Database struct {
 	Cover          *Cover             `json:"cover,omitempty"`
 	Archived       bool               `json:"archived"`
 	IsInline       bool               `json:"is_inline"`
+
+	// DataSources are the data sources of the database. Only set since API
+	// version 2025-09-03, which moved Properties to data sources.
+	DataSources []DataSourceRef `json:"data_sources,omitempty"`
+
+	// PropertyOrder contains the names of Properties, in the order of the JSON
+	// response, as Go maps aren't ordered.
+	PropertyOrder []string `json:"-"`
+
+	// IsPartial is true for partial objects (stubs), which the API returns
+	// (e.g. in search results) for databases the integration doesn't have read
+	// access to. Only ID is set.
+	IsPartial bool `json:"-"`
+
+	// Raw contains the JSON object, as returned by the API. It's only set when
+	// the client was created with WithRawCapture.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It populates PropertyOrder and
+// IsPartial.
+func (db *Database) UnmarshalJSON(b []byte) error {
+	type databaseAlias Database
+
+	var (
+		alias databaseAlias
+		raw   struct {
+			Properties json.RawMessage `json:"properties"`
+		}
+	)
+
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	order, err := jsonObjectKeys(raw.Properties)
+	if err != nil {
+		return err
+	}
+	alias.PropertyOrder = order
+	alias.IsPartial = alias.Parent.Type == "" && len(raw.Properties) == 0
+
+	*db = Database(alias)
+
+	return nil
+}
+
+// OrderedProperties returns the database properties, sorted by PropertyOrder.
+// Properties missing from PropertyOrder (e.g. when added manually) are last.
+func (db Database) OrderedProperties() []DatabaseProperty {
+	props := make([]DatabaseProperty, 0, len(db.Properties))
+	seen := make(map[string]bool, len(db.PropertyOrder))
+
+	for _, name := range db.PropertyOrder {
+		if prop, ok := db.Properties[name]; ok && !seen[name] {
+			props = append(props, prop)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range db.Properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+
+	for _, name := range rest {
+		props = append(props, db.Properties[name])
+	}
+
+	return props
+}
+
+// jsonObjectKeys returns the keys of a JSON object, in order. A null (or empty)
+// value returns nil.
+func jsonObjectKeys(b json.RawMessage) ([]string, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected JSON token %v", tok)
+		}
+		keys = append(keys, key)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
 }
 
 // DatabaseProperties is a mapping of properties defined on a database.
@@ -38,8 +154,8 @@ type (
 		Options []SelectOptions `json:"options"`
 	}
 	StatusMetadata struct {
-		Options []SelectOptions `json:"options"`
-		Groups  []StatusGroup   `json:"groups"`
+		Options []SelectOptions `json:"options,omitempty"`
+		Groups  []StatusGroup   `json:"groups,omitempty"`
 	}
 	FormulaMetadata struct {
 		Expression string `json:"expression"`
@@ -48,8 +164,8 @@ type (
 		DatabaseID string       `json:"database_id,omitempty"`
 		Type       RelationType `json:"type,omitempty"`
 
-		SingleProperty *struct{}             `json:"single_property,omitempty"`
-		DualProperty   *DualPropertyRelation `json:"dual_property,omitempty"`
+		SingleProperty *SinglePropertyRelation `json:"single_property,omitempty"`
+		DualProperty   *DualPropertyRelation   `json:"dual_property,omitempty"`
 	}
 	RollupMetadata struct {
 		RelationPropName string         `json:"relation_property_name,omitempty"`
@@ -60,6 +176,12 @@ type (
 	}
 )
 
+// SinglePropertyRelation is the metadata of a one-way relation, which only
+// has a property on the database that defines it.
+type SinglePropertyRelation struct{}
+
+// DualPropertyRelation is the metadata of a two-way relation, which has a
+// synced property on the related database.
 type DualPropertyRelation struct {
 	SyncedPropID   string `json:"synced_property_id,omitempty"`
 	SyncedPropName string `json:"synced_property_name,omitempty"`
@@ -85,6 +207,20 @@ const (
 	RollupFunctionMax               RollupFunction = "max"
 	RollupFunctionRange             RollupFunction = "range"
 	RollupFunctionShowOriginal      RollupFunction = "show_original"
+	RollupFunctionShowUnique        RollupFunction = "show_unique"
+	RollupFunctionCount             RollupFunction = "count"
+	RollupFunctionCountPerGroup     RollupFunction = "count_per_group"
+	RollupFunctionUnique            RollupFunction = "unique"
+	RollupFunctionEmpty             RollupFunction = "empty"
+	RollupFunctionNotEmpty          RollupFunction = "not_empty"
+	RollupFunctionChecked           RollupFunction = "checked"
+	RollupFunctionUnchecked         RollupFunction = "unchecked"
+	RollupFunctionPercentChecked    RollupFunction = "percent_checked"
+	RollupFunctionPercentUnchecked  RollupFunction = "percent_unchecked"
+	RollupFunctionPercentPerGroup   RollupFunction = "percent_per_group"
+	RollupFunctionEarliestDate      RollupFunction = "earliest_date"
+	RollupFunctionLatestDate        RollupFunction = "latest_date"
+	RollupFunctionDateRange         RollupFunction = "date_range"
 
 	RelationTypeSingleProperty RelationType = "single_property"
 	RelationTypeDualProperty   RelationType = "dual_property"
@@ -96,6 +232,13 @@ type SelectOptions struct {
 	Color Color  `json:"color,omitempty"`
 }
 
+// NewSelectOption returns a select option for an option that doesn't exist yet,
+// e.g. for a `select` property of CreateDatabase, or a page property value.
+// When `color` is empty, the API picks a color.
+func NewSelectOption(name string, color Color) SelectOptions {
+	return SelectOptions{Name: name, Color: color}
+}
+
 type StatusGroup struct {
 	ID        string   `json:"id,omitempty"`
 	Name      string   `json:"name,omitempty"`
@@ -117,11 +260,18 @@ type Relation struct {
 }
 
 type RollupResult struct {
-	Type RollupResultType `json:"type"`
+	Type     RollupResultType `json:"type"`
+	Function RollupFunction   `json:"function,omitempty"`
 
 	Number *float64               `json:"number,omitempty"`
 	Date   *Date                  `json:"date,omitempty"`
 	Array  []DatabasePageProperty `json:"array,omitempty"`
+
+	// Incomplete and Unsupported hold the (raw) value of rollups that the API
+	// couldn't fully evaluate, or that it doesn't support (e.g. rollups of
+	// rollups).
+	Incomplete  json.RawMessage `json:"incomplete,omitempty"`
+	Unsupported json.RawMessage `json:"unsupported,omitempty"`
 }
 
 type People struct {
@@ -162,6 +312,105 @@ type DatabaseProperty struct {
 	Relation    *RelationMetadata `json:"relation,omitempty"`
 	Rollup      *RollupMetadata   `json:"rollup,omitempty"`
 	Status      *StatusMetadata   `json:"status,omitempty"`
+
+	// Description is the description of the property, as shown in the Notion UI.
+	Description string `json:"description,omitempty"`
+
+	// Unknown contains the raw JSON values of fields that aren't supported
+	// (yet) by this library, keyed by field name, e.g. UI metadata like
+	// visibility flags. They are included when encoding to JSON, so schema
+	// exports remain faithful.
+	Unknown map[string]json.RawMessage `json:"-"`
+}
+
+// knownDatabasePropertyFields are the JSON field names of DatabaseProperty.
+var knownDatabasePropertyFields = jsonFieldNames(reflect.TypeOf(DatabaseProperty{}))
+
+// UnmarshalJSON implements json.Unmarshaler. It populates Unknown.
+func (prop *DatabaseProperty) UnmarshalJSON(b []byte) error {
+	type databasePropertyAlias DatabaseProperty
+
+	var (
+		alias databasePropertyAlias
+		raw   map[string]json.RawMessage
+	)
+
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+		if knownDatabasePropertyFields[key] {
+			continue
+		}
+		if alias.Unknown == nil {
+			alias.Unknown = make(map[string]json.RawMessage)
+		}
+		alias.Unknown[key] = value
+	}
+
+	*prop = DatabaseProperty(alias)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Fields in Unknown are included, unless
+// they clash with a known field.
+func (prop DatabaseProperty) MarshalJSON() ([]byte, error) {
+	type databasePropertyAlias DatabaseProperty
+
+	b, err := json.Marshal(databasePropertyAlias(prop))
+	if err != nil || len(prop.Unknown) == 0 {
+		return b, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range prop.Unknown {
+		if !knownDatabasePropertyFields[key] {
+			fields[key] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// Visible returns the value of the `visible` field, if the API returned it. It
+// isn't documented (yet), so `ok` is false when it's missing or not a boolean.
+func (prop DatabaseProperty) Visible() (visible bool, ok bool) {
+	raw, exists := prop.Unknown["visible"]
+	if !exists {
+		return false, false
+	}
+	if err := json.Unmarshal(raw, &visible); err != nil {
+		return false, false
+	}
+
+	return visible, true
+}
+
+// jsonFieldNames returns the set of JSON field names of struct type `t`.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		names[name] = true
+	}
+
+	return names
 }
 
 // DatabaseQuery is used for quering a database.
@@ -170,6 +419,11 @@ type DatabaseQuery struct {
 	Sorts       []DatabaseQuerySort  `json:"sorts,omitempty"`
 	StartCursor string               `json:"start_cursor,omitempty"`
 	PageSize    int                  `json:"page_size,omitempty"`
+
+	// FilterProperties are the IDs of properties to return for pages, to reduce
+	// response sizes for databases with many properties. Empty means all
+	// properties. They're sent as `filter_properties` query params.
+	FilterProperties []string `json:"-"`
 }
 
 // DatabaseQueryResponse contains the results and pagination data from a query request.
@@ -186,6 +440,9 @@ type DatabaseQueryFilter struct {
 
 	DatabaseQueryPropertyFilter
 
+	// Timestamp is used for filtering on the created or last edited time of
+	// pages, instead of a property. The condition is set in the `CreatedTime` or
+	// `LastEditedTime` field, respectively. See NewTimestampFilter.
 	Timestamp Timestamp             `json:"timestamp,omitempty"`
 	Or        []DatabaseQueryFilter `json:"or,omitempty"`
 	And       []DatabaseQueryFilter `json:"and,omitempty"`
@@ -217,6 +474,8 @@ type DatabaseQueryPropertyFilter struct {
 	LastEditedBy *PeopleDatabaseQueryFilter `json:"last_edited_by,omitempty"`
 }
 
+// Timestamp is a timestamp of pages that can be used in filters.
+// See: https://developers.notion.com/reference/post-database-query-filter#timestamp
 type Timestamp string
 
 const (
@@ -224,6 +483,21 @@ const (
 	TimestampLastEditedTime = "last_edited_time"
 )
 
+// NewTimestampFilter returns a filter on the created or last edited time of
+// pages, e.g. for querying recently edited rows.
+func NewTimestampFilter(timestamp Timestamp, condition DatePropertyFilter) DatabaseQueryFilter {
+	filter := DatabaseQueryFilter{Timestamp: timestamp}
+
+	switch timestamp {
+	case TimestampCreatedTime:
+		filter.CreatedTime = &condition
+	case TimestampLastEditedTime:
+		filter.LastEditedTime = &condition
+	}
+
+	return filter
+}
+
 type TextPropertyFilter struct {
 	Equals         string `json:"equals,omitempty"`
 	DoesNotEqual   string `json:"does_not_equal,omitempty"`
@@ -235,15 +509,17 @@ type TextPropertyFilter struct {
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 }
 
+// NumberDatabaseQueryFilter is a condition for `number` properties. Values are
+// floats, like the values of number properties. Use Float64Ptr for literals.
 type NumberDatabaseQueryFilter struct {
-	Equals               *int `json:"equals,omitempty"`
-	DoesNotEqual         *int `json:"does_not_equal,omitempty"`
-	GreaterThan          *int `json:"greater_than,omitempty"`
-	LessThan             *int `json:"less_than,omitempty"`
-	GreaterThanOrEqualTo *int `json:"greater_than_or_equal_to,omitempty"`
-	LessThanOrEqualTo    *int `json:"less_than_or_equal_to,omitempty"`
-	IsEmpty              bool `json:"is_empty,omitempty"`
-	IsNotEmpty           bool `json:"is_not_empty,omitempty"`
+	Equals               *float64 `json:"equals,omitempty"`
+	DoesNotEqual         *float64 `json:"does_not_equal,omitempty"`
+	GreaterThan          *float64 `json:"greater_than,omitempty"`
+	LessThan             *float64 `json:"less_than,omitempty"`
+	GreaterThanOrEqualTo *float64 `json:"greater_than_or_equal_to,omitempty"`
+	LessThanOrEqualTo    *float64 `json:"less_than_or_equal_to,omitempty"`
+	IsEmpty              bool     `json:"is_empty,omitempty"`
+	IsNotEmpty           bool     `json:"is_not_empty,omitempty"`
 }
 
 type CheckboxDatabaseQueryFilter struct {
@@ -272,20 +548,24 @@ type StatusDatabaseQueryFilter struct {
 	IsNotEmpty   bool   `json:"is_not_empty,omitempty"`
 }
 
+// DatePropertyFilter is a condition on a date property or timestamp. Values
+// are of type DateTime, so comparisons can be done by date (use NewDateTime
+// with `hasTime` false) or by date and time.
 type DatePropertyFilter struct {
-	Equals     *time.Time `json:"equals,omitempty"`
-	Before     *time.Time `json:"before,omitempty"`
-	After      *time.Time `json:"after,omitempty"`
-	OnOrBefore *time.Time `json:"on_or_before,omitempty"`
-	OnOrAfter  *time.Time `json:"on_or_after,omitempty"`
-	IsEmpty    bool       `json:"is_empty,omitempty"`
-	IsNotEmpty bool       `json:"is_not_empty,omitempty"`
-	PastWeek   *struct{}  `json:"past_week,omitempty"`
-	PastMonth  *struct{}  `json:"past_month,omitempty"`
-	PastYear   *struct{}  `json:"past_year,omitempty"`
-	NextWeek   *struct{}  `json:"next_week,omitempty"`
-	NextMonth  *struct{}  `json:"next_month,omitempty"`
-	NextYear   *struct{}  `json:"next_year,omitempty"`
+	Equals     *DateTime `json:"equals,omitempty"`
+	Before     *DateTime `json:"before,omitempty"`
+	After      *DateTime `json:"after,omitempty"`
+	OnOrBefore *DateTime `json:"on_or_before,omitempty"`
+	OnOrAfter  *DateTime `json:"on_or_after,omitempty"`
+	IsEmpty    bool      `json:"is_empty,omitempty"`
+	IsNotEmpty bool      `json:"is_not_empty,omitempty"`
+	PastWeek   *struct{} `json:"past_week,omitempty"`
+	PastMonth  *struct{} `json:"past_month,omitempty"`
+	PastYear   *struct{} `json:"past_year,omitempty"`
+	ThisWeek   *struct{} `json:"this_week,omitempty"`
+	NextWeek   *struct{} `json:"next_week,omitempty"`
+	NextMonth  *struct{} `json:"next_month,omitempty"`
+	NextYear   *struct{} `json:"next_year,omitempty"`
 }
 
 type PeopleDatabaseQueryFilter struct {
@@ -453,6 +733,60 @@ func (prop DatabaseProperty) Metadata() interface{} {
 	}
 }
 
+// TitleProperty returns the title property of the database. Every database
+// has exactly one title property; `ok` is false if it's missing, e.g. when the
+// properties weren't fetched from the API.
+func (props DatabaseProperties) TitleProperty() (prop DatabaseProperty, ok bool) {
+	for _, prop := range props {
+		if prop.Type == DBPropTypeTitle {
+			return prop, true
+		}
+	}
+
+	return DatabaseProperty{}, false
+}
+
+// ByType returns the subset of database properties with the given type.
+func (props DatabaseProperties) ByType(propType DatabasePropertyType) DatabaseProperties {
+	filtered := DatabaseProperties{}
+
+	for name, prop := range props {
+		if prop.Type == propType {
+			filtered[name] = prop
+		}
+	}
+
+	return filtered
+}
+
+// Options returns the options of a `select`, `multi_select` or `status`
+// property. For other property types, `nil` is returned.
+func (prop DatabaseProperty) Options() []SelectOptions {
+	switch {
+	case prop.Type == DBPropTypeSelect && prop.Select != nil:
+		return prop.Select.Options
+	case prop.Type == DBPropTypeMultiSelect && prop.MultiSelect != nil:
+		return prop.MultiSelect.Options
+	case prop.Type == DBPropTypeStatus && prop.Status != nil:
+		return prop.Status.Options
+	default:
+		return nil
+	}
+}
+
+// OptionByName returns the option of a `select`, `multi_select` or `status`
+// property with the given name. Option names are matched exactly, like Notion
+// does when writing page property values.
+func (prop DatabaseProperty) OptionByName(name string) (SelectOptions, bool) {
+	for _, option := range prop.Options() {
+		if option.Name == name {
+			return option, true
+		}
+	}
+
+	return SelectOptions{}, false
+}
+
 // Value returns the underlying result value of an evaluated formula.
 func (f FormulaResult) Value() interface{} {
 	switch f.Type {
@@ -469,7 +803,8 @@ func (f FormulaResult) Value() interface{} {
 	}
 }
 
-// Value returns the underlying result value of an evaluated rollup.
+// Value returns the underlying result value of an evaluated rollup. It returns
+// nil for incomplete and unsupported rollups.
 func (r RollupResult) Value() interface{} {
 	switch r.Type {
 	case RollupResultTypeNumber:
@@ -486,19 +821,19 @@ func (r RollupResult) Value() interface{} {
 // Validate validates params for creating a database.
 func (p CreateDatabaseParams) Validate() error {
 	if p.ParentPageID == "" {
-		return errors.New("parent page ID is required")
+		return fieldError("ParentPageID", errors.New("parent page ID is required"))
 	}
 	if p.Properties == nil {
-		return errors.New("database properties are required")
+		return fieldError("Properties", errors.New("database properties are required"))
 	}
 	if p.Icon != nil {
 		if err := p.Icon.Validate(); err != nil {
-			return err
+			return fieldError("Icon", err)
 		}
 	}
 	if p.Cover != nil {
 		if err := p.Cover.Validate(); err != nil {
-			return err
+			return fieldError("Cover", err)
 		}
 	}
 
@@ -553,12 +888,12 @@ func (p UpdateDatabaseParams) Validate() error {
 	}
 	if p.Icon != nil {
 		if err := p.Icon.Validate(); err != nil {
-			return err
+			return fieldError("Icon", err)
 		}
 	}
 	if p.Cover != nil {
 		if err := p.Cover.Validate(); err != nil {
-			return err
+			return fieldError("Cover", err)
 		}
 	}
 
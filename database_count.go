@@ -0,0 +1,42 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+)
+
+// CountPages returns the number of pages in a database matching `filter` (or
+// all pages, if nil). The Notion API doesn't have an endpoint for counting, so
+// all pages are queried, using the maximum page size. To reduce response sizes,
+// only the title property is returned for pages. See ErrCanceled for
+// cancellation.
+func (c *Client) CountPages(ctx context.Context, databaseID string, filter *DatabaseQueryFilter) (int, error) {
+	query := &DatabaseQuery{
+		Filter:           filter,
+		PageSize:         MaxPageSize,
+		FilterProperties: []string{"title"},
+	}
+
+	var count int
+
+	for {
+		if err := canceled(ctx, count, -1); err != nil {
+			return count, err
+		}
+
+		resp, err := c.QueryDatabase(ctx, databaseID, query)
+		if err != nil {
+			if err := canceled(ctx, count, -1); err != nil {
+				return count, err
+			}
+			return 0, fmt.Errorf("notion: failed to count pages: %w", err)
+		}
+
+		count += len(resp.Results)
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return count, nil
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+}
@@ -0,0 +1,74 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCountPages(t *testing.T) {
+	t.Parallel()
+
+	var bodies []map[string]interface{}
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if exp := "filter_properties=title"; r.URL.RawQuery != exp {
+				t.Errorf("query not equal (expected: %v, got: %v)", exp, r.URL.RawQuery)
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			bodies = append(bodies, body)
+
+			page := `{"object": "page", "parent": {"type": "database_id", "database_id": "db"}, "properties": {"Name": {"id": "title", "type": "title", "title": []}}}`
+			respBody := `{"object": "list", "results": [` + page + `,` + page + `], "has_more": true, "next_cursor": "cursor-1"}`
+			if len(bodies) == 2 {
+				respBody = `{"object": "list", "results": [` + page + `], "has_more": false, "next_cursor": null}`
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(respBody)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	filter := &notion.DatabaseQueryFilter{
+		Property: "Done",
+		DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+			Checkbox: &notion.CheckboxDatabaseQueryFilter{Equals: notion.BoolPtr(true)},
+		},
+	}
+
+	count, err := client.CountPages(context.Background(), "db", filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("count not equal (expected: 3, got: %v)", count)
+	}
+
+	expFilter := map[string]interface{}{
+		"property": "Done",
+		"checkbox": map[string]interface{}{"equals": true},
+	}
+	expBodies := []map[string]interface{}{
+		{"filter": expFilter, "page_size": float64(100)},
+		{"filter": expFilter, "page_size": float64(100), "start_cursor": "cursor-1"},
+	}
+
+	if diff := cmp.Diff(expBodies, bodies); diff != "" {
+		t.Fatalf("request bodies not equal (-exp, +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,494 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDatabaseProperties(t *testing.T) {
+	t.Parallel()
+
+	props := notion.DatabaseProperties{
+		"Name": {
+			ID:    "title",
+			Type:  notion.DBPropTypeTitle,
+			Name:  "Name",
+			Title: &notion.EmptyMetadata{},
+		},
+		"City": {
+			ID:   "fk%5EY",
+			Type: notion.DBPropTypeSelect,
+			Name: "City",
+			Select: &notion.SelectMetadata{
+				Options: []notion.SelectOptions{
+					{ID: "1", Name: "Paris", Color: notion.ColorBlue},
+					{ID: "2", Name: "Amsterdam", Color: notion.ColorOrange},
+				},
+			},
+		},
+		"Status": {
+			ID:   "bIAg",
+			Type: notion.DBPropTypeStatus,
+			Name: "Status",
+			Status: &notion.StatusMetadata{
+				Options: []notion.SelectOptions{
+					{ID: "3", Name: "Done", Color: notion.ColorGreen},
+				},
+			},
+		},
+	}
+
+	t.Run("title property", func(t *testing.T) {
+		t.Parallel()
+
+		prop, ok := props.TitleProperty()
+		if !ok {
+			t.Fatal("expected title property to be found")
+		}
+		if diff := cmp.Diff(props["Name"], prop); diff != "" {
+			t.Fatalf("property not equal (-exp, +got):\n%v", diff)
+		}
+	})
+
+	t.Run("by type", func(t *testing.T) {
+		t.Parallel()
+
+		exp := notion.DatabaseProperties{"City": props["City"]}
+		got := props.ByType(notion.DBPropTypeSelect)
+
+		if diff := cmp.Diff(exp, got); diff != "" {
+			t.Fatalf("properties not equal (-exp, +got):\n%v", diff)
+		}
+	})
+
+	t.Run("option by name", func(t *testing.T) {
+		t.Parallel()
+
+		option, ok := props["City"].OptionByName("Amsterdam")
+		if !ok {
+			t.Fatal("expected select option to be found")
+		}
+		if diff := cmp.Diff(notion.SelectOptions{ID: "2", Name: "Amsterdam", Color: notion.ColorOrange}, option); diff != "" {
+			t.Fatalf("option not equal (-exp, +got):\n%v", diff)
+		}
+
+		if _, ok := props["Status"].OptionByName("Done"); !ok {
+			t.Fatal("expected status option to be found")
+		}
+		if _, ok := props["Name"].OptionByName("Paris"); ok {
+			t.Fatal("expected no option for title property")
+		}
+	})
+}
+
+func TestDatabasePropertyOrder(t *testing.T) {
+	t.Parallel()
+
+	var db notion.Database
+
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"id": "668d797c-76fa-4934-9b05-ad288df2d136",
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": {}},
+			"Price": {"id": "a%7Bd", "type": "number", "number": {"format": "dollar"}},
+			"Description": {"id": "J@cS", "type": "rich_text", "rich_text": {}}
+		}
+	}`), &db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"Name", "Price", "Description"}, db.PropertyOrder); diff != "" {
+		t.Fatalf("property order not equal (-exp, +got):\n%v", diff)
+	}
+
+	// Properties that aren't in the property order are sorted by name, last.
+	db.Properties["Added"] = notion.DatabaseProperty{ID: "added", Type: notion.DBPropTypeCheckbox}
+
+	var ids []string
+	for _, prop := range db.OrderedProperties() {
+		ids = append(ids, prop.ID)
+	}
+
+	if diff := cmp.Diff([]string{"title", "a%7Bd", "J@cS", "added"}, ids); diff != "" {
+		t.Fatalf("ordered properties not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestDatabasePropertyUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	input := `{"description":"Due date of the task","id":"a","name":"Due","type":"date","date":{},"visible":false,"x_format":{"style":"relative"}}`
+
+	var prop notion.DatabaseProperty
+	if err := json.Unmarshal([]byte(input), &prop); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := notion.DatabaseProperty{
+		ID:          "a",
+		Type:        notion.DBPropTypeDate,
+		Name:        "Due",
+		Date:        &notion.EmptyMetadata{},
+		Description: "Due date of the task",
+		Unknown: map[string]json.RawMessage{
+			"visible":  json.RawMessage(`false`),
+			"x_format": json.RawMessage(`{"style":"relative"}`),
+		},
+	}
+	if diff := cmp.Diff(exp, prop); diff != "" {
+		t.Fatalf("property not equal (-exp, +got):\n%v", diff)
+	}
+
+	visible, ok := prop.Visible()
+	if !ok || visible {
+		t.Fatalf("visible not equal (expected: false, true, got: %v, %v)", visible, ok)
+	}
+	if _, ok := (notion.DatabaseProperty{}).Visible(); ok {
+		t.Fatal("expected visible to be missing")
+	}
+
+	b, err := json.Marshal(prop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp, got := `{"date":{},"description":"Due date of the task","id":"a","name":"Due","type":"date","visible":false,"x_format":{"style":"relative"}}`, string(b); exp != got {
+		t.Fatalf("JSON not equal (expected: %v, got: %v)", exp, got)
+	}
+}
+
+func TestPartialObjects(t *testing.T) {
+	t.Parallel()
+
+	var queryResp notion.DatabaseQueryResponse
+	err := json.Unmarshal([]byte(`{
+		"object": "list",
+		"results": [
+			{"object": "page", "id": "page-1", "parent": {"type": "page_id", "page_id": "parent-id"}, "properties": {}},
+			{"object": "page", "id": "page-2"}
+		],
+		"has_more": false,
+		"next_cursor": null
+	}`), &queryResp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expPages := []notion.Page{
+		{ID: "page-1", Parent: notion.Parent{Type: notion.ParentTypePage, PageID: "parent-id"}, Properties: notion.PageProperties{}},
+		{ID: "page-2", IsPartial: true},
+	}
+	if diff := cmp.Diff(expPages, queryResp.Results); diff != "" {
+		t.Fatalf("pages not equal (-exp, +got):\n%v", diff)
+	}
+
+	var searchResp notion.SearchResponse
+	err = json.Unmarshal([]byte(`{
+		"object": "list",
+		"results": [
+			{"object": "database", "id": "db-1"},
+			{"object": "page", "id": "page-1"}
+		],
+		"has_more": false,
+		"next_cursor": null
+	}`), &searchResp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expResults := notion.SearchResults{
+		notion.Database{ID: "db-1", IsPartial: true},
+		notion.Page{ID: "page-1", IsPartial: true},
+	}
+	if diff := cmp.Diff(expResults, searchResp.Results); diff != "" {
+		t.Fatalf("results not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestNewTimestampFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		timestamp notion.Timestamp
+		exp       string
+	}{
+		{
+			name:      "created time",
+			timestamp: notion.TimestampCreatedTime,
+			exp:       `{"created_time":{"past_week":{}},"timestamp":"created_time"}`,
+		},
+		{
+			name:      "last edited time",
+			timestamp: notion.TimestampLastEditedTime,
+			exp:       `{"last_edited_time":{"past_week":{}},"timestamp":"last_edited_time"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := notion.NewTimestampFilter(tt.timestamp, notion.DatePropertyFilter{PastWeek: &struct{}{}})
+
+			got, err := json.Marshal(filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.exp, string(got)); diff != "" {
+				t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseQueryFilterTextProperties(t *testing.T) {
+	t.Parallel()
+
+	filter := notion.DatabaseQueryFilter{
+		Or: []notion.DatabaseQueryFilter{
+			{
+				Property: "Description",
+				DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+					RichText: &notion.TextPropertyFilter{Contains: "foo"},
+				},
+			},
+			{
+				Property: "Website",
+				DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+					URL: &notion.TextPropertyFilter{EndsWith: ".com"},
+				},
+			},
+			{
+				Property: "Email",
+				DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+					Email: &notion.TextPropertyFilter{IsNotEmpty: true},
+				},
+			},
+			{
+				Property: "Phone",
+				DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+					PhoneNumber: &notion.TextPropertyFilter{StartsWith: "+31"},
+				},
+			},
+		},
+	}
+
+	exp := `{"or":[` +
+		`{"property":"Description","rich_text":{"contains":"foo"}},` +
+		`{"property":"Website","url":{"ends_with":".com"}},` +
+		`{"property":"Email","email":{"is_not_empty":true}},` +
+		`{"property":"Phone","phone_number":{"starts_with":"+31"}}]}`
+
+	got, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(exp, string(got)); diff != "" {
+		t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestDatePropertyFilter(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2022, 9, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter notion.DatePropertyFilter
+		exp    string
+	}{
+		{
+			name:   "date only",
+			filter: notion.DatePropertyFilter{OnOrAfter: notion.DateTimePtr(notion.NewDateTime(date, false))},
+			exp:    `{"on_or_after":"2022-09-01"}`,
+		},
+		{
+			name:   "date and time",
+			filter: notion.DatePropertyFilter{Before: notion.DateTimePtr(notion.NewDateTime(date, true))},
+			exp:    `{"before":"2022-09-01T12:30:00Z"}`,
+		},
+		{
+			name:   "this week",
+			filter: notion.DatePropertyFilter{ThisWeek: &struct{}{}},
+			exp:    `{"this_week":{}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.exp, string(got)); diff != "" {
+				t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRollupResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		json     string
+		exp      notion.RollupResult
+		expValue interface{}
+	}{
+		{
+			name: "number",
+			json: `{"type": "number", "number": 3, "function": "count"}`,
+			exp: notion.RollupResult{
+				Type:     notion.RollupResultTypeNumber,
+				Function: notion.RollupFunctionCount,
+				Number:   notion.Float64Ptr(3),
+			},
+			expValue: notion.Float64Ptr(3),
+		},
+		{
+			name: "incomplete",
+			json: `{"type": "incomplete", "incomplete": {}, "function": "show_unique"}`,
+			exp: notion.RollupResult{
+				Type:       notion.RollupResultTypeIncomplete,
+				Function:   notion.RollupFunctionShowUnique,
+				Incomplete: json.RawMessage(`{}`),
+			},
+		},
+		{
+			name: "unsupported",
+			json: `{"type": "unsupported", "unsupported": {}, "function": "percent_per_group"}`,
+			exp: notion.RollupResult{
+				Type:        notion.RollupResultTypeUnsupported,
+				Function:    notion.RollupFunctionPercentPerGroup,
+				Unsupported: json.RawMessage(`{}`),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got notion.RollupResult
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.exp, got); diff != "" {
+				t.Fatalf("rollup result not equal (-exp, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tt.expValue, got.Value()); diff != "" {
+				t.Fatalf("value not equal (-exp, +got):\n%v", diff)
+			}
+
+			// Encoding the result again doesn't drop data.
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var exp, roundTrip interface{}
+			if err := json.Unmarshal([]byte(tt.json), &exp); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(b, &roundTrip); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(exp, roundTrip); diff != "" {
+				t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestSelectOptionsWrite(t *testing.T) {
+	t.Parallel()
+
+	t.Run("page property values", func(t *testing.T) {
+		t.Parallel()
+
+		params := notion.UpdatePageParams{
+			DatabasePageProperties: notion.DatabasePageProperties{
+				// E.g. an option of a fetched page, that was renamed since.
+				"City": {Select: &notion.SelectOptions{ID: "1", Name: "Old name", Color: notion.ColorBlue}},
+				"Tags": {MultiSelect: []notion.SelectOptions{
+					{ID: "2", Name: "Work"},
+					notion.NewSelectOption("Urgent", notion.ColorRed),
+				}},
+			},
+		}
+
+		b, err := json.Marshal(params)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		exp := `{"properties":{` +
+			`"City":{"select":{"id":"1"}},` +
+			`"Tags":{"multi_select":[{"id":"2"},{"name":"Urgent","color":"red"}]}` +
+			`}}`
+		if diff := cmp.Diff(exp, string(b)); diff != "" {
+			t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+		}
+	})
+
+	t.Run("database schema", func(t *testing.T) {
+		t.Parallel()
+
+		var db notion.Database
+		err := json.Unmarshal([]byte(`{
+			"object": "database",
+			"id": "668d797c-76fa-4934-9b05-ad288df2d136",
+			"properties": {
+				"City": {
+					"id": "fk%5EY",
+					"name": "City",
+					"type": "select",
+					"select": {"options": [{"id": "1", "name": "Paris", "color": "blue"}]}
+				}
+			}
+		}`), &db)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		city := db.Properties["City"]
+		city.Select.Options = append(city.Select.Options, notion.NewSelectOption("Amsterdam", notion.ColorOrange))
+
+		b, err := json.Marshal(notion.UpdateDatabaseParams{
+			Properties: map[string]*notion.DatabaseProperty{"City": &city},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var body struct {
+			Properties map[string]notion.DatabaseProperty `json:"properties"`
+		}
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Fatal(err)
+		}
+
+		exp := []notion.SelectOptions{
+			{ID: "1", Name: "Paris", Color: notion.ColorBlue},
+			{Name: "Amsterdam", Color: notion.ColorOrange},
+		}
+		if diff := cmp.Diff(exp, body.Properties["City"].Select.Options); diff != "" {
+			t.Fatalf("options not equal (-exp, +got):\n%v", diff)
+		}
+	})
+}
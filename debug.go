@@ -0,0 +1,175 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"regexp"
+	"sync"
+	"sync/atomic"
+)
+
+// authHeaderRegexp matches the value of an `Authorization` header in an HTTP
+// dump.
+var authHeaderRegexp = regexp.MustCompile(`(?mi)^(Authorization:\s*).*$`)
+
+// WithDebugWriter writes a dump of every HTTP request and response to `w`, for
+// troubleshooting. JSON bodies are pretty-printed and the API key is redacted
+// from the output, so it's safe to write to logs. Use this instead of wrapping
+// the http.Client transport.
+//
+// Unless disabled via WithDumpRequests, dumping is enabled when this option is
+// used.
+func WithDebugWriter(w io.Writer) ClientOption {
+	return func(c *Client) {
+		c.debugWriter = w
+	}
+}
+
+// WithDumpRequests enables or disables dumping HTTP requests and responses.
+// When no writer is set via WithDebugWriter, dumps are written to os.Stderr.
+// Dumping can be toggled at runtime with `Client.SetDumpRequests`.
+func WithDumpRequests(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.dumpRequests = &enabled
+	}
+}
+
+// SetDumpRequests enables or disables dumping HTTP requests and responses at
+// runtime. It's safe for concurrent use.
+func (c *Client) SetDumpRequests(enabled bool) {
+	c.dumping.Store(enabled)
+}
+
+// newDebugTransport returns a debugTransport based on the client's options.
+func (c *Client) newDebugTransport(next http.RoundTripper) *debugTransport {
+	w := c.debugWriter
+	if w == nil {
+		w = os.Stderr
+	}
+
+	if c.dumpRequests != nil {
+		c.dumping.Store(*c.dumpRequests)
+	} else {
+		c.dumping.Store(c.debugWriter != nil)
+	}
+
+	return &debugTransport{
+		w:       w,
+		enabled: &c.dumping,
+		secret:  c.apiKey,
+		next:    next,
+	}
+}
+
+// debugTransport is an http.RoundTripper that dumps requests and responses to
+// an io.Writer, with the API key redacted.
+type debugTransport struct {
+	w       io.Writer
+	enabled *atomic.Bool
+	secret  string
+	next    http.RoundTripper
+
+	mu sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.enabled.Load() {
+		return t.next.RoundTrip(req)
+	}
+
+	reqDump, out, err := dumpRequest(req)
+	if req.Body != nil {
+		// The body of `req` is read by dumpRequest, and `out` has a new body.
+		req.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.write("-->", reqDump)
+
+	res, err := t.next.RoundTrip(out)
+	if err != nil {
+		t.write("<--", []byte(fmt.Sprintf("error: %v", err)))
+		return nil, err
+	}
+
+	resDump, err := dumpResponse(res)
+	if err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+	t.write("<--", resDump)
+
+	return res, nil
+}
+
+func (t *debugTransport) write(prefix string, dump []byte) {
+	s := authHeaderRegexp.ReplaceAllString(string(dump), "${1}"+redacted)
+	s = redact(s, t.secret)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fmt.Fprintf(t.w, "%v %s\n\n", prefix, s)
+}
+
+// dumpRequest returns the request headers, followed by the (pretty-printed)
+// request body, and a clone of `req` to send instead. The body of `req` is
+// read, but not closed; the clone has a new body with the same content.
+func dumpRequest(req *http.Request) ([]byte, *http.Request, error) {
+	out := req.Clone(req.Context())
+
+	dump, err := httputil.DumpRequestOut(out, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return bytes.TrimSpace(dump), out, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return append(dump, prettyJSON(body)...), out, nil
+}
+
+// dumpResponse returns the response headers, followed by the (pretty-printed)
+// response body. The response body is restored so it can be read afterwards.
+func dumpResponse(res *http.Response) ([]byte, error) {
+	dump, err := httputil.DumpResponse(res, false)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return append(dump, prettyJSON(body)...), nil
+}
+
+// prettyJSON returns indented JSON, or the original bytes if `b` isn't valid
+// JSON.
+func prettyJSON(b []byte) []byte {
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, b, "", "    "); err != nil {
+		return b
+	}
+	return buf.Bytes()
+}
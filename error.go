@@ -1,10 +1,16 @@
 package notion
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strconv"
+	"syscall"
+	"time"
 )
 
 // See: https://developers.notion.com/reference/errors.
@@ -20,6 +26,10 @@ var (
 	ErrRateLimited        = errors.New("notion: this request exceeds the number of requests allowed")
 	ErrInternalServer     = errors.New("notion: an unexpected error occurred")
 	ErrServiceUnavailable = errors.New("notion: service is unavailable")
+
+	// ErrTransport is matched by errors of HTTP requests that failed without a
+	// response, e.g. because of a network error or timeout.
+	ErrTransport = errors.New("notion: HTTP request failed")
 )
 
 var errMap = map[string]error{
@@ -36,20 +46,50 @@ var errMap = map[string]error{
 	"service_unavailable":   ErrServiceUnavailable,
 }
 
+// statusErrMap maps HTTP status codes to errors, for error responses without
+// (valid) JSON body, e.g. HTML error pages of proxies.
+var statusErrMap = map[int]error{
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrRestrictedResource,
+	http.StatusNotFound:            ErrObjectNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusTooManyRequests:     ErrRateLimited,
+	http.StatusInternalServerError: ErrInternalServer,
+	http.StatusBadGateway:          ErrServiceUnavailable,
+	http.StatusServiceUnavailable:  ErrServiceUnavailable,
+	http.StatusGatewayTimeout:      ErrServiceUnavailable,
+}
+
+// APIError is returned when the Notion API responds with an error. Use
+// errors.Is with one of the `Err*` variables to check for a specific error
+// code, or errors.As to access its fields.
 type APIError struct {
-	Object  string `json:"object"`
-	Status  int    `json:"status"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Object    string `json:"object"`
+	Status    int    `json:"status"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+
+	// Header contains the HTTP response headers, e.g. `Retry-After`.
+	Header http.Header `json:"-"`
+	// Body contains the raw HTTP response body, which can be useful when
+	// reporting issues to Notion support.
+	Body []byte `json:"-"`
 }
 
 // Error implements `error`.
 func (err *APIError) Error() string {
+	if err.Code == "" {
+		return fmt.Sprintf("%v (status: %v)", err.Message, err.Status)
+	}
 	return fmt.Sprintf("%v (code: %v, status: %v)", err.Message, err.Code, err.Status)
 }
 
 func (err *APIError) Unwrap() error {
 	mapped, ok := errMap[err.Code]
+	if !ok && err.Code == "" {
+		mapped, ok = statusErrMap[err.Status]
+	}
 	if !ok {
 		return fmt.Errorf("notion: %v", err.Error())
 	}
@@ -57,12 +97,161 @@ func (err *APIError) Unwrap() error {
 	return mapped
 }
 
+// TransportError is returned when an HTTP request fails without a response,
+// e.g. because of a network error or timeout. It matches ErrTransport with
+// errors.Is, and wraps the error of the HTTP client.
+type TransportError struct {
+	Err error
+
+	// ctxErr is the error of the context passed to the client method, if it
+	// was done when the request failed.
+	ctxErr error
+}
+
+// Error implements `error`.
+func (err *TransportError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *TransportError) Unwrap() error {
+	return err.Err
+}
+
+// Is returns true for ErrTransport.
+func (err *TransportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// transportError returns an error for a failed HTTP request, made with `ctx`.
+func transportError(ctx context.Context, err error) error {
+	return fmt.Errorf("notion: failed to make HTTP request: %w", &TransportError{Err: err, ctxErr: ctx.Err()})
+}
+
+// RetryAfter returns the duration to wait before retrying a request, based on
+// the `Retry-After` response header. It's typically set for `rate_limited`
+// errors. If the header is missing or invalid, `ok` is false.
+func (err *APIError) RetryAfter() (d time.Duration, ok bool) {
+	value := err.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// ValidationError is returned when params fail validation before a request is
+// made, e.g. when a required field is empty. Errors returned by the API itself,
+// including `validation_error` errors, are *APIError values instead, so use
+// errors.As to tell them apart.
+type ValidationError struct {
+	// Field is the name of the invalid params field (e.g. `ParentID`). It's
+	// empty when the error isn't about a single field.
+	Field string
+	Err   error
+}
+
+// Error implements `error`.
+func (err *ValidationError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
+
+// fieldError returns a *ValidationError for an invalid params field.
+func fieldError(field string, err error) error {
+	return &ValidationError{Field: field, Err: err}
+}
+
+// invalidParams returns an error for params (e.g. `page params`) that failed
+// validation. The error is a *ValidationError, without field if `err` isn't one
+// already.
+func invalidParams(what string, err error) error {
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		err = &ValidationError{Err: err}
+	}
+
+	return fmt.Errorf("notion: invalid %v: %w", what, err)
+}
+
+// IsRetryable returns true if a request that failed with `err` can be retried,
+// e.g. by a custom retry or queueing layer. This is the case for rate limited
+// requests, server errors, conflicts and transport errors such as timeouts of
+// WithTimeout. Other API errors (e.g. validation or authorization errors) are
+// permanent, as are errors caused by the context passed to client methods
+// being canceled or exceeding its deadline, as a retry with the same context
+// would fail too.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var timeoutErr *timeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) && transportErr.ctxErr != nil {
+		return false
+	}
+
+	// The deadline of WithTimeout is reported as a *timeoutError, so this is
+	// the deadline of the caller's context.
+	if transportErr == nil && errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case "rate_limited", "internal_server_error", "service_unavailable", "conflict_error":
+			return true
+		}
+		return apiErr.Status == http.StatusTooManyRequests || apiErr.Status >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
 func parseErrorResponse(res *http.Response) error {
 	var apiErr APIError
 
-	err := json.NewDecoder(res.Body).Decode(&apiErr)
-	if err != nil {
-		return &APIError{Status: res.StatusCode}
+	body, err := io.ReadAll(res.Body)
+	if err != nil || json.Unmarshal(body, &apiErr) != nil {
+		// E.g. an HTML error page of a proxy or load balancer.
+		apiErr = APIError{
+			Status:  res.StatusCode,
+			Message: fmt.Sprintf("unexpected error response (content type: %q)", res.Header.Get("Content-Type")),
+		}
+	}
+
+	if apiErr.Status == 0 {
+		apiErr.Status = res.StatusCode
+	}
+	apiErr.Header = res.Header
+	apiErr.Body = body
+
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = requestID(res.Header)
 	}
 
 	return &apiErr
@@ -0,0 +1,403 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	respBody := `{
+		"object": "error",
+		"status": 429,
+		"code": "rate_limited",
+		"message": "You have been rate limited. Please try again in a few minutes."
+	}`
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Status:     http.StatusText(http.StatusTooManyRequests),
+				Header: http.Header{
+					"Retry-After":  []string{"3"},
+					"X-Request-Id": []string{"e3a6a5a4-64b4-4a4f-a2a1-8b7a4e5bbd0e"},
+				},
+				Body: ioutil.NopCloser(strings.NewReader(respBody)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	_, err := client.FindPageByID(context.Background(), "00000000-0000-0000-0000-000000000000")
+
+	if !errors.Is(err, notion.ErrRateLimited) {
+		t.Fatalf("expected error to match notion.ErrRateLimited, got: %v", err)
+	}
+	if errors.Is(err, notion.ErrValidation) {
+		t.Fatalf("expected error not to match notion.ErrValidation, got: %v", err)
+	}
+
+	var apiErr *notion.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected error to be *notion.APIError, got: %T", err)
+	}
+
+	if exp := "e3a6a5a4-64b4-4a4f-a2a1-8b7a4e5bbd0e"; apiErr.RequestID != exp {
+		t.Errorf("request ID not equal (expected: %q, got: %q)", exp, apiErr.RequestID)
+	}
+	if diff := cmp.Diff(respBody, string(apiErr.Body)); diff != "" {
+		t.Errorf("body not equal (-exp, +got):\n%v", diff)
+	}
+
+	retryAfter, ok := apiErr.RetryAfter()
+	if !ok {
+		t.Fatal("expected retry after to be set")
+	}
+	if exp := 3 * time.Second; retryAfter != exp {
+		t.Errorf("retry after not equal (expected: %v, got: %v)", exp, retryAfter)
+	}
+}
+
+func TestAPIErrorRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		header        http.Header
+		expRetryAfter time.Duration
+		expOK         bool
+	}{
+		{
+			name:          "seconds",
+			header:        http.Header{"Retry-After": []string{"60"}},
+			expRetryAfter: time.Minute,
+			expOK:         true,
+		},
+		{
+			name:          "HTTP date in the past",
+			header:        http.Header{"Retry-After": []string{"Wed, 21 Oct 2015 07:28:00 GMT"}},
+			expRetryAfter: 0,
+			expOK:         true,
+		},
+		{
+			name:  "missing header",
+			expOK: false,
+		},
+		{
+			name:   "invalid header",
+			header: http.Header{"Retry-After": []string{"foobar"}},
+			expOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiErr := &notion.APIError{Header: tt.header}
+			retryAfter, ok := apiErr.RetryAfter()
+
+			if ok != tt.expOK {
+				t.Fatalf("ok not equal (expected: %v, got: %v)", tt.expOK, ok)
+			}
+			if retryAfter != tt.expRetryAfter {
+				t.Fatalf("retry after not equal (expected: %v, got: %v)", tt.expRetryAfter, retryAfter)
+			}
+		})
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		exp  bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			exp:  false,
+		},
+		{
+			name: "rate limited",
+			err:  fmt.Errorf("notion: failed to find page: %w", &notion.APIError{Status: 429, Code: "rate_limited"}),
+			exp:  true,
+		},
+		{
+			name: "conflict",
+			err:  &notion.APIError{Status: 409, Code: "conflict_error"},
+			exp:  true,
+		},
+		{
+			name: "server error without code",
+			err:  &notion.APIError{Status: 502},
+			exp:  true,
+		},
+		{
+			name: "validation error",
+			err:  &notion.APIError{Status: 400, Code: "validation_error"},
+			exp:  false,
+		},
+		{
+			name: "unauthorized",
+			err:  &notion.APIError{Status: 401, Code: "unauthorized"},
+			exp:  false,
+		},
+		{
+			name: "transport timeout",
+			err:  &url.Error{Op: "Get", URL: "https://api.notion.com/v1/pages", Err: timeoutError{}},
+			exp:  true,
+		},
+		{
+			name: "unexpected EOF",
+			err:  fmt.Errorf("notion: failed to make HTTP request: %w", io.ErrUnexpectedEOF),
+			exp:  true,
+		},
+		{
+			name: "context canceled",
+			err:  fmt.Errorf("notion: failed to make HTTP request: %w", context.Canceled),
+			exp:  false,
+		},
+		{
+			name: "context deadline exceeded",
+			err:  fmt.Errorf("notion: failed to make HTTP request: %w", context.DeadlineExceeded),
+			exp:  false,
+		},
+		{
+			name: "other error",
+			err:  errors.New("notion: invalid request"),
+			exp:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := notion.IsRetryable(tt.err); got != tt.exp {
+				t.Fatalf("expected %v, got %v", tt.exp, got)
+			}
+		})
+	}
+}
+
+func TestIsRetryableExpiredContext(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithTimeout(time.Minute))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := client.FindCurrentUser(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error not equal (expected: %v, got: %v)", context.DeadlineExceeded, err)
+	}
+	if notion.IsRetryable(err) {
+		t.Fatalf("expected error of expired context not to be retryable: %v", err)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	tests := []struct {
+		name     string
+		fn       func() error
+		expField string
+		expError string
+	}{
+		{
+			name: "create page without parent ID",
+			fn: func() error {
+				_, err := client.CreatePage(context.Background(), notion.CreatePageParams{
+					ParentType: notion.ParentTypePage,
+					Title:      []notion.RichText{notion.NewRichText("Foobar")},
+				})
+				return err
+			},
+			expField: "ParentID",
+			expError: "notion: invalid page params: parent ID is required",
+		},
+		{
+			name: "create database with invalid icon",
+			fn: func() error {
+				_, err := client.CreateDatabase(context.Background(), notion.CreateDatabaseParams{
+					ParentPageID: "b0668f48-8d66-4733-9bdb-2f82215707f7",
+					Properties:   notion.DatabaseProperties{},
+					Icon:         &notion.Icon{},
+				})
+				return err
+			},
+			expField: "Icon",
+			expError: "notion: invalid database params: icon type cannot be empty",
+		},
+		{
+			name: "update page without params",
+			fn: func() error {
+				_, err := client.UpdatePage(context.Background(), "b0668f48-8d66-4733-9bdb-2f82215707f7", notion.UpdatePageParams{})
+				return err
+			},
+			expError: "notion: invalid page params: at least one of database page properties, archived, in trash, icon or cover is required",
+		},
+		{
+			name: "find comments without block ID",
+			fn: func() error {
+				_, err := client.FindCommentsByBlockID(context.Background(), notion.FindCommentsByBlockIDQuery{})
+				return err
+			},
+			expField: "BlockID",
+			expError: "notion: invalid comments query: block ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.fn()
+			if err == nil || err.Error() != tt.expError {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			var valErr *notion.ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("expected *notion.ValidationError, got: %T", err)
+			}
+			if valErr.Field != tt.expField {
+				t.Fatalf("field not equal (expected: %q, got: %q)", tt.expField, valErr.Field)
+			}
+
+			var apiErr *notion.APIError
+			if errors.As(err, &apiErr) {
+				t.Fatal("expected error not to be an *notion.APIError")
+			}
+			if errors.Is(err, notion.ErrValidation) {
+				t.Fatal("expected error not to match notion.ErrValidation")
+			}
+		})
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			return nil, io.ErrUnexpectedEOF
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	_, err := client.FindPageByID(context.Background(), "00000000-0000-0000-0000-000000000000")
+
+	if !errors.Is(err, notion.ErrTransport) {
+		t.Fatalf("expected error to match notion.ErrTransport, got: %v", err)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected error to match io.ErrUnexpectedEOF, got: %v", err)
+	}
+
+	var transportErr *notion.TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("expected error to be *notion.TransportError, got: %T", err)
+	}
+
+	exp := `notion: failed to make HTTP request: Get "https://api.notion.com/v1/pages/00000000-0000-0000-0000-000000000000": unexpected EOF`
+	if err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+}
+
+func TestAPIErrorNonJSONBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		expTarget  error
+		expError   string
+	}{
+		{
+			name:       "rate limited",
+			statusCode: http.StatusTooManyRequests,
+			expTarget:  notion.ErrRateLimited,
+			expError:   `notion: failed to find page: unexpected error response (content type: "text/html") (status: 429)`,
+		},
+		{
+			name:       "bad gateway",
+			statusCode: http.StatusBadGateway,
+			expTarget:  notion.ErrServiceUnavailable,
+			expError:   `notion: failed to find page: unexpected error response (content type: "text/html") (status: 502)`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.statusCode,
+						Status:     http.StatusText(tt.statusCode),
+						Header:     http.Header{"Content-Type": []string{"text/html"}},
+						Body:       io.NopCloser(strings.NewReader("<html><body>Error</body></html>")),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			_, err := client.FindPageByID(context.Background(), "00000000-0000-0000-0000-000000000000")
+
+			if !errors.Is(err, tt.expTarget) {
+				t.Fatalf("expected error to match %v, got: %v", tt.expTarget, err)
+			}
+			if errors.Is(err, notion.ErrTransport) {
+				t.Fatal("expected error not to match notion.ErrTransport")
+			}
+			if err.Error() != tt.expError {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExpandPage returns a copy of a page, with all truncated properties (see
+// `DatabasePageProperty.IsTruncated`) populated with their complete values,
+// using the page property endpoint. Pages with a parent other than a database
+// are returned as-is.
+func (c *Client) ExpandPage(ctx context.Context, page Page) (Page, error) {
+	props, ok := page.DatabasePageProperties()
+	if !ok {
+		return page, nil
+	}
+
+	expanded := make(DatabasePageProperties, len(props))
+
+	for name, prop := range props {
+		if prop.IsTruncated() {
+			items, err := c.findAllPagePropItems(ctx, page.ID, prop.ID)
+			if err != nil {
+				return Page{}, fmt.Errorf("notion: failed to expand page property %q: %w", name, err)
+			}
+			prop = expandPageProp(prop, items)
+		}
+		expanded[name] = prop
+	}
+
+	page.Properties = expanded
+
+	return page, nil
+}
+
+func (c *Client) findAllPagePropItems(ctx context.Context, pageID, propID string) ([]PagePropItem, error) {
+	var items []PagePropItem
+
+	query := &PaginationQuery{PageSize: MaxPageSize}
+
+	for {
+		resp, err := c.FindPagePropertyByID(ctx, pageID, propID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, resp.Results...)
+
+		if !resp.HasMore || resp.NextCursor == "" {
+			return items, nil
+		}
+		query.StartCursor = resp.NextCursor
+	}
+}
+
+func expandPageProp(prop DatabasePageProperty, items []PagePropItem) DatabasePageProperty {
+	switch prop.Type {
+	case DBPropTypeTitle:
+		prop.Title = make([]RichText, len(items))
+		for i, item := range items {
+			prop.Title[i] = item.Title
+		}
+	case DBPropTypeRichText:
+		prop.RichText = make([]RichText, len(items))
+		for i, item := range items {
+			prop.RichText[i] = item.RichText
+		}
+	case DBPropTypeRelation:
+		prop.Relation = make([]Relation, len(items))
+		for i, item := range items {
+			prop.Relation[i] = item.Relation
+		}
+		prop.HasMore = false
+	case DBPropTypePeople:
+		prop.People = make([]User, len(items))
+		for i, item := range items {
+			prop.People[i] = item.People
+		}
+	}
+
+	return prop
+}
@@ -0,0 +1,98 @@
+package notion_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandPage(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if exp := "/v1/pages/cb261dc5-6c85-4767-8585-3852382fb466/properties/%7DnDk"; r.URL.EscapedPath() != exp {
+				t.Errorf("path not equal (expected: %v, got: %v)", exp, r.URL.EscapedPath())
+			}
+
+			body := `{
+				"object": "list",
+				"results": [
+					{"object": "property_item", "type": "relation", "relation": {"id": "2be9597f-693f-4b87-baf9-efc545d38ebe"}}
+				],
+				"has_more": true,
+				"next_cursor": "7c6b1c95-de50-45ca-94e6-af1d9fd295ab"
+			}`
+			if r.URL.Query().Get("start_cursor") == "7c6b1c95-de50-45ca-94e6-af1d9fd295ab" {
+				body = `{
+					"object": "list",
+					"results": [
+						{"object": "property_item", "type": "relation", "relation": {"id": "a9f5d2e5-4a0b-4a4c-9b14-7d5bfb8f8e5c"}}
+					],
+					"has_more": false,
+					"next_cursor": null
+				}`
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	page := notion.Page{
+		ID: "cb261dc5-6c85-4767-8585-3852382fb466",
+		Parent: notion.Parent{
+			Type:       notion.ParentTypeDatabase,
+			DatabaseID: "39ddfc9d-33c9-404c-89cf-79f01c42dd0c",
+		},
+		Properties: notion.DatabasePageProperties{
+			"Related": notion.DatabasePageProperty{
+				ID:      "}nDk",
+				Type:    notion.DBPropTypeRelation,
+				HasMore: true,
+				Relation: []notion.Relation{
+					{ID: "2be9597f-693f-4b87-baf9-efc545d38ebe"},
+				},
+			},
+			"Checked": notion.DatabasePageProperty{
+				ID:       "Y%3DdA",
+				Type:     notion.DBPropTypeCheckbox,
+				Checkbox: notion.BoolPtr(true),
+			},
+		},
+	}
+
+	if !page.Properties.(notion.DatabasePageProperties)["Related"].IsTruncated() {
+		t.Fatal("expected relation property to be truncated")
+	}
+
+	expanded, err := client.ExpandPage(context.Background(), page)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := notion.DatabasePageProperties{
+		"Related": notion.DatabasePageProperty{
+			ID:   "}nDk",
+			Type: notion.DBPropTypeRelation,
+			Relation: []notion.Relation{
+				{ID: "2be9597f-693f-4b87-baf9-efc545d38ebe"},
+				{ID: "a9f5d2e5-4a0b-4a4c-9b14-7d5bfb8f8e5c"},
+			},
+		},
+		"Checked": page.Properties.(notion.DatabasePageProperties)["Checked"],
+	}
+
+	if diff := cmp.Diff(exp, expanded.Properties); diff != "" {
+		t.Fatalf("properties not equal (-exp, +got):\n%v", diff)
+	}
+}
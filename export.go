@@ -0,0 +1,311 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ExportFormat is the file format used by ExportPage.
+type ExportFormat string
+
+const (
+	ExportFormatMarkdown ExportFormat = "markdown"
+	ExportFormatHTML     ExportFormat = "html"
+)
+
+// ExportOpts are the options used for exporting pages.
+type ExportOpts struct {
+	// Format is the format of page files. Defaults to Markdown.
+	Format ExportFormat
+
+	// SkipAssets disables downloading files hosted by Notion (e.g. images), so
+	// page files link to their (expiring) URLs instead.
+	SkipAssets bool
+
+	// HTTPClient is used for downloading assets, which are hosted outside of
+	// the Notion API. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Concurrency is the maximum number of concurrent requests for finding
+	// blocks, see FindBlockChildrenRecursiveOpts.
+	Concurrency int
+}
+
+// ExportResult is the result of ExportPage.
+type ExportResult struct {
+	// Files are the paths of the written files (pages and assets), relative to
+	// the export directory.
+	Files []string
+}
+
+// ExportPage writes a page, and its subpages recursively, to files in `dir`
+// (e.g. to back up a workspace), preserving the hierarchy as folders, like
+// Notion's own export: a page is written to `<title>.md` (or `.html`), and its
+// subpages, databases and assets are written to the `<title>` folder. Pages of
+// child databases are written to a folder named after the database. File names
+// are derived from titles; the page ID is appended when names collide.
+//
+// Files hosted by Notion are downloaded, and linked with relative URLs. See
+// ToMarkdown and ToHTML for how blocks are rendered. If exporting fails, the
+// files written so far are returned along with the error.
+func (c *Client) ExportPage(ctx context.Context, pageID, dir string, opts *ExportOpts) (ExportResult, error) {
+	if opts == nil {
+		opts = &ExportOpts{}
+	}
+
+	e := &exporter{
+		client: c,
+		opts:   *opts,
+		root:   dir,
+		used:   make(map[string]bool),
+	}
+	if e.opts.Format == "" {
+		e.opts.Format = ExportFormatMarkdown
+	}
+	if e.opts.HTTPClient == nil {
+		e.opts.HTTPClient = http.DefaultClient
+	}
+
+	switch e.opts.Format {
+	case ExportFormatMarkdown, ExportFormatHTML:
+	default:
+		return ExportResult{}, fmt.Errorf("notion: unsupported export format %q", e.opts.Format)
+	}
+
+	page, err := c.FindPageByID(ctx, pageID)
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	err = e.exportPage(ctx, page, dir)
+
+	return ExportResult{Files: e.files}, err
+}
+
+type exporter struct {
+	client *Client
+	opts   ExportOpts
+	root   string
+
+	// used are the paths of files and folders that are written.
+	used  map[string]bool
+	files []string
+}
+
+func (e *exporter) exportPage(ctx context.Context, page Page, dir string) error {
+	name := e.name(dir, PlainText(pageTitle(page)), page.ID)
+	pageDir := filepath.Join(dir, name)
+
+	blocks, err := e.client.FindBlockChildrenRecursive(ctx, page.ID, &FindBlockChildrenRecursiveOpts{
+		Concurrency: e.opts.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	content, err := e.downloadAssets(ctx, blocks, pageDir, name)
+	if err != nil {
+		return err
+	}
+
+	var out, ext string
+	switch e.opts.Format {
+	case ExportFormatHTML:
+		out, ext = PageToHTML(page, content)+"\n", ".html"
+	default:
+		out, ext = PageToMarkdown(page, content), ".md"
+	}
+
+	if err := e.write(filepath.Join(dir, name+ext), strings.NewReader(out)); err != nil {
+		return err
+	}
+
+	return e.exportSubpages(ctx, blocks, pageDir)
+}
+
+// exportSubpages exports the child pages and databases in a block tree.
+func (e *exporter) exportSubpages(ctx context.Context, blocks []Block, dir string) error {
+	for _, block := range blocks {
+		switch b := blockPtr(block).(type) {
+		case *ChildPageBlock:
+			page, err := e.client.FindPageByID(ctx, b.ID())
+			if err != nil {
+				return err
+			}
+			if err := e.exportPage(ctx, page, dir); err != nil {
+				return err
+			}
+		case *ChildDatabaseBlock:
+			pages, err := e.client.queryAllPages(ctx, b.ID(), nil)
+			if err != nil {
+				return err
+			}
+			dbDir := filepath.Join(dir, e.name(dir, b.Title, b.ID()))
+			for _, page := range pages {
+				if err := e.exportPage(ctx, page, dbDir); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := e.exportSubpages(ctx, blockChildren(block), dir); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// downloadAssets downloads the files hosted by Notion in a block tree to `dir`,
+// and returns copies of the blocks that link to the downloaded files, relative
+// to the folder of `dir`.
+func (e *exporter) downloadAssets(ctx context.Context, blocks []Block, dir, relDir string) ([]Block, error) {
+	if e.opts.SkipAssets {
+		return blocks, nil
+	}
+
+	result := make([]Block, len(blocks))
+
+	for i, block := range blocks {
+		block = cloneBlock(block)
+
+		if file := blockFile(block); file != nil && *file != nil {
+			name := block.ID() + path.Ext(urlPath((*file).URL))
+			if err := e.download(ctx, (*file).URL, filepath.Join(dir, name)); err != nil {
+				return nil, err
+			}
+			*file = &FileFile{URL: (&url.URL{Path: relDir + "/" + name}).String()}
+		}
+
+		if children := blockChildren(block); children != nil {
+			children, err := e.downloadAssets(ctx, children, dir, relDir)
+			if err != nil {
+				return nil, err
+			}
+			setBlockChildren(block, children)
+		}
+
+		result[i] = block
+	}
+
+	return result, nil
+}
+
+func (e *exporter) download(ctx context.Context, rawURL, filename string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := e.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion: failed to download file: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("notion: failed to download file: unexpected status %v", res.Status)
+	}
+
+	return e.write(filename, res.Body)
+}
+
+func (e *exporter) write(filename string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("notion: failed to create directory: %w", err)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("notion: failed to create file: %w", err)
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("notion: failed to write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("notion: failed to write file: %w", err)
+	}
+
+	rel, err := filepath.Rel(e.root, filename)
+	if err != nil {
+		rel = filename
+	}
+	e.files = append(e.files, filepath.ToSlash(rel))
+
+	return nil
+}
+
+// name returns a file name (without extension) for `title` that isn't used yet
+// in `dir`. The ID is appended when needed.
+func (e *exporter) name(dir, title, id string) string {
+	name := sanitizeFileName(title)
+	if e.used[filepath.Join(dir, name)] {
+		name += " " + strings.ReplaceAll(id, "-", "")
+	}
+	e.used[filepath.Join(dir, name)] = true
+	return name
+}
+
+// fileNameReplacer replaces characters that aren't allowed in file names on
+// common file systems.
+var fileNameReplacer = strings.NewReplacer(
+	"/", "_", `\`, "_", ":", "_", "*", "_", "?", "_", `"`, "_", "<", "_", ">", "_", "|", "_",
+)
+
+const maxFileNameLength = 100
+
+func sanitizeFileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, fileNameReplacer.Replace(title))
+
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "Untitled"
+	}
+
+	return name
+}
+
+// blockFile returns a pointer to the field with the file hosted by Notion of a
+// (pointer to a) file block, or nil for other block types.
+func blockFile(block Block) **FileFile {
+	switch b := block.(type) {
+	case *ImageBlock:
+		return &b.File
+	case *VideoBlock:
+		return &b.File
+	case *AudioBlock:
+		return &b.File
+	case *FileBlock:
+		return &b.File
+	case *PDFBlock:
+		return &b.File
+	default:
+		return nil
+	}
+}
+
+func urlPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
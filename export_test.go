@@ -0,0 +1,153 @@
+package notion_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func exportTransport(t *testing.T) http.RoundTripper {
+	page := func(id, parent, title string) string {
+		return `{"object": "page", "id": "` + id + `", "parent": ` + parent + `, "properties": {"title": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "` + title + `"}, "plain_text": "` + title + `"}]}}}`
+	}
+	list := func(results ...string) string {
+		return `{"object": "list", "results": [` + strings.Join(results, ",") + `], "has_more": false, "next_cursor": null}`
+	}
+
+	responses := map[string]string{
+		"GET /v1/pages/root": page("root", `{"type": "workspace", "workspace": true}`, "Home: Root"),
+		"GET /v1/blocks/root/children": list(
+			`{"object": "block", "id": "p1", "type": "paragraph", "paragraph": {"rich_text": [{"type": "text", "text": {"content": "Hello"}, "plain_text": "Hello"}]}}`,
+			`{"object": "block", "id": "img", "type": "image", "image": {"type": "file", "file": {"url": "https://files.example.com/abc/photo.png?signature=foo", "expiry_time": "2021-05-19T18:34:00.000Z"}}}`,
+			`{"object": "block", "id": "sub", "type": "child_page", "has_children": true, "child_page": {"title": "Sub"}}`,
+			`{"object": "block", "id": "db", "type": "child_database", "child_database": {"title": "Tasks"}}`,
+		),
+		"GET /v1/pages/sub": page("sub", `{"type": "page_id", "page_id": "root"}`, "Sub"),
+		"GET /v1/blocks/sub/children": list(
+			`{"object": "block", "id": "p2", "type": "paragraph", "paragraph": {"rich_text": [{"type": "text", "text": {"content": "Nested"}, "plain_text": "Nested"}]}}`,
+		),
+		"POST /v1/databases/db/query": `{"object": "list", "results": [` +
+			`{"object": "page", "id": "t-1", "parent": {"type": "database_id", "database_id": "db"}, "properties": {"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Task"}, "plain_text": "Task"}]}}},` +
+			`{"object": "page", "id": "t-2", "parent": {"type": "database_id", "database_id": "db"}, "properties": {"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Task"}, "plain_text": "Task"}]}}}` +
+			`], "has_more": false, "next_cursor": null}`,
+		"GET /v1/blocks/t-1/children": list(),
+		"GET /v1/blocks/t-2/children": list(),
+	}
+
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		key := r.Method + " " + r.URL.Path
+		body, ok := responses[key]
+		if !ok {
+			t.Fatalf("unexpected request: %v", key)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	}}
+}
+
+func TestExportPage(t *testing.T) {
+	t.Parallel()
+
+	assetClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if r.URL.String() != "https://files.example.com/abc/photo.png?signature=foo" {
+				t.Fatalf("unexpected asset request: %v", r.URL)
+			}
+			if r.Header.Get("Authorization") != "" {
+				t.Fatal("expected asset request without authorization header")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader("PNG")),
+			}, nil
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		opts     *notion.ExportOpts
+		expFiles []string
+		expRoot  string
+	}{
+		{
+			name: "markdown",
+			opts: &notion.ExportOpts{HTTPClient: assetClient},
+			expFiles: []string{
+				"Home_ Root/img.png",
+				"Home_ Root.md",
+				"Home_ Root/Sub.md",
+				"Home_ Root/Tasks/Task.md",
+				"Home_ Root/Tasks/Task t2.md",
+			},
+			expRoot: "# Home: Root\n\nHello\n\n![](Home_%20Root/img.png)\n\n**Sub**\n\n**Tasks**\n",
+		},
+		{
+			name: "html without assets",
+			opts: &notion.ExportOpts{Format: notion.ExportFormatHTML, SkipAssets: true},
+			expFiles: []string{
+				"Home_ Root.html",
+				"Home_ Root/Sub.html",
+				"Home_ Root/Tasks/Task.html",
+				"Home_ Root/Tasks/Task t2.html",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: exportTransport(t)}))
+
+			result, err := client.ExportPage(context.Background(), "root", dir, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expFiles, result.Files); diff != "" {
+				t.Fatalf("files not equal (-exp, +got):\n%v", diff)
+			}
+			for _, file := range result.Files {
+				if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if tt.expRoot != "" {
+				got, err := ioutil.ReadFile(filepath.Join(dir, tt.expFiles[1]))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(tt.expRoot, string(got)); diff != "" {
+					t.Fatalf("page file not equal (-exp, +got):\n%v", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestExportPageUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	client := notion.NewClient("secret-api-key")
+
+	_, err := client.ExportPage(context.Background(), "root", t.TempDir(), &notion.ExportOpts{Format: "pdf"})
+
+	exp := `notion: unsupported export format "pdf"`
+	if err == nil || err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+}
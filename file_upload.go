@@ -0,0 +1,259 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"time"
+)
+
+type FileUploadStatus string
+
+const (
+	FileUploadStatusPending  FileUploadStatus = "pending"
+	FileUploadStatusUploaded FileUploadStatus = "uploaded"
+	FileUploadStatusExpired  FileUploadStatus = "expired"
+	FileUploadStatusFailed   FileUploadStatus = "failed"
+)
+
+// FileUpload represents a file upload. Once uploaded, it can be attached (by ID)
+// to e.g. comments, see CommentAttachment.
+// See: https://developers.notion.com/reference/file-upload
+type FileUpload struct {
+	ID             string           `json:"id"`
+	CreatedTime    time.Time        `json:"created_time"`
+	LastEditedTime time.Time        `json:"last_edited_time"`
+	ExpiryTime     *time.Time       `json:"expiry_time"`
+	Status         FileUploadStatus `json:"status"`
+	Filename       string           `json:"filename"`
+	ContentType    string           `json:"content_type"`
+	ContentLength  int64            `json:"content_length"`
+	UploadURL      string           `json:"upload_url"`
+}
+
+// FileParam is a file to upload.
+type FileParam struct {
+	Filename string
+	// ContentType is the MIME type of the file. Optional, when empty it's
+	// derived from the extension of Filename.
+	ContentType string
+	Content     io.Reader
+}
+
+func (p FileParam) Validate() error {
+	if p.Filename == "" {
+		return fieldError("Filename", errors.New("filename is required"))
+	}
+	if p.Content == nil {
+		return fieldError("Content", errors.New("content is required"))
+	}
+
+	return nil
+}
+
+func (p FileParam) contentType() string {
+	if p.ContentType != "" {
+		return p.ContentType
+	}
+	return mime.TypeByExtension(filepath.Ext(p.Filename))
+}
+
+// fileUploadPollInterval is the interval for polling the status of a file
+// upload, while it's being processed.
+var fileUploadPollInterval = 500 * time.Millisecond
+
+// CreateFileUpload creates a (single part) file upload for `file`. The file
+// content isn't sent yet, see SendFileUpload.
+// See: https://developers.notion.com/reference/create-a-file-upload
+func (c *Client) CreateFileUpload(ctx context.Context, file FileParam) (upload FileUpload, err error) {
+	type PostBody struct {
+		Mode        string `json:"mode"`
+		Filename    string `json:"filename"`
+		ContentType string `json:"content_type,omitempty"`
+	}
+
+	body := &bytes.Buffer{}
+
+	err = json.NewEncoder(body).Encode(PostBody{"single_part", file.Filename, file.contentType()})
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: failed to encode body params to JSON: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/file_uploads", body)
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return FileUpload{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return FileUpload{}, fmt.Errorf("notion: failed to create file upload: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &upload)
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	return upload, nil
+}
+
+// SendFileUpload sends the content of `file` for the file upload with ID
+// `fileUploadID`, as multipart form data.
+// See: https://developers.notion.com/reference/send-a-file-upload
+func (c *Client) SendFileUpload(ctx context.Context, fileUploadID string, file FileParam) (upload FileUpload, err error) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     "file",
+		"filename": file.Filename,
+	}))
+	if contentType := file.contentType(); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: failed to create multipart form data: %w", err)
+	}
+	if _, err := io.Copy(part, file.Content); err != nil {
+		return FileUpload{}, fmt.Errorf("notion: failed to read file content: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return FileUpload{}, fmt.Errorf("notion: failed to create multipart form data: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/file_uploads/"+fileUploadID+"/send", body)
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return FileUpload{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return FileUpload{}, fmt.Errorf("notion: failed to send file upload: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &upload)
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	return upload, nil
+}
+
+// FindFileUploadByID fetches a file upload by ID.
+// See: https://developers.notion.com/reference/retrieve-a-file-upload
+func (c *Client) FindFileUploadByID(ctx context.Context, id string) (upload FileUpload, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/file_uploads/"+id, nil)
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return FileUpload{}, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return FileUpload{}, fmt.Errorf("notion: failed to find file upload: %w", parseErrorResponse(res))
+	}
+
+	err = c.decode(res.Body, &upload)
+	if err != nil {
+		return FileUpload{}, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	return upload, nil
+}
+
+// UploadFile uploads a file: it creates a file upload, sends the file content
+// and waits until the upload is processed. The returned file upload has status
+// `uploaded`, and can be attached by ID.
+func (c *Client) UploadFile(ctx context.Context, file FileParam) (FileUpload, error) {
+	if err := file.Validate(); err != nil {
+		return FileUpload{}, invalidParams("file params", err)
+	}
+
+	upload, err := c.CreateFileUpload(ctx, file)
+	if err != nil {
+		return FileUpload{}, err
+	}
+
+	upload, err = c.SendFileUpload(ctx, upload.ID, file)
+	if err != nil {
+		return FileUpload{}, err
+	}
+
+	for {
+		switch upload.Status {
+		case FileUploadStatusUploaded:
+			return upload, nil
+		case FileUploadStatusExpired, FileUploadStatusFailed:
+			return FileUpload{}, fmt.Errorf("notion: file upload %v has status %q", upload.ID, upload.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return FileUpload{}, ctx.Err()
+		case <-time.After(fileUploadPollInterval):
+		}
+
+		upload, err = c.FindFileUploadByID(ctx, upload.ID)
+		if err != nil {
+			return FileUpload{}, err
+		}
+	}
+}
+
+// CreateCommentWithFiles uploads `files` (see UploadFile), and creates a comment
+// with the uploaded files as attachments, in addition to the attachments of
+// `params` (if any). Params are validated before any file is uploaded.
+func (c *Client) CreateCommentWithFiles(ctx context.Context, params CreateCommentParams, files []FileParam) (Comment, error) {
+	if err := params.Validate(); err != nil {
+		return Comment{}, invalidParams("comment params", err)
+	}
+	if n := len(params.Attachments) + len(files); n > maxCommentAttachments {
+		return Comment{}, invalidParams("comment params", fieldError("Attachments", fmt.Errorf("at most %v attachments are allowed (got: %v)", maxCommentAttachments, n)))
+	}
+	for i, file := range files {
+		if err := file.Validate(); err != nil {
+			return Comment{}, invalidParams("file params", fmt.Errorf("file [%v]: %w", i, err))
+		}
+	}
+
+	attachments := make([]CommentAttachment, 0, len(params.Attachments)+len(files))
+	attachments = append(attachments, params.Attachments...)
+
+	for _, file := range files {
+		upload, err := c.UploadFile(ctx, file)
+		if err != nil {
+			return Comment{}, fmt.Errorf("notion: failed to upload file %q: %w", file.Filename, err)
+		}
+		attachments = append(attachments, CommentAttachment{FileUploadID: upload.ID})
+	}
+
+	params.Attachments = attachments
+
+	return c.CreateComment(ctx, params)
+}
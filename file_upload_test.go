@@ -0,0 +1,143 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateCommentWithFiles(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		uploads  = make(map[string]string)
+	)
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			requests = append(requests, r.Method+" "+r.URL.Path)
+
+			var body string
+
+			switch {
+			case r.URL.Path == "/v1/file_uploads":
+				var params map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+					t.Fatal(err)
+				}
+				id := fmt.Sprintf("upload-%v", len(uploads)+1)
+				uploads[id] = params["filename"].(string)
+				if params["mode"] != "single_part" {
+					t.Fatalf("unexpected mode: %v", params["mode"])
+				}
+				body = fmt.Sprintf(`{"object": "file_upload", "id": %q, "status": "pending", "filename": %q}`, id, params["filename"])
+			case strings.HasSuffix(r.URL.Path, "/send"):
+				id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/file_uploads/"), "/send")
+				file, header, err := r.FormFile("file")
+				if err != nil {
+					t.Fatalf("failed to read form file: %v", err)
+				}
+				content, err := ioutil.ReadAll(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if header.Filename != uploads[id] {
+					t.Fatalf("filename not equal (expected: %v, got: %v)", uploads[id], header.Filename)
+				}
+				if exp := "content of " + header.Filename; string(content) != exp {
+					t.Fatalf("content not equal (expected: %v, got: %v)", exp, string(content))
+				}
+				body = fmt.Sprintf(`{"object": "file_upload", "id": %q, "status": "uploaded", "filename": %q}`, id, header.Filename)
+			case r.URL.Path == "/v1/comments":
+				var params map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+					t.Fatal(err)
+				}
+				exp := []interface{}{
+					map[string]interface{}{"type": "file_upload", "file_upload_id": "existing-upload"},
+					map[string]interface{}{"type": "file_upload", "file_upload_id": "upload-1"},
+					map[string]interface{}{"type": "file_upload", "file_upload_id": "upload-2"},
+				}
+				if diff := cmp.Diff(exp, params["attachments"]); diff != "" {
+					t.Fatalf("attachments not equal (-exp, +got):\n%v", diff)
+				}
+				body = `{"object": "comment", "id": "comment-id", "discussion_id": "discussion-id", "parent": {"type": "page_id", "page_id": "page-id"}}`
+			default:
+				t.Fatalf("unexpected request: %v %v", r.Method, r.URL.Path)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	comment, err := client.CreateCommentWithFiles(context.Background(), notion.CreateCommentParams{
+		ParentPageID: "page-id",
+		RichText:     []notion.RichText{{Text: &notion.Text{Content: "See attached."}}},
+		Attachments:  []notion.CommentAttachment{{FileUploadID: "existing-upload"}},
+	}, []notion.FileParam{
+		{Filename: "foo.txt", Content: strings.NewReader("content of foo.txt")},
+		{Filename: "bar.png", Content: strings.NewReader("content of bar.png")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comment.ID != "comment-id" {
+		t.Fatalf("comment ID not equal (expected: comment-id, got: %v)", comment.ID)
+	}
+
+	expRequests := []string{
+		"POST /v1/file_uploads",
+		"POST /v1/file_uploads/upload-1/send",
+		"POST /v1/file_uploads",
+		"POST /v1/file_uploads/upload-2/send",
+		"POST /v1/comments",
+	}
+	if diff := cmp.Diff(expRequests, requests); diff != "" {
+		t.Fatalf("requests not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestCreateCommentWithFilesTooManyAttachments(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request: %v %v", r.Method, r.URL.Path)
+			return nil, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	files := make([]notion.FileParam, 4)
+	for i := range files {
+		files[i] = notion.FileParam{Filename: fmt.Sprintf("%v.txt", i), Content: strings.NewReader("foobar")}
+	}
+
+	_, err := client.CreateCommentWithFiles(context.Background(), notion.CreateCommentParams{
+		ParentPageID: "page-id",
+		RichText:     []notion.RichText{{Text: &notion.Text{Content: "Foobar"}}},
+	}, files)
+
+	exp := "notion: invalid comment params: at most 3 attachments are allowed (got: 4)"
+	if err == nil || err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+}
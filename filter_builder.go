@@ -0,0 +1,590 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxFilterDepth is the maximum nesting depth of compound filters.
+// See: https://developers.notion.com/reference/post-database-query-filter#compound-filter-object
+const maxFilterDepth = 2
+
+// FilterBuilder is used to build a DatabaseQueryFilter with a fluent API. A
+// filter either has a single condition on a property (or timestamp), or is a
+// compound of other filters.
+//
+// Example:
+//
+//	filter, err := notion.Filter().Property("Status").Status().Equals("Done").
+//		And(notion.Filter().Timestamp(notion.TimestampLastEditedTime).PastWeek()).
+//		Build()
+type FilterBuilder struct {
+	filter       DatabaseQueryFilter
+	hasCondition bool
+	err          error
+}
+
+// Filter returns a new FilterBuilder.
+func Filter() *FilterBuilder {
+	return &FilterBuilder{}
+}
+
+// Property starts a condition on the property with name.
+func (b *FilterBuilder) Property(name string) *PropertyFilterBuilder {
+	switch {
+	case b.err != nil:
+	case name == "":
+		b.err = errors.New("property name cannot be empty")
+	case !b.isEmpty():
+		b.err = fmt.Errorf("cannot set property %q on a non-empty filter", name)
+	default:
+		b.filter.Property = name
+	}
+
+	return &PropertyFilterBuilder{b: b}
+}
+
+// Timestamp starts a condition on the created or last edited time of pages.
+func (b *FilterBuilder) Timestamp(timestamp Timestamp) *DateFilterBuilder {
+	var set func(*DatabaseQueryPropertyFilter, *DatePropertyFilter)
+
+	switch timestamp {
+	case TimestampCreatedTime:
+		set = func(p *DatabaseQueryPropertyFilter, f *DatePropertyFilter) { p.CreatedTime = f }
+	case TimestampLastEditedTime:
+		set = func(p *DatabaseQueryPropertyFilter, f *DatePropertyFilter) { p.LastEditedTime = f }
+	}
+
+	switch {
+	case b.err != nil:
+	case set == nil:
+		b.err = fmt.Errorf("invalid timestamp %q", timestamp)
+	case !b.isEmpty():
+		b.err = fmt.Errorf("cannot set timestamp %q on a non-empty filter", timestamp)
+	default:
+		b.filter.Timestamp = timestamp
+	}
+
+	return &DateFilterBuilder{b: b, set: set}
+}
+
+// And returns a compound filter that matches when this filter and all
+// `filters` match. When this filter is empty, it's omitted.
+func (b *FilterBuilder) And(filters ...*FilterBuilder) *FilterBuilder {
+	return b.compound(filters, func(f DatabaseQueryFilter) []DatabaseQueryFilter { return f.And }, func(f *DatabaseQueryFilter, and []DatabaseQueryFilter) { f.And = and })
+}
+
+// Or returns a compound filter that matches when this filter or any of
+// `filters` match. When this filter is empty, it's omitted.
+func (b *FilterBuilder) Or(filters ...*FilterBuilder) *FilterBuilder {
+	return b.compound(filters, func(f DatabaseQueryFilter) []DatabaseQueryFilter { return f.Or }, func(f *DatabaseQueryFilter, or []DatabaseQueryFilter) { f.Or = or })
+}
+
+func (b *FilterBuilder) compound(
+	filters []*FilterBuilder,
+	get func(DatabaseQueryFilter) []DatabaseQueryFilter,
+	set func(*DatabaseQueryFilter, []DatabaseQueryFilter),
+) *FilterBuilder {
+	result := &FilterBuilder{}
+
+	if b.err != nil {
+		result.err = b.err
+		return result
+	}
+
+	var parts []DatabaseQueryFilter
+
+	// Filters of the same compound type as the result are flattened, so
+	// chained calls (e.g. `a.And(b).And(c)`) don't increase nesting depth.
+	add := func(f *FilterBuilder) {
+		if children := get(f.filter); len(children) > 0 {
+			parts = append(parts, children...)
+			return
+		}
+		parts = append(parts, f.filter)
+	}
+
+	if !b.isEmpty() {
+		if err := b.check(); err != nil {
+			result.err = err
+			return result
+		}
+		add(b)
+	}
+
+	for _, f := range filters {
+		if f == nil {
+			result.err = errors.New("filter cannot be nil")
+			return result
+		}
+		if err := f.check(); err != nil {
+			result.err = err
+			return result
+		}
+		add(f)
+	}
+
+	set(&result.filter, parts)
+
+	return result
+}
+
+// Build returns the filter, or the first error that occurred while building
+// it.
+func (b *FilterBuilder) Build() (*DatabaseQueryFilter, error) {
+	if err := b.check(); err != nil {
+		return nil, invalidParams("filter", err)
+	}
+	if depth := filterDepth(b.filter); depth > maxFilterDepth {
+		return nil, invalidParams("filter", fmt.Errorf("compound filters are nested %v levels deep (max: %v)", depth, maxFilterDepth))
+	}
+
+	filter := b.filter
+
+	return &filter, nil
+}
+
+func (b *FilterBuilder) isEmpty() bool {
+	return b.filter.Property == "" && b.filter.Timestamp == "" && !b.hasCondition &&
+		len(b.filter.And) == 0 && len(b.filter.Or) == 0
+}
+
+// check returns an error if the filter is invalid or incomplete.
+func (b *FilterBuilder) check() error {
+	switch {
+	case b.err != nil:
+		return b.err
+	case b.isEmpty():
+		return errors.New("filter is empty")
+	case b.filter.Property != "" && !b.hasCondition:
+		return fmt.Errorf("property %q has no condition", b.filter.Property)
+	case b.filter.Timestamp != "" && !b.hasCondition:
+		return fmt.Errorf("timestamp %q has no condition", b.filter.Timestamp)
+	default:
+		return nil
+	}
+}
+
+func (b *FilterBuilder) condition(fn func(*DatabaseQueryPropertyFilter)) *FilterBuilder {
+	switch {
+	case b.err != nil:
+	case b.filter.Property == "" && b.filter.Timestamp == "":
+		b.err = errors.New("condition must follow a property or timestamp")
+	case b.hasCondition:
+		b.err = errors.New("filter condition is set more than once")
+	default:
+		fn(&b.filter.DatabaseQueryPropertyFilter)
+		b.hasCondition = true
+	}
+
+	return b
+}
+
+func (b *FilterBuilder) fail(err error) *FilterBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+func filterDepth(filter DatabaseQueryFilter) int {
+	children := append(append([]DatabaseQueryFilter{}, filter.And...), filter.Or...)
+	if len(children) == 0 {
+		return 0
+	}
+
+	max := 0
+	for _, child := range children {
+		if depth := filterDepth(child); depth > max {
+			max = depth
+		}
+	}
+
+	return max + 1
+}
+
+// PropertyFilterBuilder is used to select the type of a property condition.
+type PropertyFilterBuilder struct {
+	b *FilterBuilder
+}
+
+// Title starts a condition on a `title` property.
+func (p *PropertyFilterBuilder) Title() *TextFilterBuilder {
+	return &TextFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, t *TextPropertyFilter) { f.Title = t }}
+}
+
+// RichText starts a condition on a `rich_text` property.
+func (p *PropertyFilterBuilder) RichText() *TextFilterBuilder {
+	return &TextFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, t *TextPropertyFilter) { f.RichText = t }}
+}
+
+// URL starts a condition on a `url` property.
+func (p *PropertyFilterBuilder) URL() *TextFilterBuilder {
+	return &TextFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, t *TextPropertyFilter) { f.URL = t }}
+}
+
+// Email starts a condition on an `email` property.
+func (p *PropertyFilterBuilder) Email() *TextFilterBuilder {
+	return &TextFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, t *TextPropertyFilter) { f.Email = t }}
+}
+
+// PhoneNumber starts a condition on a `phone_number` property.
+func (p *PropertyFilterBuilder) PhoneNumber() *TextFilterBuilder {
+	return &TextFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, t *TextPropertyFilter) { f.PhoneNumber = t }}
+}
+
+// Number starts a condition on a `number` property.
+func (p *PropertyFilterBuilder) Number() *NumberFilterBuilder {
+	return &NumberFilterBuilder{b: p.b}
+}
+
+// Checkbox starts a condition on a `checkbox` property.
+func (p *PropertyFilterBuilder) Checkbox() *CheckboxFilterBuilder {
+	return &CheckboxFilterBuilder{b: p.b}
+}
+
+// Select starts a condition on a `select` property.
+func (p *PropertyFilterBuilder) Select() *SelectFilterBuilder {
+	return &SelectFilterBuilder{b: p.b, status: false}
+}
+
+// Status starts a condition on a `status` property.
+func (p *PropertyFilterBuilder) Status() *SelectFilterBuilder {
+	return &SelectFilterBuilder{b: p.b, status: true}
+}
+
+// MultiSelect starts a condition on a `multi_select` property.
+func (p *PropertyFilterBuilder) MultiSelect() *ContainsFilterBuilder {
+	return &ContainsFilterBuilder{b: p.b, typ: DBPropTypeMultiSelect}
+}
+
+// People starts a condition on a `people` property.
+func (p *PropertyFilterBuilder) People() *ContainsFilterBuilder {
+	return &ContainsFilterBuilder{b: p.b, typ: DBPropTypePeople}
+}
+
+// CreatedBy starts a condition on a `created_by` property.
+func (p *PropertyFilterBuilder) CreatedBy() *ContainsFilterBuilder {
+	return &ContainsFilterBuilder{b: p.b, typ: DBPropTypeCreatedBy}
+}
+
+// LastEditedBy starts a condition on a `last_edited_by` property.
+func (p *PropertyFilterBuilder) LastEditedBy() *ContainsFilterBuilder {
+	return &ContainsFilterBuilder{b: p.b, typ: DBPropTypeLastEditedBy}
+}
+
+// Relation starts a condition on a `relation` property.
+func (p *PropertyFilterBuilder) Relation() *ContainsFilterBuilder {
+	return &ContainsFilterBuilder{b: p.b, typ: DBPropTypeRelation}
+}
+
+// Date starts a condition on a `date` property.
+func (p *PropertyFilterBuilder) Date() *DateFilterBuilder {
+	return &DateFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, d *DatePropertyFilter) { f.Date = d }}
+}
+
+// CreatedTime starts a condition on a `created_time` property.
+func (p *PropertyFilterBuilder) CreatedTime() *DateFilterBuilder {
+	return &DateFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, d *DatePropertyFilter) { f.CreatedTime = d }}
+}
+
+// LastEditedTime starts a condition on a `last_edited_time` property.
+func (p *PropertyFilterBuilder) LastEditedTime() *DateFilterBuilder {
+	return &DateFilterBuilder{b: p.b, set: func(f *DatabaseQueryPropertyFilter, d *DatePropertyFilter) { f.LastEditedTime = d }}
+}
+
+// FilesIsEmpty matches when a `files` property is empty.
+func (p *PropertyFilterBuilder) FilesIsEmpty() *FilterBuilder {
+	return p.b.condition(func(f *DatabaseQueryPropertyFilter) { f.Files = &FilesDatabaseQueryFilter{IsEmpty: true} })
+}
+
+// FilesIsNotEmpty matches when a `files` property isn't empty.
+func (p *PropertyFilterBuilder) FilesIsNotEmpty() *FilterBuilder {
+	return p.b.condition(func(f *DatabaseQueryPropertyFilter) { f.Files = &FilesDatabaseQueryFilter{IsNotEmpty: true} })
+}
+
+// Formula sets a condition on the result of a `formula` property.
+func (p *PropertyFilterBuilder) Formula(condition FormulaDatabaseQueryFilter) *FilterBuilder {
+	return p.b.condition(func(f *DatabaseQueryPropertyFilter) { f.Formula = &condition })
+}
+
+// Rollup sets a condition on the result of a `rollup` property.
+func (p *PropertyFilterBuilder) Rollup(condition RollupDatabaseQueryFilter) *FilterBuilder {
+	return p.b.condition(func(f *DatabaseQueryPropertyFilter) { f.Rollup = &condition })
+}
+
+// Condition sets a condition as-is.
+func (p *PropertyFilterBuilder) Condition(condition DatabaseQueryPropertyFilter) *FilterBuilder {
+	return p.b.condition(func(f *DatabaseQueryPropertyFilter) { *f = condition })
+}
+
+// TextFilterBuilder is used to set a condition on a text property.
+type TextFilterBuilder struct {
+	b   *FilterBuilder
+	set func(*DatabaseQueryPropertyFilter, *TextPropertyFilter)
+}
+
+func (t *TextFilterBuilder) condition(filter TextPropertyFilter) *FilterBuilder {
+	return t.b.condition(func(f *DatabaseQueryPropertyFilter) { t.set(f, &filter) })
+}
+
+func (t *TextFilterBuilder) value(s string, filter TextPropertyFilter) *FilterBuilder {
+	if s == "" {
+		return t.b.fail(errors.New("text condition value cannot be empty"))
+	}
+	return t.condition(filter)
+}
+
+func (t *TextFilterBuilder) Equals(s string) *FilterBuilder {
+	return t.value(s, TextPropertyFilter{Equals: s})
+}
+
+func (t *TextFilterBuilder) DoesNotEqual(s string) *FilterBuilder {
+	return t.value(s, TextPropertyFilter{DoesNotEqual: s})
+}
+
+func (t *TextFilterBuilder) Contains(s string) *FilterBuilder {
+	return t.value(s, TextPropertyFilter{Contains: s})
+}
+
+func (t *TextFilterBuilder) DoesNotContain(s string) *FilterBuilder {
+	return t.value(s, TextPropertyFilter{DoesNotContain: s})
+}
+
+func (t *TextFilterBuilder) StartsWith(s string) *FilterBuilder {
+	return t.value(s, TextPropertyFilter{StartsWith: s})
+}
+
+func (t *TextFilterBuilder) EndsWith(s string) *FilterBuilder {
+	return t.value(s, TextPropertyFilter{EndsWith: s})
+}
+
+func (t *TextFilterBuilder) IsEmpty() *FilterBuilder {
+	return t.condition(TextPropertyFilter{IsEmpty: true})
+}
+
+func (t *TextFilterBuilder) IsNotEmpty() *FilterBuilder {
+	return t.condition(TextPropertyFilter{IsNotEmpty: true})
+}
+
+// NumberFilterBuilder is used to set a condition on a `number` property.
+type NumberFilterBuilder struct {
+	b *FilterBuilder
+}
+
+func (n *NumberFilterBuilder) condition(filter NumberDatabaseQueryFilter) *FilterBuilder {
+	return n.b.condition(func(f *DatabaseQueryPropertyFilter) { f.Number = &filter })
+}
+
+func (n *NumberFilterBuilder) Equals(f float64) *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{Equals: &f})
+}
+
+func (n *NumberFilterBuilder) DoesNotEqual(f float64) *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{DoesNotEqual: &f})
+}
+
+func (n *NumberFilterBuilder) GreaterThan(f float64) *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{GreaterThan: &f})
+}
+
+func (n *NumberFilterBuilder) LessThan(f float64) *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{LessThan: &f})
+}
+
+func (n *NumberFilterBuilder) GreaterThanOrEqualTo(f float64) *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{GreaterThanOrEqualTo: &f})
+}
+
+func (n *NumberFilterBuilder) LessThanOrEqualTo(f float64) *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{LessThanOrEqualTo: &f})
+}
+
+func (n *NumberFilterBuilder) IsEmpty() *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{IsEmpty: true})
+}
+
+func (n *NumberFilterBuilder) IsNotEmpty() *FilterBuilder {
+	return n.condition(NumberDatabaseQueryFilter{IsNotEmpty: true})
+}
+
+// CheckboxFilterBuilder is used to set a condition on a `checkbox` property.
+type CheckboxFilterBuilder struct {
+	b *FilterBuilder
+}
+
+func (c *CheckboxFilterBuilder) Equals(checked bool) *FilterBuilder {
+	return c.b.condition(func(f *DatabaseQueryPropertyFilter) {
+		f.Checkbox = &CheckboxDatabaseQueryFilter{Equals: &checked}
+	})
+}
+
+func (c *CheckboxFilterBuilder) DoesNotEqual(checked bool) *FilterBuilder {
+	return c.b.condition(func(f *DatabaseQueryPropertyFilter) {
+		f.Checkbox = &CheckboxDatabaseQueryFilter{DoesNotEqual: &checked}
+	})
+}
+
+// SelectFilterBuilder is used to set a condition on a `select` or `status`
+// property.
+type SelectFilterBuilder struct {
+	b      *FilterBuilder
+	status bool
+}
+
+func (s *SelectFilterBuilder) condition(filter SelectDatabaseQueryFilter) *FilterBuilder {
+	return s.b.condition(func(f *DatabaseQueryPropertyFilter) {
+		if s.status {
+			status := StatusDatabaseQueryFilter(filter)
+			f.Status = &status
+			return
+		}
+		f.Select = &filter
+	})
+}
+
+func (s *SelectFilterBuilder) value(option string, filter SelectDatabaseQueryFilter) *FilterBuilder {
+	if option == "" {
+		return s.b.fail(errors.New("option name cannot be empty"))
+	}
+	return s.condition(filter)
+}
+
+func (s *SelectFilterBuilder) Equals(option string) *FilterBuilder {
+	return s.value(option, SelectDatabaseQueryFilter{Equals: option})
+}
+
+func (s *SelectFilterBuilder) DoesNotEqual(option string) *FilterBuilder {
+	return s.value(option, SelectDatabaseQueryFilter{DoesNotEqual: option})
+}
+
+func (s *SelectFilterBuilder) IsEmpty() *FilterBuilder {
+	return s.condition(SelectDatabaseQueryFilter{IsEmpty: true})
+}
+
+func (s *SelectFilterBuilder) IsNotEmpty() *FilterBuilder {
+	return s.condition(SelectDatabaseQueryFilter{IsNotEmpty: true})
+}
+
+// ContainsFilterBuilder is used to set a condition on a `multi_select`,
+// `people`, `created_by`, `last_edited_by` or `relation` property.
+type ContainsFilterBuilder struct {
+	b   *FilterBuilder
+	typ DatabasePropertyType
+}
+
+// condition sets the filter for the property type. The filter types of these
+// properties have the same fields, so they are converted.
+func (c *ContainsFilterBuilder) condition(filter PeopleDatabaseQueryFilter) *FilterBuilder {
+	return c.b.condition(func(f *DatabaseQueryPropertyFilter) {
+		switch c.typ {
+		case DBPropTypeMultiSelect:
+			multiSelect := MultiSelectDatabaseQueryFilter(filter)
+			f.MultiSelect = &multiSelect
+		case DBPropTypeRelation:
+			relation := RelationDatabaseQueryFilter(filter)
+			f.Relation = &relation
+		case DBPropTypePeople:
+			f.People = &filter
+		case DBPropTypeCreatedBy:
+			f.CreatedBy = &filter
+		case DBPropTypeLastEditedBy:
+			f.LastEditedBy = &filter
+		}
+	})
+}
+
+func (c *ContainsFilterBuilder) value(value string, filter PeopleDatabaseQueryFilter) *FilterBuilder {
+	if value == "" {
+		return c.b.fail(fmt.Errorf("%v condition value cannot be empty", c.typ))
+	}
+	return c.condition(filter)
+}
+
+// Contains matches when the property contains `value`: an option name for
+// `multi_select` properties, a user ID for `people` properties or a page ID for
+// `relation` properties.
+func (c *ContainsFilterBuilder) Contains(value string) *FilterBuilder {
+	return c.value(value, PeopleDatabaseQueryFilter{Contains: value})
+}
+
+func (c *ContainsFilterBuilder) DoesNotContain(value string) *FilterBuilder {
+	return c.value(value, PeopleDatabaseQueryFilter{DoesNotContain: value})
+}
+
+func (c *ContainsFilterBuilder) IsEmpty() *FilterBuilder {
+	return c.condition(PeopleDatabaseQueryFilter{IsEmpty: true})
+}
+
+func (c *ContainsFilterBuilder) IsNotEmpty() *FilterBuilder {
+	return c.condition(PeopleDatabaseQueryFilter{IsNotEmpty: true})
+}
+
+// DateFilterBuilder is used to set a condition on a date property or timestamp.
+type DateFilterBuilder struct {
+	b   *FilterBuilder
+	set func(*DatabaseQueryPropertyFilter, *DatePropertyFilter)
+}
+
+func (d *DateFilterBuilder) condition(filter DatePropertyFilter) *FilterBuilder {
+	if d.set == nil {
+		return d.b.fail(errors.New("invalid timestamp"))
+	}
+	return d.b.condition(func(f *DatabaseQueryPropertyFilter) { d.set(f, &filter) })
+}
+
+func (d *DateFilterBuilder) Equals(dt DateTime) *FilterBuilder {
+	return d.condition(DatePropertyFilter{Equals: &dt})
+}
+
+func (d *DateFilterBuilder) Before(dt DateTime) *FilterBuilder {
+	return d.condition(DatePropertyFilter{Before: &dt})
+}
+
+func (d *DateFilterBuilder) After(dt DateTime) *FilterBuilder {
+	return d.condition(DatePropertyFilter{After: &dt})
+}
+
+func (d *DateFilterBuilder) OnOrBefore(dt DateTime) *FilterBuilder {
+	return d.condition(DatePropertyFilter{OnOrBefore: &dt})
+}
+
+func (d *DateFilterBuilder) OnOrAfter(dt DateTime) *FilterBuilder {
+	return d.condition(DatePropertyFilter{OnOrAfter: &dt})
+}
+
+func (d *DateFilterBuilder) IsEmpty() *FilterBuilder {
+	return d.condition(DatePropertyFilter{IsEmpty: true})
+}
+
+func (d *DateFilterBuilder) IsNotEmpty() *FilterBuilder {
+	return d.condition(DatePropertyFilter{IsNotEmpty: true})
+}
+
+func (d *DateFilterBuilder) PastWeek() *FilterBuilder {
+	return d.condition(DatePropertyFilter{PastWeek: &struct{}{}})
+}
+
+func (d *DateFilterBuilder) PastMonth() *FilterBuilder {
+	return d.condition(DatePropertyFilter{PastMonth: &struct{}{}})
+}
+
+func (d *DateFilterBuilder) PastYear() *FilterBuilder {
+	return d.condition(DatePropertyFilter{PastYear: &struct{}{}})
+}
+
+func (d *DateFilterBuilder) ThisWeek() *FilterBuilder {
+	return d.condition(DatePropertyFilter{ThisWeek: &struct{}{}})
+}
+
+func (d *DateFilterBuilder) NextWeek() *FilterBuilder {
+	return d.condition(DatePropertyFilter{NextWeek: &struct{}{}})
+}
+
+func (d *DateFilterBuilder) NextMonth() *FilterBuilder {
+	return d.condition(DatePropertyFilter{NextMonth: &struct{}{}})
+}
+
+func (d *DateFilterBuilder) NextYear() *FilterBuilder {
+	return d.condition(DatePropertyFilter{NextYear: &struct{}{}})
+}
@@ -0,0 +1,217 @@
+package notion_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilterBuilder(t *testing.T) {
+	t.Parallel()
+
+	date := notion.NewDateTime(time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC), true)
+
+	tests := []struct {
+		name      string
+		builder   *notion.FilterBuilder
+		expFilter *notion.DatabaseQueryFilter
+		expError  string
+	}{
+		{
+			name:    "property condition",
+			builder: notion.Filter().Property("Status").Select().Equals("Done"),
+			expFilter: &notion.DatabaseQueryFilter{
+				Property: "Status",
+				DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+					Select: &notion.SelectDatabaseQueryFilter{Equals: "Done"},
+				},
+			},
+		},
+		{
+			name:    "timestamp condition",
+			builder: notion.Filter().Timestamp(notion.TimestampCreatedTime).After(date),
+			expFilter: &notion.DatabaseQueryFilter{
+				Timestamp: notion.TimestampCreatedTime,
+				DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+					CreatedTime: &notion.DatePropertyFilter{After: &date},
+				},
+			},
+		},
+		{
+			name: "chained and is flattened",
+			builder: notion.Filter().Property("Status").Status().Equals("Done").
+				And(notion.Filter().Property("Tags").MultiSelect().Contains("urgent")).
+				And(notion.Filter().Property("Points").Number().GreaterThan(2.5)),
+			expFilter: &notion.DatabaseQueryFilter{
+				And: []notion.DatabaseQueryFilter{
+					{
+						Property: "Status",
+						DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+							Status: &notion.StatusDatabaseQueryFilter{Equals: "Done"},
+						},
+					},
+					{
+						Property: "Tags",
+						DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+							MultiSelect: &notion.MultiSelectDatabaseQueryFilter{Contains: "urgent"},
+						},
+					},
+					{
+						Property: "Points",
+						DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+							Number: &notion.NumberDatabaseQueryFilter{GreaterThan: notion.Float64Ptr(2.5)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "nested or in and",
+			builder: notion.Filter().And(
+				notion.Filter().Property("Done").Checkbox().Equals(false),
+				notion.Filter().Or(
+					notion.Filter().Property("Name").Title().Contains("foo"),
+					notion.Filter().Property("Owner").People().IsEmpty(),
+				),
+			),
+			expFilter: &notion.DatabaseQueryFilter{
+				And: []notion.DatabaseQueryFilter{
+					{
+						Property: "Done",
+						DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+							Checkbox: &notion.CheckboxDatabaseQueryFilter{Equals: notion.BoolPtr(false)},
+						},
+					},
+					{
+						Or: []notion.DatabaseQueryFilter{
+							{
+								Property: "Name",
+								DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+									Title: &notion.TextPropertyFilter{Contains: "foo"},
+								},
+							},
+							{
+								Property: "Owner",
+								DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+									People: &notion.PeopleDatabaseQueryFilter{IsEmpty: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "formula and rollup",
+			builder: notion.Filter().Or(
+				notion.Filter().Property("Overdue").Formula(notion.FormulaDatabaseQueryFilter{
+					Checkbox: &notion.CheckboxDatabaseQueryFilter{Equals: notion.BoolPtr(true)},
+				}),
+				notion.Filter().Property("Subtasks").Rollup(notion.RollupDatabaseQueryFilter{
+					Any: &notion.DatabaseQueryPropertyFilter{
+						Status: &notion.StatusDatabaseQueryFilter{Equals: "Blocked"},
+					},
+				}),
+			),
+			expFilter: &notion.DatabaseQueryFilter{
+				Or: []notion.DatabaseQueryFilter{
+					{
+						Property: "Overdue",
+						DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+							Formula: &notion.FormulaDatabaseQueryFilter{
+								Checkbox: &notion.CheckboxDatabaseQueryFilter{Equals: notion.BoolPtr(true)},
+							},
+						},
+					},
+					{
+						Property: "Subtasks",
+						DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+							Rollup: &notion.RollupDatabaseQueryFilter{
+								Any: &notion.DatabaseQueryPropertyFilter{
+									Status: &notion.StatusDatabaseQueryFilter{Equals: "Blocked"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "date property this week",
+			builder: notion.Filter().Property("Due").Date().ThisWeek(),
+			expFilter: &notion.DatabaseQueryFilter{
+				Property: "Due",
+				DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+					Date: &notion.DatePropertyFilter{ThisWeek: &struct{}{}},
+				},
+			},
+		},
+		{
+			name:     "empty filter",
+			builder:  notion.Filter(),
+			expError: "notion: invalid filter: filter is empty",
+		},
+		{
+			name:     "property without condition",
+			builder:  notion.Filter().And(notion.Filter().Property("Status").Select().Equals("Done"), propertyOnly("Name")),
+			expError: `notion: invalid filter: property "Name" has no condition`,
+		},
+		{
+			name:     "empty value",
+			builder:  notion.Filter().Property("Name").RichText().Equals(""),
+			expError: "notion: invalid filter: text condition value cannot be empty",
+		},
+		{
+			name:     "invalid timestamp",
+			builder:  notion.Filter().Timestamp("foo").PastWeek(),
+			expError: `notion: invalid filter: invalid timestamp "foo"`,
+		},
+		{
+			name:     "condition set twice",
+			builder:  notion.Filter().Property("Name").Title().Equals("foo").Property("Tags").MultiSelect().IsEmpty(),
+			expError: `notion: invalid filter: cannot set property "Tags" on a non-empty filter`,
+		},
+		{
+			name: "nested too deep",
+			builder: notion.Filter().And(
+				notion.Filter().Property("A").Checkbox().Equals(true),
+				notion.Filter().Or(
+					notion.Filter().Property("B").Checkbox().Equals(true),
+					notion.Filter().And(
+						notion.Filter().Property("C").Checkbox().Equals(true),
+						notion.Filter().Property("D").Checkbox().Equals(true),
+					),
+				),
+			),
+			expError: "notion: invalid filter: compound filters are nested 3 levels deep (max: 2)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := tt.builder.Build()
+			if tt.expError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != "" && (err == nil || err.Error() != tt.expError) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			if diff := cmp.Diff(tt.expFilter, filter); diff != "" {
+				t.Fatalf("filter not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+// propertyOnly returns a filter builder with a property, but no condition.
+func propertyOnly(name string) *notion.FilterBuilder {
+	b := notion.Filter()
+	b.Property(name)
+	return b
+}
@@ -0,0 +1,100 @@
+package notion
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger is used for logging HTTP requests made by the client. It's satisfied
+// by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger logs a line for every HTTP request made by the client, with the
+// method, path, status, duration and request ID (if any).
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRequestHook registers a func that's called before each HTTP request is
+// sent. The request must not be modified. The `Authorization` header isn't
+// set yet when the hook is called.
+func WithRequestHook(fn func(*http.Request)) ClientOption {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, fn)
+	}
+}
+
+// WithResponseHook registers a func that's called for each HTTP response,
+// before its body is read. The response body must not be read or closed. The
+// originating request is available via `Response.Request`.
+func WithResponseHook(fn func(*http.Response)) ClientOption {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, fn)
+	}
+}
+
+// hookTransport is an http.RoundTripper that calls request and response hooks,
+// and logs requests.
+type hookTransport struct {
+	requestHooks  []func(*http.Request)
+	responseHooks []func(*http.Response)
+	logger        Logger
+	secret        string
+	next          http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, fn := range t.requestHooks {
+		fn(req)
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		t.logf("notion: %v %v failed after %v: %v", req.Method, req.URL.Path, duration, err)
+		return nil, err
+	}
+
+	if res.Request == nil {
+		res.Request = req
+	}
+
+	for _, fn := range t.responseHooks {
+		fn(res)
+	}
+
+	if reqID := requestID(res.Header); reqID != "" {
+		t.logf("notion: %v %v %v (duration: %v, request ID: %v)", req.Method, req.URL.Path, res.StatusCode, duration, reqID)
+	} else {
+		t.logf("notion: %v %v %v (duration: %v)", req.Method, req.URL.Path, res.StatusCode, duration)
+	}
+
+	return res, nil
+}
+
+func (t *hookTransport) logf(format string, v ...interface{}) {
+	if t.logger == nil {
+		return
+	}
+	for i := range v {
+		if err, ok := v[i].(error); ok {
+			v[i] = redactError(err, t.secret)
+		}
+	}
+	t.logger.Printf(format, v...)
+}
+
+// requestID returns the Notion request ID from HTTP response headers.
+func requestID(h http.Header) string {
+	if id := h.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	return h.Get("X-Notion-Request-Id")
+}
@@ -0,0 +1,336 @@
+package notion
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// ToHTML renders blocks as semantic HTML, e.g. for displaying Notion content in
+// web apps. Like ToMarkdown, nested blocks are read from the `Children` field of
+// blocks, so these must be populated first.
+//
+// Colors are rendered as CSS classes, prefixed with `notion-color-` (e.g.
+// `notion-color-red_background`), as are other Notion specific elements (e.g.
+// `notion-callout`), so they can be styled. URLs with schemes other than `http`,
+// `https` and `mailto` are omitted.
+func ToHTML(blocks []Block) string {
+	return htmlBlocks(blocks)
+}
+
+// PageToHTML renders a page as HTML, with the page title as `<h1>` element,
+// followed by its content blocks. See ToHTML.
+func PageToHTML(page Page, blocks []Block) string {
+	title := "<h1>" + htmlRichText(pageTitle(page)) + "</h1>"
+	if content := htmlBlocks(blocks); content != "" {
+		return title + "\n" + content
+	}
+	return title
+}
+
+func htmlBlocks(blocks []Block) string {
+	var (
+		elems []string
+		list  string
+		items []string
+	)
+
+	// List items are grouped into list elements.
+	flushList := func() {
+		if list == "" {
+			return
+		}
+		elems = append(elems, list+"\n"+strings.Join(items, "\n")+"\n"+htmlListEnd(list))
+		list, items = "", nil
+	}
+
+	for _, block := range blocks {
+		block = blockPtr(block)
+
+		if start, item := htmlListItem(block); start != "" {
+			if start != list {
+				flushList()
+				list = start
+			}
+			items = append(items, item)
+			continue
+		}
+
+		flushList()
+
+		if elem := htmlBlock(block); elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+
+	flushList()
+
+	return strings.Join(elems, "\n")
+}
+
+// htmlListItem returns the start tag of the list element and the list item for
+// list item blocks.
+func htmlListItem(block Block) (start, item string) {
+	switch b := block.(type) {
+	case *BulletedListItemBlock:
+		return "<ul>", "<li" + htmlColorClass(b.Color, "") + ">" + htmlRichText(b.RichText) + htmlChildren(b.Children, "") + "</li>"
+	case *NumberedListItemBlock:
+		return "<ol>", "<li" + htmlColorClass(b.Color, "") + ">" + htmlRichText(b.RichText) + htmlChildren(b.Children, "") + "</li>"
+	case *ToDoBlock:
+		checkbox := `<input type="checkbox" disabled>`
+		if b.Checked != nil && *b.Checked {
+			checkbox = `<input type="checkbox" disabled checked>`
+		}
+		return `<ul class="notion-to-do">`, "<li" + htmlColorClass(b.Color, "") + ">" + checkbox + " " + htmlRichText(b.RichText) + htmlChildren(b.Children, "") + "</li>"
+	default:
+		return "", ""
+	}
+}
+
+func htmlListEnd(start string) string {
+	if start == "<ol>" {
+		return "</ol>"
+	}
+	return "</ul>"
+}
+
+func htmlBlock(block Block) string {
+	switch b := block.(type) {
+	case *ParagraphBlock:
+		return "<p" + htmlColorClass(b.Color, "") + ">" + htmlRichText(b.RichText) + "</p>" + htmlChildren(b.Children, "notion-children")
+	case *Heading1Block:
+		return htmlHeading("h1", b.RichText, b.Color, b.Children, b.IsToggleable)
+	case *Heading2Block:
+		return htmlHeading("h2", b.RichText, b.Color, b.Children, b.IsToggleable)
+	case *Heading3Block:
+		return htmlHeading("h3", b.RichText, b.Color, b.Children, b.IsToggleable)
+	case *ToggleBlock:
+		return "<details" + htmlColorClass(b.Color, "") + ">\n<summary>" + htmlRichText(b.RichText) + "</summary>" + htmlChildren(b.Children, "") + "</details>"
+	case *QuoteBlock:
+		return "<blockquote" + htmlColorClass(b.Color, "") + ">" + htmlRichText(b.RichText) + htmlChildren(b.Children, "") + "</blockquote>"
+	case *CalloutBlock:
+		var icon string
+		if b.Icon != nil {
+			switch {
+			case b.Icon.Emoji != nil:
+				icon = html.EscapeString(*b.Icon.Emoji)
+			case b.Icon.File != nil || b.Icon.External != nil:
+				icon = htmlImg(fileURL(b.Icon.File, b.Icon.External), "")
+			}
+		}
+		return "<aside" + htmlColorClass(b.Color, "notion-callout") + ">" +
+			`<span class="notion-callout-icon">` + icon + "</span>" +
+			"<div>" + htmlRichText(b.RichText) + htmlChildren(b.Children, "") + "</div></aside>"
+	case *CodeBlock:
+		class := ""
+		if b.Language != nil && *b.Language != "plain text" {
+			class = ` class="language-` + html.EscapeString(strings.ReplaceAll(*b.Language, " ", "-")) + `"`
+		}
+		return htmlFigure("<pre><code"+class+">"+html.EscapeString(PlainText(b.RichText))+"</code></pre>", b.Caption)
+	case *EquationBlock:
+		return `<div class="notion-equation">` + html.EscapeString(b.Expression) + "</div>"
+	case *DividerBlock:
+		return "<hr>"
+	case *ImageBlock:
+		return htmlFigure(htmlImg(fileURL(b.File, b.External), PlainText(b.Caption)), b.Caption)
+	case *VideoBlock:
+		return htmlFigure(`<video src="`+htmlURL(fileURL(b.File, b.External))+`" controls></video>`, b.Caption)
+	case *AudioBlock:
+		return htmlFigure(`<audio src="`+htmlURL(fileURL(b.File, b.External))+`" controls></audio>`, b.Caption)
+	case *FileBlock:
+		return htmlLink(fileURL(b.File, b.External), b.Caption)
+	case *PDFBlock:
+		return htmlLink(fileURL(b.File, b.External), b.Caption)
+	case *BookmarkBlock:
+		return htmlLink(b.URL, b.Caption)
+	case *EmbedBlock:
+		return htmlLink(b.URL, nil)
+	case *LinkPreviewBlock:
+		return htmlLink(b.URL, nil)
+	case *ChildPageBlock:
+		return `<p class="notion-child-page">` + html.EscapeString(b.Title) + "</p>"
+	case *ChildDatabaseBlock:
+		return `<p class="notion-child-database">` + html.EscapeString(b.Title) + "</p>"
+	case *TableBlock:
+		return htmlTable(b)
+	case *ColumnListBlock:
+		return `<div class="notion-column-list">` + "\n" + htmlBlocks(blockChildren(b)) + "\n</div>"
+	case *ColumnBlock:
+		return `<div class="notion-column">` + "\n" + htmlBlocks(b.Children) + "\n</div>"
+	case *SyncedBlock, *TemplateBlock:
+		return htmlBlocks(blockChildren(b))
+	default:
+		return ""
+	}
+}
+
+// htmlChildren renders children, wrapped in a `div` element with `class` (if
+// not empty).
+func htmlChildren(children []Block, class string) string {
+	content := htmlBlocks(children)
+	if content == "" {
+		return ""
+	}
+	if class == "" {
+		return "\n" + content + "\n"
+	}
+	return "\n" + `<div class="` + class + `">` + "\n" + content + "\n</div>"
+}
+
+func htmlHeading(tag string, richText []RichText, color Color, children []Block, toggleable bool) string {
+	heading := "<" + tag + htmlColorClass(color, "") + ">" + htmlRichText(richText) + "</" + tag + ">"
+	if toggleable {
+		return "<details>\n<summary>" + heading + "</summary>" + htmlChildren(children, "") + "</details>"
+	}
+	return heading + htmlChildren(children, "notion-children")
+}
+
+func htmlFigure(content string, caption []RichText) string {
+	if len(caption) == 0 {
+		return "<figure>" + content + "</figure>"
+	}
+	return "<figure>" + content + "<figcaption>" + htmlRichText(caption) + "</figcaption></figure>"
+}
+
+func htmlImg(src, alt string) string {
+	return `<img src="` + htmlURL(src) + `" alt="` + html.EscapeString(alt) + `">`
+}
+
+func htmlLink(href string, caption []RichText) string {
+	text := htmlRichText(caption)
+	if text == "" {
+		text = html.EscapeString(href)
+	}
+	return `<p><a href="` + htmlURL(href) + `">` + text + "</a></p>"
+}
+
+func htmlTable(table *TableBlock) string {
+	var rows []*TableRowBlock
+	for _, child := range table.Children {
+		if row, ok := blockPtr(child).(*TableRowBlock); ok {
+			rows = append(rows, row)
+		}
+	}
+
+	htmlRow := func(row *TableRowBlock, header bool) string {
+		var sb strings.Builder
+		sb.WriteString("<tr>")
+		for i, cell := range row.Cells {
+			switch {
+			case header:
+				sb.WriteString(`<th scope="col">` + htmlRichText(cell) + "</th>")
+			case i == 0 && table.HasRowHeader:
+				sb.WriteString(`<th scope="row">` + htmlRichText(cell) + "</th>")
+			default:
+				sb.WriteString("<td>" + htmlRichText(cell) + "</td>")
+			}
+		}
+		sb.WriteString("</tr>")
+		return sb.String()
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(`<table class="notion-table">`)
+	if table.HasColumnHeader && len(rows) > 0 {
+		sb.WriteString("\n<thead>\n" + htmlRow(rows[0], true) + "\n</thead>")
+		rows = rows[1:]
+	}
+	if len(rows) > 0 {
+		sb.WriteString("\n<tbody>")
+		for _, row := range rows {
+			sb.WriteString("\n" + htmlRow(row, false))
+		}
+		sb.WriteString("\n</tbody>")
+	}
+	sb.WriteString("\n</table>")
+
+	return sb.String()
+}
+
+func htmlRichText(richText []RichText) string {
+	var sb strings.Builder
+	for _, rt := range richText {
+		sb.WriteString(htmlRichTextItem(rt))
+	}
+	return sb.String()
+}
+
+func htmlRichTextItem(rt RichText) string {
+	var s string
+	if rt.Equation != nil {
+		s = `<span class="notion-equation">` + html.EscapeString(rt.Equation.Expression) + "</span>"
+	} else {
+		s = strings.ReplaceAll(html.EscapeString(PlainText([]RichText{rt})), "\n", "<br>")
+	}
+	if s == "" {
+		return ""
+	}
+
+	if rt.Annotations != nil {
+		ann := rt.Annotations
+		if ann.Code {
+			s = "<code>" + s + "</code>"
+		}
+		if ann.Bold {
+			s = "<strong>" + s + "</strong>"
+		}
+		if ann.Italic {
+			s = "<em>" + s + "</em>"
+		}
+		if ann.Strikethrough {
+			s = "<s>" + s + "</s>"
+		}
+		if ann.Underline {
+			s = "<u>" + s + "</u>"
+		}
+		if ann.Color != "" && ann.Color != ColorDefault {
+			s = "<span" + htmlColorClass(ann.Color, "") + ">" + s + "</span>"
+		}
+	}
+
+	var href string
+	switch {
+	case rt.HRef != nil:
+		href = *rt.HRef
+	case rt.Text != nil && rt.Text.Link != nil:
+		href = rt.Text.Link.URL
+	}
+	if href != "" {
+		s = `<a href="` + htmlURL(href) + `">` + s + "</a>"
+	}
+
+	return s
+}
+
+// htmlColorClass returns a `class` attribute with `class` (if not empty) and a
+// class for `color` (if not default).
+func htmlColorClass(color Color, class string) string {
+	var classes []string
+	if class != "" {
+		classes = append(classes, class)
+	}
+	if color != "" && color != ColorDefault {
+		classes = append(classes, fmt.Sprintf("notion-color-%v", color))
+	}
+	if len(classes) == 0 {
+		return ""
+	}
+	return ` class="` + html.EscapeString(strings.Join(classes, " ")) + `"`
+}
+
+// htmlURL returns an escaped URL for use in attributes. URLs with unsafe schemes
+// (e.g. `javascript:`) are omitted.
+func htmlURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return html.EscapeString(rawURL)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,140 @@
+package notion_test
+
+import (
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		blocks []notion.Block
+		exp    string
+	}{
+		{
+			name:   "no blocks",
+			blocks: nil,
+			exp:    "",
+		},
+		{
+			name: "rich text annotations and colors",
+			blocks: []notion.Block{
+				notion.ParagraphBlock{
+					RichText: []notion.RichText{
+						{Text: &notion.Text{Content: "<b>Hi</b> "}},
+						{Text: &notion.Text{Content: "bold"}, Annotations: &notion.Annotations{Bold: true, Color: notion.ColorRed}},
+						{Text: &notion.Text{Content: " "}},
+						{Text: &notion.Text{Content: "link", Link: &notion.Link{URL: "https://example.com/?a=1&b=2"}}, Annotations: &notion.Annotations{Italic: true}},
+						{Text: &notion.Text{Content: " "}},
+						{Text: &notion.Text{Content: "unsafe", Link: &notion.Link{URL: "javascript:alert(1)"}}},
+					},
+					Color: notion.ColorBlueBg,
+				},
+			},
+			exp: `<p class="notion-color-blue_background">&lt;b&gt;Hi&lt;/b&gt; ` +
+				`<span class="notion-color-red"><strong>bold</strong></span> ` +
+				`<a href="https://example.com/?a=1&amp;b=2"><em>link</em></a> ` +
+				`<a href="">unsafe</a></p>`,
+		},
+		{
+			name: "lists with nested children",
+			blocks: []notion.Block{
+				&notion.BulletedListItemBlock{
+					RichText: []notion.RichText{{PlainText: "Foo"}},
+					Children: []notion.Block{
+						&notion.NumberedListItemBlock{RichText: []notion.RichText{{PlainText: "One"}}},
+					},
+				},
+				&notion.BulletedListItemBlock{RichText: []notion.RichText{{PlainText: "Bar"}}},
+				&notion.ToDoBlock{RichText: []notion.RichText{{PlainText: "Done"}}, Checked: notion.BoolPtr(true)},
+			},
+			exp: "<ul>\n<li>Foo\n<ol>\n<li>One</li>\n</ol>\n</li>\n<li>Bar</li>\n</ul>\n" +
+				"<ul class=\"notion-to-do\">\n<li><input type=\"checkbox\" disabled checked> Done</li>\n</ul>",
+		},
+		{
+			name: "toggle, callout and code",
+			blocks: []notion.Block{
+				&notion.ToggleBlock{
+					RichText: []notion.RichText{{PlainText: "More"}},
+					Children: []notion.Block{
+						&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "Hidden"}}},
+					},
+				},
+				&notion.CalloutBlock{
+					RichText: []notion.RichText{{PlainText: "Note"}},
+					Icon:     &notion.Icon{Type: notion.IconTypeEmoji, Emoji: notion.StringPtr("💡")},
+					Color:    notion.ColorGrayBg,
+				},
+				&notion.CodeBlock{
+					RichText: []notion.RichText{{PlainText: "if a < b {}"}},
+					Language: notion.StringPtr("go"),
+				},
+			},
+			exp: "<details>\n<summary>More</summary>\n<p>Hidden</p>\n</details>\n" +
+				`<aside class="notion-callout notion-color-gray_background"><span class="notion-callout-icon">💡</span><div>Note</div></aside>` + "\n" +
+				`<figure><pre><code class="language-go">if a &lt; b {}</code></pre></figure>`,
+		},
+		{
+			name: "table",
+			blocks: []notion.Block{
+				&notion.TableBlock{
+					TableWidth:      2,
+					HasColumnHeader: true,
+					HasRowHeader:    true,
+					Children: []notion.Block{
+						&notion.TableRowBlock{Cells: [][]notion.RichText{{{PlainText: "Name"}}, {{PlainText: "Value"}}}},
+						&notion.TableRowBlock{Cells: [][]notion.RichText{{{PlainText: "a"}}, {{PlainText: "1"}}}},
+					},
+				},
+			},
+			exp: "<table class=\"notion-table\">\n" +
+				"<thead>\n<tr><th scope=\"col\">Name</th><th scope=\"col\">Value</th></tr>\n</thead>\n" +
+				"<tbody>\n<tr><th scope=\"row\">a</th><td>1</td></tr>\n</tbody>\n" +
+				"</table>",
+		},
+		{
+			name: "image",
+			blocks: []notion.Block{
+				&notion.ImageBlock{
+					Type:    notion.FileTypeFile,
+					File:    &notion.FileFile{URL: "https://example.com/image.png"},
+					Caption: []notion.RichText{{PlainText: "An image"}},
+				},
+			},
+			exp: `<figure><img src="https://example.com/image.png" alt="An image"><figcaption>An image</figcaption></figure>`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := notion.ToHTML(tt.blocks)
+			if diff := cmp.Diff(tt.exp, got); diff != "" {
+				t.Fatalf("HTML not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestPageToHTML(t *testing.T) {
+	t.Parallel()
+
+	page := notion.Page{
+		Properties: notion.PageProperties{
+			Title: notion.PageTitle{Title: []notion.RichText{{PlainText: "Foo & Bar"}}},
+		},
+	}
+
+	exp := "<h1>Foo &amp; Bar</h1>\n<hr>"
+	got := notion.PageToHTML(page, []notion.Block{&notion.DividerBlock{}})
+
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("HTML not equal (-exp, +got):\n%v", diff)
+	}
+}
@@ -1,23 +1,51 @@
 package notion
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 type IconType string
 
 const (
-	IconTypeEmoji    IconType = "emoji"
-	IconTypeFile     IconType = "file"
-	IconTypeExternal IconType = "external"
+	IconTypeEmoji       IconType = "emoji"
+	IconTypeFile        IconType = "file"
+	IconTypeExternal    IconType = "external"
+	IconTypeCustomEmoji IconType = "custom_emoji"
 )
 
-// Icon has one non-nil Emoji or External field, denoted by the corresponding
-// IconType.
+// Icon has one non-nil Emoji, File, External or CustomEmoji field, denoted by
+// the corresponding IconType.
 type Icon struct {
 	Type IconType `json:"type"`
 
-	Emoji    *string       `json:"emoji,omitempty"`
-	File     *FileFile     `json:"file,omitempty"`
-	External *FileExternal `json:"external,omitempty"`
+	Emoji       *string       `json:"emoji,omitempty"`
+	File        *FileFile     `json:"file,omitempty"`
+	External    *FileExternal `json:"external,omitempty"`
+	CustomEmoji *CustomEmoji  `json:"custom_emoji,omitempty"`
+}
+
+// CustomEmoji is a custom emoji of a workspace. Only ID is needed for setting
+// an icon.
+type CustomEmoji struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// EmojiIcon returns an icon of type emoji, e.g. EmojiIcon("✅").
+func EmojiIcon(emoji string) *Icon {
+	return &Icon{Type: IconTypeEmoji, Emoji: &emoji}
+}
+
+// ExternalIcon returns an icon of type external, for an image URL.
+func ExternalIcon(url string) *Icon {
+	return &Icon{Type: IconTypeExternal, External: &FileExternal{URL: url}}
+}
+
+// CustomEmojiIcon returns an icon of type custom emoji, by custom emoji ID.
+func CustomEmojiIcon(id string) *Icon {
+	return &Icon{Type: IconTypeCustomEmoji, CustomEmoji: &CustomEmoji{ID: id}}
 }
 
 func (icon Icon) Validate() error {
@@ -31,6 +59,12 @@ func (icon Icon) Validate() error {
 	if icon.Type == IconTypeExternal && icon.External == nil {
 		return errors.New("icon external cannot be empty")
 	}
+	if icon.Type == IconTypeCustomEmoji && (icon.CustomEmoji == nil || icon.CustomEmoji.ID == "") {
+		return errors.New("icon custom emoji ID cannot be empty")
+	}
+	if err := validateFileExternal(icon.External); err != nil {
+		return fmt.Errorf("icon: %w", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,97 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIcon(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		icon     *notion.Icon
+		expJSON  string
+		expError error
+	}{
+		{
+			name:    "emoji",
+			icon:    notion.EmojiIcon("✅"),
+			expJSON: `{"type":"emoji","emoji":"✅"}`,
+		},
+		{
+			name:    "external",
+			icon:    notion.ExternalIcon("https://example.com/icon.png"),
+			expJSON: `{"type":"external","external":{"url":"https://example.com/icon.png"}}`,
+		},
+		{
+			name:    "custom emoji",
+			icon:    notion.CustomEmojiIcon("45ce454c-d427-4f53-9489-e5d0f3d1db6b"),
+			expJSON: `{"type":"custom_emoji","custom_emoji":{"id":"45ce454c-d427-4f53-9489-e5d0f3d1db6b"}}`,
+		},
+		{
+			name:     "custom emoji without ID",
+			icon:     notion.CustomEmojiIcon(""),
+			expError: errors.New("icon custom emoji ID cannot be empty"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.icon.Validate()
+			if tt.expError != nil {
+				if err == nil || err.Error() != tt.expError.Error() {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			b, err := json.Marshal(tt.icon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.expJSON {
+				t.Errorf("JSON not equal (expected: %v, got: %v)", tt.expJSON, string(b))
+			}
+		})
+	}
+}
+
+func TestIconUnmarshalCustomEmoji(t *testing.T) {
+	t.Parallel()
+
+	var icon notion.Icon
+	err := json.Unmarshal([]byte(`{
+		"type": "custom_emoji",
+		"custom_emoji": {
+			"id": "45ce454c-d427-4f53-9489-e5d0f3d1db6b",
+			"name": "bufo",
+			"url": "https://s3-us-west-2.amazonaws.com/public.notion-static.com/bufo.png"
+		}
+	}`), &icon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := notion.Icon{
+		Type: notion.IconTypeCustomEmoji,
+		CustomEmoji: &notion.CustomEmoji{
+			ID:   "45ce454c-d427-4f53-9489-e5d0f3d1db6b",
+			Name: "bufo",
+			URL:  "https://s3-us-west-2.amazonaws.com/public.notion-static.com/bufo.png",
+		},
+	}
+	if diff := cmp.Diff(exp, icon); diff != "" {
+		t.Fatalf("icon not equal (-exp, +got):\n%v", diff)
+	}
+}
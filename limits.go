@@ -0,0 +1,206 @@
+package notion
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Request limits of the Notion API, which are checked client side when using
+// WithStrictValidation.
+// See: https://developers.notion.com/reference/request-limits
+const (
+	maxArrayElements    = 100
+	maxBlocksPerRequest = 1000
+	maxEquationLength   = 1000
+)
+
+// WithStrictValidation checks request payloads of CreatePage, UpdatePage and
+// AppendBlockChildren against the size limits of the Notion API before they
+// are sent, e.g. the maximum of 100 blocks per array, and 2000 characters per
+// rich text content and per URL. Requests that exceed a limit fail with a
+// descriptive error, instead of a generic validation error from the API.
+func WithStrictValidation() ClientOption {
+	return func(c *Client) {
+		c.strictValidation = true
+	}
+}
+
+// validateLimits checks the size limits of page params.
+func (p CreatePageParams) validateLimits() error {
+	if err := validateRichTextLimits(p.Title); err != nil {
+		return fmt.Errorf("title: %w", err)
+	}
+	if p.DatabasePageProperties != nil {
+		if err := p.DatabasePageProperties.validateLimits(); err != nil {
+			return err
+		}
+	}
+	if err := validateBlockLimits(p.Children); err != nil {
+		return fmt.Errorf("children: %w", err)
+	}
+	return nil
+}
+
+// validateLimits checks the size limits of page params.
+func (p UpdatePageParams) validateLimits() error {
+	return p.DatabasePageProperties.validateLimits()
+}
+
+func (props DatabasePageProperties) validateLimits() error {
+	for _, name := range sortedPropNames(props) {
+		if err := props[name].validateLimits(); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (prop DatabasePageProperty) validateLimits() error {
+	if err := validateRichTextLimits(prop.Title); err != nil {
+		return err
+	}
+	if err := validateRichTextLimits(prop.RichText); err != nil {
+		return err
+	}
+
+	if prop.URL != nil && utf8.RuneCountInString(*prop.URL) > MaxURLLength {
+		return fmt.Errorf("url exceeds the maximum of %v characters", MaxURLLength)
+	}
+
+	for _, values := range []struct {
+		name string
+		len  int
+	}{
+		{"multi-select options", len(prop.MultiSelect)},
+		{"people", len(prop.People)},
+		{"relations", len(prop.Relation)},
+	} {
+		if values.len > maxArrayElements {
+			return fmt.Errorf("%v %v exceed the maximum of %v", values.len, values.name, maxArrayElements)
+		}
+	}
+
+	return nil
+}
+
+// validateRichTextLimits checks the number of rich text items, and the length
+// of their content and links.
+func validateRichTextLimits(richText []RichText) error {
+	if len(richText) > maxArrayElements {
+		return fmt.Errorf("%v rich text items exceed the maximum of %v", len(richText), maxArrayElements)
+	}
+	for i, rt := range richText {
+		if rt.Text != nil && utf8.RuneCountInString(rt.Text.Content) > MaxRichTextLength {
+			return fmt.Errorf("rich text [%v]: content exceeds the maximum of %v characters (see SplitRichText)", i, MaxRichTextLength)
+		}
+		if rt.Equation != nil && utf8.RuneCountInString(rt.Equation.Expression) > maxEquationLength {
+			return fmt.Errorf("rich text [%v]: equation exceeds the maximum of %v characters", i, maxEquationLength)
+		}
+		if rt.Text != nil && rt.Text.Link != nil && utf8.RuneCountInString(rt.Text.Link.URL) > MaxURLLength {
+			return fmt.Errorf("rich text [%v]: link exceeds the maximum of %v characters", i, MaxURLLength)
+		}
+	}
+	return nil
+}
+
+// validateBlockLimits checks the number of blocks in a request, and per array
+// of children, as well as the rich text and URLs of all blocks.
+func validateBlockLimits(blocks []Block) error {
+	if n := countBlocks(blocks); n > maxBlocksPerRequest {
+		return fmt.Errorf("%v blocks exceed the maximum of %v per request", n, maxBlocksPerRequest)
+	}
+	return validateBlockArrayLimits(blocks)
+}
+
+func validateBlockArrayLimits(blocks []Block) error {
+	if len(blocks) > maxArrayElements {
+		return fmt.Errorf("%v blocks exceed the maximum of %v per array (see AppendBlockChildrenAll)", len(blocks), maxArrayElements)
+	}
+	for i, block := range blocks {
+		if rawURL := blockURL(block); utf8.RuneCountInString(rawURL) > MaxURLLength {
+			return fmt.Errorf("block [%v]: URL exceeds the maximum of %v characters", i, MaxURLLength)
+		}
+		for _, richText := range blockRichText(block) {
+			if err := validateRichTextLimits(richText); err != nil {
+				return fmt.Errorf("block [%v]: %w", i, err)
+			}
+		}
+		if err := validateBlockArrayLimits(blockChildren(block)); err != nil {
+			return fmt.Errorf("block [%v]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// blockURL returns the URL of a bookmark or embed block, or the URL of an
+// external file of a file block, e.g. an image.
+func blockURL(block Block) string {
+	var external *FileExternal
+
+	switch b := blockPtr(block).(type) {
+	case *BookmarkBlock:
+		return b.URL
+	case *EmbedBlock:
+		return b.URL
+	case *ImageBlock:
+		external = b.External
+	case *AudioBlock:
+		external = b.External
+	case *VideoBlock:
+		external = b.External
+	case *FileBlock:
+		external = b.External
+	case *PDFBlock:
+		external = b.External
+	}
+
+	if external == nil {
+		return ""
+	}
+	return external.URL
+}
+
+// blockRichText returns the rich text of a block, e.g. its content and caption.
+func blockRichText(block Block) [][]RichText {
+	switch b := blockPtr(block).(type) {
+	case *ParagraphBlock:
+		return [][]RichText{b.RichText}
+	case *BulletedListItemBlock:
+		return [][]RichText{b.RichText}
+	case *NumberedListItemBlock:
+		return [][]RichText{b.RichText}
+	case *QuoteBlock:
+		return [][]RichText{b.RichText}
+	case *ToggleBlock:
+		return [][]RichText{b.RichText}
+	case *Heading1Block:
+		return [][]RichText{b.RichText}
+	case *Heading2Block:
+		return [][]RichText{b.RichText}
+	case *Heading3Block:
+		return [][]RichText{b.RichText}
+	case *HeadingUpdate:
+		return [][]RichText{b.RichText}
+	case *ToDoBlock:
+		return [][]RichText{b.RichText}
+	case *CalloutBlock:
+		return [][]RichText{b.RichText}
+	case *CodeBlock:
+		return [][]RichText{b.RichText, b.Caption}
+	case *BookmarkBlock:
+		return [][]RichText{b.Caption}
+	case *ImageBlock:
+		return [][]RichText{b.Caption}
+	case *AudioBlock:
+		return [][]RichText{b.Caption}
+	case *VideoBlock:
+		return [][]RichText{b.Caption}
+	case *FileBlock:
+		return [][]RichText{b.Caption}
+	case *PDFBlock:
+		return [][]RichText{b.Caption}
+	case *TableRowBlock:
+		return b.Cells
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+)
+
+func TestWithStrictValidation(t *testing.T) {
+	t.Parallel()
+
+	paragraphs := func(n int) []notion.Block {
+		blocks := make([]notion.Block, n)
+		for i := range blocks {
+			blocks[i] = &notion.ParagraphBlock{RichText: []notion.RichText{notion.NewRichText("Foobar")}}
+		}
+		return blocks
+	}
+	options := func(n int) []notion.SelectOptions {
+		opts := make([]notion.SelectOptions, n)
+		for i := range opts {
+			opts[i] = notion.SelectOptions{Name: "Foobar"}
+		}
+		return opts
+	}
+	longText := notion.NewRichText(strings.Repeat("a", notion.MaxRichTextLength+1))
+
+	tests := []struct {
+		name     string
+		call     func(client *notion.Client) error
+		expError error
+	}{
+		{
+			name: "create page with long title",
+			call: func(client *notion.Client) error {
+				_, err := client.CreatePage(context.Background(), notion.CreatePageParams{
+					ParentType: notion.ParentTypePage,
+					ParentID:   "parent-id",
+					Title:      []notion.RichText{notion.NewRichText("Foo"), longText},
+				})
+				return err
+			},
+			expError: errors.New("notion: invalid page params: title: rich text [1]: content exceeds the maximum of 2000 characters (see SplitRichText)"),
+		},
+		{
+			name: "create page with too many nested children",
+			call: func(client *notion.Client) error {
+				_, err := client.CreatePage(context.Background(), notion.CreatePageParams{
+					ParentType: notion.ParentTypePage,
+					ParentID:   "parent-id",
+					Title:      []notion.RichText{notion.NewRichText("Foo")},
+					Children: []notion.Block{
+						&notion.ToggleBlock{RichText: []notion.RichText{notion.NewRichText("Foo")}, Children: paragraphs(101)},
+					},
+				})
+				return err
+			},
+			expError: errors.New("notion: invalid page params: children: block [0]: 101 blocks exceed the maximum of 100 per array (see AppendBlockChildrenAll)"),
+		},
+		{
+			name: "update page with too many multi-select options",
+			call: func(client *notion.Client) error {
+				_, err := client.UpdatePage(context.Background(), "page-id", notion.UpdatePageParams{
+					DatabasePageProperties: notion.DatabasePageProperties{
+						"Tags": {MultiSelect: options(101)},
+					},
+				})
+				return err
+			},
+			expError: errors.New(`notion: invalid page params: property "Tags": 101 multi-select options exceed the maximum of 100`),
+		},
+		{
+			name: "append too many nested blocks",
+			call: func(client *notion.Client) error {
+				_, err := client.AppendBlockChildren(context.Background(), "block-id", []notion.Block{
+					&notion.ToggleBlock{RichText: []notion.RichText{notion.NewRichText("Foo")}},
+					&notion.ToggleBlock{RichText: []notion.RichText{notion.NewRichText("Bar")}, Children: paragraphs(101)},
+				})
+				return err
+			},
+			expError: errors.New("notion: invalid block children: block [1]: 101 blocks exceed the maximum of 100 per array (see AppendBlockChildrenAll)"),
+		},
+		{
+			name: "append block with long table cell",
+			call: func(client *notion.Client) error {
+				_, err := client.AppendBlockChildren(context.Background(), "block-id", []notion.Block{
+					&notion.TableBlock{
+						TableWidth: 1,
+						Children: []notion.Block{
+							&notion.TableRowBlock{Cells: [][]notion.RichText{{longText}}},
+						},
+					},
+				})
+				return err
+			},
+			expError: errors.New("notion: invalid block children: block [0]: block [0]: rich text [0]: content exceeds the maximum of 2000 characters (see SplitRichText)"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests int
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					requests++
+
+					return &http.Response{
+						StatusCode: http.StatusBadRequest,
+						Status:     http.StatusText(http.StatusBadRequest),
+						Body: ioutil.NopCloser(strings.NewReader(
+							`{"object": "error", "status": 400, "code": "validation_error", "message": "Foobar"}`,
+						)),
+					}, nil
+				}},
+			}
+
+			strict := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithStrictValidation())
+			err := tt.call(strict)
+
+			if err == nil || err.Error() != tt.expError.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+			if requests != 0 {
+				t.Fatalf("expected no requests, got %v", requests)
+			}
+
+			// Without strict validation, the request is sent.
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+			if err := tt.call(client); !errors.Is(err, notion.ErrValidation) {
+				t.Fatalf("expected API validation error, got: %v", err)
+			}
+			if requests != 1 {
+				t.Fatalf("expected 1 request, got %v", requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrMaxItemsExceeded is returned by ListAllUsers, QueryDatabaseAllPages and
+// FindAllBlockChildren when there are more items than ListAllOpts.MaxItems. The
+// first MaxItems items are returned along with the error.
+var ErrMaxItemsExceeded = errors.New("notion: maximum number of items exceeded")
+
+// ListAllOpts are options for helpers that follow pagination, and return all
+// items in a slice.
+type ListAllOpts struct {
+	// MaxItems is a safety limit for the number of items held in memory. When
+	// there are more items, ErrMaxItemsExceeded is returned. Optional, zero
+	// means no limit.
+	MaxItems int
+}
+
+// ListAllUsers returns all users, following pagination. If a request fails, the
+// users found so far are returned along with the error; see ErrCanceled.
+func (c *Client) ListAllUsers(ctx context.Context, opts *ListAllOpts) ([]User, error) {
+	return listAll(ctx, opts, func(cursor string) ([]User, *string, error) {
+		resp, err := c.ListUsers(ctx, &PaginationQuery{StartCursor: cursor, PageSize: MaxPageSize})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !resp.HasMore {
+			return resp.Results, nil, nil
+		}
+		return resp.Results, resp.NextCursor, nil
+	})
+}
+
+// QueryDatabaseAllPages returns all pages of a database that match `query`
+// (optional), following pagination. The page size of `query` defaults to the
+// maximum (100). For large databases, consider QueryDatabaseStream instead. If
+// a request fails, the pages found so far are returned along with the error;
+// see ErrCanceled.
+func (c *Client) QueryDatabaseAllPages(ctx context.Context, id string, query *DatabaseQuery, opts *ListAllOpts) ([]Page, error) {
+	q := DatabaseQuery{PageSize: MaxPageSize}
+	if query != nil {
+		q = *query
+		if q.PageSize == 0 {
+			q.PageSize = MaxPageSize
+		}
+	}
+
+	return listAll(ctx, opts, func(cursor string) ([]Page, *string, error) {
+		q.StartCursor = cursor
+		resp, err := c.QueryDatabase(ctx, id, &q)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !resp.HasMore {
+			return resp.Results, nil, nil
+		}
+		return resp.Results, resp.NextCursor, nil
+	})
+}
+
+// FindAllBlockChildren returns all children of a block, following pagination.
+// Nested children aren't fetched, see FindBlockChildrenRecursive for that. If a
+// request fails, the blocks found so far are returned along with the error;
+// see ErrCanceled.
+func (c *Client) FindAllBlockChildren(ctx context.Context, blockID string, opts *ListAllOpts) ([]Block, error) {
+	return listAll(ctx, opts, func(cursor string) ([]Block, *string, error) {
+		resp, err := c.FindBlockChildrenByID(ctx, blockID, &PaginationQuery{StartCursor: cursor, PageSize: MaxPageSize})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		blocks := make([]Block, len(resp.Results))
+		for i, block := range resp.Results {
+			blocks[i] = blockPtr(block)
+		}
+
+		if !resp.HasMore {
+			return blocks, nil, nil
+		}
+		return blocks, resp.NextCursor, nil
+	})
+}
+
+// listAll calls `fetch` for each page of results, starting with an empty
+// cursor, until there's no next cursor, or the max items of `opts` are
+// exceeded. On error, the items fetched so far are returned along with it.
+func listAll[T any](ctx context.Context, opts *ListAllOpts, fetch func(cursor string) (results []T, nextCursor *string, err error)) ([]T, error) {
+	var (
+		items  []T
+		cursor string
+	)
+
+	for {
+		if err := canceled(ctx, len(items), -1); err != nil {
+			return items, err
+		}
+
+		results, nextCursor, err := fetch(cursor)
+		if err != nil {
+			if err := canceled(ctx, len(items), -1); err != nil {
+				return items, err
+			}
+			return items, err
+		}
+
+		items = append(items, results...)
+
+		if opts != nil && opts.MaxItems > 0 && len(items) > opts.MaxItems {
+			return items[:opts.MaxItems], fmt.Errorf("%w (max: %v)", ErrMaxItemsExceeded, opts.MaxItems)
+		}
+		if nextCursor == nil {
+			return items, nil
+		}
+		cursor = *nextCursor
+	}
+}
@@ -0,0 +1,197 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// listAllRoundtripper returns two pages of results, with IDs `<prefix>-<n>`.
+func listAllRoundtripper(t *testing.T, object, prefix string, cursors *[]string) *mockRoundtripper {
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		cursor := r.URL.Query().Get("start_cursor")
+		if r.Method == http.MethodPost {
+			var body struct {
+				StartCursor string `json:"start_cursor"`
+				PageSize    int    `json:"page_size"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			cursor = body.StartCursor
+			if body.PageSize != 100 {
+				t.Errorf("page size not equal (expected: 100, got: %v)", body.PageSize)
+			}
+		} else if exp, got := "100", r.URL.Query().Get("page_size"); exp != got {
+			t.Errorf("page size not equal (expected: %v, got: %v)", exp, got)
+		}
+		*cursors = append(*cursors, cursor)
+
+		result := func(n int) string {
+			return fmt.Sprintf(`{"object": %q, "id": "%v-%v", "type": "paragraph", "paragraph": {"rich_text": []}}`, object, prefix, n)
+		}
+
+		body := fmt.Sprintf(`{"object": "list", "results": [%v, %v], "has_more": true, "next_cursor": "cursor-2"}`, result(1), result(2))
+		if cursor == "cursor-2" {
+			body = fmt.Sprintf(`{"object": "list", "results": [%v], "has_more": false, "next_cursor": null}`, result(3))
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}}
+}
+
+func TestListAll(t *testing.T) {
+	t.Parallel()
+
+	ids := func(fn func(i int) string, n int) []string {
+		var ids []string
+		for i := 0; i < n; i++ {
+			ids = append(ids, fn(i))
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name       string
+		object     string
+		prefix     string
+		fn         func(client *notion.Client, opts *notion.ListAllOpts) ([]string, error)
+		opts       *notion.ListAllOpts
+		expIDs     []string
+		expCursors []string
+		expError   error
+	}{
+		{
+			name:   "list all users",
+			object: "user",
+			prefix: "user",
+			fn: func(client *notion.Client, opts *notion.ListAllOpts) ([]string, error) {
+				users, err := client.ListAllUsers(context.Background(), opts)
+				return ids(func(i int) string { return users[i].ID }, len(users)), err
+			},
+			expIDs:     []string{"user-1", "user-2", "user-3"},
+			expCursors: []string{"", "cursor-2"},
+		},
+		{
+			name:   "query all pages of database",
+			object: "page",
+			prefix: "page",
+			fn: func(client *notion.Client, opts *notion.ListAllOpts) ([]string, error) {
+				pages, err := client.QueryDatabaseAllPages(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", nil, opts)
+				return ids(func(i int) string { return pages[i].ID }, len(pages)), err
+			},
+			expIDs:     []string{"page-1", "page-2", "page-3"},
+			expCursors: []string{"", "cursor-2"},
+		},
+		{
+			name:   "find all block children",
+			object: "block",
+			prefix: "block",
+			fn: func(client *notion.Client, opts *notion.ListAllOpts) ([]string, error) {
+				blocks, err := client.FindAllBlockChildren(context.Background(), "block-id", opts)
+				return ids(func(i int) string { return blocks[i].ID() }, len(blocks)), err
+			},
+			expIDs:     []string{"block-1", "block-2", "block-3"},
+			expCursors: []string{"", "cursor-2"},
+		},
+		{
+			name:   "max items not exceeded",
+			object: "user",
+			prefix: "user",
+			fn: func(client *notion.Client, opts *notion.ListAllOpts) ([]string, error) {
+				users, err := client.ListAllUsers(context.Background(), opts)
+				return ids(func(i int) string { return users[i].ID }, len(users)), err
+			},
+			opts:       &notion.ListAllOpts{MaxItems: 3},
+			expIDs:     []string{"user-1", "user-2", "user-3"},
+			expCursors: []string{"", "cursor-2"},
+		},
+		{
+			name:   "max items exceeded",
+			object: "page",
+			prefix: "page",
+			fn: func(client *notion.Client, opts *notion.ListAllOpts) ([]string, error) {
+				pages, err := client.QueryDatabaseAllPages(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", nil, opts)
+				return ids(func(i int) string { return pages[i].ID }, len(pages)), err
+			},
+			opts:       &notion.ListAllOpts{MaxItems: 1},
+			expIDs:     []string{"page-1"},
+			expCursors: []string{""},
+			expError:   notion.ErrMaxItemsExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cursors []string
+
+			httpClient := &http.Client{Transport: listAllRoundtripper(t, tt.object, tt.prefix, &cursors)}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			ids, err := tt.fn(client, tt.opts)
+			if !errors.Is(err, tt.expError) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			if diff := cmp.Diff(tt.expIDs, ids, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("IDs not equal (-exp, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tt.expCursors, cursors); diff != "" {
+				t.Errorf("cursors not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestListAllCanceled(t *testing.T) {
+	t.Parallel()
+
+	var cursors []string
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transport := listAllRoundtripper(t, "user", "user", &cursors)
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			// Cancel once the first page of results is served.
+			defer cancel()
+			return transport.RoundTrip(r)
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	users, err := client.ListAllUsers(ctx, nil)
+
+	var canceledErr *notion.ErrCanceled
+	if !errors.As(err, &canceledErr) {
+		t.Fatalf("error not equal (expected: *notion.ErrCanceled, got: %v)", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error not equal (expected: %v, got: %v)", context.Canceled, err)
+	}
+	if exp, got := 2, canceledErr.Completed; exp != got {
+		t.Errorf("completed not equal (expected: %v, got: %v)", exp, got)
+	}
+	if exp, got := 2, len(users); exp != got {
+		t.Errorf("users not equal (expected: %v, got: %v)", exp, got)
+	}
+	if diff := cmp.Diff([]string{""}, cursors); diff != "" {
+		t.Errorf("cursors not equal (-exp, +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,383 @@
+package notion
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// markdownEscaper escapes characters with special meaning in (inline) GitHub
+// Flavored Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`~`, `\~`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+)
+
+// mdLineStartRegexp matches the start of lines that would be parsed as a block
+// other than a paragraph, e.g. `# Foo` or `1. Foo`, up to and including the
+// marker.
+var mdLineStartRegexp = regexp.MustCompile(`(?m)^ {0,3}(?:[#>+-]|\d{1,9}[.)](?:[ \t]|$))`)
+
+// ToMarkdown renders blocks as GitHub Flavored Markdown. Nested blocks are read
+// from the `Children` field of blocks, so use `Client.FindBlockChildrenRecursive`
+// to find blocks with their children populated.
+//
+// Block types without a Markdown equivalent (e.g. `table_of_contents`) are
+// omitted. Toggles are rendered as HTML `<details>` elements, which GitHub
+// supports.
+func ToMarkdown(blocks []Block) string {
+	md := markdownBlocks(blocks)
+	if md == "" {
+		return ""
+	}
+	return md + "\n"
+}
+
+// PageToMarkdown renders a page as GitHub Flavored Markdown, with the page
+// title as top level heading, followed by its content blocks. See ToMarkdown.
+func PageToMarkdown(page Page, blocks []Block) string {
+	md := "# " + markdownRichText(pageTitle(page))
+	if content := markdownBlocks(blocks); content != "" {
+		md += "\n\n" + content
+	}
+	return md + "\n"
+}
+
+// pageTitle returns the title of a page, regardless of its parent type.
+func pageTitle(page Page) []RichText {
+	for _, prop := range page.AllProperties() {
+		if prop.Type == DBPropTypeTitle {
+			return prop.Title
+		}
+	}
+	return nil
+}
+
+func markdownBlocks(blocks []Block) string {
+	var (
+		sb       strings.Builder
+		prevList bool
+		num      int
+	)
+
+	for _, block := range blocks {
+		block = blockPtr(block)
+
+		if _, ok := block.(*NumberedListItemBlock); ok {
+			num++
+		} else {
+			num = 0
+		}
+
+		md := markdownBlock(block, num)
+		if md == "" {
+			continue
+		}
+
+		list := isMarkdownListItem(block)
+		if sb.Len() > 0 {
+			// Consecutive list items form a (tight) list.
+			if prevList && list {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(md)
+		prevList = list
+	}
+
+	return sb.String()
+}
+
+func isMarkdownListItem(block Block) bool {
+	switch block.(type) {
+	case *BulletedListItemBlock, *NumberedListItemBlock, *ToDoBlock:
+		return true
+	default:
+		return false
+	}
+}
+
+// markdownBlock renders a single block. For numbered list items, `num` is the
+// position in the list.
+func markdownBlock(block Block, num int) string {
+	switch b := block.(type) {
+	case *ParagraphBlock:
+		return markdownWithChildren(escapeMarkdownLineStarts(markdownRichText(b.RichText)), b.Children, "", "\n\n")
+	case *Heading1Block:
+		return markdownHeading("#", b.RichText, b.Children, b.IsToggleable)
+	case *Heading2Block:
+		return markdownHeading("##", b.RichText, b.Children, b.IsToggleable)
+	case *Heading3Block:
+		return markdownHeading("###", b.RichText, b.Children, b.IsToggleable)
+	case *BulletedListItemBlock:
+		return markdownWithChildren("- "+markdownRichText(b.RichText), b.Children, "  ", "\n")
+	case *NumberedListItemBlock:
+		marker := fmt.Sprintf("%d. ", num)
+		return markdownWithChildren(marker+markdownRichText(b.RichText), b.Children, strings.Repeat(" ", len(marker)), "\n")
+	case *ToDoBlock:
+		marker := "- [ ] "
+		if b.Checked != nil && *b.Checked {
+			marker = "- [x] "
+		}
+		return markdownWithChildren(marker+markdownRichText(b.RichText), b.Children, "  ", "\n")
+	case *ToggleBlock:
+		return markdownDetails(markdownRichText(b.RichText), b.Children)
+	case *QuoteBlock:
+		return indentLines(markdownWithChildren(markdownRichText(b.RichText), b.Children, "", "\n\n"), "> ")
+	case *CalloutBlock:
+		text := markdownRichText(b.RichText)
+		if b.Icon != nil && b.Icon.Emoji != nil {
+			text = *b.Icon.Emoji + " " + text
+		}
+		return indentLines(markdownWithChildren(text, b.Children, "", "\n\n"), "> ")
+	case *CodeBlock:
+		lang := ""
+		if b.Language != nil && *b.Language != "plain text" {
+			lang = *b.Language
+		}
+		code := PlainText(b.RichText)
+		fence := markdownCodeFence(code)
+		return fence + lang + "\n" + code + "\n" + fence
+	case *EquationBlock:
+		return "$$\n" + b.Expression + "\n$$"
+	case *DividerBlock:
+		return "---"
+	case *ImageBlock:
+		return fmt.Sprintf("![%v](%v)", markdownRichText(b.Caption), fileURL(b.File, b.External))
+	case *VideoBlock:
+		return markdownLink(b.Caption, fileURL(b.File, b.External))
+	case *AudioBlock:
+		return markdownLink(b.Caption, fileURL(b.File, b.External))
+	case *FileBlock:
+		return markdownLink(b.Caption, fileURL(b.File, b.External))
+	case *PDFBlock:
+		return markdownLink(b.Caption, fileURL(b.File, b.External))
+	case *BookmarkBlock:
+		return markdownLink(b.Caption, b.URL)
+	case *EmbedBlock:
+		return markdownLink(nil, b.URL)
+	case *LinkPreviewBlock:
+		return markdownLink(nil, b.URL)
+	case *ChildPageBlock:
+		return "**" + markdownEscaper.Replace(b.Title) + "**"
+	case *ChildDatabaseBlock:
+		return "**" + markdownEscaper.Replace(b.Title) + "**"
+	case *TableBlock:
+		return markdownTable(b)
+	case *ColumnListBlock, *ColumnBlock, *SyncedBlock, *TemplateBlock:
+		return markdownBlocks(blockChildren(b))
+	default:
+		return ""
+	}
+}
+
+// escapeMarkdownLineStarts escapes markers of headings, quotes, list items and
+// dividers at the start of lines of paragraph text, so the text isn't parsed as
+// another block. Other markers (e.g. `*` and `_`) are escaped by
+// markdownEscaper.
+func escapeMarkdownLineStarts(md string) string {
+	return mdLineStartRegexp.ReplaceAllStringFunc(md, func(s string) string {
+		if i := strings.LastIndexAny(s, ".)"); i > 0 {
+			return s[:i] + `\` + s[i:]
+		}
+		indent := strings.TrimRight(s, "#>+-")
+		return indent + `\` + s[len(indent):]
+	})
+}
+
+// markdownCodeFence returns a code fence for `code`: one backtick longer than
+// the longest run of backticks in it, and at least three.
+func markdownCodeFence(code string) string {
+	longest, run := 0, 0
+	for _, c := range code {
+		if c != '`' {
+			run = 0
+			continue
+		}
+		run++
+		if run > longest {
+			longest = run
+		}
+	}
+
+	if longest < 3 {
+		return "```"
+	}
+	return strings.Repeat("`", longest+1)
+}
+
+// markdownWithChildren renders `text`, followed by `children` indented with
+// `indent` and separated by `sep`.
+func markdownWithChildren(text string, children []Block, indent, sep string) string {
+	md := markdownBlocks(children)
+	if md == "" {
+		return text
+	}
+	return text + sep + indentLines(md, indent)
+}
+
+func markdownHeading(prefix string, richText []RichText, children []Block, toggleable bool) string {
+	if toggleable {
+		return markdownDetails(markdownRichText(richText), children)
+	}
+	return markdownWithChildren(prefix+" "+markdownRichText(richText), children, "", "\n\n")
+}
+
+func markdownDetails(summary string, children []Block) string {
+	md := "<details>\n<summary>" + summary + "</summary>"
+	if content := markdownBlocks(children); content != "" {
+		md += "\n\n" + content + "\n"
+	}
+	return md + "\n</details>"
+}
+
+func markdownLink(caption []RichText, url string) string {
+	text := markdownRichText(caption)
+	if text == "" {
+		text = markdownEscaper.Replace(url)
+	}
+	return fmt.Sprintf("[%v](%v)", text, url)
+}
+
+func markdownTable(table *TableBlock) string {
+	var rows [][][]RichText
+	for _, child := range table.Children {
+		if row, ok := blockPtr(child).(*TableRowBlock); ok {
+			rows = append(rows, row.Cells)
+		}
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := table.TableWidth
+	if width == 0 {
+		width = len(rows[0])
+	}
+
+	var sb strings.Builder
+
+	writeRow := func(cells [][]RichText) {
+		sb.WriteString("|")
+		for i := 0; i < width; i++ {
+			var cell string
+			if i < len(cells) {
+				cell = markdownRichText(cells[i])
+				cell = strings.ReplaceAll(cell, "|", `\|`)
+				cell = strings.ReplaceAll(cell, "\n", "<br>")
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+	}
+
+	// GitHub Flavored Markdown tables require a header row, so the first row is
+	// used, regardless of `HasColumnHeader`.
+	writeRow(rows[0])
+	sb.WriteString("\n|" + strings.Repeat(" --- |", width))
+	for _, row := range rows[1:] {
+		sb.WriteString("\n")
+		writeRow(row)
+	}
+
+	return sb.String()
+}
+
+func markdownRichText(richText []RichText) string {
+	var sb strings.Builder
+	for _, rt := range richText {
+		sb.WriteString(markdownRichTextItem(rt))
+	}
+	return sb.String()
+}
+
+func markdownRichTextItem(rt RichText) string {
+	if rt.Equation != nil {
+		return "$" + rt.Equation.Expression + "$"
+	}
+
+	text := PlainText([]RichText{rt})
+	if text == "" {
+		return ""
+	}
+
+	// Emphasis markers must be adjacent to non-whitespace characters, so
+	// leading and trailing whitespace is kept outside of them.
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, trimmed)]
+	trail := text[len(lead)+len(trimmed):]
+
+	var annotations Annotations
+	if rt.Annotations != nil {
+		annotations = *rt.Annotations
+	}
+
+	md := markdownEscaper.Replace(trimmed)
+	if annotations.Code {
+		fence := "`"
+		if strings.Contains(trimmed, "`") {
+			fence = "``"
+		}
+		md = fence + trimmed + fence
+	}
+	if annotations.Bold {
+		md = "**" + md + "**"
+	}
+	if annotations.Italic {
+		md = "_" + md + "_"
+	}
+	if annotations.Strikethrough {
+		md = "~~" + md + "~~"
+	}
+
+	var href string
+	switch {
+	case rt.HRef != nil:
+		href = *rt.HRef
+	case rt.Text != nil && rt.Text.Link != nil:
+		href = rt.Text.Link.URL
+	}
+	if href != "" {
+		md = "[" + md + "](" + href + ")"
+	}
+
+	return lead + md + trail
+}
+
+func fileURL(file *FileFile, external *FileExternal) string {
+	switch {
+	case file != nil:
+		return file.URL
+	case external != nil:
+		return external.URL
+	default:
+		return ""
+	}
+}
+
+// indentLines prefixes every line of `s` with `indent`. Trailing whitespace of
+// the indent is omitted for empty lines.
+func indentLines(s, indent string) string {
+	if indent == "" {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = strings.TrimRight(indent, " ")
+		} else {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
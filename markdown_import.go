@@ -0,0 +1,780 @@
+package notion
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	mdHeadingRegexp  = regexp.MustCompile(`^(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	mdFenceRegexp    = regexp.MustCompile("^(`{3,}|~{3,})\\s*([^`\\s]*)")
+	mdListItemRegexp = regexp.MustCompile(`^([-*+]|\d{1,9}[.)])(?:( +)|$)`)
+	mdTaskRegexp     = regexp.MustCompile(`^\[([ xX])\](?: +|$)`)
+	mdDividerRegexp  = regexp.MustCompile(`^(?:(?:-[ ]*){3,}|(?:\*[ ]*){3,}|(?:_[ ]*){3,})$`)
+	mdImageRegexp    = regexp.MustCompile(`^!\[(.*)\]\(\s*(\S+?)(?:\s+"(.*)")?\s*\)$`)
+	mdTableSepRegexp = regexp.MustCompile(`^\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?$`)
+)
+
+// codeLanguages are the languages supported by Notion code blocks.
+// See: https://developers.notion.com/reference/block#code
+var codeLanguages = map[string]bool{
+	"abap": true, "arduino": true, "bash": true, "basic": true, "c": true,
+	"clojure": true, "coffeescript": true, "c++": true, "c#": true, "css": true,
+	"dart": true, "diff": true, "docker": true, "elixir": true, "elm": true,
+	"erlang": true, "flow": true, "fortran": true, "f#": true, "gherkin": true,
+	"glsl": true, "go": true, "graphql": true, "groovy": true, "haskell": true,
+	"html": true, "java": true, "javascript": true, "json": true, "julia": true,
+	"kotlin": true, "latex": true, "less": true, "lisp": true, "livescript": true,
+	"lua": true, "makefile": true, "markdown": true, "markup": true, "matlab": true,
+	"mermaid": true, "nix": true, "objective-c": true, "ocaml": true, "pascal": true,
+	"perl": true, "php": true, "plain text": true, "powershell": true, "prolog": true,
+	"protobuf": true, "python": true, "r": true, "reason": true, "ruby": true,
+	"rust": true, "sass": true, "scala": true, "scheme": true, "scss": true,
+	"shell": true, "sql": true, "swift": true, "typescript": true, "vb.net": true,
+	"verilog": true, "vhdl": true, "visual basic": true, "webassembly": true,
+	"xml": true, "yaml": true, "java/c/c++/c#": true,
+}
+
+// codeLanguageAliases maps common Markdown info strings to Notion languages.
+var codeLanguageAliases = map[string]string{
+	"sh":         "shell",
+	"zsh":        "shell",
+	"console":    "shell",
+	"js":         "javascript",
+	"jsx":        "javascript",
+	"ts":         "typescript",
+	"tsx":        "typescript",
+	"py":         "python",
+	"rb":         "ruby",
+	"rs":         "rust",
+	"golang":     "go",
+	"yml":        "yaml",
+	"md":         "markdown",
+	"cpp":        "c++",
+	"cs":         "c#",
+	"csharp":     "c#",
+	"fsharp":     "f#",
+	"objc":       "objective-c",
+	"dockerfile": "docker",
+	"tex":        "latex",
+	"proto":      "protobuf",
+	"ps1":        "powershell",
+	"text":       "plain text",
+	"txt":        "plain text",
+	"plaintext":  "plain text",
+}
+
+// BlocksFromMarkdown parses (GitHub Flavored) Markdown into blocks, which can be
+// used for `CreatePageParams.Children` or `Client.AppendBlockChildren`.
+//
+// Supported are headings, paragraphs, bulleted and numbered lists (including
+// nested lists), to-dos, fenced code blocks, quotes, tables, images, dividers
+// and `$$` equations. Inline, bold, italic, strikethrough, code and links are
+// supported. Headings beyond level 3 are mapped to `heading_3` blocks.
+//
+// Because Notion doesn't support relative URLs, an error is returned for images
+// and links with relative URLs.
+func BlocksFromMarkdown(md string) ([]Block, error) {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+
+	lines := strings.Split(md, "\n")
+	expandMarkdownTabs(lines)
+
+	blocks, err := parseMarkdownBlocks(lines)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to parse markdown: %w", err)
+	}
+
+	return blocks, nil
+}
+
+func parseMarkdownBlocks(lines []string) ([]Block, error) {
+	var blocks []Block
+
+	for i := 0; i < len(lines); {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			i++
+			continue
+		}
+
+		var (
+			block Block
+			err   error
+		)
+
+		switch {
+		case mdFenceRegexp.MatchString(line):
+			block, i = parseMarkdownCode(lines, i)
+		case line == "$$":
+			block, i = parseMarkdownEquation(lines, i)
+		case mdHeadingRegexp.MatchString(line):
+			block, err = parseMarkdownHeading(line)
+			i++
+		case mdDividerRegexp.MatchString(line):
+			block = &DividerBlock{}
+			i++
+		case strings.HasPrefix(line, ">"):
+			block, i, err = parseMarkdownQuote(lines, i)
+		case mdListItemRegexp.MatchString(line):
+			block, i, err = parseMarkdownListItem(lines, i)
+		case mdImageRegexp.MatchString(line):
+			block, err = parseMarkdownImage(line)
+			i++
+		case isMarkdownTable(lines, i):
+			block, i, err = parseMarkdownTable(lines, i)
+		default:
+			block, i, err = parseMarkdownParagraph(lines, i)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// isMarkdownBlockStart returns true if the (trimmed) line starts a block other
+// than a paragraph, and thus ends a paragraph.
+func isMarkdownBlockStart(line string) bool {
+	return mdFenceRegexp.MatchString(line) ||
+		line == "$$" ||
+		mdHeadingRegexp.MatchString(line) ||
+		mdDividerRegexp.MatchString(line) ||
+		strings.HasPrefix(line, ">") ||
+		mdListItemRegexp.MatchString(line) ||
+		mdImageRegexp.MatchString(line)
+}
+
+func parseMarkdownCode(lines []string, i int) (Block, int) {
+	indent := leadingSpaces(lines[i])
+	match := mdFenceRegexp.FindStringSubmatch(strings.TrimSpace(lines[i]))
+	fence := match[1]
+
+	var content []string
+
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		if n := leadingSpaces(line); n < indent {
+			line = line[n:]
+		} else {
+			line = line[indent:]
+		}
+		content = append(content, line)
+	}
+
+	return &CodeBlock{
+		RichText: newMarkdownRichText(strings.Join(content, "\n"), Annotations{}, nil),
+		Language: StringPtr(codeLanguage(match[2])),
+	}, i
+}
+
+// codeLanguage returns the Notion code block language for a Markdown info
+// string. Unsupported languages are mapped to `plain text`.
+func codeLanguage(info string) string {
+	lang := strings.ToLower(info)
+	if alias, ok := codeLanguageAliases[lang]; ok {
+		return alias
+	}
+	if codeLanguages[lang] {
+		return lang
+	}
+	return "plain text"
+}
+
+func parseMarkdownEquation(lines []string, i int) (Block, int) {
+	var expr []string
+
+	for i++; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "$$" {
+			i++
+			break
+		}
+		expr = append(expr, lines[i])
+	}
+
+	return &EquationBlock{Expression: strings.TrimSpace(strings.Join(expr, "\n"))}, i
+}
+
+func parseMarkdownHeading(line string) (Block, error) {
+	match := mdHeadingRegexp.FindStringSubmatch(line)
+
+	richText, err := parseMarkdownInline(match[2], Annotations{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(match[1]) {
+	case 1:
+		return &Heading1Block{RichText: richText}, nil
+	case 2:
+		return &Heading2Block{RichText: richText}, nil
+	default:
+		return &Heading3Block{RichText: richText}, nil
+	}
+}
+
+func parseMarkdownQuote(lines []string, i int) (Block, int, error) {
+	var content []string
+
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, ">") {
+			break
+		}
+		line = strings.TrimPrefix(line, ">")
+		line = strings.TrimPrefix(line, " ")
+		content = append(content, line)
+	}
+
+	children, err := parseMarkdownBlocks(content)
+	if err != nil {
+		return nil, i, err
+	}
+
+	quote := &QuoteBlock{RichText: []RichText{}}
+	if len(children) > 0 {
+		if p, ok := children[0].(*ParagraphBlock); ok {
+			quote.RichText = p.RichText
+			children = children[1:]
+		}
+	}
+	if len(children) > 0 {
+		quote.Children = children
+	}
+
+	return quote, i, nil
+}
+
+func parseMarkdownListItem(lines []string, i int) (Block, int, error) {
+	indent := leadingSpaces(lines[i])
+	line := lines[i][indent:]
+	match := mdListItemRegexp.FindStringSubmatch(line)
+	marker, spaces := match[1], len(match[2])
+
+	// Content indented by more than 4 spaces after the marker is an indented code
+	// block per the CommonMark spec, which isn't supported, so a single space is
+	// assumed.
+	if spaces == 0 || spaces > 4 {
+		spaces = 1
+	}
+	offset := indent + len(marker) + spaces
+
+	text := []string{strings.TrimSpace(line[len(match[0]):])}
+
+	var (
+		children []string
+		blank    bool
+	)
+
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			blank = true
+			children = append(children, "")
+			continue
+		case leadingSpaces(line) >= offset:
+			children = append(children, line[offset:])
+			continue
+		case !blank && len(children) == 0 && !isMarkdownBlockStart(trimmed):
+			// Lazy continuation line.
+			text = append(text, trimmed)
+			continue
+		}
+		break
+	}
+
+	// Trailing blank lines belong to the parent.
+	for len(children) > 0 && children[len(children)-1] == "" {
+		children = children[:len(children)-1]
+		i--
+	}
+
+	childBlocks, err := parseMarkdownBlocks(children)
+	if err != nil {
+		return nil, i, err
+	}
+	if len(childBlocks) == 0 {
+		childBlocks = nil
+	}
+
+	content := joinMarkdownLines(text)
+
+	if marker == "-" || marker == "*" || marker == "+" {
+		if task := mdTaskRegexp.FindStringSubmatch(content); task != nil {
+			richText, err := parseMarkdownInline(content[len(task[0]):], Annotations{}, nil)
+			if err != nil {
+				return nil, i, err
+			}
+			return &ToDoBlock{
+				RichText: richText,
+				Checked:  BoolPtr(task[1] != " "),
+				Children: childBlocks,
+			}, i, nil
+		}
+	}
+
+	richText, err := parseMarkdownInline(content, Annotations{}, nil)
+	if err != nil {
+		return nil, i, err
+	}
+
+	if marker == "-" || marker == "*" || marker == "+" {
+		return &BulletedListItemBlock{RichText: richText, Children: childBlocks}, i, nil
+	}
+
+	return &NumberedListItemBlock{RichText: richText, Children: childBlocks}, i, nil
+}
+
+func parseMarkdownImage(line string) (Block, error) {
+	match := mdImageRegexp.FindStringSubmatch(line)
+
+	if err := validateMarkdownURL("image", match[2]); err != nil {
+		return nil, err
+	}
+
+	caption, err := parseMarkdownInline(match[1], Annotations{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageBlock{
+		Type:     FileTypeExternal,
+		External: &FileExternal{URL: match[2]},
+		Caption:  caption,
+	}, nil
+}
+
+func isMarkdownTable(lines []string, i int) bool {
+	return i+1 < len(lines) &&
+		strings.Contains(lines[i], "|") &&
+		strings.Contains(lines[i+1], "|") &&
+		mdTableSepRegexp.MatchString(strings.TrimSpace(lines[i+1]))
+}
+
+func parseMarkdownTable(lines []string, i int) (Block, int, error) {
+	header := splitMarkdownTableRow(lines[i])
+	width := len(header)
+
+	rows := [][]string{header}
+	for i += 2; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || !strings.Contains(line, "|") {
+			break
+		}
+		rows = append(rows, splitMarkdownTableRow(line))
+	}
+
+	table := &TableBlock{
+		TableWidth:      width,
+		HasColumnHeader: true,
+		Children:        make([]Block, len(rows)),
+	}
+
+	for r, row := range rows {
+		cells := make([][]RichText, width)
+		for c := range cells {
+			if c >= len(row) {
+				cells[c] = []RichText{}
+				continue
+			}
+			richText, err := parseMarkdownInline(row[c], Annotations{}, nil)
+			if err != nil {
+				return nil, i, err
+			}
+			cells[c] = richText
+		}
+		table.Children[r] = &TableRowBlock{Cells: cells}
+	}
+
+	return table, i, nil
+}
+
+// splitMarkdownTableRow splits a table row into (trimmed) cells. Escaped pipes
+// (`\|`) are preserved, to be unescaped when parsing inline content.
+func splitMarkdownTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func parseMarkdownParagraph(lines []string, i int) (Block, int, error) {
+	text := []string{strings.TrimLeft(lines[i], " ")}
+
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || isMarkdownBlockStart(trimmed) || isMarkdownTable(lines, i) {
+			break
+		}
+		text = append(text, strings.TrimLeft(lines[i], " "))
+	}
+
+	richText, err := parseMarkdownInline(joinMarkdownLines(text), Annotations{}, nil)
+	if err != nil {
+		return nil, i, err
+	}
+
+	return &ParagraphBlock{RichText: richText}, i, nil
+}
+
+// joinMarkdownLines joins the lines of a paragraph. Soft line breaks become
+// spaces, and hard line breaks (a line ending with two spaces or a backslash)
+// become newlines.
+func joinMarkdownLines(lines []string) string {
+	var sb strings.Builder
+
+	for i, line := range lines {
+		last := i == len(lines)-1
+
+		switch {
+		case last:
+			sb.WriteString(strings.TrimRight(line, " "))
+		case strings.HasSuffix(line, "  "):
+			sb.WriteString(strings.TrimRight(line, " ") + "\n")
+		case strings.HasSuffix(line, `\`) && !strings.HasSuffix(line, `\\`):
+			sb.WriteString(strings.TrimSuffix(line, `\`) + "\n")
+		default:
+			sb.WriteString(strings.TrimRight(line, " ") + " ")
+		}
+	}
+
+	return sb.String()
+}
+
+// parseMarkdownInline parses inline Markdown into rich text, with `ann` and
+// `link` applied to all of its text.
+func parseMarkdownInline(s string, ann Annotations, link *Link) ([]RichText, error) {
+	var (
+		richText []RichText
+		buf      strings.Builder
+	)
+
+	flush := func() {
+		if buf.Len() > 0 {
+			richText = append(richText, newMarkdownRichText(buf.String(), ann, link)...)
+			buf.Reset()
+		}
+	}
+
+	appendInline := func(inner string, ann Annotations, link *Link) error {
+		flush()
+		rt, err := parseMarkdownInline(inner, ann, link)
+		if err != nil {
+			return err
+		}
+		richText = append(richText, rt...)
+		return nil
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			n := 1
+			for i+n < len(s) && s[i+n] == '`' {
+				n++
+			}
+			fence := s[i : i+n]
+			if end := strings.Index(s[i+n:], fence); end >= 0 {
+				code := s[i+n : i+n+end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				flush()
+				a := ann
+				a.Code = true
+				richText = append(richText, newMarkdownRichText(code, a, link)...)
+				i += n + end + n
+				continue
+			}
+			buf.WriteString(fence)
+			i += n
+			continue
+
+		case c == '[' && link == nil:
+			if text, href, n, ok := parseMarkdownLink(s[i:]); ok {
+				if err := validateMarkdownURL("link", href); err != nil {
+					return nil, err
+				}
+				if err := appendInline(text, ann, &Link{URL: href}); err != nil {
+					return nil, err
+				}
+				i += n
+				continue
+			}
+
+		case c == '<' && link == nil:
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				href := s[i+1 : i+end]
+				if u, err := url.Parse(href); err == nil && (u.Scheme == "http" || u.Scheme == "https") && !strings.ContainsAny(href, " <") {
+					flush()
+					richText = append(richText, newMarkdownRichText(href, ann, &Link{URL: href})...)
+					i += end + 1
+					continue
+				}
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			delim := string(c)
+			if i+1 < len(s) && s[i+1] == c {
+				delim += string(c)
+			}
+			if c == '~' && len(delim) == 1 {
+				break
+			}
+			if end, ok := findMarkdownDelim(s, i, delim); ok {
+				a := ann
+				switch {
+				case c == '~':
+					a.Strikethrough = true
+				case len(delim) == 2:
+					a.Bold = true
+				default:
+					a.Italic = true
+				}
+				if err := appendInline(s[i+len(delim):end], a, link); err != nil {
+					return nil, err
+				}
+				i = end + len(delim)
+				continue
+			}
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+
+	flush()
+
+	if richText == nil {
+		richText = []RichText{}
+	}
+
+	return richText, nil
+}
+
+// parseMarkdownLink parses an inline link (`[text](url)`) at the start of `s`.
+// It returns the link text, its URL and the length of the Markdown.
+func parseMarkdownLink(s string) (text, href string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(s) || s[i+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			dest := strings.TrimSpace(s[i+2 : i+2+end])
+			// Strip an optional title, e.g. `[text](https://example.com "Title")`.
+			if j := strings.IndexAny(dest, " \t"); j >= 0 {
+				dest = dest[:j]
+			}
+			dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+			return s[1:i], dest, i + 2 + end + 1, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// findMarkdownDelim returns the index of the delimiter closing the one at
+// `start`, following (a subset of) the CommonMark rules for emphasis.
+func findMarkdownDelim(s string, start int, delim string) (int, bool) {
+	open := start + len(delim)
+	if open >= len(s) || isSpaceByte(s[open]) {
+		return 0, false
+	}
+	// Intraword underscores (e.g. `snake_case`) don't start emphasis.
+	if delim[0] == '_' && start > 0 && isWordByte(s[start-1]) {
+		return 0, false
+	}
+
+	for i := open + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+			continue
+		case s[i] == '`':
+			// Skip code spans.
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+			continue
+		case !strings.HasPrefix(s[i:], delim) || isSpaceByte(s[i-1]):
+			continue
+		}
+
+		run := 1
+		for i+run < len(s) && s[i+run] == delim[0] {
+			run++
+		}
+		if len(delim) == 1 && run > 1 {
+			// Part of a stronger delimiter, e.g. `**` within `*foo **bar** baz*`.
+			i += run - 1
+			continue
+		}
+
+		// Prefer the last delimiter of a run, e.g. for `***foo***`.
+		end := i + run - len(delim)
+		if delim[0] == '_' && end+len(delim) < len(s) && isWordByte(s[end+len(delim)]) {
+			continue
+		}
+		return end, true
+	}
+
+	return 0, false
+}
+
+// newMarkdownRichText returns text rich text for `content`, split into multiple
+// items if it exceeds the maximum content length.
+func newMarkdownRichText(content string, ann Annotations, link *Link) []RichText {
+	richText := []RichText{}
+
+	for _, chunk := range splitContent(content, MaxRichTextLength) {
+		rt := RichText{
+			Type: RichTextTypeText,
+			Text: &Text{Content: chunk, Link: link},
+		}
+		if ann != (Annotations{}) {
+			a := ann
+			rt.Annotations = &a
+		}
+		richText = append(richText, rt)
+	}
+
+	return richText
+}
+
+func validateMarkdownURL(kind, href string) error {
+	u, err := url.Parse(href)
+	if err != nil {
+		return fmt.Errorf("invalid %v URL %q: %w", kind, href, err)
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("invalid %v URL %q: must be absolute", kind, href)
+	}
+	return nil
+}
+
+// expandMarkdownTabs expands tabs (to 4 columns) in the leading whitespace of
+// lines, which determines list and indent depth. In fenced code blocks, only
+// the indentation of the fence is expanded, so tabs in code are kept.
+func expandMarkdownTabs(lines []string) {
+	var (
+		fence       string
+		fenceIndent int
+	)
+
+	for i, line := range lines {
+		if fence != "" {
+			lines[i] = expandIndent(line, fenceIndent)
+			trimmed := strings.TrimSpace(lines[i])
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+
+		lines[i] = expandIndent(line, -1)
+		if match := mdFenceRegexp.FindStringSubmatch(strings.TrimSpace(lines[i])); match != nil {
+			fence = match[1]
+			fenceIndent = leadingSpaces(lines[i])
+		}
+	}
+}
+
+// expandIndent expands tabs in the leading whitespace of `line`, up to `max`
+// columns, or all leading whitespace if `max` is negative.
+func expandIndent(line string, max int) string {
+	var (
+		b   strings.Builder
+		col int
+	)
+
+	for i := 0; i < len(line); i++ {
+		if max >= 0 && col >= max {
+			return b.String() + line[i:]
+		}
+		switch line[i] {
+		case ' ':
+			b.WriteByte(' ')
+			col++
+		case '\t':
+			n := 4 - col%4
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		default:
+			return b.String() + line[i:]
+		}
+	}
+
+	return b.String()
+}
+
+func leadingSpaces(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " "))
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\n'
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
@@ -0,0 +1,281 @@
+package notion_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func mdText(content string) notion.RichText {
+	return notion.RichText{Type: notion.RichTextTypeText, Text: &notion.Text{Content: content}}
+}
+
+func TestBlocksFromMarkdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		markdown  string
+		expBlocks []notion.Block
+		expError  string
+	}{
+		{
+			name:      "empty",
+			markdown:  "\n\n",
+			expBlocks: nil,
+		},
+		{
+			name:     "headings and paragraphs",
+			markdown: "# Title\n\nSome **bold**, _italic_ and ~~struck~~ text,\nwith `code` and a [link](https://example.com).\n\n## Subtitle\n#### Deep heading\nsnake_case and 2 * 3 * 4",
+			expBlocks: []notion.Block{
+				&notion.Heading1Block{RichText: []notion.RichText{mdText("Title")}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{
+					mdText("Some "),
+					{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "bold"}, Annotations: &notion.Annotations{Bold: true}},
+					mdText(", "),
+					{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "italic"}, Annotations: &notion.Annotations{Italic: true}},
+					mdText(" and "),
+					{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "struck"}, Annotations: &notion.Annotations{Strikethrough: true}},
+					mdText(" text, with "),
+					{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "code"}, Annotations: &notion.Annotations{Code: true}},
+					mdText(" and a "),
+					{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "link", Link: &notion.Link{URL: "https://example.com"}}},
+					mdText("."),
+				}},
+				&notion.Heading2Block{RichText: []notion.RichText{mdText("Subtitle")}},
+				&notion.Heading3Block{RichText: []notion.RichText{mdText("Deep heading")}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{mdText("snake_case and 2 * 3 * 4")}},
+			},
+		},
+		{
+			name:     "nested emphasis and escapes",
+			markdown: `***both*** and \*not italic\*`,
+			expBlocks: []notion.Block{
+				&notion.ParagraphBlock{RichText: []notion.RichText{
+					{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "both"}, Annotations: &notion.Annotations{Bold: true, Italic: true}},
+					mdText(" and *not italic*"),
+				}},
+			},
+		},
+		{
+			name:     "lists and to-dos",
+			markdown: "- Foo\n  - Nested\n- Bar\n\n1. One\n2. Two\n\n- [ ] Todo\n- [x] Done",
+			expBlocks: []notion.Block{
+				&notion.BulletedListItemBlock{
+					RichText: []notion.RichText{mdText("Foo")},
+					Children: []notion.Block{
+						&notion.BulletedListItemBlock{RichText: []notion.RichText{mdText("Nested")}},
+					},
+				},
+				&notion.BulletedListItemBlock{RichText: []notion.RichText{mdText("Bar")}},
+				&notion.NumberedListItemBlock{RichText: []notion.RichText{mdText("One")}},
+				&notion.NumberedListItemBlock{RichText: []notion.RichText{mdText("Two")}},
+				&notion.ToDoBlock{RichText: []notion.RichText{mdText("Todo")}, Checked: notion.BoolPtr(false)},
+				&notion.ToDoBlock{RichText: []notion.RichText{mdText("Done")}, Checked: notion.BoolPtr(true)},
+			},
+		},
+		{
+			name:     "code, quote, divider and equation",
+			markdown: "```js\nconsole.log(\"**hi**\");\n\n```\n\n> Quoted\n> text\n\n---\n\n$$\nE = mc^2\n$$",
+			expBlocks: []notion.Block{
+				&notion.CodeBlock{
+					RichText: []notion.RichText{mdText("console.log(\"**hi**\");\n")},
+					Language: notion.StringPtr("javascript"),
+				},
+				&notion.QuoteBlock{RichText: []notion.RichText{mdText("Quoted text")}},
+				&notion.DividerBlock{},
+				&notion.EquationBlock{Expression: "E = mc^2"},
+			},
+		},
+		{
+			name:     "tab-indented code",
+			markdown: "```go\nfunc f() {\n\treturn\n}\n```\n\n- Foo\n\t```makefile\n\tall:\n\t\tgo build\n\t```\n\t- Nested",
+			expBlocks: []notion.Block{
+				&notion.CodeBlock{
+					RichText: []notion.RichText{mdText("func f() {\n\treturn\n}")},
+					Language: notion.StringPtr("go"),
+				},
+				&notion.BulletedListItemBlock{
+					RichText: []notion.RichText{mdText("Foo")},
+					Children: []notion.Block{
+						&notion.CodeBlock{
+							RichText: []notion.RichText{mdText("all:\n\tgo build")},
+							Language: notion.StringPtr("makefile"),
+						},
+						&notion.BulletedListItemBlock{RichText: []notion.RichText{mdText("Nested")}},
+					},
+				},
+			},
+		},
+		{
+			name:     "unsupported code language",
+			markdown: "```brainfuck\n+++\n```",
+			expBlocks: []notion.Block{
+				&notion.CodeBlock{
+					RichText: []notion.RichText{mdText("+++")},
+					Language: notion.StringPtr("plain text"),
+				},
+			},
+		},
+		{
+			name:     "table",
+			markdown: "| Name | Value |\n| :--- | ---: |\n| a\\|b | **42** |\n| c |",
+			expBlocks: []notion.Block{
+				&notion.TableBlock{
+					TableWidth:      2,
+					HasColumnHeader: true,
+					Children: []notion.Block{
+						&notion.TableRowBlock{Cells: [][]notion.RichText{
+							{mdText("Name")},
+							{mdText("Value")},
+						}},
+						&notion.TableRowBlock{Cells: [][]notion.RichText{
+							{mdText("a|b")},
+							{{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "42"}, Annotations: &notion.Annotations{Bold: true}}},
+						}},
+						&notion.TableRowBlock{Cells: [][]notion.RichText{
+							{mdText("c")},
+							{},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name:     "image",
+			markdown: "![A cat](https://example.com/cat.png \"Cat\")",
+			expBlocks: []notion.Block{
+				&notion.ImageBlock{
+					Type:     notion.FileTypeExternal,
+					External: &notion.FileExternal{URL: "https://example.com/cat.png"},
+					Caption:  []notion.RichText{mdText("A cat")},
+				},
+			},
+		},
+		{
+			name:     "long text is split",
+			markdown: strings.Repeat("a", 2500),
+			expBlocks: []notion.Block{
+				&notion.ParagraphBlock{RichText: []notion.RichText{
+					mdText(strings.Repeat("a", 2000)),
+					mdText(strings.Repeat("a", 500)),
+				}},
+			},
+		},
+		{
+			name:     "relative image URL",
+			markdown: "![Cat](cat.png)",
+			expError: `notion: failed to parse markdown: invalid image URL "cat.png": must be absolute`,
+		},
+		{
+			name:     "relative link URL",
+			markdown: "See [docs](/docs).",
+			expError: `notion: failed to parse markdown: invalid link URL "/docs": must be absolute`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			blocks, err := notion.BlocksFromMarkdown(tt.markdown)
+
+			if tt.expError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != "" && (err == nil || err.Error() != tt.expError) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			opts := cmpopts.IgnoreUnexported(
+				notion.ParagraphBlock{},
+				notion.Heading1Block{},
+				notion.Heading2Block{},
+				notion.Heading3Block{},
+				notion.BulletedListItemBlock{},
+				notion.NumberedListItemBlock{},
+				notion.ToDoBlock{},
+				notion.CodeBlock{},
+				notion.QuoteBlock{},
+				notion.DividerBlock{},
+				notion.EquationBlock{},
+				notion.TableBlock{},
+				notion.TableRowBlock{},
+				notion.ImageBlock{},
+			)
+			if diff := cmp.Diff(tt.expBlocks, blocks, opts); diff != "" {
+				t.Fatalf("blocks not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestBlocksFromMarkdownRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	markdown := "# Title\n\n" +
+		"Some **bold** and _italic_ text with a [link](https://example.com).\n\n" +
+		"- Foo\n  - Nested\n- Bar\n1. One\n2. Two\n\n" +
+		"```go\nfmt.Println(\"hi\")\n```\n\n" +
+		"> Quote\n\n" +
+		"| a | b |\n| --- | --- |\n| c | d |\n"
+
+	blocks, err := notion.BlocksFromMarkdown(markdown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(markdown, notion.ToMarkdown(blocks)); diff != "" {
+		t.Fatalf("markdown not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestToMarkdownRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		blocks []notion.Block
+	}{
+		{
+			name: "code with backtick fences",
+			blocks: []notion.Block{
+				&notion.CodeBlock{
+					RichText: []notion.RichText{mdText("Use a fence:\n```go\nfmt.Println(\"hi\")\n```\nor a longer one: ````")},
+					Language: notion.StringPtr("markdown"),
+				},
+			},
+		},
+		{
+			name: "paragraphs starting with block markers",
+			blocks: []notion.Block{
+				&notion.ParagraphBlock{RichText: []notion.RichText{mdText("# Not a heading")}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{mdText("> Not a quote")}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{mdText("- Not a list item")}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{mdText("+ Not a list item")}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{mdText("42. Not a numbered list item")}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{mdText("---")}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			blocks, err := notion.BlocksFromMarkdown(notion.ToMarkdown(tt.blocks))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.blocks, blocks, cmpopts.IgnoreUnexported(notion.CodeBlock{}, notion.ParagraphBlock{})); diff != "" {
+				t.Fatalf("blocks not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
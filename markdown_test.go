@@ -0,0 +1,186 @@
+package notion_test
+
+import (
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToMarkdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		blocks []notion.Block
+		exp    string
+	}{
+		{
+			name:   "no blocks",
+			blocks: nil,
+			exp:    "",
+		},
+		{
+			name: "headings and paragraphs",
+			blocks: []notion.Block{
+				&notion.Heading1Block{RichText: []notion.RichText{{Text: &notion.Text{Content: "Title"}}}},
+				notion.ParagraphBlock{RichText: []notion.RichText{
+					{Text: &notion.Text{Content: "Some "}},
+					{Text: &notion.Text{Content: "bold "}, Annotations: &notion.Annotations{Bold: true}},
+					{Text: &notion.Text{Content: "italic"}, Annotations: &notion.Annotations{Italic: true, Strikethrough: true}},
+					{Text: &notion.Text{Content: ", "}},
+					{Text: &notion.Text{Content: "fmt.Println"}, Annotations: &notion.Annotations{Code: true}},
+					{Text: &notion.Text{Content: " and a "}},
+					{Text: &notion.Text{Content: "link", Link: &notion.Link{URL: "https://example.com"}}},
+					{Text: &notion.Text{Content: " with *stars*."}},
+				}},
+				&notion.Heading2Block{RichText: []notion.RichText{{PlainText: "Subtitle"}}},
+				&notion.Heading3Block{
+					RichText:     []notion.RichText{{PlainText: "Toggle heading"}},
+					IsToggleable: true,
+					Children: []notion.Block{
+						&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "Hidden"}}},
+					},
+				},
+			},
+			exp: "# Title\n\n" +
+				"Some **bold** ~~_italic_~~, `fmt.Println` and a [link](https://example.com) with \\*stars\\*.\n\n" +
+				"## Subtitle\n\n" +
+				"<details>\n<summary>Toggle heading</summary>\n\nHidden\n\n</details>\n",
+		},
+		{
+			name: "lists and to-dos",
+			blocks: []notion.Block{
+				&notion.BulletedListItemBlock{
+					RichText: []notion.RichText{{PlainText: "Foo"}},
+					Children: []notion.Block{
+						&notion.BulletedListItemBlock{RichText: []notion.RichText{{PlainText: "Nested"}}},
+					},
+				},
+				&notion.BulletedListItemBlock{RichText: []notion.RichText{{PlainText: "Bar"}}},
+				&notion.NumberedListItemBlock{RichText: []notion.RichText{{PlainText: "One"}}},
+				&notion.NumberedListItemBlock{RichText: []notion.RichText{{PlainText: "Two"}}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "Tasks:"}}},
+				&notion.ToDoBlock{RichText: []notion.RichText{{PlainText: "Done"}}, Checked: notion.BoolPtr(true)},
+				&notion.ToDoBlock{RichText: []notion.RichText{{PlainText: "Todo"}}, Checked: notion.BoolPtr(false)},
+			},
+			exp: "- Foo\n  - Nested\n- Bar\n1. One\n2. Two\n\n" +
+				"Tasks:\n\n" +
+				"- [x] Done\n- [ ] Todo\n",
+		},
+		{
+			name: "code, quote, callout and divider",
+			blocks: []notion.Block{
+				&notion.CodeBlock{
+					RichText: []notion.RichText{{PlainText: "fmt.Println(\"*hi*\")"}},
+					Language: notion.StringPtr("go"),
+				},
+				&notion.QuoteBlock{RichText: []notion.RichText{{PlainText: "Quote"}}},
+				&notion.DividerBlock{},
+				&notion.CalloutBlock{
+					RichText: []notion.RichText{{PlainText: "Note"}},
+					Icon:     &notion.Icon{Type: notion.IconTypeEmoji, Emoji: notion.StringPtr("💡")},
+				},
+			},
+			exp: "```go\nfmt.Println(\"*hi*\")\n```\n\n" +
+				"> Quote\n\n" +
+				"---\n\n" +
+				"> 💡 Note\n",
+		},
+		{
+			name: "images and bookmarks",
+			blocks: []notion.Block{
+				&notion.ImageBlock{
+					Type:     notion.FileTypeExternal,
+					External: &notion.FileExternal{URL: "https://example.com/image.png"},
+					Caption:  []notion.RichText{{PlainText: "An image"}},
+				},
+				&notion.BookmarkBlock{URL: "https://example.com"},
+			},
+			exp: "![An image](https://example.com/image.png)\n\n" +
+				"[https://example.com](https://example.com)\n",
+		},
+		{
+			name: "table",
+			blocks: []notion.Block{
+				&notion.TableBlock{
+					TableWidth:      2,
+					HasColumnHeader: true,
+					Children: []notion.Block{
+						&notion.TableRowBlock{Cells: [][]notion.RichText{
+							{{PlainText: "Name"}},
+							{{PlainText: "Value"}},
+						}},
+						&notion.TableRowBlock{Cells: [][]notion.RichText{
+							{{PlainText: "a|b"}},
+							{{PlainText: "42", Annotations: &notion.Annotations{Bold: true}}},
+						}},
+					},
+				},
+			},
+			exp: "| Name | Value |\n| --- | --- |\n| a\\|b | **42** |\n",
+		},
+		{
+			name: "code block with backticks",
+			blocks: []notion.Block{
+				&notion.CodeBlock{
+					RichText: []notion.RichText{{PlainText: "```go\nfmt.Println(\"hi\")\n```"}},
+					Language: notion.StringPtr("markdown"),
+				},
+			},
+			exp: "````markdown\n```go\nfmt.Println(\"hi\")\n```\n````\n",
+		},
+		{
+			name: "paragraphs with block markers",
+			blocks: []notion.Block{
+				&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "# Foo\n> Bar"}}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "1. Baz"}}},
+				&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "3.14 and #hashtags"}}},
+			},
+			exp: "\\# Foo\n\\> Bar\n\n1\\. Baz\n\n3.14 and #hashtags\n",
+		},
+		{
+			name: "unsupported blocks are omitted",
+			blocks: []notion.Block{
+				&notion.TableOfContentsBlock{},
+				&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "Foobar"}}},
+			},
+			exp: "Foobar\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := notion.ToMarkdown(tt.blocks)
+			if diff := cmp.Diff(tt.exp, got); diff != "" {
+				t.Fatalf("markdown not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestPageToMarkdown(t *testing.T) {
+	t.Parallel()
+
+	page := notion.Page{
+		Properties: notion.DatabasePageProperties{
+			"Name": notion.DatabasePageProperty{
+				Type:  notion.DBPropTypeTitle,
+				Title: []notion.RichText{{PlainText: "Foobar"}},
+			},
+		},
+	}
+	blocks := []notion.Block{
+		&notion.ParagraphBlock{RichText: []notion.RichText{{PlainText: "Lorem ipsum."}}},
+	}
+
+	exp := "# Foobar\n\nLorem ipsum.\n"
+	got := notion.PageToMarkdown(page, blocks)
+
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("markdown not equal (-exp, +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,39 @@
+package notion
+
+import "net/http"
+
+// Middleware wraps the http.RoundTripper used for requests to the Notion API,
+// e.g. for metrics, tracing, injecting headers or audit logging.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to use a func as http.RoundTripper, e.g. in a
+// Middleware.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (fn RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// WithMiddleware adds middlewares to the HTTP requests made by the client. The
+// first middleware is the outermost one, so it sees a request first and its
+// response last. Middlewares wrap the transports of other client options (e.g.
+// WithRateLimit and WithTimeout), and are called for each HTTP request,
+// including redirects. The `Authorization` header isn't set yet when a
+// middleware is called, so it's never exposed to them.
+//
+// Per the http.RoundTripper contract, middlewares must not modify the request;
+// clone it (with Request.Clone) to change headers.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// applyMiddlewares wraps `rt` with the middlewares of the client.
+func (c *Client) applyMiddlewares(rt http.RoundTripper) http.RoundTripper {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+	return rt
+}
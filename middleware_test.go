@@ -0,0 +1,82 @@
+package notion_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, s)
+	}
+
+	middleware := func(name string) notion.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return notion.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Authorization") != "" {
+					t.Error("expected authorization header not to be set")
+				}
+
+				record(name + " request")
+
+				req = req.Clone(req.Context())
+				req.Header.Add("X-Middleware", name)
+
+				res, err := next.RoundTrip(req)
+				record(name + " response")
+
+				return res, err
+			})
+		}
+	}
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			record("request")
+
+			if diff := cmp.Diff([]string{"outer", "inner"}, r.Header.Values("X-Middleware")); diff != "" {
+				t.Errorf("headers not equal (-exp, +got):\n%v", diff)
+			}
+			if exp := "Bearer secret-api-key"; r.Header.Get("Authorization") != exp {
+				t.Errorf("authorization header not equal (expected: %q, got: %q)", exp, r.Header.Get("Authorization"))
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body: io.NopCloser(strings.NewReader(
+					`{"object": "user", "id": "be32e790-8292-46df-a248-b784fdf483cf", "type": "bot", "bot": {}}`,
+				)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(httpClient),
+		notion.WithMiddleware(middleware("outer")),
+		notion.WithMiddleware(middleware("inner")),
+	)
+
+	if _, err := client.FindCurrentUser(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := []string{"outer request", "inner request", "request", "inner response", "outer response"}
+	if diff := cmp.Diff(exp, calls); diff != "" {
+		t.Fatalf("calls not equal (-exp, +got):\n%v", diff)
+	}
+}
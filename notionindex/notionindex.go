@@ -0,0 +1,247 @@
+// Package notionindex maintains an index of child pages on a parent page, e.g.
+// for wiki-style home pages that are kept up to date by automation.
+//
+// The index is a section of the parent page, starting with a heading (`Index`
+// by default), followed by a "link to page" block for each child page, sorted
+// by title and optionally grouped under sub headings:
+//
+//	err := notionindex.Update(ctx, client, pageID, notionindex.Options{
+//		GroupBy: notionindex.ByFirstLetter,
+//	})
+package notionindex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// API is the subset of *notion.Client used for updating indexes.
+type API interface {
+	FindBlockChildrenByID(ctx context.Context, blockID string, query *notion.PaginationQuery) (notion.BlockChildrenResponse, error)
+	AppendBlockChildrenAll(ctx context.Context, blockID string, children []notion.Block, opts *notion.AppendBlockChildrenOpts) (notion.BlockChildrenResponse, error)
+	DeleteBlock(ctx context.Context, blockID string) (notion.Block, error)
+}
+
+// DefaultHeading is the text of the heading that marks the index section.
+const DefaultHeading = "Index"
+
+// Options are used to configure the index.
+type Options struct {
+	// Heading is the text of the (level 2) heading that starts the index
+	// section. Defaults to DefaultHeading.
+	Heading string
+
+	// GroupBy returns the group of an entry by its title. Groups are rendered as
+	// (level 3) headings, sorted by name. When nil, entries aren't grouped.
+	GroupBy func(title string) string
+
+	// IncludeDatabases adds links to child databases to the index.
+	IncludeDatabases bool
+}
+
+// Entry is a child page (or database) listed in the index.
+type Entry struct {
+	ID       string
+	Title    string
+	Database bool
+}
+
+// ByFirstLetter groups entries by the uppercased first letter of their title.
+// Titles that don't start with a letter are grouped under `#`.
+func ByFirstLetter(title string) string {
+	for _, r := range strings.TrimSpace(title) {
+		if unicode.IsLetter(r) {
+			return string(unicode.ToUpper(r))
+		}
+		break
+	}
+	return "#"
+}
+
+// Blocks returns the blocks of an index (without the section heading) for
+// entries. Entries are sorted by title (case insensitive) and grouped with
+// `groupBy`, if not nil.
+func Blocks(entries []Entry, groupBy func(title string) string) []notion.Block {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, tj := strings.ToLower(sorted[i].Title), strings.ToLower(sorted[j].Title)
+		if ti != tj {
+			return ti < tj
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	if groupBy == nil {
+		blocks := make([]notion.Block, len(sorted))
+		for i, entry := range sorted {
+			blocks[i] = link(entry)
+		}
+		return blocks
+	}
+
+	groups := make(map[string][]Entry)
+	var names []string
+	for _, entry := range sorted {
+		name := groupBy(entry.Title)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], entry)
+	}
+	sort.Strings(names)
+
+	var blocks []notion.Block
+	for _, name := range names {
+		blocks = append(blocks, &notion.Heading3Block{RichText: richText(name)})
+		for _, entry := range groups[name] {
+			blocks = append(blocks, link(entry))
+		}
+	}
+
+	return blocks
+}
+
+// Update creates or updates the index section on a page, for the child pages
+// (and optionally databases) of the page. An existing section is found by its
+// heading; the "link to page" blocks and sub headings directly following it are
+// replaced. If the section doesn't exist, it's appended to the page. Nothing is
+// changed when the index is up to date.
+func Update(ctx context.Context, api API, pageID string, opts Options) error {
+	heading := opts.Heading
+	if heading == "" {
+		heading = DefaultHeading
+	}
+
+	children, err := findChildren(ctx, api, pageID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		entries   []Entry
+		headingID string
+		existing  []notion.Block
+	)
+
+	for i, block := range children {
+		switch b := block.(type) {
+		case *notion.ChildPageBlock:
+			entries = append(entries, Entry{ID: b.ID(), Title: b.Title})
+		case *notion.ChildDatabaseBlock:
+			if opts.IncludeDatabases {
+				entries = append(entries, Entry{ID: b.ID(), Title: b.Title, Database: true})
+			}
+		case *notion.Heading2Block:
+			if headingID == "" && notion.PlainText(b.RichText) == heading {
+				headingID = b.ID()
+				existing = section(children[i+1:])
+			}
+		}
+	}
+
+	blocks := Blocks(entries, opts.GroupBy)
+
+	if headingID == "" {
+		blocks = append([]notion.Block{&notion.Heading2Block{RichText: richText(heading)}}, blocks...)
+		if _, err := api.AppendBlockChildrenAll(ctx, pageID, blocks, nil); err != nil {
+			return fmt.Errorf("notionindex: failed to append index: %w", err)
+		}
+		return nil
+	}
+
+	if equal(existing, blocks) {
+		return nil
+	}
+
+	for _, block := range existing {
+		if _, err := api.DeleteBlock(ctx, block.ID()); err != nil {
+			return fmt.Errorf("notionindex: failed to delete block %v: %w", block.ID(), err)
+		}
+	}
+
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	_, err = api.AppendBlockChildrenAll(ctx, pageID, blocks, &notion.AppendBlockChildrenOpts{After: headingID})
+	if err != nil {
+		return fmt.Errorf("notionindex: failed to append index: %w", err)
+	}
+
+	return nil
+}
+
+func findChildren(ctx context.Context, api API, blockID string) ([]notion.Block, error) {
+	var blocks []notion.Block
+
+	query := &notion.PaginationQuery{PageSize: notion.MaxPageSize}
+
+	for {
+		resp, err := api.FindBlockChildrenByID(ctx, blockID, query)
+		if err != nil {
+			return nil, fmt.Errorf("notionindex: failed to find children of block %v: %w", blockID, err)
+		}
+
+		blocks = append(blocks, resp.Results...)
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return blocks, nil
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+}
+
+// section returns the leading "link to page" blocks and sub headings of blocks.
+func section(blocks []notion.Block) []notion.Block {
+	for i, block := range blocks {
+		switch block.(type) {
+		case *notion.LinkToPageBlock, *notion.Heading3Block:
+		default:
+			return blocks[:i]
+		}
+	}
+	return blocks
+}
+
+// equal returns true if existing index blocks link to the same pages, with the
+// same sub headings, as generated blocks.
+func equal(existing, generated []notion.Block) bool {
+	if len(existing) != len(generated) {
+		return false
+	}
+	for i := range existing {
+		if key(existing[i]) != key(generated[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func key(block notion.Block) string {
+	switch b := block.(type) {
+	case *notion.LinkToPageBlock:
+		return fmt.Sprintf("link:%v:%v%v", b.Type, b.PageID, b.DatabaseID)
+	case *notion.Heading3Block:
+		return "heading:" + notion.PlainText(b.RichText)
+	default:
+		return ""
+	}
+}
+
+func link(entry Entry) *notion.LinkToPageBlock {
+	if entry.Database {
+		return &notion.LinkToPageBlock{Type: notion.LinkToPageTypeDatabaseID, DatabaseID: entry.ID}
+	}
+	return &notion.LinkToPageBlock{Type: notion.LinkToPageTypePageID, PageID: entry.ID}
+}
+
+func richText(s string) []notion.RichText {
+	return []notion.RichText{{Type: notion.RichTextTypeText, Text: &notion.Text{Content: s}}}
+}
@@ -0,0 +1,194 @@
+package notionindex_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/dstotijn/go-notion/notionindex"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type appendCall struct {
+	blockID  string
+	children []notion.Block
+	opts     *notion.AppendBlockChildrenOpts
+}
+
+type mockAPI struct {
+	children []notion.Block
+	appended []appendCall
+	deleted  []string
+}
+
+func (m *mockAPI) FindBlockChildrenByID(_ context.Context, _ string, _ *notion.PaginationQuery) (notion.BlockChildrenResponse, error) {
+	return notion.BlockChildrenResponse{Results: m.children}, nil
+}
+
+func (m *mockAPI) AppendBlockChildrenAll(_ context.Context, blockID string, children []notion.Block, opts *notion.AppendBlockChildrenOpts) (notion.BlockChildrenResponse, error) {
+	m.appended = append(m.appended, appendCall{blockID, children, opts})
+	return notion.BlockChildrenResponse{}, nil
+}
+
+func (m *mockAPI) DeleteBlock(_ context.Context, blockID string) (notion.Block, error) {
+	m.deleted = append(m.deleted, blockID)
+	return nil, nil
+}
+
+// decodeBlock returns a block with an ID, like blocks returned by the API.
+func decodeBlock(t *testing.T, json string) notion.Block {
+	t.Helper()
+
+	var resp notion.BlockChildrenResponse
+	if err := resp.UnmarshalJSON([]byte(`{"results": [` + json + `]}`)); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+
+	return resp.Results[0]
+}
+
+func pageLink(id string) *notion.LinkToPageBlock {
+	return &notion.LinkToPageBlock{Type: notion.LinkToPageTypePageID, PageID: id}
+}
+
+func heading(level int, text string) notion.Block {
+	richText := []notion.RichText{{Type: notion.RichTextTypeText, Text: &notion.Text{Content: text}}}
+	if level == 2 {
+		return &notion.Heading2Block{RichText: richText}
+	}
+	return &notion.Heading3Block{RichText: richText}
+}
+
+var blockOpts = cmpopts.IgnoreUnexported(notion.LinkToPageBlock{}, notion.Heading2Block{}, notion.Heading3Block{})
+
+func TestBlocks(t *testing.T) {
+	t.Parallel()
+
+	entries := []notionindex.Entry{
+		{ID: "p1", Title: "banana"},
+		{ID: "p2", Title: "Apple"},
+		{ID: "d1", Title: "2022 archive", Database: true},
+		{ID: "p3", Title: "Avocado"},
+	}
+
+	tests := []struct {
+		name    string
+		groupBy func(string) string
+		exp     []notion.Block
+	}{
+		{
+			name: "sorted",
+			exp: []notion.Block{
+				&notion.LinkToPageBlock{Type: notion.LinkToPageTypeDatabaseID, DatabaseID: "d1"},
+				pageLink("p2"),
+				pageLink("p3"),
+				pageLink("p1"),
+			},
+		},
+		{
+			name:    "grouped by first letter",
+			groupBy: notionindex.ByFirstLetter,
+			exp: []notion.Block{
+				heading(3, "#"),
+				&notion.LinkToPageBlock{Type: notion.LinkToPageTypeDatabaseID, DatabaseID: "d1"},
+				heading(3, "A"),
+				pageLink("p2"),
+				pageLink("p3"),
+				heading(3, "B"),
+				pageLink("p1"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := notionindex.Blocks(entries, tt.groupBy)
+			if diff := cmp.Diff(tt.exp, got, blockOpts); diff != "" {
+				t.Fatalf("blocks not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	childPages := func(t *testing.T) []notion.Block {
+		return []notion.Block{
+			decodeBlock(t, `{"id": "p1", "type": "child_page", "child_page": {"title": "Foo"}}`),
+			decodeBlock(t, `{"id": "p2", "type": "child_page", "child_page": {"title": "Bar"}}`),
+			decodeBlock(t, `{"id": "d1", "type": "child_database", "child_database": {"title": "Tasks"}}`),
+		}
+	}
+	indexHeading := func(t *testing.T) notion.Block {
+		return decodeBlock(t, `{"id": "h", "type": "heading_2", "heading_2": {"rich_text": [{"type": "text", "text": {"content": "Index"}, "plain_text": "Index"}]}}`)
+	}
+	linkBlock := func(t *testing.T, id, pageID string) notion.Block {
+		return decodeBlock(t, `{"id": "`+id+`", "type": "link_to_page", "link_to_page": {"type": "page_id", "page_id": "`+pageID+`"}}`)
+	}
+	paragraph := func(t *testing.T) notion.Block {
+		return decodeBlock(t, `{"id": "after", "type": "paragraph", "paragraph": {"rich_text": []}}`)
+	}
+
+	tests := []struct {
+		name        string
+		children    func(t *testing.T) []notion.Block
+		expAppended []appendCall
+		expDeleted  []string
+	}{
+		{
+			name:     "new index",
+			children: childPages,
+			expAppended: []appendCall{
+				{
+					blockID:  "page",
+					children: []notion.Block{heading(2, "Index"), pageLink("p2"), pageLink("p1")},
+				},
+			},
+		},
+		{
+			name: "stale index",
+			children: func(t *testing.T) []notion.Block {
+				return append(childPages(t), indexHeading(t), linkBlock(t, "l1", "p1"), paragraph(t))
+			},
+			expAppended: []appendCall{
+				{
+					blockID:  "page",
+					children: []notion.Block{pageLink("p2"), pageLink("p1")},
+					opts:     &notion.AppendBlockChildrenOpts{After: "h"},
+				},
+			},
+			expDeleted: []string{"l1"},
+		},
+		{
+			name: "up to date index",
+			children: func(t *testing.T) []notion.Block {
+				return append(childPages(t), indexHeading(t), linkBlock(t, "l1", "p2"), linkBlock(t, "l2", "p1"), paragraph(t))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &mockAPI{children: tt.children(t)}
+
+			if err := notionindex.Update(context.Background(), api, "page", notionindex.Options{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expAppended, api.appended, cmp.AllowUnexported(appendCall{}), blockOpts); diff != "" {
+				t.Fatalf("appended blocks not equal (-exp, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tt.expDeleted, api.deleted); diff != "" {
+				t.Fatalf("deleted blocks not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
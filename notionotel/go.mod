@@ -0,0 +1,21 @@
+module github.com/dstotijn/go-notion/notionotel
+
+go 1.19
+
+require (
+	github.com/dstotijn/go-notion v0.0.0
+	github.com/google/go-cmp v0.5.9
+	go.opentelemetry.io/otel v1.16.0
+	go.opentelemetry.io/otel/metric v1.16.0
+	go.opentelemetry.io/otel/sdk v1.16.0
+	go.opentelemetry.io/otel/sdk/metric v0.39.0
+	go.opentelemetry.io/otel/trace v1.16.0
+)
+
+require (
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	golang.org/x/sys v0.8.0 // indirect
+)
+
+replace github.com/dstotijn/go-notion => ../
@@ -0,0 +1,175 @@
+// Package notionotel provides OpenTelemetry instrumentation for requests to the
+// Notion API.
+//
+// Middleware returns a notion.Middleware that creates a client span, and
+// records the duration, of each HTTP request made by the client:
+//
+//	client := notion.NewClient(apiKey, notion.WithMiddleware(notionotel.Middleware()))
+//
+// Spans and metrics have the HTTP method, endpoint (e.g. `/pages/{id}`), status
+// code and request ID as attributes. Object IDs are replaced in endpoints, so
+// they can be used for grouping. Trace context isn't propagated to the API.
+package notionotel
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// instrumentationName is the name of the tracer and meter.
+const instrumentationName = "github.com/dstotijn/go-notion/notionotel"
+
+// Attribute keys, besides the semantic conventions for HTTP clients.
+const (
+	EndpointKey   = attribute.Key("notion.endpoint")
+	RequestIDKey  = attribute.Key("notion.request_id")
+	APIVersionKey = attribute.Key("notion.api_version")
+)
+
+// Option configures Middleware.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the tracer provider. Defaults to the global provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider. Defaults to the global provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = mp
+	}
+}
+
+// Middleware returns a notion.Middleware that creates a span for each HTTP
+// request to the Notion API, and records its duration in the
+// `notion.client.request.duration` histogram (in seconds). As middlewares wrap
+// the transports of other client options, the duration includes time spent
+// waiting for the rate limiter of notion.WithRateLimit.
+//
+// Spans of requests that failed, or got an error response, have an error
+// status. The client doesn't retry requests itself; retries made by callers
+// (see notion.IsRetryable) are separate spans.
+func Middleware(opts ...Option) notion.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram("notion.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP requests to the Notion API."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return notion.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint := endpoint(req.URL.Path)
+			attrs := []attribute.KeyValue{
+				attribute.String("http.request.method", req.Method),
+				attribute.String("server.address", req.URL.Hostname()),
+				EndpointKey.String(endpoint),
+			}
+
+			ctx, span := tracer.Start(req.Context(), req.Method+" "+endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(APIVersionKey.String(req.Header.Get("Notion-Version"))),
+			)
+			defer span.End()
+
+			start := time.Now()
+			res, err := next.RoundTrip(req.WithContext(ctx))
+			elapsed := time.Since(start)
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, attribute.String("error.type", "transport"))
+			} else {
+				attrs = append(attrs, attribute.Int("http.response.status_code", res.StatusCode))
+				span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+				if id := requestID(res.Header); id != "" {
+					span.SetAttributes(RequestIDKey.String(id))
+				}
+				if res.StatusCode >= http.StatusBadRequest {
+					span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+					attrs = append(attrs, attribute.String("error.type", strconv.Itoa(res.StatusCode)))
+				}
+			}
+
+			if duration != nil {
+				duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+			}
+
+			return res, err
+		})
+	}
+}
+
+// endpoint returns the path of a request to the API, without version prefix,
+// and with IDs replaced, e.g. `/pages/{id}/properties/{property_id}`.
+func endpoint(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) > 0 && parts[0] == "v1" {
+		parts = parts[1:]
+	}
+
+	for i, part := range parts {
+		switch {
+		case i > 0 && parts[i-1] == "properties":
+			parts[i] = "{property_id}"
+		case isID(part):
+			parts[i] = "{id}"
+		}
+	}
+
+	return "/" + strings.Join(parts, "/")
+}
+
+// isID returns true for object IDs, which are UUIDs, with or without dashes.
+func isID(s string) bool {
+	s = strings.ReplaceAll(s, "-", "")
+	if len(s) != 32 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// requestID returns the request ID of an API response, if any.
+func requestID(h http.Header) string {
+	if id := h.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	return h.Get("X-Notion-Request-Id")
+}
@@ -0,0 +1,183 @@
+package notionotel_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/dstotijn/go-notion/notionotel"
+	"github.com/google/go-cmp/cmp"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		fn          func(client *notion.Client) error
+		respStatus  int
+		respBody    string
+		respErr     error
+		expSpanName string
+		expAttrs    map[attribute.Key]attribute.Value
+		expStatus   codes.Code
+	}{
+		{
+			name: "successful request",
+			fn: func(client *notion.Client) error {
+				_, err := client.FindPageByID(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136")
+				return err
+			},
+			respStatus:  http.StatusOK,
+			respBody:    `{"object": "page", "id": "668d797c-76fa-4934-9b05-ad288df2d136", "parent": {"type": "workspace", "workspace": true}, "properties": {}}`,
+			expSpanName: "GET /pages/{id}",
+			expAttrs: map[attribute.Key]attribute.Value{
+				"http.request.method":       attribute.StringValue("GET"),
+				"server.address":            attribute.StringValue("api.notion.com"),
+				"http.response.status_code": attribute.IntValue(200),
+				notionotel.EndpointKey:      attribute.StringValue("/pages/{id}"),
+				notionotel.RequestIDKey:     attribute.StringValue("req-1"),
+				notionotel.APIVersionKey:    attribute.StringValue("2022-06-28"),
+			},
+			expStatus: codes.Unset,
+		},
+		{
+			name: "error response",
+			fn: func(client *notion.Client) error {
+				_, err := client.FindPagePropertyByID(context.Background(), "668d797c76fa49349b05ad288df2d136", "a%3Db", nil)
+				return err
+			},
+			respStatus:  http.StatusNotFound,
+			respBody:    `{"object": "error", "status": 404, "code": "object_not_found", "message": "Not found."}`,
+			expSpanName: "GET /pages/{id}/properties/{property_id}",
+			expAttrs: map[attribute.Key]attribute.Value{
+				"http.request.method":       attribute.StringValue("GET"),
+				"server.address":            attribute.StringValue("api.notion.com"),
+				"http.response.status_code": attribute.IntValue(404),
+				notionotel.EndpointKey:      attribute.StringValue("/pages/{id}/properties/{property_id}"),
+				notionotel.RequestIDKey:     attribute.StringValue("req-1"),
+				notionotel.APIVersionKey:    attribute.StringValue("2022-06-28"),
+			},
+			expStatus: codes.Error,
+		},
+		{
+			name: "transport error",
+			fn: func(client *notion.Client) error {
+				_, err := client.Search(context.Background(), nil)
+				return err
+			},
+			respErr:     errors.New("connection refused"),
+			expSpanName: "POST /search",
+			expAttrs: map[attribute.Key]attribute.Value{
+				"http.request.method":    attribute.StringValue("POST"),
+				"server.address":         attribute.StringValue("api.notion.com"),
+				notionotel.EndpointKey:   attribute.StringValue("/search"),
+				notionotel.APIVersionKey: attribute.StringValue("2022-06-28"),
+			},
+			expStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spanRecorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			httpClient := &http.Client{
+				Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					if !trace.SpanContextFromContext(r.Context()).IsValid() {
+						t.Error("expected request context to contain span")
+					}
+					if tt.respErr != nil {
+						return nil, tt.respErr
+					}
+
+					return &http.Response{
+						StatusCode: tt.respStatus,
+						Status:     http.StatusText(tt.respStatus),
+						Header:     http.Header{"Content-Type": []string{"application/json"}, "X-Request-Id": []string{"req-1"}},
+						Body:       io.NopCloser(strings.NewReader(tt.respBody)),
+					}, nil
+				}),
+			}
+			client := notion.NewClient("secret-api-key",
+				notion.WithHTTPClient(httpClient),
+				notion.WithMiddleware(notionotel.Middleware(
+					notionotel.WithTracerProvider(tp),
+					notionotel.WithMeterProvider(mp),
+				)),
+			)
+
+			err := tt.fn(client)
+			if (err != nil) != (tt.expStatus == codes.Error) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			spans := spanRecorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got: %v", len(spans))
+			}
+			span := spans[0]
+
+			if span.Name() != tt.expSpanName {
+				t.Errorf("span name not equal (expected: %q, got: %q)", tt.expSpanName, span.Name())
+			}
+			if span.SpanKind() != trace.SpanKindClient {
+				t.Errorf("span kind not equal (expected: %v, got: %v)", trace.SpanKindClient, span.SpanKind())
+			}
+			if span.Status().Code != tt.expStatus {
+				t.Errorf("span status not equal (expected: %v, got: %v)", tt.expStatus, span.Status().Code)
+			}
+
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, attr := range span.Attributes() {
+				attrs[attr.Key] = attr.Value
+			}
+			if diff := cmp.Diff(tt.expAttrs, attrs, cmp.AllowUnexported(attribute.Value{})); diff != "" {
+				t.Errorf("span attributes not equal (-exp, +got):\n%v", diff)
+			}
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &rm); err != nil {
+				t.Fatal(err)
+			}
+			if len(rm.ScopeMetrics) != 1 || len(rm.ScopeMetrics[0].Metrics) != 1 {
+				t.Fatalf("expected 1 metric, got: %+v", rm.ScopeMetrics)
+			}
+			metric := rm.ScopeMetrics[0].Metrics[0]
+			if exp := "notion.client.request.duration"; metric.Name != exp {
+				t.Errorf("metric name not equal (expected: %q, got: %q)", exp, metric.Name)
+			}
+			histogram, ok := metric.Data.(metricdata.Histogram[float64])
+			if !ok {
+				t.Fatalf("unexpected metric data type: %T", metric.Data)
+			}
+			if len(histogram.DataPoints) != 1 || histogram.DataPoints[0].Count != 1 {
+				t.Errorf("expected 1 recorded duration, got: %+v", histogram.DataPoints)
+			}
+		})
+	}
+}
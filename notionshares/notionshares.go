@@ -0,0 +1,140 @@
+// Package notionshares detects pages and databases that are newly shared with
+// an integration, or that are no longer accessible to it.
+//
+// All objects visible to the integration are listed via search, and recorded
+// in a snapshot. Snapshots can be persisted between runs and compared, so
+// integrations can react when users share new content with the bot:
+//
+//	snapshot, changes, err := notionshares.Sync(ctx, client, prev)
+package notionshares
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// ObjectType is the type of a shared object.
+type ObjectType string
+
+const (
+	ObjectTypePage     ObjectType = "page"
+	ObjectTypeDatabase ObjectType = "database"
+)
+
+// Object is a page or database visible to the integration. Its fields are
+// tagged for JSON, so snapshots can be stored and loaded between runs.
+type Object struct {
+	ID    string     `json:"id"`
+	Type  ObjectType `json:"type"`
+	Title string     `json:"title"`
+	URL   string     `json:"url,omitempty"`
+}
+
+// Searcher searches pages and databases. It's satisfied by *notion.Client.
+type Searcher interface {
+	Search(ctx context.Context, opts *notion.SearchOpts) (notion.SearchResponse, error)
+}
+
+// Snapshot returns all pages and databases visible to the integration, sorted
+// by ID. Archived objects are omitted.
+func Snapshot(ctx context.Context, client Searcher) ([]Object, error) {
+	var objects []Object
+
+	opts := &notion.SearchOpts{PageSize: notion.MaxPageSize}
+
+	for {
+		resp, err := client.Search(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("notionshares: failed to search: %w", err)
+		}
+
+		for _, result := range resp.Results {
+			switch r := result.(type) {
+			case notion.Page:
+				if !r.Archived {
+					objects = append(objects, Object{ID: r.ID, Type: ObjectTypePage, Title: r.TitlePlainText(), URL: r.URL})
+				}
+			case notion.Database:
+				if !r.Archived {
+					objects = append(objects, Object{ID: r.ID, Type: ObjectTypeDatabase, Title: notion.PlainText(r.Title), URL: r.URL})
+				}
+			}
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			break
+		}
+		opts.StartCursor = *resp.NextCursor
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].ID < objects[j].ID
+	})
+
+	return objects, nil
+}
+
+// ChangeType is the type of change of an object between two snapshots.
+type ChangeType string
+
+const (
+	// ChangeTypeShared is used for objects that became visible.
+	ChangeTypeShared ChangeType = "shared"
+	// ChangeTypeRevoked is used for objects that are no longer visible, because
+	// access was revoked, or because they were archived or deleted.
+	ChangeTypeRevoked ChangeType = "revoked"
+)
+
+// Change describes a shared or revoked object. For revoked objects, Object
+// contains the object of the previous snapshot.
+type Change struct {
+	Type   ChangeType `json:"type"`
+	Object Object     `json:"object"`
+}
+
+// Diff returns the changes between a previous and the current snapshot, sorted
+// by object ID. Changes of titles or URLs aren't reported.
+func Diff(prev, curr []Object) []Change {
+	prevIDs := make(map[string]bool, len(prev))
+	for _, o := range prev {
+		prevIDs[o.ID] = true
+	}
+	currIDs := make(map[string]bool, len(curr))
+	for _, o := range curr {
+		currIDs[o.ID] = true
+	}
+
+	var changes []Change
+
+	for _, o := range curr {
+		if !prevIDs[o.ID] {
+			changes = append(changes, Change{Type: ChangeTypeShared, Object: o})
+		}
+	}
+	for _, o := range prev {
+		if !currIDs[o.ID] {
+			changes = append(changes, Change{Type: ChangeTypeRevoked, Object: o})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Object.ID < changes[j].Object.ID
+	})
+
+	return changes
+}
+
+// Sync takes a snapshot and returns it, along with the changes compared to the
+// snapshot of a previous run. Store the returned snapshot to pass it as `prev`
+// on the next run.
+func Sync(ctx context.Context, client Searcher, prev []Object) ([]Object, []Change, error) {
+	snapshot, err := Snapshot(ctx, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return snapshot, Diff(prev, snapshot), nil
+}
@@ -0,0 +1,105 @@
+package notionshares_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/dstotijn/go-notion/notionshares"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockSearcher struct {
+	responses []notion.SearchResponse
+	opts      []notion.SearchOpts
+	err       error
+}
+
+func (s *mockSearcher) Search(_ context.Context, opts *notion.SearchOpts) (notion.SearchResponse, error) {
+	if s.err != nil {
+		return notion.SearchResponse{}, s.err
+	}
+	s.opts = append(s.opts, *opts)
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	searcher := &mockSearcher{
+		responses: []notion.SearchResponse{
+			{
+				Results: notion.SearchResults{
+					notion.Page{
+						ID:  "page-2",
+						URL: "https://www.notion.so/page-2",
+						Properties: notion.PageProperties{
+							Title: notion.PageTitle{Title: []notion.RichText{{PlainText: "Roadmap"}}},
+						},
+					},
+					notion.Page{ID: "page-3", Archived: true, Properties: notion.PageProperties{}},
+				},
+				HasMore:    true,
+				NextCursor: notion.StringPtr("cursor-1"),
+			},
+			{
+				Results: notion.SearchResults{
+					notion.Database{
+						ID:    "db-1",
+						Title: []notion.RichText{{PlainText: "Tasks"}},
+					},
+					notion.Page{
+						ID: "page-4",
+						Properties: notion.DatabasePageProperties{
+							"Name": {Type: notion.DBPropTypeTitle, Title: []notion.RichText{{PlainText: "Task"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	prev := []notionshares.Object{
+		{ID: "db-1", Type: notionshares.ObjectTypeDatabase, Title: "Old title"},
+		{ID: "page-1", Type: notionshares.ObjectTypePage, Title: "Revoked"},
+	}
+
+	snapshot, changes, err := notionshares.Sync(context.Background(), searcher, prev)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expSnapshot := []notionshares.Object{
+		{ID: "db-1", Type: notionshares.ObjectTypeDatabase, Title: "Tasks"},
+		{ID: "page-2", Type: notionshares.ObjectTypePage, Title: "Roadmap", URL: "https://www.notion.so/page-2"},
+		{ID: "page-4", Type: notionshares.ObjectTypePage, Title: "Task"},
+	}
+	if diff := cmp.Diff(expSnapshot, snapshot); diff != "" {
+		t.Fatalf("snapshot not equal (-exp, +got):\n%v", diff)
+	}
+
+	expChanges := []notionshares.Change{
+		{Type: notionshares.ChangeTypeRevoked, Object: prev[1]},
+		{Type: notionshares.ChangeTypeShared, Object: expSnapshot[1]},
+		{Type: notionshares.ChangeTypeShared, Object: expSnapshot[2]},
+	}
+	if diff := cmp.Diff(expChanges, changes); diff != "" {
+		t.Fatalf("changes not equal (-exp, +got):\n%v", diff)
+	}
+
+	if len(searcher.opts) != 2 || searcher.opts[1].StartCursor != "cursor-1" || searcher.opts[0].PageSize != 100 {
+		t.Fatalf("unexpected search options: %+v", searcher.opts)
+	}
+}
+
+func TestSyncError(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := notionshares.Sync(context.Background(), &mockSearcher{err: errors.New("boom")}, nil)
+	if err == nil || err.Error() != "notionshares: failed to search: boom" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
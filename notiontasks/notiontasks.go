@@ -0,0 +1,258 @@
+// Package notiontasks maps pages of a "task" database (a common Notion schema
+// with a title, status, assignees, due date and tags) to a typed Task struct.
+//
+// Property names are configurable, so the package can be used with existing
+// databases:
+//
+//	tasks := notiontasks.New(client, notiontasks.Config{
+//		DatabaseID: "...",
+//		Properties: notiontasks.Properties{Assignees: "Owner"},
+//	})
+//	open, err := tasks.Query(ctx, nil)
+package notiontasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// API is the subset of *notion.Client used for managing tasks.
+type API interface {
+	QueryDatabase(ctx context.Context, id string, query *notion.DatabaseQuery) (notion.DatabaseQueryResponse, error)
+	CreatePage(ctx context.Context, params notion.CreatePageParams) (notion.Page, error)
+	UpdatePage(ctx context.Context, pageID string, params notion.UpdatePageParams) (notion.Page, error)
+}
+
+// Properties are the names of database properties that task fields are mapped
+// to. Empty names are set to the values of DefaultProperties.
+type Properties struct {
+	Title     string
+	Status    string
+	Assignees string
+	Due       string
+	Tags      string
+}
+
+// DefaultProperties are the property names used when not configured.
+var DefaultProperties = Properties{
+	Title:     "Name",
+	Status:    "Status",
+	Assignees: "Assignee",
+	Due:       "Due",
+	Tags:      "Tags",
+}
+
+// Config is used to configure Tasks.
+type Config struct {
+	DatabaseID string
+	Properties Properties
+
+	// StatusType is the type of the status property, either `status` (default)
+	// or `select`.
+	StatusType notion.DatabasePropertyType
+
+	// DoneStatus is the status option of completed tasks. Defaults to `Done`.
+	DoneStatus string
+
+	// Lookup configures how property names are matched when reading pages, e.g.
+	// case insensitively. Optional, see notion.DatabasePageProperties.Lookup.
+	Lookup *notion.LookupOpts
+}
+
+// Task is a page in a task database.
+type Task struct {
+	ID     string
+	URL    string
+	Title  string
+	Status string
+	// Assignees contains user IDs.
+	Assignees []string
+	Due       *notion.Date
+	Tags      []string
+}
+
+// Tasks is used to manage tasks in a database.
+type Tasks struct {
+	api API
+	cfg Config
+}
+
+// New returns a new Tasks.
+func New(api API, cfg Config) *Tasks {
+	defaultStr := func(s *string, def string) {
+		if *s == "" {
+			*s = def
+		}
+	}
+
+	defaultStr(&cfg.Properties.Title, DefaultProperties.Title)
+	defaultStr(&cfg.Properties.Status, DefaultProperties.Status)
+	defaultStr(&cfg.Properties.Assignees, DefaultProperties.Assignees)
+	defaultStr(&cfg.Properties.Due, DefaultProperties.Due)
+	defaultStr(&cfg.Properties.Tags, DefaultProperties.Tags)
+	defaultStr(&cfg.DoneStatus, "Done")
+
+	if cfg.StatusType == "" {
+		cfg.StatusType = notion.DBPropTypeStatus
+	}
+
+	return &Tasks{api: api, cfg: cfg}
+}
+
+// Query returns all tasks matching `filter`, following pagination. A nil
+// filter returns all tasks.
+func (t *Tasks) Query(ctx context.Context, filter *notion.DatabaseQueryFilter) ([]Task, error) {
+	var tasks []Task
+
+	query := &notion.DatabaseQuery{Filter: filter}
+
+	for {
+		resp, err := t.api.QueryDatabase(ctx, t.cfg.DatabaseID, query)
+		if err != nil {
+			return nil, fmt.Errorf("notiontasks: failed to query tasks: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			task, err := t.FromPage(page)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, task)
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return tasks, nil
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+}
+
+// Upsert creates a task when its ID is empty, else it updates the existing
+// task. Only non-empty fields are written, so fields can't be cleared.
+func (t *Tasks) Upsert(ctx context.Context, task Task) (Task, error) {
+	props, err := t.ToProperties(task)
+	if err != nil {
+		return Task{}, err
+	}
+
+	var page notion.Page
+
+	if task.ID == "" {
+		if task.Title == "" {
+			return Task{}, errors.New("notiontasks: title is required when creating a task")
+		}
+		page, err = t.api.CreatePage(ctx, notion.CreatePageParams{
+			ParentType:             notion.ParentTypeDatabase,
+			ParentID:               t.cfg.DatabaseID,
+			DatabasePageProperties: &props,
+		})
+		if err != nil {
+			return Task{}, fmt.Errorf("notiontasks: failed to create task: %w", err)
+		}
+	} else {
+		page, err = t.api.UpdatePage(ctx, task.ID, notion.UpdatePageParams{
+			DatabasePageProperties: props,
+		})
+		if err != nil {
+			return Task{}, fmt.Errorf("notiontasks: failed to update task: %w", err)
+		}
+	}
+
+	return t.FromPage(page)
+}
+
+// Complete sets the status of a task to the configured done status.
+func (t *Tasks) Complete(ctx context.Context, taskID string) (Task, error) {
+	return t.Upsert(ctx, Task{ID: taskID, Status: t.cfg.DoneStatus})
+}
+
+// IsDone returns true if the task has the configured done status.
+func (t *Tasks) IsDone(task Task) bool {
+	return task.Status == t.cfg.DoneStatus
+}
+
+// ToProperties returns database page properties for the non-empty fields of a
+// task.
+func (t *Tasks) ToProperties(task Task) (notion.DatabasePageProperties, error) {
+	names := t.cfg.Properties
+	b := notion.NewPageProps()
+
+	if task.Title != "" {
+		b.Title(names.Title, notion.RichText{Text: &notion.Text{Content: task.Title}})
+	}
+	if task.Status != "" {
+		if t.cfg.StatusType == notion.DBPropTypeSelect {
+			b.Select(names.Status, task.Status)
+		} else {
+			b.Status(names.Status, task.Status)
+		}
+	}
+	if len(task.Assignees) > 0 {
+		b.People(names.Assignees, task.Assignees...)
+	}
+	if task.Due != nil {
+		b.Date(names.Due, *task.Due)
+	}
+	if len(task.Tags) > 0 {
+		b.MultiSelect(names.Tags, task.Tags...)
+	}
+
+	props, err := b.Build()
+	if err != nil {
+		return nil, fmt.Errorf("notiontasks: %w", err)
+	}
+
+	return props, nil
+}
+
+// FromPage returns the task for a database page. Properties that don't exist
+// in the database are left empty.
+func (t *Tasks) FromPage(page notion.Page) (Task, error) {
+	props, ok := page.DatabasePageProperties()
+	if !ok {
+		return Task{}, fmt.Errorf("notiontasks: page %v is not a database page", page.ID)
+	}
+
+	var lookupErr error
+	prop := func(name string) notion.DatabasePageProperty {
+		prop, _, err := props.Lookup(name, t.cfg.Lookup)
+		if err != nil && lookupErr == nil {
+			lookupErr = err
+		}
+		return prop
+	}
+
+	names := t.cfg.Properties
+	task := Task{
+		ID:    page.ID,
+		URL:   page.URL,
+		Title: notion.PlainText(prop(names.Title).Title),
+	}
+
+	status := prop(names.Status)
+	switch {
+	case status.Status != nil:
+		task.Status = status.Status.Name
+	case status.Select != nil:
+		task.Status = status.Select.Name
+	}
+
+	for _, user := range prop(names.Assignees).People {
+		task.Assignees = append(task.Assignees, user.ID)
+	}
+
+	task.Due = prop(names.Due).Date
+
+	for _, option := range prop(names.Tags).MultiSelect {
+		task.Tags = append(task.Tags, option.Name)
+	}
+
+	if lookupErr != nil {
+		return Task{}, fmt.Errorf("notiontasks: failed to read page %v: %w", page.ID, lookupErr)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,189 @@
+package notiontasks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/dstotijn/go-notion/notiontasks"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockAPI struct {
+	queryResponses []notion.DatabaseQueryResponse
+	queries        []notion.DatabaseQuery
+	created        []notion.CreatePageParams
+	updated        map[string]notion.UpdatePageParams
+	page           notion.Page
+}
+
+func (m *mockAPI) QueryDatabase(_ context.Context, _ string, query *notion.DatabaseQuery) (notion.DatabaseQueryResponse, error) {
+	m.queries = append(m.queries, *query)
+	resp := m.queryResponses[0]
+	m.queryResponses = m.queryResponses[1:]
+	return resp, nil
+}
+
+func (m *mockAPI) CreatePage(_ context.Context, params notion.CreatePageParams) (notion.Page, error) {
+	m.created = append(m.created, params)
+	return m.page, nil
+}
+
+func (m *mockAPI) UpdatePage(_ context.Context, pageID string, params notion.UpdatePageParams) (notion.Page, error) {
+	if m.updated == nil {
+		m.updated = map[string]notion.UpdatePageParams{}
+	}
+	m.updated[pageID] = params
+	return m.page, nil
+}
+
+func taskPage(id, title, status string) notion.Page {
+	return notion.Page{
+		ID:     id,
+		Parent: notion.Parent{Type: notion.ParentTypeDatabase, DatabaseID: "db"},
+		Properties: notion.DatabasePageProperties{
+			"Name": {
+				Type:  notion.DBPropTypeTitle,
+				Title: []notion.RichText{{PlainText: title}},
+			},
+			"State": {
+				Type:   notion.DBPropTypeSelect,
+				Select: &notion.SelectOptions{Name: status},
+			},
+			"Assignee": {
+				Type:   notion.DBPropTypePeople,
+				People: []notion.User{{BaseUser: notion.BaseUser{ID: "user-1"}}},
+			},
+			"Tags": {
+				Type:        notion.DBPropTypeMultiSelect,
+				MultiSelect: []notion.SelectOptions{{Name: "urgent"}},
+			},
+		},
+	}
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	api := &mockAPI{
+		queryResponses: []notion.DatabaseQueryResponse{
+			{
+				Results:    []notion.Page{taskPage("task-1", "Foo", "Todo")},
+				HasMore:    true,
+				NextCursor: notion.StringPtr("cursor-1"),
+			},
+			{
+				Results: []notion.Page{taskPage("task-2", "Bar", "Done")},
+			},
+		},
+	}
+
+	tasks := notiontasks.New(api, notiontasks.Config{
+		DatabaseID: "db",
+		Properties: notiontasks.Properties{Status: "State"},
+		StatusType: notion.DBPropTypeSelect,
+	})
+
+	got, err := tasks.Query(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := []notiontasks.Task{
+		{ID: "task-1", Title: "Foo", Status: "Todo", Assignees: []string{"user-1"}, Tags: []string{"urgent"}},
+		{ID: "task-2", Title: "Bar", Status: "Done", Assignees: []string{"user-1"}, Tags: []string{"urgent"}},
+	}
+
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("tasks not equal (-exp, +got):\n%v", diff)
+	}
+	if len(api.queries) != 2 || api.queries[1].StartCursor != "cursor-1" {
+		t.Fatalf("unexpected queries: %+v", api.queries)
+	}
+	if !tasks.IsDone(got[1]) {
+		t.Fatal("expected task to be done")
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	t.Parallel()
+
+	due := notion.Date{Start: notion.NewDateTime(time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC), false)}
+
+	api := &mockAPI{page: taskPage("task-1", "Foo", "Todo")}
+	tasks := notiontasks.New(api, notiontasks.Config{DatabaseID: "db"})
+
+	_, err := tasks.Upsert(context.Background(), notiontasks.Task{
+		Title:     "Foo",
+		Status:    "Todo",
+		Assignees: []string{"user-1"},
+		Due:       &due,
+		Tags:      []string{"urgent"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expProps := notion.DatabasePageProperties{
+		"Name":     {Title: []notion.RichText{{Text: &notion.Text{Content: "Foo"}}}},
+		"Status":   {Status: &notion.SelectOptions{Name: "Todo"}},
+		"Assignee": {People: []notion.User{{BaseUser: notion.BaseUser{ID: "user-1"}}}},
+		"Due":      {Date: &due},
+		"Tags":     {MultiSelect: []notion.SelectOptions{{Name: "urgent"}}},
+	}
+
+	if len(api.created) != 1 {
+		t.Fatalf("expected 1 created page, got %v", len(api.created))
+	}
+	if api.created[0].ParentID != "db" || api.created[0].ParentType != notion.ParentTypeDatabase {
+		t.Fatalf("unexpected parent: %v %v", api.created[0].ParentType, api.created[0].ParentID)
+	}
+	if diff := cmp.Diff(expProps, *api.created[0].DatabasePageProperties, cmp.AllowUnexported(notion.DateTime{})); diff != "" {
+		t.Fatalf("properties not equal (-exp, +got):\n%v", diff)
+	}
+
+	if _, err := tasks.Complete(context.Background(), "task-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expUpdate := notion.UpdatePageParams{
+		DatabasePageProperties: notion.DatabasePageProperties{
+			"Status": {Status: &notion.SelectOptions{Name: "Done"}},
+		},
+	}
+	if diff := cmp.Diff(expUpdate, api.updated["task-1"]); diff != "" {
+		t.Fatalf("update params not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestUpsertWithoutTitle(t *testing.T) {
+	t.Parallel()
+
+	tasks := notiontasks.New(&mockAPI{}, notiontasks.Config{DatabaseID: "db"})
+
+	_, err := tasks.Upsert(context.Background(), notiontasks.Task{Status: "Todo"})
+	if err == nil || err.Error() != "notiontasks: title is required when creating a task" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFromPageLookup(t *testing.T) {
+	t.Parallel()
+
+	tasks := notiontasks.New(&mockAPI{}, notiontasks.Config{
+		DatabaseID: "db",
+		Properties: notiontasks.Properties{Title: "name", Status: " state"},
+		Lookup:     &notion.LookupOpts{IgnoreCase: true, NormalizeSpace: true},
+	})
+
+	got, err := tasks.FromPage(taskPage("task-1", "Foo", "Todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := notiontasks.Task{ID: "task-1", Title: "Foo", Status: "Todo", Assignees: []string{"user-1"}, Tags: []string{"urgent"}}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("task not equal (-exp, +got):\n%v", diff)
+	}
+}
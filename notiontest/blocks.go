@@ -0,0 +1,394 @@
+package notiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// Blocks are stored as JSON objects, like the API returns them, as block types
+// can't be constructed with IDs and timestamps outside of package notion. They
+// are decoded on every read.
+
+// FindBlockByID implements notion.API.
+func (c *Client) FindBlockByID(ctx context.Context, blockID string) (notion.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.blocks[blockID]; !ok {
+		return nil, fmt.Errorf("notion: failed to find block: %w", notFound("block", blockID))
+	}
+
+	block, err := c.decodeBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to find block: %w", err)
+	}
+
+	return block, nil
+}
+
+// FindBlockChildrenByID implements notion.API. Archived blocks are omitted.
+func (c *Client) FindBlockChildrenByID(ctx context.Context, blockID string, query *notion.PaginationQuery) (notion.BlockChildrenResponse, error) {
+	if query == nil {
+		query = &notion.PaginationQuery{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.exists(blockID) {
+		return notion.BlockChildrenResponse{}, fmt.Errorf("notion: failed to find block children: %w", notFound("block", blockID))
+	}
+
+	ids, nextCursor, err := paginate(c.activeChildren(blockID), query.StartCursor, query.PageSize)
+	if err != nil {
+		return notion.BlockChildrenResponse{}, fmt.Errorf("notion: failed to find block children: %w", err)
+	}
+
+	resp := notion.BlockChildrenResponse{
+		Results:    make([]notion.Block, len(ids)),
+		HasMore:    nextCursor != nil,
+		NextCursor: nextCursor,
+	}
+	for i, id := range ids {
+		if resp.Results[i], err = c.decodeBlock(id); err != nil {
+			return notion.BlockChildrenResponse{}, fmt.Errorf("notion: failed to find block children: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// AppendBlockChildren implements notion.API. Nested children are created too.
+// The created (top level) blocks are returned.
+func (c *Client) AppendBlockChildren(ctx context.Context, blockID string, children []notion.Block) (notion.BlockChildrenResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.exists(blockID) {
+		return notion.BlockChildrenResponse{}, fmt.Errorf("notion: failed to append block children: %w", notFound("block", blockID))
+	}
+	if c.isArchived(blockID) {
+		return notion.BlockChildrenResponse{}, fmt.Errorf("notion: failed to append block children: %w", validationError("Can't edit block that is archived. You must unarchive the block before editing."))
+	}
+
+	ids, err := c.appendBlocks(blockID, children)
+	if err != nil {
+		return notion.BlockChildrenResponse{}, fmt.Errorf("notion: failed to append block children: %w", err)
+	}
+
+	resp := notion.BlockChildrenResponse{Results: make([]notion.Block, len(ids))}
+	for i, id := range ids {
+		if resp.Results[i], err = c.decodeBlock(id); err != nil {
+			return notion.BlockChildrenResponse{}, fmt.Errorf("notion: failed to append block children: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// UpdateBlock implements notion.API. The type specific fields of `block` are
+// merged with the existing fields; children are ignored.
+func (c *Client) UpdateBlock(ctx context.Context, blockID string, block notion.Block) (notion.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.blocks[blockID]; !ok {
+		return nil, fmt.Errorf("notion: failed to update block: %w", notFound("block", blockID))
+	}
+	if err := c.updateBlock(blockID, block); err != nil {
+		return nil, fmt.Errorf("notion: failed to update block: %w", err)
+	}
+
+	updated, err := c.decodeBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to update block: %w", err)
+	}
+
+	return updated, nil
+}
+
+// UpdateBlockWithParams implements notion.API. Like with the API, `archived`
+// and `in_trash` both move the block to (or restore it from) the trash.
+func (c *Client) UpdateBlockWithParams(ctx context.Context, blockID string, params notion.UpdateBlockParams) (notion.Block, error) {
+	if err := params.Validate(); err != nil {
+		return nil, invalidParams("block params", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.blocks[blockID]; !ok {
+		return nil, fmt.Errorf("notion: failed to update block: %w", notFound("block", blockID))
+	}
+
+	trash := params.InTrash
+	if trash == nil {
+		trash = params.Archived
+	}
+
+	// A block is restored before, and archived after its fields are updated,
+	// as archived blocks can't be edited.
+	if trash != nil && !*trash {
+		c.setArchived(blockID, false)
+	}
+	if params.Block != nil {
+		if err := c.updateBlock(blockID, params.Block); err != nil {
+			return nil, fmt.Errorf("notion: failed to update block: %w", err)
+		}
+	}
+	if trash != nil && *trash {
+		c.setArchived(blockID, true)
+	}
+
+	updated, err := c.decodeBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to update block: %w", err)
+	}
+
+	return updated, nil
+}
+
+// updateBlock merges the type specific fields of `block` with the fields of the
+// existing block with ID `blockID`.
+func (c *Client) updateBlock(blockID string, block notion.Block) error {
+	obj := c.blocks[blockID]
+	if c.isArchived(blockID) {
+		return validationError("Can't edit block that is archived. You must unarchive the block before editing.")
+	}
+
+	b, err := json.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("notiontest: failed to encode block: %w", err)
+	}
+	blockType, data, err := splitBlock(b)
+	if err != nil {
+		return err
+	}
+	delete(data, "children")
+
+	var storedType string
+	if err := json.Unmarshal(obj["type"], &storedType); err != nil {
+		return err
+	}
+	if blockType != storedType {
+		return validationError("Block type %v can't be updated to %v.", storedType, blockType)
+	}
+
+	var stored map[string]json.RawMessage
+	if err := json.Unmarshal(obj[storedType], &stored); err != nil {
+		return err
+	}
+	for key, value := range data {
+		stored[key] = value
+	}
+
+	obj[storedType] = mustMarshal(stored)
+	obj["last_edited_time"] = mustMarshal(c.now())
+	obj["last_edited_by"] = mustMarshal(c.userRef())
+
+	return nil
+}
+
+// DeleteBlock implements notion.API. The block is archived; for `child_page`
+// and `child_database` blocks, the page or database is archived too.
+func (c *Client) DeleteBlock(ctx context.Context, blockID string) (notion.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.blocks[blockID]; !ok {
+		return nil, fmt.Errorf("notion: failed to delete block: %w", notFound("block", blockID))
+	}
+
+	c.setArchived(blockID, true)
+
+	if page, ok := c.pages[blockID]; ok {
+		page.Archived, page.InTrash = true, true
+		page.LastEditedTime = c.now()
+		c.pages[blockID] = page
+	}
+	if db, ok := c.databases[blockID]; ok {
+		db.Archived = true
+		db.LastEditedTime = c.now()
+		c.databases[blockID] = db
+	}
+
+	block, err := c.decodeBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to delete block: %w", err)
+	}
+
+	return block, nil
+}
+
+// appendBlocks creates blocks (and their nested children) as children of the
+// page or block with ID `parentID`, and returns their IDs.
+func (c *Client) appendBlocks(parentID string, blocks []notion.Block) ([]string, error) {
+	raw := make([]json.RawMessage, len(blocks))
+	for i, block := range blocks {
+		b, err := json.Marshal(block)
+		if err != nil {
+			return nil, fmt.Errorf("notiontest: failed to encode block: %w", err)
+		}
+		raw[i] = b
+	}
+
+	return c.appendRawBlocks(parentID, raw)
+}
+
+func (c *Client) appendRawBlocks(parentID string, blocks []json.RawMessage) ([]string, error) {
+	parent := notion.Parent{Type: notion.ParentTypeBlock, BlockID: parentID}
+	if _, ok := c.pages[parentID]; ok {
+		parent = notion.Parent{Type: notion.ParentTypePage, PageID: parentID}
+	}
+
+	ids := make([]string, 0, len(blocks))
+
+	for _, raw := range blocks {
+		blockType, data, err := splitBlock(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		var children []json.RawMessage
+		if data["children"] != nil {
+			if err := json.Unmarshal(data["children"], &children); err != nil {
+				return nil, fmt.Errorf("notiontest: failed to decode block children: %w", err)
+			}
+			delete(data, "children")
+		}
+
+		id := c.newBlockObject(c.newID(), parent, notion.BlockType(blockType), data)
+		c.addChild(parentID, id)
+		ids = append(ids, id)
+
+		if len(children) > 0 {
+			if _, err := c.appendRawBlocks(id, children); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// splitBlock returns the type of a block (encoded as JSON by its MarshalJSON
+// method), and its type specific fields.
+func splitBlock(b json.RawMessage) (string, map[string]json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return "", nil, fmt.Errorf("notiontest: failed to decode block: %w", err)
+	}
+	delete(obj, "type")
+	delete(obj, "object")
+	if len(obj) != 1 {
+		return "", nil, validationError("Block should have exactly one type.")
+	}
+
+	var (
+		blockType string
+		data      map[string]json.RawMessage
+	)
+	for key, value := range obj {
+		blockType = key
+		if err := json.Unmarshal(value, &data); err != nil {
+			return "", nil, fmt.Errorf("notiontest: failed to decode block: %w", err)
+		}
+	}
+	if data == nil {
+		data = make(map[string]json.RawMessage)
+	}
+
+	return blockType, data, nil
+}
+
+// newBlockObject stores a block object, and returns its ID.
+func (c *Client) newBlockObject(id string, parent notion.Parent, blockType notion.BlockType, data interface{}) string {
+	now := c.now()
+
+	c.blocks[id] = map[string]json.RawMessage{
+		"object":           mustMarshal("block"),
+		"id":               mustMarshal(id),
+		"parent":           mustMarshal(parent),
+		"type":             mustMarshal(blockType),
+		string(blockType):  mustMarshal(data),
+		"created_time":     mustMarshal(now),
+		"created_by":       mustMarshal(c.userRef()),
+		"last_edited_time": mustMarshal(now),
+		"last_edited_by":   mustMarshal(c.userRef()),
+		"archived":         mustMarshal(false),
+		"in_trash":         mustMarshal(false),
+	}
+
+	return id
+}
+
+func (c *Client) addChild(parentID, id string) {
+	c.children[parentID] = append(c.children[parentID], id)
+}
+
+// activeChildren returns the IDs of the children of a block that aren't
+// archived.
+func (c *Client) activeChildren(id string) []string {
+	var ids []string
+	for _, childID := range c.children[id] {
+		if !c.isArchived(childID) {
+			ids = append(ids, childID)
+		}
+	}
+	return ids
+}
+
+func (c *Client) isArchived(id string) bool {
+	if page, ok := c.pages[id]; ok {
+		return page.Archived
+	}
+
+	var archived bool
+	if obj, ok := c.blocks[id]; ok {
+		_ = json.Unmarshal(obj["archived"], &archived)
+	}
+	return archived
+}
+
+// setArchived archives or restores a block, if it exists.
+func (c *Client) setArchived(id string, archived bool) {
+	obj, ok := c.blocks[id]
+	if !ok {
+		return
+	}
+
+	obj["archived"] = mustMarshal(archived)
+	obj["in_trash"] = mustMarshal(archived)
+	obj["last_edited_time"] = mustMarshal(c.now())
+}
+
+// decodeBlock returns the block with ID `id`.
+func (c *Client) decodeBlock(id string) (notion.Block, error) {
+	obj := make(map[string]json.RawMessage, len(c.blocks[id])+1)
+	for key, value := range c.blocks[id] {
+		obj[key] = value
+	}
+	obj["has_children"] = mustMarshal(len(c.activeChildren(id)) > 0)
+
+	var resp notion.BlockChildrenResponse
+	if err := json.Unmarshal(mustMarshal(map[string]interface{}{"results": []interface{}{obj}}), &resp); err != nil {
+		return nil, fmt.Errorf("notiontest: failed to decode block: %w", err)
+	}
+
+	return resp.Results[0], nil
+}
+
+func (c *Client) userRef() map[string]string {
+	return map[string]string{"object": "user", "id": c.bot.ID}
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: failed to encode JSON: %v", err))
+	}
+	return b
+}
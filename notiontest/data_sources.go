@@ -0,0 +1,103 @@
+package notiontest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// The fake models each database as a database with a single data source, which
+// has the same ID as the database.
+
+// FindDataSourceByID implements notion.API.
+func (c *Client) FindDataSourceByID(ctx context.Context, id string) (notion.DataSource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, ok := c.databases[id]
+	if !ok {
+		return notion.DataSource{}, fmt.Errorf("notion: failed to find data source: %w", notFound("data source", id))
+	}
+
+	return dataSource(clone(db)), nil
+}
+
+// QueryDataSource implements notion.API, like QueryDatabase.
+func (c *Client) QueryDataSource(ctx context.Context, id string, query *notion.DatabaseQuery) (notion.DatabaseQueryResponse, error) {
+	resp, err := c.QueryDatabase(ctx, id, query)
+	if err != nil {
+		return notion.DatabaseQueryResponse{}, dataSourceError("query", err)
+	}
+
+	return resp, nil
+}
+
+// CreateDataSource implements notion.API. The fake doesn't support databases
+// with multiple data sources, so it always returns a validation error.
+func (c *Client) CreateDataSource(ctx context.Context, params notion.CreateDataSourceParams) (notion.DataSource, error) {
+	if err := params.Validate(); err != nil {
+		return notion.DataSource{}, invalidParams("data source params", err)
+	}
+
+	return notion.DataSource{}, fmt.Errorf("notion: failed to create data source: %w",
+		validationError("Databases with multiple data sources are not supported by notiontest."))
+}
+
+// UpdateDataSource implements notion.API, like UpdateDatabase.
+func (c *Client) UpdateDataSource(ctx context.Context, dataSourceID string, params notion.UpdateDataSourceParams) (notion.DataSource, error) {
+	if err := params.Validate(); err != nil {
+		return notion.DataSource{}, invalidParams("data source params", err)
+	}
+
+	archived := params.Archived
+	if params.InTrash != nil {
+		archived = params.InTrash
+	}
+
+	db, err := c.UpdateDatabase(ctx, dataSourceID, notion.UpdateDatabaseParams{
+		Title:      params.Title,
+		Properties: params.Properties,
+		Icon:       params.Icon,
+		Archived:   archived,
+	})
+	if err != nil {
+		return notion.DataSource{}, dataSourceError("update", err)
+	}
+
+	return dataSource(db), nil
+}
+
+// dataSource returns the data source of a database.
+func dataSource(db notion.Database) notion.DataSource {
+	parent := db.Parent
+
+	return notion.DataSource{
+		ID:             db.ID,
+		CreatedTime:    db.CreatedTime,
+		CreatedBy:      db.CreatedBy,
+		LastEditedTime: db.LastEditedTime,
+		LastEditedBy:   db.LastEditedBy,
+		Title:          db.Title,
+		Description:    db.Description,
+		Properties:     db.Properties,
+		Icon:           db.Icon,
+		Archived:       db.Archived,
+		InTrash:        db.Archived,
+		URL:            db.URL,
+		Parent:         notion.Parent{Type: notion.ParentTypeDatabase, DatabaseID: db.ID},
+		DatabaseParent: &parent,
+	}
+}
+
+// dataSourceError rewraps an error returned for a database operation, so that
+// it refers to the data source.
+func dataSourceError(op string, err error) error {
+	var apiErr *notion.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	return fmt.Errorf("notion: failed to %v data source: %w", op, apiErr)
+}
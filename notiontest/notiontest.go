@@ -0,0 +1,1265 @@
+// Package notiontest provides an in-memory fake of the Notion API, for unit
+// testing code that depends on notion.API, without mocking HTTP requests.
+//
+// The fake stores pages, databases, blocks, users, comments and file uploads
+// in maps, and mimics the API for common use: IDs and timestamps are generated,
+// parents must exist, archived objects are omitted from queries, search results
+// and block children, and errors are *notion.APIError values, so errors.Is
+// works with notion.ErrObjectNotFound and notion.ErrValidation.
+//
+// Computed values (formulas and rollups) aren't computed. Filter conditions and
+// sorts that the fake doesn't support return an error, instead of being ignored.
+//
+// Example:
+//
+//	fake := notiontest.NewClient()
+//	root := fake.AddPage(notion.Page{Properties: notion.PageProperties{
+//		Title: notion.PageTitle{Title: []notion.RichText{notion.NewRichText("Root")}},
+//	}})
+//	db, err := fake.CreateDatabase(ctx, notion.CreateDatabaseParams{
+//		ParentPageID: root.ID,
+//		// ...
+//	})
+//
+// For integration tests against the real API, Recorder records responses to
+// golden files and replays them, e.g. in CI.
+package notiontest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// Client is an in-memory fake of the Notion API. It's safe for concurrent use.
+// Use NewClient to create one.
+type Client struct {
+	// Now returns the time used for timestamps. Defaults to time.Now.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	nextID int
+	bot    notion.User
+
+	pages     map[string]notion.Page
+	databases map[string]notion.Database
+	blocks    map[string]map[string]json.RawMessage
+	children  map[string][]string
+	users     map[string]notion.User
+	comments  map[string]notion.Comment
+	uploads   map[string]notion.FileUpload
+
+	// Objects in creation order, for stable results.
+	pageIDs     []string
+	databaseIDs []string
+	userIDs     []string
+	commentIDs  []string
+}
+
+var _ notion.API = (*Client)(nil)
+
+// NewClient returns a new, empty fake. Its current user is a bot user, used for
+// the `created_by` and `last_edited_by` fields of objects.
+func NewClient() *Client {
+	c := &Client{
+		Now:       time.Now,
+		pages:     make(map[string]notion.Page),
+		databases: make(map[string]notion.Database),
+		blocks:    make(map[string]map[string]json.RawMessage),
+		children:  make(map[string][]string),
+		users:     make(map[string]notion.User),
+		comments:  make(map[string]notion.Comment),
+		uploads:   make(map[string]notion.FileUpload),
+	}
+
+	c.bot = c.AddUser(notion.User{
+		Type: notion.UserTypeBot,
+		Name: "Test Integration",
+		Bot:  &notion.Bot{WorkspaceName: "Test Workspace"},
+	})
+
+	return c
+}
+
+// AddPage stores a page as-is, e.g. a page with a workspace parent, which can't
+// be created via the API, to be used as parent of other objects. The ID, parent
+// (workspace), timestamps and URL are set when empty. The stored page is
+// returned.
+func (c *Client) AddPage(page notion.Page) notion.Page {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if page.ID == "" {
+		page.ID = c.newID()
+	}
+	if page.Parent.Type == "" {
+		page.Parent = notion.Parent{Type: notion.ParentTypeWorkspace, Workspace: true}
+	}
+	if page.CreatedTime.IsZero() {
+		page.CreatedTime = now
+	}
+	if page.LastEditedTime.IsZero() {
+		page.LastEditedTime = now
+	}
+	if page.URL == "" {
+		page.URL = objectURL(page.ID)
+	}
+	if page.Properties == nil {
+		page.Properties = notion.PageProperties{}
+	}
+
+	c.storePage(page)
+
+	return clone(page)
+}
+
+// AddUser stores a user, to be returned by FindUserByID and ListUsers. The ID
+// is set when empty. The stored user is returned.
+func (c *Client) AddUser(user notion.User) notion.User {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = c.newID()
+	}
+	if user.Type == "" {
+		user.Type = notion.UserTypePerson
+	}
+	if _, ok := c.users[user.ID]; !ok {
+		c.userIDs = append(c.userIDs, user.ID)
+	}
+	c.users[user.ID] = user
+
+	return clone(user)
+}
+
+// FindDatabaseByID implements notion.API.
+func (c *Client) FindDatabaseByID(ctx context.Context, id string) (notion.Database, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, ok := c.databases[id]
+	if !ok {
+		return notion.Database{}, fmt.Errorf("notion: failed to find database: %w", notFound("database", id))
+	}
+
+	return clone(db), nil
+}
+
+// CreateDatabase implements notion.API.
+func (c *Client) CreateDatabase(ctx context.Context, params notion.CreateDatabaseParams) (notion.Database, error) {
+	if err := params.Validate(); err != nil {
+		return notion.Database{}, invalidParams("database params", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.pages[params.ParentPageID]; !ok {
+		return notion.Database{}, fmt.Errorf("notion: failed to create database: %w", notFound("page", params.ParentPageID))
+	}
+
+	now := c.now()
+	db := notion.Database{
+		ID:             c.newID(),
+		CreatedTime:    now,
+		CreatedBy:      c.bot.BaseUser,
+		LastEditedTime: now,
+		LastEditedBy:   c.bot.BaseUser,
+		Title:          richText(params.Title),
+		Description:    richText(params.Description),
+		Properties:     make(notion.DatabaseProperties),
+		Parent:         notion.Parent{Type: notion.ParentTypePage, PageID: params.ParentPageID},
+		Icon:           params.Icon,
+		Cover:          params.Cover,
+		IsInline:       params.IsInline,
+	}
+	db.URL = objectURL(db.ID)
+
+	for name, prop := range params.Properties {
+		prop := prop
+		if err := c.setDatabaseProperty(&db, name, &prop); err != nil {
+			return notion.Database{}, fmt.Errorf("notion: failed to create database: %w", err)
+		}
+	}
+
+	c.databases[db.ID] = db
+	c.databaseIDs = append(c.databaseIDs, db.ID)
+	c.addChild(params.ParentPageID, c.newBlockObject(db.ID, db.Parent, notion.BlockTypeChildDatabase, map[string]interface{}{
+		"title": notion.PlainText(db.Title),
+	}))
+
+	return clone(db), nil
+}
+
+// UpdateDatabase implements notion.API. Properties set to nil are removed, and
+// properties with a Name are renamed, also on the pages of the database.
+func (c *Client) UpdateDatabase(ctx context.Context, databaseID string, params notion.UpdateDatabaseParams) (notion.Database, error) {
+	if err := params.Validate(); err != nil {
+		return notion.Database{}, invalidParams("database params", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	db, ok := c.databases[databaseID]
+	if !ok {
+		return notion.Database{}, fmt.Errorf("notion: failed to update database: %w", notFound("database", databaseID))
+	}
+
+	if params.Title != nil {
+		db.Title = richText(params.Title)
+	}
+	if params.Description != nil {
+		db.Description = richText(params.Description)
+	}
+	if params.Icon != nil {
+		db.Icon = params.Icon
+	}
+	if params.Cover != nil {
+		db.Cover = params.Cover
+	}
+	if params.Archived != nil {
+		db.Archived = *params.Archived
+	}
+	if params.IsInline != nil {
+		db.IsInline = *params.IsInline
+	}
+
+	db.Properties = clone(db.Properties)
+
+	for key, prop := range params.Properties {
+		name, existing, ok := findDatabaseProperty(db.Properties, key)
+
+		switch {
+		case prop == nil && !ok:
+			return notion.Database{}, fmt.Errorf("notion: failed to update database: %w", validationError("%v is not a property that exists.", key))
+		case prop == nil:
+			delete(db.Properties, name)
+			c.updateDatabasePages(db.ID, func(props notion.DatabasePageProperties) {
+				delete(props, name)
+			})
+		case ok:
+			newName := name
+			if prop.Name != "" && prop.Name != name {
+				newName = prop.Name
+			}
+			updated := existing
+			if prop.Type != "" || hasMetadata(*prop) {
+				updated = *prop
+				updated.ID = existing.ID
+			}
+			delete(db.Properties, name)
+			if err := c.setDatabaseProperty(&db, newName, &updated); err != nil {
+				return notion.Database{}, fmt.Errorf("notion: failed to update database: %w", err)
+			}
+			if newName != name {
+				c.updateDatabasePages(db.ID, func(props notion.DatabasePageProperties) {
+					if value, ok := props[name]; ok {
+						delete(props, name)
+						props[newName] = value
+					}
+				})
+			}
+		default:
+			newProp := *prop
+			newProp.ID = ""
+			if err := c.setDatabaseProperty(&db, key, &newProp); err != nil {
+				return notion.Database{}, fmt.Errorf("notion: failed to update database: %w", err)
+			}
+		}
+	}
+
+	db.LastEditedTime = c.now()
+	db.LastEditedBy = c.bot.BaseUser
+	c.databases[db.ID] = db
+
+	return clone(db), nil
+}
+
+// setDatabaseProperty adds a property to a database, with a generated ID.
+func (c *Client) setDatabaseProperty(db *notion.Database, name string, prop *notion.DatabaseProperty) error {
+	if prop.Type == "" {
+		prop.Type = metadataType(*prop)
+	}
+	if prop.Type == "" {
+		return validationError("Property %v is missing a type.", name)
+	}
+	if prop.ID == "" {
+		c.nextID++
+		prop.ID = "prop-" + strconv.Itoa(c.nextID)
+		if prop.Type == notion.DBPropTypeTitle {
+			prop.ID = "title"
+		}
+	}
+	prop.Name = name
+
+	switch {
+	case prop.Select != nil:
+		prop.Select = &notion.SelectMetadata{Options: c.selectOptions(prop.Select.Options)}
+	case prop.MultiSelect != nil:
+		prop.MultiSelect = &notion.SelectMetadata{Options: c.selectOptions(prop.MultiSelect.Options)}
+	}
+
+	db.Properties[name] = *prop
+
+	return nil
+}
+
+func (c *Client) selectOptions(options []notion.SelectOptions) []notion.SelectOptions {
+	result := make([]notion.SelectOptions, len(options))
+	for i, option := range options {
+		if option.ID == "" {
+			option.ID = c.newID()
+		}
+		if option.Color == "" {
+			option.Color = notion.ColorDefault
+		}
+		result[i] = option
+	}
+	return result
+}
+
+// updateDatabasePages calls `fn` with the properties of each page of a
+// database, to update them.
+func (c *Client) updateDatabasePages(databaseID string, fn func(notion.DatabasePageProperties)) {
+	for id, page := range c.pages {
+		if page.Parent.DatabaseID != databaseID {
+			continue
+		}
+		props := clone(page.Properties.(notion.DatabasePageProperties))
+		fn(props)
+		page.Properties = props
+		c.pages[id] = page
+	}
+}
+
+// QueryDatabase implements notion.API. Pages are returned in creation order,
+// unless sorted.
+func (c *Client) QueryDatabase(ctx context.Context, id string, query *notion.DatabaseQuery) (notion.DatabaseQueryResponse, error) {
+	if query == nil {
+		query = &notion.DatabaseQuery{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.databases[id]; !ok {
+		return notion.DatabaseQueryResponse{}, fmt.Errorf("notion: failed to query database: %w", notFound("database", id))
+	}
+
+	var pages []notion.Page
+	for _, pageID := range c.pageIDs {
+		page := c.pages[pageID]
+		if page.Parent.DatabaseID != id || page.Archived {
+			continue
+		}
+		if query.Filter != nil {
+			ok, err := matchFilter(page, *query.Filter)
+			if err != nil {
+				return notion.DatabaseQueryResponse{}, fmt.Errorf("notion: failed to query database: %w", err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		pages = append(pages, page)
+	}
+
+	if err := sortPages(pages, query.Sorts); err != nil {
+		return notion.DatabaseQueryResponse{}, fmt.Errorf("notion: failed to query database: %w", err)
+	}
+
+	results, nextCursor, err := paginate(pages, query.StartCursor, query.PageSize)
+	if err != nil {
+		return notion.DatabaseQueryResponse{}, fmt.Errorf("notion: failed to query database: %w", err)
+	}
+
+	resp := notion.DatabaseQueryResponse{
+		Results:    make([]notion.Page, len(results)),
+		HasMore:    nextCursor != nil,
+		NextCursor: nextCursor,
+	}
+	for i, page := range results {
+		resp.Results[i] = filterProperties(clone(page), query.FilterProperties)
+	}
+
+	return resp, nil
+}
+
+// FindPageByID implements notion.API.
+func (c *Client) FindPageByID(ctx context.Context, id string) (notion.Page, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	page, ok := c.pages[id]
+	if !ok {
+		return notion.Page{}, fmt.Errorf("notion: failed to find page: %w", notFound("page", id))
+	}
+
+	return clone(page), nil
+}
+
+// FindPageByIDWithOpts implements notion.API. Properties are filtered by ID (or
+// name).
+func (c *Client) FindPageByIDWithOpts(ctx context.Context, id string, opts *notion.FindPageByIDOpts) (notion.Page, error) {
+	page, err := c.FindPageByID(ctx, id)
+	if err != nil || opts == nil {
+		return page, err
+	}
+
+	return filterProperties(page, opts.FilterProperties), nil
+}
+
+// CreatePage implements notion.API. For pages in a database, missing options of
+// `select` and `multi_select` properties are added to the database.
+func (c *Client) CreatePage(ctx context.Context, params notion.CreatePageParams) (notion.Page, error) {
+	if err := params.Validate(); err != nil {
+		return notion.Page{}, invalidParams("page params", err)
+	}
+	if params.Parent != (notion.Parent{}) {
+		params.ParentType, params.ParentID = params.Parent.Type, params.Parent.ID()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	page := notion.Page{
+		ID:             c.newID(),
+		CreatedTime:    now,
+		CreatedBy:      &notion.BaseUser{ID: c.bot.ID},
+		LastEditedTime: now,
+		LastEditedBy:   &notion.BaseUser{ID: c.bot.ID},
+		Icon:           params.Icon,
+		Cover:          params.Cover,
+	}
+	page.URL = objectURL(page.ID)
+
+	switch params.ParentType {
+	case notion.ParentTypeDatabase, notion.ParentTypeDataSource:
+		db, ok := c.databases[params.ParentID]
+		if !ok {
+			return notion.Page{}, fmt.Errorf("notion: failed to create page: %w", notFound("database", params.ParentID))
+		}
+		page.Parent = notion.Parent{Type: notion.ParentTypeDatabase, DatabaseID: db.ID}
+		if params.ParentType == notion.ParentTypeDataSource {
+			page.Parent = notion.Parent{Type: notion.ParentTypeDataSource, DataSourceID: db.ID, DatabaseID: db.ID}
+		}
+
+		props := make(notion.DatabasePageProperties, len(db.Properties))
+		for name, prop := range db.Properties {
+			props[name] = notion.DatabasePageProperty{ID: prop.ID, Type: prop.Type}
+		}
+		page.Properties = props
+
+		if err := c.setPageProperties(&page, *params.DatabasePageProperties); err != nil {
+			return notion.Page{}, fmt.Errorf("notion: failed to create page: %w", err)
+		}
+	case notion.ParentTypePage, notion.ParentTypeBlock:
+		if !c.exists(params.ParentID) {
+			return notion.Page{}, fmt.Errorf("notion: failed to create page: %w", notFound("block", params.ParentID))
+		}
+		page.Parent = notion.Parent{Type: params.ParentType}
+		if params.ParentType == notion.ParentTypePage {
+			page.Parent.PageID = params.ParentID
+		} else {
+			page.Parent.BlockID = params.ParentID
+		}
+		page.Properties = notion.PageProperties{Title: notion.PageTitle{Title: richText(params.Title)}}
+
+		c.addChild(params.ParentID, c.newBlockObject(page.ID, page.Parent, notion.BlockTypeChildPage, map[string]interface{}{
+			"title": notion.PlainText(richText(params.Title)),
+		}))
+	case notion.ParentTypeWorkspace:
+		page.Parent = notion.WorkspaceParent()
+		page.Properties = notion.PageProperties{Title: notion.PageTitle{Title: richText(params.Title)}}
+	}
+
+	c.storePage(page)
+
+	if len(params.Children) > 0 {
+		if _, err := c.appendBlocks(page.ID, params.Children); err != nil {
+			return notion.Page{}, fmt.Errorf("notion: failed to create page: %w", err)
+		}
+	}
+
+	return clone(page), nil
+}
+
+// UpdatePage implements notion.API.
+func (c *Client) UpdatePage(ctx context.Context, pageID string, params notion.UpdatePageParams) (notion.Page, error) {
+	if err := params.Validate(); err != nil {
+		return notion.Page{}, invalidParams("page params", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	page, ok := c.pages[pageID]
+	if !ok {
+		return notion.Page{}, fmt.Errorf("notion: failed to update page properties: %w", notFound("page", pageID))
+	}
+
+	// The API treats `archived` as an alias of `in_trash`.
+	var archived *bool
+	switch {
+	case params.InTrash != nil:
+		archived = params.InTrash
+	case params.Archived != nil:
+		archived = params.Archived
+	}
+	if page.Archived && (archived == nil || *archived) {
+		return notion.Page{}, fmt.Errorf("notion: failed to update page properties: %w", validationError("Can't edit block that is archived. You must unarchive the block before editing."))
+	}
+
+	page.LastEditedTime = c.now()
+	page.LastEditedBy = &notion.BaseUser{ID: c.bot.ID}
+
+	if params.DatabasePageProperties != nil {
+		if err := c.setPageProperties(&page, params.DatabasePageProperties); err != nil {
+			return notion.Page{}, fmt.Errorf("notion: failed to update page properties: %w", err)
+		}
+	}
+	if archived != nil {
+		page.Archived, page.InTrash = *archived, *archived
+		c.setArchived(page.ID, *archived)
+	}
+	if params.Icon != nil {
+		page.Icon = params.Icon
+	}
+	if params.Cover != nil {
+		page.Cover = params.Cover
+	}
+
+	c.storePage(page)
+
+	return clone(page), nil
+}
+
+// setPageProperties sets property values of a page, by property name or ID.
+func (c *Client) setPageProperties(page *notion.Page, values notion.DatabasePageProperties) error {
+	if pageProps, ok := page.Properties.(notion.PageProperties); ok {
+		for key, value := range values {
+			if key != "title" {
+				return validationError("%v is not a property that exists.", key)
+			}
+			pageProps.Title.Title = richText(value.Title)
+		}
+		page.Properties = pageProps
+		return nil
+	}
+
+	db := c.databases[page.Parent.DatabaseID]
+	props := clone(page.Properties.(notion.DatabasePageProperties))
+
+	for key, value := range values {
+		name, schema, ok := findDatabaseProperty(db.Properties, key)
+		if !ok {
+			return validationError("%v is not a property that exists.", key)
+		}
+		if value.Type != "" && value.Type != schema.Type {
+			return validationError("%v is expected to be %v.", name, schema.Type)
+		}
+
+		if value.Clear {
+			value = notion.DatabasePageProperty{}
+		}
+		value.ID, value.Type, value.Name, value.Clear = schema.ID, schema.Type, "", false
+		value.Title = richText(value.Title)
+		value.RichText = richText(value.RichText)
+
+		switch schema.Type {
+		case notion.DBPropTypeSelect:
+			if value.Select != nil {
+				option := c.selectOption(&db, name, *value.Select)
+				value.Select = &option
+			}
+		case notion.DBPropTypeMultiSelect:
+			for i, option := range value.MultiSelect {
+				value.MultiSelect[i] = c.selectOption(&db, name, option)
+			}
+		case notion.DBPropTypeStatus:
+			if value.Status != nil && schema.Status != nil {
+				for _, option := range schema.Status.Options {
+					if option.Name == value.Status.Name {
+						option := option
+						value.Status = &option
+						break
+					}
+				}
+			}
+		}
+
+		props[name] = value
+	}
+
+	for name, prop := range db.Properties {
+		value := props[name]
+		switch prop.Type {
+		case notion.DBPropTypeCreatedTime:
+			value.CreatedTime = &page.CreatedTime
+		case notion.DBPropTypeLastEditedTime:
+			value.LastEditedTime = &page.LastEditedTime
+		case notion.DBPropTypeCreatedBy:
+			value.CreatedBy = &notion.User{BaseUser: *page.CreatedBy}
+		case notion.DBPropTypeLastEditedBy:
+			value.LastEditedBy = &notion.User{BaseUser: *page.LastEditedBy}
+		default:
+			continue
+		}
+		props[name] = value
+	}
+
+	page.Properties = props
+	c.databases[db.ID] = db
+
+	return nil
+}
+
+// selectOption returns the option of a `select` or `multi_select` database
+// property with the name of `option`, which is added to the database if it
+// doesn't exist, like the API does.
+func (c *Client) selectOption(db *notion.Database, name string, option notion.SelectOptions) notion.SelectOptions {
+	prop := db.Properties[name]
+	meta := prop.Select
+	if prop.Type == notion.DBPropTypeMultiSelect {
+		meta = prop.MultiSelect
+	}
+	if meta == nil {
+		meta = &notion.SelectMetadata{}
+	}
+
+	for _, existing := range meta.Options {
+		if existing.Name == option.Name || (option.ID != "" && existing.ID == option.ID) {
+			return existing
+		}
+	}
+
+	added := c.selectOptions([]notion.SelectOptions{option})[0]
+	meta = &notion.SelectMetadata{Options: append(append([]notion.SelectOptions(nil), meta.Options...), added)}
+	if prop.Type == notion.DBPropTypeMultiSelect {
+		prop.MultiSelect = meta
+	} else {
+		prop.Select = meta
+	}
+	db.Properties = clone(db.Properties)
+	db.Properties[name] = prop
+
+	return added
+}
+
+// FindPagePropertyByID implements notion.API. Properties are found by ID (or
+// name). Values of `title`, `rich_text`, `relation` and `people` properties
+// are returned as paginated lists of items.
+func (c *Client) FindPagePropertyByID(ctx context.Context, pageID, propID string, query *notion.PaginationQuery) (notion.PagePropResponse, error) {
+	if query == nil {
+		query = &notion.PaginationQuery{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	page, ok := c.pages[pageID]
+	if !ok {
+		return notion.PagePropResponse{}, fmt.Errorf("notion: failed to find page property: %w", notFound("page", pageID))
+	}
+
+	var prop notion.DatabasePageProperty
+	for name, p := range page.AllProperties() {
+		if p.ID == propID || name == propID {
+			prop, ok = p, true
+		}
+	}
+	if !ok {
+		return notion.PagePropResponse{}, fmt.Errorf("notion: failed to find page property: %w", notFound("property", propID))
+	}
+
+	var items []notion.PagePropItem
+
+	switch prop.Type {
+	case notion.DBPropTypeTitle:
+		for _, rt := range prop.Title {
+			items = append(items, notion.PagePropItem{Type: prop.Type, Title: rt})
+		}
+	case notion.DBPropTypeRichText:
+		for _, rt := range prop.RichText {
+			items = append(items, notion.PagePropItem{Type: prop.Type, RichText: rt})
+		}
+	case notion.DBPropTypeRelation:
+		for _, relation := range prop.Relation {
+			items = append(items, notion.PagePropItem{Type: prop.Type, Relation: relation})
+		}
+	case notion.DBPropTypePeople:
+		for _, user := range prop.People {
+			items = append(items, notion.PagePropItem{Type: prop.Type, People: user})
+		}
+	default:
+		item, err := propertyItem(prop)
+		if err != nil {
+			return notion.PagePropResponse{}, fmt.Errorf("notion: failed to find page property: %w", err)
+		}
+		return notion.PagePropResponse{PagePropItem: item}, nil
+	}
+
+	results, nextCursor, err := paginate(items, query.StartCursor, query.PageSize)
+	if err != nil {
+		return notion.PagePropResponse{}, fmt.Errorf("notion: failed to find page property: %w", err)
+	}
+
+	resp := notion.PagePropResponse{
+		PagePropItem: notion.PagePropItem{Type: notion.DBPropTypePropertyItem},
+		Results:      results,
+		HasMore:      nextCursor != nil,
+		PropertyItem: notion.PagePropListItem{ID: prop.ID, Type: prop.Type},
+	}
+	if nextCursor != nil {
+		resp.NextCursor = *nextCursor
+	}
+
+	return resp, nil
+}
+
+// propertyItem returns the property item of a property with a single value.
+func propertyItem(prop notion.DatabasePageProperty) (notion.PagePropItem, error) {
+	item := notion.PagePropItem{Type: prop.Type}
+
+	switch prop.Type {
+	case notion.DBPropTypeNumber:
+		if prop.Number != nil {
+			item.Number = *prop.Number
+		}
+	case notion.DBPropTypeSelect:
+		if prop.Select != nil {
+			item.Select = *prop.Select
+		}
+	case notion.DBPropTypeDate:
+		if prop.Date != nil {
+			item.Date = *prop.Date
+		}
+	case notion.DBPropTypeCheckbox:
+		item.Checkbox = prop.Checkbox != nil && *prop.Checkbox
+	case notion.DBPropTypeURL:
+		item.URL = stringValue(prop.URL)
+	case notion.DBPropTypeEmail:
+		item.Email = stringValue(prop.Email)
+	case notion.DBPropTypePhoneNumber:
+		item.PhoneNumber = stringValue(prop.PhoneNumber)
+	case notion.DBPropTypeCreatedTime:
+		item.CreatedTime = *prop.CreatedTime
+	case notion.DBPropTypeLastEditedTime:
+		item.LastEditedTime = *prop.LastEditedTime
+	case notion.DBPropTypeCreatedBy:
+		item.CreatedBy = *prop.CreatedBy
+	case notion.DBPropTypeLastEditedBy:
+		item.LastEditedBy = *prop.LastEditedBy
+	default:
+		return notion.PagePropItem{}, fmt.Errorf("notiontest: unsupported property type %q", prop.Type)
+	}
+
+	return item, nil
+}
+
+// FindUserByID implements notion.API.
+func (c *Client) FindUserByID(ctx context.Context, id string) (notion.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, ok := c.users[id]
+	if !ok {
+		return notion.User{}, fmt.Errorf("notion: failed to find user: %w", notFound("user", id))
+	}
+
+	return clone(user), nil
+}
+
+// FindCurrentUser implements notion.API. It returns the bot user of the fake.
+func (c *Client) FindCurrentUser(ctx context.Context) (notion.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return clone(c.bot), nil
+}
+
+// ListUsers implements notion.API. Users are returned in the order they were
+// added.
+func (c *Client) ListUsers(ctx context.Context, query *notion.PaginationQuery) (notion.ListUsersResponse, error) {
+	if query == nil {
+		query = &notion.PaginationQuery{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	users := make([]notion.User, len(c.userIDs))
+	for i, id := range c.userIDs {
+		users[i] = clone(c.users[id])
+	}
+
+	results, nextCursor, err := paginate(users, query.StartCursor, query.PageSize)
+	if err != nil {
+		return notion.ListUsersResponse{}, fmt.Errorf("notion: failed to list users: %w", err)
+	}
+
+	return notion.ListUsersResponse{Results: results, HasMore: nextCursor != nil, NextCursor: nextCursor}, nil
+}
+
+// Search implements notion.API. Pages and databases are matched by title (case
+// insensitive), and sorted by last edited time, most recent first unless
+// sorted otherwise.
+func (c *Client) Search(ctx context.Context, opts *notion.SearchOpts) (notion.SearchResponse, error) {
+	if opts == nil {
+		opts = &notion.SearchOpts{}
+	}
+	if err := opts.Validate(); err != nil {
+		return notion.SearchResponse{}, invalidParams("search options", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	type result struct {
+		obj            interface{}
+		lastEditedTime time.Time
+	}
+
+	var (
+		results []result
+		query   = strings.ToLower(opts.Query)
+		object  string
+	)
+	if opts.Filter != nil {
+		object = opts.Filter.Value
+	}
+
+	if object != notion.SearchFilterValueDatabase {
+		for _, id := range c.pageIDs {
+			page := c.pages[id]
+			if !page.Archived && strings.Contains(strings.ToLower(page.TitlePlainText()), query) {
+				results = append(results, result{clone(page), page.LastEditedTime})
+			}
+		}
+	}
+	if object != notion.SearchFilterValuePage {
+		for _, id := range c.databaseIDs {
+			db := c.databases[id]
+			if !db.Archived && strings.Contains(strings.ToLower(notion.PlainText(db.Title)), query) {
+				results = append(results, result{clone(db), db.LastEditedTime})
+			}
+		}
+	}
+
+	asc := opts.Sort != nil && opts.Sort.Direction == notion.SortDirAsc
+	sort.SliceStable(results, func(i, j int) bool {
+		if asc {
+			return results[i].lastEditedTime.Before(results[j].lastEditedTime)
+		}
+		return results[i].lastEditedTime.After(results[j].lastEditedTime)
+	})
+
+	page, nextCursor, err := paginate(results, opts.StartCursor, opts.PageSize)
+	if err != nil {
+		return notion.SearchResponse{}, fmt.Errorf("notion: failed to search: %w", err)
+	}
+
+	resp := notion.SearchResponse{
+		Results:    make(notion.SearchResults, len(page)),
+		HasMore:    nextCursor != nil,
+		NextCursor: nextCursor,
+	}
+	for i, r := range page {
+		resp.Results[i] = r.obj
+	}
+
+	return resp, nil
+}
+
+// CreateComment implements notion.API.
+func (c *Client) CreateComment(ctx context.Context, params notion.CreateCommentParams) (notion.Comment, error) {
+	if err := params.Validate(); err != nil {
+		return notion.Comment{}, invalidParams("comment params", err)
+	}
+	if params.Parent != (notion.Parent{}) {
+		params.ParentPageID, params.ParentBlockID = params.Parent.PageID, params.Parent.BlockID
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	comment := notion.Comment{
+		ID:             c.newID(),
+		RichText:       richText(params.RichText),
+		CreatedTime:    now,
+		LastEditedTime: now,
+		CreatedBy:      c.bot.BaseUser,
+		DisplayName:    params.DisplayName,
+	}
+
+	switch {
+	case params.ParentPageID != "":
+		if _, ok := c.pages[params.ParentPageID]; !ok {
+			return notion.Comment{}, fmt.Errorf("notion: failed to create comment: %w", notFound("page", params.ParentPageID))
+		}
+		comment.Parent = notion.Parent{Type: notion.ParentTypePage, PageID: params.ParentPageID}
+		comment.DiscussionID = c.newID()
+	case params.ParentBlockID != "":
+		if _, ok := c.blocks[params.ParentBlockID]; !ok {
+			return notion.Comment{}, fmt.Errorf("notion: failed to create comment: %w", notFound("block", params.ParentBlockID))
+		}
+		comment.Parent = notion.Parent{Type: notion.ParentTypeBlock, BlockID: params.ParentBlockID}
+		comment.DiscussionID = c.newID()
+	default:
+		var found bool
+		for _, id := range c.commentIDs {
+			if existing := c.comments[id]; existing.DiscussionID == params.DiscussionID {
+				comment.Parent, found = existing.Parent, true
+				break
+			}
+		}
+		if !found {
+			return notion.Comment{}, fmt.Errorf("notion: failed to create comment: %w", notFound("discussion", params.DiscussionID))
+		}
+		comment.DiscussionID = params.DiscussionID
+	}
+
+	for _, attachment := range params.Attachments {
+		upload, ok := c.uploads[attachment.FileUploadID]
+		if !ok || upload.Status != notion.FileUploadStatusUploaded {
+			return notion.Comment{}, fmt.Errorf("notion: failed to create comment: %w", validationError("File upload %v is not uploaded.", attachment.FileUploadID))
+		}
+		comment.Attachments = append(comment.Attachments, notion.CommentFile{
+			Category: strings.Split(upload.ContentType, "/")[0],
+			File:     notion.FileFile{URL: "https://files.example.com/" + upload.ID + "/" + upload.Filename},
+		})
+	}
+
+	c.comments[comment.ID] = comment
+	c.commentIDs = append(c.commentIDs, comment.ID)
+
+	return clone(comment), nil
+}
+
+// FindCommentsByBlockID implements notion.API. It returns the comments on a
+// page or block, oldest first.
+func (c *Client) FindCommentsByBlockID(ctx context.Context, query notion.FindCommentsByBlockIDQuery) (notion.FindCommentsResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.exists(query.BlockID) {
+		return notion.FindCommentsResponse{}, fmt.Errorf("notion: failed to list comments: %w", notFound("block", query.BlockID))
+	}
+
+	var comments []notion.Comment
+	for _, id := range c.commentIDs {
+		comment := c.comments[id]
+		if comment.Parent.PageID == query.BlockID || comment.Parent.BlockID == query.BlockID {
+			comments = append(comments, clone(comment))
+		}
+	}
+
+	results, nextCursor, err := paginate(comments, query.StartCursor, query.PageSize)
+	if err != nil {
+		return notion.FindCommentsResponse{}, fmt.Errorf("notion: failed to list comments: %w", err)
+	}
+
+	return notion.FindCommentsResponse{Results: results, HasMore: nextCursor != nil, NextCursor: nextCursor}, nil
+}
+
+// FindCommentByID implements notion.API.
+func (c *Client) FindCommentByID(ctx context.Context, id string) (notion.Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	comment, ok := c.comments[id]
+	if !ok {
+		return notion.Comment{}, fmt.Errorf("notion: failed to find comment: %w", notFound("comment", id))
+	}
+
+	return clone(comment), nil
+}
+
+// CreateFileUpload implements notion.API.
+func (c *Client) CreateFileUpload(ctx context.Context, file notion.FileParam) (notion.FileUpload, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	upload := notion.FileUpload{
+		ID:             c.newID(),
+		CreatedTime:    now,
+		LastEditedTime: now,
+		Status:         notion.FileUploadStatusPending,
+		Filename:       file.Filename,
+		ContentType:    contentType(file),
+	}
+	upload.UploadURL = "https://api.notion.com/v1/file_uploads/" + upload.ID + "/send"
+
+	c.uploads[upload.ID] = upload
+
+	return upload, nil
+}
+
+// SendFileUpload implements notion.API. The content is read, but not stored.
+func (c *Client) SendFileUpload(ctx context.Context, fileUploadID string, file notion.FileParam) (notion.FileUpload, error) {
+	if file.Content == nil {
+		return notion.FileUpload{}, fmt.Errorf("notion: failed to read file content: %w", io.ErrUnexpectedEOF)
+	}
+	n, err := io.Copy(io.Discard, file.Content)
+	if err != nil {
+		return notion.FileUpload{}, fmt.Errorf("notion: failed to read file content: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	upload, ok := c.uploads[fileUploadID]
+	if !ok {
+		return notion.FileUpload{}, fmt.Errorf("notion: failed to send file upload: %w", notFound("file upload", fileUploadID))
+	}
+	if upload.Status != notion.FileUploadStatusPending {
+		return notion.FileUpload{}, fmt.Errorf("notion: failed to send file upload: %w", validationError("File upload is not pending."))
+	}
+
+	upload.Status = notion.FileUploadStatusUploaded
+	upload.ContentLength = n
+	upload.UploadURL = ""
+	upload.LastEditedTime = c.now()
+	c.uploads[upload.ID] = upload
+
+	return upload, nil
+}
+
+// FindFileUploadByID implements notion.API.
+func (c *Client) FindFileUploadByID(ctx context.Context, id string) (notion.FileUpload, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	upload, ok := c.uploads[id]
+	if !ok {
+		return notion.FileUpload{}, fmt.Errorf("notion: failed to find file upload: %w", notFound("file upload", id))
+	}
+
+	return upload, nil
+}
+
+func (c *Client) now() time.Time {
+	return c.Now().UTC()
+}
+
+// newID returns a new UUID-like ID. IDs are sequential, so they're predictable
+// in tests.
+func (c *Client) newID() string {
+	c.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", c.nextID)
+}
+
+func (c *Client) storePage(page notion.Page) {
+	if _, ok := c.pages[page.ID]; !ok {
+		c.pageIDs = append(c.pageIDs, page.ID)
+	}
+	c.pages[page.ID] = page
+}
+
+// exists returns true if a page or block with ID `id` exists.
+func (c *Client) exists(id string) bool {
+	_, isPage := c.pages[id]
+	_, isBlock := c.blocks[id]
+	return isPage || isBlock
+}
+
+// paginate returns the items of a page of results. Cursors are offsets.
+func paginate[T any](items []T, cursor string, pageSize int) ([]T, *string, error) {
+	if pageSize < 0 || pageSize > notion.MaxPageSize {
+		return nil, nil, validationError("body.page_size should be less than or equal to `%v`, instead was `%v`.", notion.MaxPageSize, pageSize)
+	}
+	if pageSize == 0 {
+		pageSize = notion.MaxPageSize
+	}
+
+	var offset int
+	if cursor != "" {
+		var err error
+		offset, err = strconv.Atoi(cursor)
+		if err != nil || offset < 0 || offset > len(items) {
+			return nil, nil, validationError("start_cursor should be a valid cursor, instead was `%v`.", cursor)
+		}
+	}
+
+	end := offset + pageSize
+	if end >= len(items) {
+		return append([]T{}, items[offset:]...), nil, nil
+	}
+
+	next := strconv.Itoa(end)
+
+	return append([]T{}, items[offset:end]...), &next, nil
+}
+
+func notFound(object, id string) *notion.APIError {
+	return &notion.APIError{
+		Object:  "error",
+		Status:  http.StatusNotFound,
+		Code:    "object_not_found",
+		Message: fmt.Sprintf("Could not find %v with ID: %v.", object, id),
+	}
+}
+
+// invalidParams returns an error for params that failed validation, like the
+// client does.
+func invalidParams(what string, err error) error {
+	var valErr *notion.ValidationError
+	if !errors.As(err, &valErr) {
+		err = &notion.ValidationError{Err: err}
+	}
+
+	return fmt.Errorf("notion: invalid %v: %w", what, err)
+}
+
+func validationError(format string, args ...interface{}) *notion.APIError {
+	return &notion.APIError{
+		Object:  "error",
+		Status:  http.StatusBadRequest,
+		Code:    "validation_error",
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func objectURL(id string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+}
+
+// clone returns a deep copy of `v`, via JSON, so stored objects can't be
+// modified by callers. Decoding also normalizes values, like the API does
+// (e.g. page properties are decoded based on their parent).
+func clone[T any](v T) T {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: failed to encode object: %v", err))
+	}
+	var result T
+	if err := json.Unmarshal(b, &result); err != nil {
+		panic(fmt.Sprintf("notiontest: failed to decode object: %v", err))
+	}
+	return result
+}
+
+// richText returns a copy of rich text, with plain text set for text elements,
+// like the API does.
+func richText(richText []notion.RichText) []notion.RichText {
+	if richText == nil {
+		return nil
+	}
+
+	result := make([]notion.RichText, len(richText))
+	for i, rt := range richText {
+		if rt.Type == "" && rt.Text != nil {
+			rt.Type = notion.RichTextTypeText
+		}
+		if rt.PlainText == "" && rt.Text != nil {
+			rt.PlainText = rt.Text.Content
+		}
+		if rt.Annotations == nil {
+			rt.Annotations = &notion.Annotations{Color: notion.ColorDefault}
+		}
+		result[i] = rt
+	}
+	return result
+}
+
+func findDatabaseProperty(props notion.DatabaseProperties, key string) (string, notion.DatabaseProperty, bool) {
+	if prop, ok := props[key]; ok {
+		return key, prop, true
+	}
+	for name, prop := range props {
+		if prop.ID == key {
+			return name, prop, true
+		}
+	}
+	return "", notion.DatabaseProperty{}, false
+}
+
+// metadataType returns the type of a database property, based on which
+// metadata field is set.
+func metadataType(prop notion.DatabaseProperty) notion.DatabasePropertyType {
+	for typ, set := range map[notion.DatabasePropertyType]bool{
+		notion.DBPropTypeTitle:          prop.Title != nil,
+		notion.DBPropTypeRichText:       prop.RichText != nil,
+		notion.DBPropTypeDate:           prop.Date != nil,
+		notion.DBPropTypePeople:         prop.People != nil,
+		notion.DBPropTypeFiles:          prop.Files != nil,
+		notion.DBPropTypeCheckbox:       prop.Checkbox != nil,
+		notion.DBPropTypeURL:            prop.URL != nil,
+		notion.DBPropTypeEmail:          prop.Email != nil,
+		notion.DBPropTypePhoneNumber:    prop.PhoneNumber != nil,
+		notion.DBPropTypeCreatedTime:    prop.CreatedTime != nil,
+		notion.DBPropTypeCreatedBy:      prop.CreatedBy != nil,
+		notion.DBPropTypeLastEditedTime: prop.LastEditedTime != nil,
+		notion.DBPropTypeLastEditedBy:   prop.LastEditedBy != nil,
+		notion.DBPropTypeNumber:         prop.Number != nil,
+		notion.DBPropTypeSelect:         prop.Select != nil,
+		notion.DBPropTypeMultiSelect:    prop.MultiSelect != nil,
+		notion.DBPropTypeFormula:        prop.Formula != nil,
+		notion.DBPropTypeRelation:       prop.Relation != nil,
+		notion.DBPropTypeRollup:         prop.Rollup != nil,
+		notion.DBPropTypeStatus:         prop.Status != nil,
+	} {
+		if set {
+			return typ
+		}
+	}
+	return ""
+}
+
+func hasMetadata(prop notion.DatabaseProperty) bool {
+	return metadataType(prop) != ""
+}
+
+// filterProperties returns a page with only the properties with IDs (or names)
+// in `ids`, or all properties if `ids` is empty.
+func filterProperties(page notion.Page, ids []string) notion.Page {
+	props, ok := page.Properties.(notion.DatabasePageProperties)
+	if !ok || len(ids) == 0 {
+		return page
+	}
+
+	filtered := make(notion.DatabasePageProperties)
+	for name, prop := range props {
+		for _, id := range ids {
+			if prop.ID == id || name == id {
+				filtered[name] = prop
+			}
+		}
+	}
+	page.Properties = filtered
+
+	return page
+}
+
+func contentType(file notion.FileParam) string {
+	if file.ContentType != "" {
+		return file.ContentType
+	}
+	return mime.TypeByExtension(path.Ext(file.Filename))
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,593 @@
+package notiontest_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/dstotijn/go-notion/notiontest"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTasksDatabase returns a fake with a root page and a database of tasks.
+func newTasksDatabase(t *testing.T) (*notiontest.Client, notion.Page, notion.Database) {
+	t.Helper()
+
+	fake := notiontest.NewClient()
+	root := fake.AddPage(notion.Page{Properties: notion.PageProperties{
+		Title: notion.PageTitle{Title: []notion.RichText{notion.NewRichText("Root")}},
+	}})
+
+	db, err := fake.CreateDatabase(context.Background(), notion.CreateDatabaseParams{
+		ParentPageID: root.ID,
+		Title:        []notion.RichText{notion.NewRichText("Tasks")},
+		Properties: notion.DatabaseProperties{
+			"Name":     {Type: notion.DBPropTypeTitle, Title: &notion.EmptyMetadata{}},
+			"Priority": {Type: notion.DBPropTypeNumber, Number: &notion.NumberMetadata{}},
+			"Done":     {Type: notion.DBPropTypeCheckbox, Checkbox: &notion.EmptyMetadata{}},
+			"Tag":      {Type: notion.DBPropTypeSelect, Select: &notion.SelectMetadata{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return fake, root, db
+}
+
+func createTask(t *testing.T, fake *notiontest.Client, dbID, name string, priority float64, done bool) notion.Page {
+	t.Helper()
+
+	page, err := fake.CreatePage(context.Background(), notion.CreatePageParams{
+		ParentType: notion.ParentTypeDatabase,
+		ParentID:   dbID,
+		DatabasePageProperties: &notion.DatabasePageProperties{
+			"Name":     {Title: []notion.RichText{notion.NewRichText(name)}},
+			"Priority": {Number: notion.Float64Ptr(priority)},
+			"Done":     {Checkbox: notion.BoolPtr(done)},
+			"Tag":      {Select: &notion.SelectOptions{Name: "Work"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return page
+}
+
+func pageNames(pages []notion.Page) []string {
+	var names []string
+	for _, page := range pages {
+		names = append(names, page.TitlePlainText())
+	}
+	return names
+}
+
+func TestQueryDatabase(t *testing.T) {
+	t.Parallel()
+
+	fake, _, db := newTasksDatabase(t)
+	createTask(t, fake, db.ID, "Write docs", 2, false)
+	createTask(t, fake, db.ID, "Fix bug", 1, true)
+	createTask(t, fake, db.ID, "Release", 3, false)
+
+	tests := []struct {
+		name     string
+		query    *notion.DatabaseQuery
+		expNames []string
+	}{
+		{
+			name:     "all pages",
+			expNames: []string{"Write docs", "Fix bug", "Release"},
+		},
+		{
+			name: "filter",
+			query: &notion.DatabaseQuery{
+				Filter: &notion.DatabaseQueryFilter{
+					Property: "Done",
+					DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+						Checkbox: &notion.CheckboxDatabaseQueryFilter{Equals: notion.BoolPtr(false)},
+					},
+				},
+			},
+			expNames: []string{"Write docs", "Release"},
+		},
+		{
+			name: "compound filter",
+			query: &notion.DatabaseQuery{
+				Filter: &notion.DatabaseQueryFilter{Or: []notion.DatabaseQueryFilter{
+					{Property: "Name", DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+						Title: &notion.TextPropertyFilter{Contains: "bug"},
+					}},
+					{Property: "Priority", DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+						Number: &notion.NumberDatabaseQueryFilter{GreaterThan: notion.Float64Ptr(2)},
+					}},
+				}},
+			},
+			expNames: []string{"Fix bug", "Release"},
+		},
+		{
+			name: "sort",
+			query: &notion.DatabaseQuery{
+				Sorts: []notion.DatabaseQuerySort{{Property: "Priority", Direction: notion.SortDirDesc}},
+			},
+			expNames: []string{"Release", "Write docs", "Fix bug"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := fake.QueryDatabase(context.Background(), db.ID, tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expNames, pageNames(resp.Results)); diff != "" {
+				t.Fatalf("pages not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestQueryDatabasePagination(t *testing.T) {
+	t.Parallel()
+
+	fake, _, db := newTasksDatabase(t)
+	for _, name := range []string{"A", "B", "C"} {
+		createTask(t, fake, db.ID, name, 1, false)
+	}
+
+	var names []string
+	query := &notion.DatabaseQuery{PageSize: 2}
+	for {
+		resp, err := fake.QueryDatabase(context.Background(), db.ID, query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, pageNames(resp.Results)...)
+		if !resp.HasMore {
+			break
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+
+	if diff := cmp.Diff([]string{"A", "B", "C"}, names); diff != "" {
+		t.Fatalf("pages not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestQueryDatabaseUnsupportedFilter(t *testing.T) {
+	t.Parallel()
+
+	fake, _, db := newTasksDatabase(t)
+	createTask(t, fake, db.ID, "A", 1, false)
+
+	_, err := fake.QueryDatabase(context.Background(), db.ID, &notion.DatabaseQuery{
+		Filter: &notion.DatabaseQueryFilter{
+			Property: "Name",
+			DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+				Formula: &notion.FormulaDatabaseQueryFilter{},
+			},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "notiontest: unsupported filter") {
+		t.Fatalf("expected unsupported filter error, got: %v", err)
+	}
+}
+
+func TestPages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	fake, _, db := newTasksDatabase(t)
+	fake.Now = func() time.Time { return now }
+
+	page := createTask(t, fake, db.ID, "Write docs", 2, false)
+	if !page.CreatedTime.Equal(now) {
+		t.Fatalf("created time not equal (expected: %v, got: %v)", now, page.CreatedTime)
+	}
+
+	// New select options are added to the database.
+	db, err := fake.FindDatabaseByID(ctx, db.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if options := db.Properties["Tag"].Select.Options; len(options) != 1 || options[0].Name != "Work" {
+		t.Fatalf("unexpected select options: %+v", options)
+	}
+
+	now = now.Add(time.Hour)
+	page, err = fake.UpdatePage(ctx, page.ID, notion.UpdatePageParams{
+		DatabasePageProperties: notion.DatabasePageProperties{
+			"Done": {Checkbox: notion.BoolPtr(true)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done, _ := page.Property("Done").AsCheckbox(); !done {
+		t.Fatal("expected page to be done")
+	}
+	if !page.LastEditedTime.Equal(now) {
+		t.Fatalf("last edited time not equal (expected: %v, got: %v)", now, page.LastEditedTime)
+	}
+
+	found, err := fake.FindPageByID(ctx, page.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(page, found); diff != "" {
+		t.Fatalf("page not equal (-exp, +got):\n%v", diff)
+	}
+
+	_, err = fake.UpdatePage(ctx, page.ID, notion.UpdatePageParams{
+		DatabasePageProperties: notion.DatabasePageProperties{"Unknown": {Checkbox: notion.BoolPtr(true)}},
+	})
+	if !errors.Is(err, notion.ErrValidation) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+
+	if _, err := fake.UpdatePage(ctx, page.ID, notion.UpdatePageParams{InTrash: notion.BoolPtr(true)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := fake.QueryDatabase(ctx, db.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Results) != 0 {
+		t.Fatalf("expected no pages, got %v", len(resp.Results))
+	}
+
+	filtered, err := fake.FindPageByIDWithOpts(ctx, page.ID, &notion.FindPageByIDOpts{FilterProperties: []string{"Name"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if props := filtered.Properties.(notion.DatabasePageProperties); len(props) != 1 {
+		t.Fatalf("expected 1 property, got: %v", len(props))
+	}
+
+	_, err = fake.FindPageByID(ctx, "unknown")
+	if !errors.Is(err, notion.ErrObjectNotFound) {
+		t.Fatalf("expected object not found error, got: %v", err)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake, root, _ := newTasksDatabase(t)
+
+	page, err := fake.CreatePage(ctx, notion.CreatePageParams{
+		ParentType: notion.ParentTypePage,
+		ParentID:   root.ID,
+		Title:      []notion.RichText{notion.NewRichText("Notes")},
+		Children: []notion.Block{
+			notion.ToggleBlock{
+				RichText: []notion.RichText{notion.NewRichText("Toggle")},
+				Children: []notion.Block{
+					notion.ParagraphBlock{RichText: []notion.RichText{notion.NewRichText("Nested")}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The page and database are child blocks of the root page.
+	rootChildren, err := fake.FindBlockChildrenByID(ctx, root.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rootChildren.Results) != 2 || rootChildren.Results[1].ID() != page.ID {
+		t.Fatalf("unexpected root children: %+v", rootChildren.Results)
+	}
+
+	children, err := fake.FindBlockChildrenByID(ctx, page.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	toggle, ok := children.Results[0].(*notion.ToggleBlock)
+	if !ok || !toggle.HasChildren() || toggle.Parent().PageID != page.ID {
+		t.Fatalf("unexpected toggle block: %#v", children.Results[0])
+	}
+
+	nested, err := fake.FindBlockChildrenByID(ctx, toggle.ID(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paragraph := nested.Results[0].(*notion.ParagraphBlock)
+	if got := notion.PlainText(paragraph.RichText); got != "Nested" {
+		t.Fatalf("text not equal (expected: Nested, got: %v)", got)
+	}
+
+	updated, err := fake.UpdateBlock(ctx, paragraph.ID(), notion.ParagraphBlock{
+		RichText: []notion.RichText{notion.NewRichText("Updated")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := notion.PlainText(updated.(*notion.ParagraphBlock).RichText); got != "Updated" {
+		t.Fatalf("text not equal (expected: Updated, got: %v)", got)
+	}
+
+	_, err = fake.UpdateBlock(ctx, paragraph.ID(), notion.QuoteBlock{})
+	if !errors.Is(err, notion.ErrValidation) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+
+	// Archived blocks can be restored and updated in one request.
+	if _, err := fake.UpdateBlockWithParams(ctx, paragraph.ID(), notion.UpdateBlockParams{Archived: notion.BoolPtr(true)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated, err = fake.UpdateBlockWithParams(ctx, paragraph.ID(), notion.UpdateBlockParams{
+		Block:   notion.ParagraphBlock{RichText: []notion.RichText{notion.NewRichText("Restored")}},
+		InTrash: notion.BoolPtr(false),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Archived() {
+		t.Fatal("expected restored block not to be archived")
+	}
+	if got := notion.PlainText(updated.(*notion.ParagraphBlock).RichText); got != "Restored" {
+		t.Fatalf("text not equal (expected: Restored, got: %v)", got)
+	}
+
+	deleted, err := fake.DeleteBlock(ctx, paragraph.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !deleted.Archived() {
+		t.Fatal("expected deleted block to be archived")
+	}
+	toggleBlock, err := fake.FindBlockByID(ctx, toggle.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if toggleBlock.HasChildren() {
+		t.Fatal("expected toggle block without children")
+	}
+
+	// Deleting a child page block archives the page.
+	if _, err := fake.DeleteBlock(ctx, page.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	page, err = fake.FindPageByID(ctx, page.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !page.Archived {
+		t.Fatal("expected page to be archived")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	fake := notiontest.NewClient()
+	fake.Now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+
+	root := fake.AddPage(notion.Page{Properties: notion.PageProperties{
+		Title: notion.PageTitle{Title: []notion.RichText{notion.NewRichText("Project notes")}},
+	}})
+	_, err := fake.CreateDatabase(context.Background(), notion.CreateDatabaseParams{
+		ParentPageID: root.ID,
+		Title:        []notion.RichText{notion.NewRichText("Project tasks")},
+		Properties: notion.DatabaseProperties{
+			"Name": {Type: notion.DBPropTypeTitle, Title: &notion.EmptyMetadata{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      *notion.SearchOpts
+		expTitles []string
+	}{
+		{
+			name:      "query",
+			opts:      &notion.SearchOpts{Query: "project"},
+			expTitles: []string{"Project tasks", "Project notes"},
+		},
+		{
+			name: "filter",
+			opts: &notion.SearchOpts{
+				Query:  "project",
+				Filter: &notion.SearchFilter{Property: notion.SearchFilterPropertyObject, Value: notion.SearchFilterValuePage},
+			},
+			expTitles: []string{"Project notes"},
+		},
+		{
+			name:      "no results",
+			opts:      &notion.SearchOpts{Query: "unknown"},
+			expTitles: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := fake.Search(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var titles []string
+			for _, result := range resp.Results {
+				switch result := result.(type) {
+				case notion.Page:
+					titles = append(titles, result.TitlePlainText())
+				case notion.Database:
+					titles = append(titles, notion.PlainText(result.Title))
+				}
+			}
+			if diff := cmp.Diff(tt.expTitles, titles); diff != "" {
+				t.Fatalf("results not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestComments(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake, root, _ := newTasksDatabase(t)
+
+	comment, err := fake.CreateComment(ctx, notion.CreateCommentParams{
+		ParentPageID: root.ID,
+		RichText:     []notion.RichText{notion.NewRichText("First")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = fake.CreateComment(ctx, notion.CreateCommentParams{
+		DiscussionID: comment.DiscussionID,
+		RichText:     []notion.RichText{notion.NewRichText("Reply")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := fake.FindCommentsByBlockID(ctx, notion.FindCommentsByBlockIDQuery{BlockID: root.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var texts []string
+	for _, c := range resp.Results {
+		texts = append(texts, notion.PlainText(c.RichText))
+	}
+	if diff := cmp.Diff([]string{"First", "Reply"}, texts); diff != "" {
+		t.Fatalf("comments not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := notiontest.NewClient()
+	user := fake.AddUser(notion.User{Name: "Alice", Person: &notion.Person{Email: "alice@example.com"}})
+
+	found, err := fake.FindUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(user, found); diff != "" {
+		t.Fatalf("user not equal (-exp, +got):\n%v", diff)
+	}
+
+	bot, err := fake.FindCurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := fake.ListUsers(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]notion.User{bot, user}, resp.Results); diff != "" {
+		t.Fatalf("users not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestFileUploads(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := notiontest.NewClient()
+
+	upload, err := fake.CreateFileUpload(ctx, notion.FileParam{Filename: "notes.txt"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upload.Status != notion.FileUploadStatusPending {
+		t.Fatalf("status not equal (expected: pending, got: %v)", upload.Status)
+	}
+
+	upload, err = fake.SendFileUpload(ctx, upload.ID, notion.FileParam{Filename: "notes.txt", Content: strings.NewReader("hello")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upload.Status != notion.FileUploadStatusUploaded || upload.ContentLength != 5 {
+		t.Fatalf("unexpected file upload: %+v", upload)
+	}
+}
+
+func TestDataSources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake, root, db := newTasksDatabase(t)
+
+	ds, err := fake.FindDataSourceByID(ctx, db.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ds.Parent.DatabaseID != db.ID || ds.DatabaseParent.PageID != root.ID {
+		t.Fatalf("unexpected parents: %+v, %+v", ds.Parent, ds.DatabaseParent)
+	}
+
+	page, err := fake.CreatePage(ctx, notion.CreatePageParams{
+		ParentType: notion.ParentTypeDataSource,
+		ParentID:   ds.ID,
+		DatabasePageProperties: &notion.DatabasePageProperties{
+			"Name": {Title: []notion.RichText{notion.NewRichText("Write docs")}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(notion.Parent{Type: notion.ParentTypeDataSource, DataSourceID: ds.ID, DatabaseID: db.ID}, page.Parent); diff != "" {
+		t.Fatalf("parent not equal (-exp, +got):\n%v", diff)
+	}
+
+	resp, err := fake.QueryDataSource(ctx, ds.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Write docs"}, pageNames(resp.Results)); diff != "" {
+		t.Fatalf("results not equal (-exp, +got):\n%v", diff)
+	}
+
+	ds, err = fake.UpdateDataSource(ctx, ds.ID, notion.UpdateDataSourceParams{
+		Title: []notion.RichText{notion.NewRichText("Todos")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := notion.PlainText(ds.Title); got != "Todos" {
+		t.Fatalf("title not equal (expected: Todos, got: %v)", got)
+	}
+
+	_, err = fake.CreateDataSource(ctx, notion.CreateDataSourceParams{
+		DatabaseID: db.ID,
+		Properties: notion.DatabaseProperties{},
+	})
+	if !errors.Is(err, notion.ErrValidation) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+
+	_, err = fake.QueryDataSource(ctx, "unknown", nil)
+	if !errors.Is(err, notion.ErrObjectNotFound) || !strings.HasPrefix(err.Error(), "notion: failed to query data source:") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,377 @@
+package notiontest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// matchFilter returns true if a database page matches a filter. Compound
+// filters, timestamp filters and conditions on title, rich text, URL, email,
+// phone number, number, checkbox, select, multi-select, status, date and
+// relation properties are supported.
+func matchFilter(page notion.Page, filter notion.DatabaseQueryFilter) (bool, error) {
+	switch {
+	case len(filter.And) > 0:
+		for _, f := range filter.And {
+			ok, err := matchFilter(page, f)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case len(filter.Or) > 0:
+		for _, f := range filter.Or {
+			ok, err := matchFilter(page, f)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case filter.Timestamp == notion.TimestampCreatedTime && filter.CreatedTime != nil:
+		return matchDate(&page.CreatedTime, *filter.CreatedTime)
+	case filter.Timestamp == notion.TimestampLastEditedTime && filter.LastEditedTime != nil:
+		return matchDate(&page.LastEditedTime, *filter.LastEditedTime)
+	case filter.Property == "":
+		return false, unsupportedFilter(filter)
+	}
+
+	var prop notion.DatabasePageProperty
+	for name, p := range page.AllProperties() {
+		if name == filter.Property || p.ID == filter.Property {
+			prop = p
+		}
+	}
+	if prop.Type == "" {
+		return false, validationError("Could not find property with name or id: %v", filter.Property)
+	}
+
+	cond := filter.DatabaseQueryPropertyFilter
+
+	switch {
+	case cond.Title != nil:
+		return matchText(notion.PlainText(prop.Title), *cond.Title), nil
+	case cond.RichText != nil:
+		return matchText(notion.PlainText(prop.RichText), *cond.RichText), nil
+	case cond.URL != nil:
+		return matchText(stringValue(prop.URL), *cond.URL), nil
+	case cond.Email != nil:
+		return matchText(stringValue(prop.Email), *cond.Email), nil
+	case cond.PhoneNumber != nil:
+		return matchText(stringValue(prop.PhoneNumber), *cond.PhoneNumber), nil
+	case cond.Number != nil:
+		return matchNumber(prop.Number, *cond.Number), nil
+	case cond.Checkbox != nil:
+		checked := prop.Checkbox != nil && *prop.Checkbox
+		switch {
+		case cond.Checkbox.Equals != nil:
+			return checked == *cond.Checkbox.Equals, nil
+		case cond.Checkbox.DoesNotEqual != nil:
+			return checked != *cond.Checkbox.DoesNotEqual, nil
+		}
+	case cond.Select != nil:
+		return matchOption(prop.Select, cond.Select.Equals, cond.Select.DoesNotEqual, cond.Select.IsEmpty, cond.Select.IsNotEmpty), nil
+	case cond.Status != nil:
+		return matchOption(prop.Status, cond.Status.Equals, cond.Status.DoesNotEqual, cond.Status.IsEmpty, cond.Status.IsNotEmpty), nil
+	case cond.MultiSelect != nil:
+		var names []string
+		for _, option := range prop.MultiSelect {
+			names = append(names, option.Name)
+		}
+		return matchList(names, cond.MultiSelect.Contains, cond.MultiSelect.DoesNotContain, cond.MultiSelect.IsEmpty, cond.MultiSelect.IsNotEmpty), nil
+	case cond.Relation != nil:
+		var ids []string
+		for _, relation := range prop.Relation {
+			ids = append(ids, relation.ID)
+		}
+		return matchList(ids, cond.Relation.Contains, cond.Relation.DoesNotContain, cond.Relation.IsEmpty, cond.Relation.IsNotEmpty), nil
+	case cond.Date != nil:
+		var start *time.Time
+		if prop.Date != nil {
+			start = &prop.Date.Start.Time
+		}
+		return matchDate(start, *cond.Date)
+	}
+
+	return false, unsupportedFilter(filter)
+}
+
+func unsupportedFilter(filter notion.DatabaseQueryFilter) error {
+	return fmt.Errorf("notiontest: unsupported filter: %+v", filter)
+}
+
+func matchText(value string, cond notion.TextPropertyFilter) bool {
+	switch {
+	case cond.Equals != "":
+		return value == cond.Equals
+	case cond.DoesNotEqual != "":
+		return value != cond.DoesNotEqual
+	case cond.Contains != "":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(cond.Contains))
+	case cond.DoesNotContain != "":
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(cond.DoesNotContain))
+	case cond.StartsWith != "":
+		return strings.HasPrefix(value, cond.StartsWith)
+	case cond.EndsWith != "":
+		return strings.HasSuffix(value, cond.EndsWith)
+	case cond.IsEmpty:
+		return value == ""
+	case cond.IsNotEmpty:
+		return value != ""
+	}
+	return true
+}
+
+func matchNumber(value *float64, cond notion.NumberDatabaseQueryFilter) bool {
+	switch {
+	case cond.IsEmpty:
+		return value == nil
+	case cond.IsNotEmpty:
+		return value != nil
+	case value == nil:
+		return cond.DoesNotEqual != nil
+	case cond.Equals != nil:
+		return *value == *cond.Equals
+	case cond.DoesNotEqual != nil:
+		return *value != *cond.DoesNotEqual
+	case cond.GreaterThan != nil:
+		return *value > *cond.GreaterThan
+	case cond.LessThan != nil:
+		return *value < *cond.LessThan
+	case cond.GreaterThanOrEqualTo != nil:
+		return *value >= *cond.GreaterThanOrEqualTo
+	case cond.LessThanOrEqualTo != nil:
+		return *value <= *cond.LessThanOrEqualTo
+	}
+	return true
+}
+
+func matchOption(value *notion.SelectOptions, equals, doesNotEqual string, isEmpty, isNotEmpty bool) bool {
+	var name string
+	if value != nil {
+		name = value.Name
+	}
+
+	switch {
+	case equals != "":
+		return name == equals
+	case doesNotEqual != "":
+		return name != doesNotEqual
+	case isEmpty:
+		return name == ""
+	case isNotEmpty:
+		return name != ""
+	}
+	return true
+}
+
+func matchList(values []string, contains, doesNotContain string, isEmpty, isNotEmpty bool) bool {
+	has := func(s string) bool {
+		for _, v := range values {
+			if v == s {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case contains != "":
+		return has(contains)
+	case doesNotContain != "":
+		return !has(doesNotContain)
+	case isEmpty:
+		return len(values) == 0
+	case isNotEmpty:
+		return len(values) > 0
+	}
+	return true
+}
+
+// matchDate returns true if a (possibly nil) time matches a date condition.
+// Relative conditions (e.g. `past_week`) aren't supported.
+func matchDate(value *time.Time, cond notion.DatePropertyFilter) (bool, error) {
+	switch {
+	case cond.IsEmpty:
+		return value == nil, nil
+	case cond.IsNotEmpty:
+		return value != nil, nil
+	case cond.PastWeek != nil, cond.PastMonth != nil, cond.PastYear != nil,
+		cond.ThisWeek != nil, cond.NextWeek != nil, cond.NextMonth != nil, cond.NextYear != nil:
+		return false, fmt.Errorf("notiontest: unsupported relative date filter: %+v", cond)
+	case value == nil:
+		return false, nil
+	case cond.Equals != nil:
+		return compareDate(*value, *cond.Equals) == 0, nil
+	case cond.Before != nil:
+		return compareDate(*value, *cond.Before) < 0, nil
+	case cond.After != nil:
+		return compareDate(*value, *cond.After) > 0, nil
+	case cond.OnOrBefore != nil:
+		return compareDate(*value, *cond.OnOrBefore) <= 0, nil
+	case cond.OnOrAfter != nil:
+		return compareDate(*value, *cond.OnOrAfter) >= 0, nil
+	}
+	return true, nil
+}
+
+// compareDate compares a time with a date (by day, in UTC) or date and time.
+func compareDate(t time.Time, dt notion.DateTime) int {
+	other := dt.Time
+	if !dt.HasTime() {
+		t = time.Date(t.UTC().Year(), t.UTC().Month(), t.UTC().Day(), 0, 0, 0, 0, time.UTC)
+		other = time.Date(other.Year(), other.Month(), other.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	switch {
+	case t.Before(other):
+		return -1
+	case t.After(other):
+		return 1
+	}
+	return 0
+}
+
+// sortPages sorts pages (stable) by timestamps, or by values of title, rich
+// text, number, checkbox, select, status, date, URL, email or phone number
+// properties. Empty values are sorted last.
+func sortPages(pages []notion.Page, sorts []notion.DatabaseQuerySort) error {
+	for _, s := range sorts {
+		if s.Timestamp == "" && s.Property == "" {
+			return validationError("Sort should have a property or timestamp.")
+		}
+		if s.Property != "" && len(pages) > 0 {
+			if _, err := sortValue(pages[0], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		for _, s := range sorts {
+			a, _ := sortValue(pages[i], s)
+			b, _ := sortValue(pages[j], s)
+
+			cmp := compareValues(a, b)
+			if cmp == 0 {
+				continue
+			}
+			if a == nil || b == nil {
+				// Empty values are sorted last, regardless of direction.
+				return b == nil
+			}
+			if s.Direction == notion.SortDirDesc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+
+	return nil
+}
+
+// sortValue returns the value of a page to sort by: a string, float64, bool or
+// time.Time, or nil if empty.
+func sortValue(page notion.Page, s notion.DatabaseQuerySort) (interface{}, error) {
+	switch s.Timestamp {
+	case notion.SortTimeStampCreatedTime:
+		return page.CreatedTime, nil
+	case notion.SortTimeStampLastEditedTime:
+		return page.LastEditedTime, nil
+	case "":
+	default:
+		return nil, validationError("Invalid sort timestamp %v.", s.Timestamp)
+	}
+
+	var (
+		prop  notion.DatabasePageProperty
+		found bool
+	)
+	for name, p := range page.AllProperties() {
+		if name == s.Property || p.ID == s.Property {
+			prop, found = p, true
+		}
+	}
+	if !found {
+		return nil, validationError("Could not find sort property with name or id: %v", s.Property)
+	}
+
+	var value interface{}
+
+	switch prop.Type {
+	case notion.DBPropTypeTitle:
+		value = notion.PlainText(prop.Title)
+	case notion.DBPropTypeRichText:
+		value = notion.PlainText(prop.RichText)
+	case notion.DBPropTypeNumber:
+		if prop.Number != nil {
+			value = *prop.Number
+		}
+	case notion.DBPropTypeCheckbox:
+		value = prop.Checkbox != nil && *prop.Checkbox
+	case notion.DBPropTypeSelect:
+		if prop.Select != nil {
+			value = prop.Select.Name
+		}
+	case notion.DBPropTypeStatus:
+		if prop.Status != nil {
+			value = prop.Status.Name
+		}
+	case notion.DBPropTypeDate:
+		if prop.Date != nil {
+			value = prop.Date.Start.Time
+		}
+	case notion.DBPropTypeURL:
+		value = stringValue(prop.URL)
+	case notion.DBPropTypeEmail:
+		value = stringValue(prop.Email)
+	case notion.DBPropTypePhoneNumber:
+		value = stringValue(prop.PhoneNumber)
+	default:
+		return nil, fmt.Errorf("notiontest: unsupported sort on %v property %q", prop.Type, s.Property)
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case float64:
+		switch b := b.(float64); {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case bool:
+		switch b := b.(bool); {
+		case !a && b:
+			return -1
+		case a && !b:
+			return 1
+		}
+	case time.Time:
+		return compareDate(a, notion.NewDateTime(b.(time.Time), true))
+	}
+
+	return 0
+}
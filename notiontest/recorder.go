@@ -0,0 +1,295 @@
+package notiontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay replays recorded responses, without sending requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests, and records their responses.
+	ModeRecord
+)
+
+// RecordEnv is the environment variable that ModeFromEnv checks.
+const RecordEnv = "NOTIONTEST_RECORD"
+
+// ModeFromEnv returns ModeRecord if the `NOTIONTEST_RECORD` environment
+// variable is set to a non-empty value, and ModeReplay otherwise. This way,
+// responses can be recorded locally, and replayed in CI.
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// redactedValue replaces scrubbed values in recorded interactions.
+const redactedValue = "REDACTED"
+
+// tokenRegexp matches Notion integration tokens and OAuth client secrets.
+var tokenRegexp = regexp.MustCompile(`\b(secret|ntn)_[A-Za-z0-9]+`)
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded HTTP request. Request headers aren't recorded,
+// as they contain the API token.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// recordedHeaders are the response headers that are recorded.
+var recordedHeaders = []string{"Content-Type", "Retry-After"}
+
+// Recorder is an http.RoundTripper that records API responses to a golden file
+// (in ModeRecord), or replays them from it (in ModeReplay). Use it as transport
+// of the HTTP client of a notion.Client:
+//
+//	rec, err := notiontest.NewRecorder("testdata/query.json", notiontest.ModeFromEnv())
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Close()
+//
+//	client := notion.NewClient(os.Getenv("NOTION_API_KEY"), notion.WithHTTPClient(&http.Client{
+//		Transport: rec,
+//	}))
+//
+// In replay mode, requests are matched on method and URL; requests with the same
+// method and URL get responses in recorded order. Request bodies aren't matched,
+// as multipart bodies (for file uploads) differ per request.
+//
+// Notion tokens (`secret_...` and `ntn_...`) are scrubbed from recorded URLs and
+// bodies. Set Scrub to scrub other values (e.g. email addresses) too.
+type Recorder struct {
+	// Scrub is called for every interaction before it's saved, after tokens
+	// are scrubbed. Optional.
+	Scrub func(*Interaction)
+
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     map[int]bool
+}
+
+// RecorderOption is used to configure a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithTransport sets the transport used to send requests in ModeRecord.
+// Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// NewRecorder returns a new Recorder for the golden file at `path`. In replay
+// mode, the file is loaded and must exist.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		replayed:  make(map[int]bool),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("notiontest: failed to read golden file: %w", err)
+	}
+	if err := json.Unmarshal(b, &r.interactions); err != nil {
+		return nil, fmt.Errorf("notiontest: failed to parse golden file: %w", err)
+	}
+
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("notiontest: failed to read request body: %w", err)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	outReq := req.Clone(req.Context())
+	if req.Body != nil {
+		outReq.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	res, err := r.transport.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("notiontest: failed to read response body: %w", err)
+	}
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   string(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     make(http.Header),
+			Body:       string(resBody),
+		},
+	}
+	for _, key := range recordedHeaders {
+		if value := res.Header.Get(key); value != "" {
+			interaction.Response.Header.Set(key, value)
+		}
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := scrubTokens(req.URL.String())
+
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.URL != url {
+			continue
+		}
+		r.replayed[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("notiontest: no recorded response for %v %v", req.Method, url)
+}
+
+// Close saves the recorded interactions to the golden file in ModeRecord, after
+// scrubbing them. Parent directories are created if needed. In ModeReplay, it's
+// a no-op.
+func (r *Recorder) Close() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interactions := make([]Interaction, len(r.interactions))
+	for i, interaction := range r.interactions {
+		interaction.Request.URL = scrubTokens(interaction.Request.URL)
+		interaction.Request.Body = scrubTokens(interaction.Request.Body)
+		interaction.Response.Body = scrubTokens(interaction.Response.Body)
+		if r.Scrub != nil {
+			r.Scrub(&interaction)
+		}
+		interactions[i] = interaction
+	}
+
+	b, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("notiontest: failed to encode golden file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("notiontest: failed to write golden file: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("notiontest: failed to write golden file: %w", err)
+	}
+
+	return nil
+}
+
+// Unused returns the recorded interactions that weren't replayed. It can be
+// used to assert that a test made all recorded requests.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode != ModeReplay {
+		return nil
+	}
+
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.replayed[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+func scrubTokens(s string) string {
+	return tokenRegexp.ReplaceAllString(s, redactedValue)
+}
+
+var _ http.RoundTripper = (*Recorder)(nil)
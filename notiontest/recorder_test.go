@@ -0,0 +1,126 @@
+package notiontest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/dstotijn/go-notion/notiontest"
+)
+
+type mockRoundtripper struct {
+	fn func(*http.Request) (*http.Response, error)
+}
+
+func (m *mockRoundtripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return m.fn(r)
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	const token = "secret_abc123"
+	golden := filepath.Join(t.TempDir(), "testdata", "find_page.json")
+
+	var calls int32
+	upstream := &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			t.Errorf("unexpected authorization header: %v", r.Header.Get("Authorization"))
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}, "Set-Cookie": []string{"session=foo"}},
+			Body: io.NopCloser(strings.NewReader(
+				`{
+					"object": "page",
+					"id": "606ed832-7d79-46de-bbed-5b4896e7bc02",
+					"created_time": "2021-05-19T18:34:00.000Z",
+					"last_edited_time": "2021-05-19T18:34:00.000Z",
+					"parent": {"type": "workspace", "workspace": true},
+					"properties": {
+						"title": {
+							"id": "title",
+							"type": "title",
+							"title": [{"type": "text", "text": {"content": "Token: ` + token + `"}, "plain_text": "Token: ` + token + `"}]
+						}
+					}
+				}`,
+			)),
+		}, nil
+	}}
+
+	// Record.
+	rec, err := notiontest.NewRecorder(golden, notiontest.ModeRecord, notiontest.WithTransport(upstream))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := notion.NewClient(token, notion.WithHTTPClient(&http.Client{Transport: rec}))
+	recorded, err := client.FindPageByID(context.Background(), "606ed832-7d79-46de-bbed-5b4896e7bc02")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range []string{token, "Authorization", "session=foo"} {
+		if strings.Contains(string(b), s) {
+			t.Fatalf("golden file contains %q:\n%s", s, b)
+		}
+	}
+
+	// Replay.
+	rec, err = notiontest.NewRecorder(golden, notiontest.ModeReplay, notiontest.WithTransport(upstream))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client = notion.NewClient("", notion.WithHTTPClient(&http.Client{Transport: rec}))
+	replayed, err := client.FindPageByID(context.Background(), "606ed832-7d79-46de-bbed-5b4896e7bc02")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 upstream request, got %v", got)
+	}
+	if replayed.ID != recorded.ID {
+		t.Fatalf("page ID not equal (expected: %v, got: %v)", recorded.ID, replayed.ID)
+	}
+	if exp, got := "Token: REDACTED", replayed.TitlePlainText(); got != exp {
+		t.Fatalf("title not equal (expected: %v, got: %v)", exp, got)
+	}
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Fatalf("expected no unused interactions, got: %+v", unused)
+	}
+
+	// Responses are replayed only once.
+	_, err = client.FindPageByID(context.Background(), "606ed832-7d79-46de-bbed-5b4896e7bc02")
+	if err == nil || !strings.Contains(err.Error(), "notiontest: no recorded response for GET") {
+		t.Fatalf("expected no recorded response error, got: %v", err)
+	}
+}
+
+func TestRecorderMissingGoldenFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := notiontest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), notiontest.ModeReplay)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not exist error, got: %v", err)
+	}
+}
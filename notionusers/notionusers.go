@@ -0,0 +1,181 @@
+// Package notionusers provides helpers for syncing Notion workspace users to
+// external systems, e.g. HR or identity tooling.
+//
+// Users are listed (with pagination) and normalized into flat records, which
+// can be persisted between runs and compared to detect changes.
+package notionusers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dstotijn/go-notion"
+)
+
+// Kind is the kind of user: a person or a bot.
+type Kind string
+
+const (
+	KindPerson Kind = "person"
+	KindBot    Kind = "bot"
+)
+
+// Record is a normalized Notion user. Its fields are tagged for JSON, so
+// records can be stored and loaded between runs.
+type Record struct {
+	ID        string `json:"id"`
+	Kind      Kind   `json:"kind"`
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+
+	// OwnerType and OwnerUserID are only set for bots.
+	OwnerType   notion.BotOwnerType `json:"owner_type,omitempty"`
+	OwnerUserID string              `json:"owner_user_id,omitempty"`
+}
+
+// Lister lists users. It's satisfied by *notion.Client.
+type Lister interface {
+	ListUsers(ctx context.Context, query *notion.PaginationQuery) (notion.ListUsersResponse, error)
+}
+
+// Each lists all users in the workspace, following pagination, and calls `fn`
+// with the normalized record of each user. Iteration stops when `fn` returns
+// an error, which is then returned.
+func Each(ctx context.Context, client Lister, fn func(Record) error) error {
+	query := &notion.PaginationQuery{PageSize: notion.MaxPageSize}
+
+	for {
+		resp, err := client.ListUsers(ctx, query)
+		if err != nil {
+			return fmt.Errorf("notionusers: failed to list users: %w", err)
+		}
+
+		for _, user := range resp.Results {
+			if err := fn(Normalize(user)); err != nil {
+				return err
+			}
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return nil
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+}
+
+// List returns the normalized records of all users in the workspace, sorted by
+// ID.
+func List(ctx context.Context, client Lister) ([]Record, error) {
+	var records []Record
+
+	err := Each(ctx, client, func(r Record) error {
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortRecords(records)
+
+	return records, nil
+}
+
+// Normalize returns a record for a user. Whitespace is trimmed from names, and
+// emails are lowercased.
+func Normalize(user notion.User) Record {
+	r := Record{
+		ID:        user.ID,
+		Kind:      KindPerson,
+		Name:      strings.TrimSpace(user.Name),
+		AvatarURL: user.AvatarURL,
+	}
+
+	if user.Type == notion.UserTypeBot || user.Bot != nil {
+		r.Kind = KindBot
+	}
+	if user.Person != nil {
+		r.Email = strings.ToLower(strings.TrimSpace(user.Person.Email))
+	}
+	if user.Bot != nil {
+		r.OwnerType = user.Bot.Owner.Type
+		if user.Bot.Owner.User != nil {
+			r.OwnerUserID = user.Bot.Owner.User.ID
+		}
+	}
+
+	return r
+}
+
+// ChangeType is the type of change of a record between two runs.
+type ChangeType string
+
+const (
+	ChangeTypeAdded   ChangeType = "added"
+	ChangeTypeUpdated ChangeType = "updated"
+	ChangeTypeRemoved ChangeType = "removed"
+)
+
+// Change describes a changed record. For updated records, Previous contains the
+// record of the previous run. For removed records, Record contains the record of
+// the previous run.
+type Change struct {
+	Type     ChangeType `json:"type"`
+	Record   Record     `json:"record"`
+	Previous *Record    `json:"previous,omitempty"`
+}
+
+// Diff returns the changes between records of a previous run and the current
+// run, sorted by user ID.
+func Diff(prev, curr []Record) []Change {
+	prevByID := make(map[string]Record, len(prev))
+	for _, r := range prev {
+		prevByID[r.ID] = r
+	}
+
+	var changes []Change
+
+	for _, r := range curr {
+		p, ok := prevByID[r.ID]
+		delete(prevByID, r.ID)
+
+		switch {
+		case !ok:
+			changes = append(changes, Change{Type: ChangeTypeAdded, Record: r})
+		case p != r:
+			p := p
+			changes = append(changes, Change{Type: ChangeTypeUpdated, Record: r, Previous: &p})
+		}
+	}
+
+	for _, r := range prevByID {
+		changes = append(changes, Change{Type: ChangeTypeRemoved, Record: r})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Record.ID < changes[j].Record.ID
+	})
+
+	return changes
+}
+
+// Sync lists all users and returns their records, along with the changes
+// compared to the records of a previous run. Store the returned records to
+// pass them as `prev` on the next run.
+func Sync(ctx context.Context, client Lister, prev []Record) ([]Record, []Change, error) {
+	records, err := List(ctx, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return records, Diff(prev, records), nil
+}
+
+func sortRecords(records []Record) {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+}
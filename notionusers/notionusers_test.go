@@ -0,0 +1,139 @@
+package notionusers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/dstotijn/go-notion/notionusers"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockLister struct {
+	pages   []notion.ListUsersResponse
+	cursors []string
+	err     error
+}
+
+func (l *mockLister) ListUsers(ctx context.Context, query *notion.PaginationQuery) (notion.ListUsersResponse, error) {
+	if l.err != nil {
+		return notion.ListUsersResponse{}, l.err
+	}
+	l.cursors = append(l.cursors, query.StartCursor)
+	resp := l.pages[0]
+	l.pages = l.pages[1:]
+	return resp, nil
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	lister := &mockLister{
+		pages: []notion.ListUsersResponse{
+			{
+				Results: []notion.User{
+					{
+						BaseUser:  notion.BaseUser{ID: "user-b"},
+						Type:      notion.UserTypePerson,
+						Name:      " John Doe ",
+						AvatarURL: "https://example.com/avatar.png",
+						Person:    &notion.Person{Email: "John@Example.com"},
+					},
+				},
+				HasMore:    true,
+				NextCursor: notion.StringPtr("cursor-1"),
+			},
+			{
+				Results: []notion.User{
+					{
+						BaseUser: notion.BaseUser{ID: "user-a"},
+						Type:     notion.UserTypeBot,
+						Name:     "Integration",
+						Bot: &notion.Bot{
+							Owner: notion.BotOwner{
+								Type: notion.BotOwnerTypeUser,
+								User: &notion.User{BaseUser: notion.BaseUser{ID: "user-b"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	records, err := notionusers.List(context.Background(), lister)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := []notionusers.Record{
+		{
+			ID:          "user-a",
+			Kind:        notionusers.KindBot,
+			Name:        "Integration",
+			OwnerType:   notion.BotOwnerTypeUser,
+			OwnerUserID: "user-b",
+		},
+		{
+			ID:        "user-b",
+			Kind:      notionusers.KindPerson,
+			Name:      "John Doe",
+			Email:     "john@example.com",
+			AvatarURL: "https://example.com/avatar.png",
+		},
+	}
+
+	if diff := cmp.Diff(exp, records); diff != "" {
+		t.Fatalf("records not equal (-exp, +got):\n%v", diff)
+	}
+	if diff := cmp.Diff([]string{"", "cursor-1"}, lister.cursors); diff != "" {
+		t.Fatalf("cursors not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestListError(t *testing.T) {
+	t.Parallel()
+
+	lister := &mockLister{err: errors.New("boom")}
+
+	_, err := notionusers.List(context.Background(), lister)
+	if err == nil || err.Error() != "notionusers: failed to list users: boom" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	prev := []notionusers.Record{
+		{ID: "a", Kind: notionusers.KindPerson, Name: "Alice"},
+		{ID: "b", Kind: notionusers.KindPerson, Name: "Bob"},
+		{ID: "c", Kind: notionusers.KindBot, Name: "Bot"},
+	}
+	curr := []notionusers.Record{
+		{ID: "a", Kind: notionusers.KindPerson, Name: "Alice"},
+		{ID: "b", Kind: notionusers.KindPerson, Name: "Bob", Email: "bob@example.com"},
+		{ID: "d", Kind: notionusers.KindPerson, Name: "Dave"},
+	}
+
+	exp := []notionusers.Change{
+		{
+			Type:     notionusers.ChangeTypeUpdated,
+			Record:   notionusers.Record{ID: "b", Kind: notionusers.KindPerson, Name: "Bob", Email: "bob@example.com"},
+			Previous: &notionusers.Record{ID: "b", Kind: notionusers.KindPerson, Name: "Bob"},
+		},
+		{
+			Type:   notionusers.ChangeTypeRemoved,
+			Record: notionusers.Record{ID: "c", Kind: notionusers.KindBot, Name: "Bot"},
+		},
+		{
+			Type:   notionusers.ChangeTypeAdded,
+			Record: notionusers.Record{ID: "d", Kind: notionusers.KindPerson, Name: "Dave"},
+		},
+	}
+
+	if diff := cmp.Diff(exp, notionusers.Diff(prev, curr)); diff != "" {
+		t.Fatalf("changes not equal (-exp, +got):\n%v", diff)
+	}
+}
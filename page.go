@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -18,6 +20,7 @@ type Page struct {
 	LastEditedBy   *BaseUser `json:"last_edited_by,omitempty"`
 	Parent         Parent    `json:"parent"`
 	Archived       bool      `json:"archived"`
+	InTrash        bool      `json:"in_trash"`
 	URL            string    `json:"url"`
 	Icon           *Icon     `json:"icon,omitempty"`
 	Cover          *Cover    `json:"cover,omitempty"`
@@ -25,6 +28,16 @@ type Page struct {
 	// Properties differ between parent type.
 	// See the `UnmarshalJSON` method.
 	Properties interface{} `json:"properties"`
+
+	// IsPartial is true for partial objects (stubs), which the API returns
+	// (e.g. in query and search results) for pages the integration doesn't
+	// have read access to. Only ID is set; share the page with the
+	// integration to access it.
+	IsPartial bool `json:"-"`
+
+	// Raw contains the JSON object, as returned by the API. It's only set when
+	// the client was created with WithRawCapture.
+	Raw json.RawMessage `json:"-"`
 }
 
 // PageProperties are properties of a page whose parent is a page or a workspace.
@@ -64,13 +77,113 @@ type DatabasePageProperty struct {
 	CreatedBy      *User           `json:"created_by,omitempty"`
 	LastEditedTime *time.Time      `json:"last_edited_time,omitempty"`
 	LastEditedBy   *User           `json:"last_edited_by,omitempty"`
+
+	// HasMore is set for `relation` properties with more related pages than
+	// included in the page object.
+	HasMore bool `json:"has_more,omitempty"`
+
+	// Clear is used to remove the value of a property when updating a page.
+	// When set, `Type` is required and value fields must be empty. The property
+	// is encoded with an explicit `null` (or empty array) value.
+	Clear bool `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (prop DatabasePageProperty) MarshalJSON() ([]byte, error) {
+	type propAlias DatabasePageProperty
+
+	if !prop.Clear {
+		return json.Marshal(propAlias(prop))
+	}
+
+	var value interface{}
+
+	switch prop.Type {
+	case DBPropTypeTitle, DBPropTypeRichText, DBPropTypeMultiSelect, DBPropTypePeople, DBPropTypeRelation, DBPropTypeFiles:
+		value = []struct{}{}
+	case DBPropTypeCheckbox:
+		// Checkboxes can't be null, so they're cleared by unchecking.
+		value = false
+	}
+
+	return json.Marshal(map[string]interface{}{string(prop.Type): value})
+}
+
+// writeProps are page property values sent to the API. The API only accepts
+// user IDs for `people` properties, so fields like `name` and `type` of users
+// (e.g. of a fetched page) are omitted. Likewise, `select` and `multi_select`
+// options are sent by ID only, if set, so stale names don't cause errors.
+type writeProps DatabasePageProperties
+
+// MarshalJSON implements json.Marshaler.
+func (props writeProps) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage, len(props))
+
+	for name, prop := range props {
+		if prop.Select != nil {
+			option := writeSelectOption(*prop.Select)
+			prop.Select = &option
+		}
+		if len(prop.MultiSelect) > 0 {
+			options := make([]SelectOptions, len(prop.MultiSelect))
+			for i, option := range prop.MultiSelect {
+				options[i] = writeSelectOption(option)
+			}
+			prop.MultiSelect = options
+		}
+
+		b, err := json.Marshal(prop)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(prop.People) > 0 && !prop.Clear {
+			var value map[string]json.RawMessage
+			if err := json.Unmarshal(b, &value); err != nil {
+				return nil, err
+			}
+			refs := make([]BaseUser, len(prop.People))
+			for i, user := range prop.People {
+				refs[i] = user.BaseUser
+			}
+			if value["people"], err = json.Marshal(refs); err != nil {
+				return nil, err
+			}
+			if b, err = json.Marshal(value); err != nil {
+				return nil, err
+			}
+		}
+
+		fields[name] = b
+	}
+
+	return json.Marshal(fields)
+}
+
+// writeSelectOption returns the fields of a select option that are used to set
+// a page property value: the ID of an existing option, or the name (and color)
+// of an option that's added to the database if it doesn't exist yet.
+func writeSelectOption(option SelectOptions) SelectOptions {
+	if option.ID != "" {
+		return SelectOptions{ID: option.ID}
+	}
+	return SelectOptions{Name: option.Name, Color: option.Color}
 }
 
+// maxPagePropItems is the maximum number of items returned in page objects for
+// `title`, `rich_text`, `relation` and `people` properties.
+// See: https://developers.notion.com/reference/retrieve-a-page#limits
+const maxPagePropItems = 25
+
 // CreatePageParams are the params used for creating a page.
 type CreatePageParams struct {
 	ParentType ParentType
 	ParentID   string
 
+	// Parent is an alternative to ParentType and ParentID, e.g. PageParent(id).
+	// Optional, can't be combined with them.
+	Parent Parent
+
 	// Either DatabasePageProperties or Title must be not nil.
 	DatabasePageProperties *DatabasePageProperties
 	Title                  []RichText
@@ -87,6 +200,7 @@ type CreatePageParams struct {
 type UpdatePageParams struct {
 	DatabasePageProperties DatabasePageProperties `json:"properties,omitempty"`
 	Archived               *bool                  `json:"archived,omitempty"`
+	InTrash                *bool                  `json:"in_trash,omitempty"`
 	Icon                   *Icon                  `json:"icon,omitempty"`
 	Cover                  *Cover                 `json:"cover,omitempty"`
 }
@@ -190,27 +304,202 @@ func (prop DatabasePageProperty) Value() interface{} {
 	}
 }
 
+// IsTruncated returns true if the property value (possibly) has more items than
+// included in a page object. Use `Client.FindPagePropertyByID` or
+// `Client.ExpandPage` to fetch all items.
+func (prop DatabasePageProperty) IsTruncated() bool {
+	switch prop.Type {
+	case DBPropTypeTitle:
+		return len(prop.Title) >= maxPagePropItems
+	case DBPropTypeRichText:
+		return len(prop.RichText) >= maxPagePropItems
+	case DBPropTypeRelation:
+		return prop.HasMore || len(prop.Relation) >= maxPagePropItems
+	case DBPropTypePeople:
+		return len(prop.People) >= maxPagePropItems
+	default:
+		return false
+	}
+}
+
+// Validate returns an error when the property doesn't have exactly one value
+// field set. Notion requires this when creating or updating pages, but its API
+// error (e.g. `body.properties.Name.title should be defined`) is often hard to
+// relate to the cause.
+func (prop DatabasePageProperty) Validate() error {
+	fields := prop.valueFields()
+
+	if prop.Clear {
+		switch {
+		case prop.Type == "":
+			return errors.New("type is required when clearing a property")
+		case len(fields) > 0:
+			return fmt.Errorf("value fields are set (%v) when clearing a property", strings.Join(fields, ", "))
+		case prop.isReadOnly():
+			return fmt.Errorf("cannot clear read-only %v property", prop.Type)
+		}
+		return nil
+	}
+
+	switch len(fields) {
+	case 0:
+		return errors.New("no value field is set")
+	case 1:
+		return prop.validateURLs()
+	default:
+		return fmt.Errorf("multiple value fields are set (%v), expected exactly one", strings.Join(fields, ", "))
+	}
+}
+
+// validateURLs validates the URL, rich text links and external files of the
+// property value, see `validateURL`.
+func (prop DatabasePageProperty) validateURLs() error {
+	if prop.URL != nil {
+		if err := validateURL(*prop.URL, webURLSchemes); err != nil {
+			return fmt.Errorf("url: %w", err)
+		}
+	}
+	if err := firstErr(validateRichText(prop.Title), validateRichText(prop.RichText)); err != nil {
+		return err
+	}
+	for i, file := range prop.Files {
+		if err := validateFileExternal(file.External); err != nil {
+			return fmt.Errorf("files [%v]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// isReadOnly returns true for property types whose values are computed.
+func (prop DatabasePageProperty) isReadOnly() bool {
+	switch prop.Type {
+	case DBPropTypeFormula, DBPropTypeRollup, DBPropTypeCreatedTime, DBPropTypeCreatedBy,
+		DBPropTypeLastEditedTime, DBPropTypeLastEditedBy:
+		return true
+	}
+	return false
+}
+
+// valueFields returns the JSON field names of all non-empty value fields.
+func (prop DatabasePageProperty) valueFields() []string {
+	var fields []string
+
+	add := func(ok bool, field DatabasePropertyType) {
+		if ok {
+			fields = append(fields, string(field))
+		}
+	}
+
+	add(len(prop.Title) > 0, DBPropTypeTitle)
+	add(len(prop.RichText) > 0, DBPropTypeRichText)
+	add(prop.Number != nil, DBPropTypeNumber)
+	add(prop.Select != nil, DBPropTypeSelect)
+	add(len(prop.MultiSelect) > 0, DBPropTypeMultiSelect)
+	add(prop.Date != nil, DBPropTypeDate)
+	add(prop.Formula != nil, DBPropTypeFormula)
+	add(len(prop.Relation) > 0, DBPropTypeRelation)
+	add(prop.Rollup != nil, DBPropTypeRollup)
+	add(len(prop.People) > 0, DBPropTypePeople)
+	add(len(prop.Files) > 0, DBPropTypeFiles)
+	add(prop.Checkbox != nil, DBPropTypeCheckbox)
+	add(prop.URL != nil, DBPropTypeURL)
+	add(prop.Email != nil, DBPropTypeEmail)
+	add(prop.PhoneNumber != nil, DBPropTypePhoneNumber)
+	add(prop.Status != nil, DBPropTypeStatus)
+	add(prop.CreatedTime != nil, DBPropTypeCreatedTime)
+	add(prop.CreatedBy != nil, DBPropTypeCreatedBy)
+	add(prop.LastEditedTime != nil, DBPropTypeLastEditedTime)
+	add(prop.LastEditedBy != nil, DBPropTypeLastEditedBy)
+
+	return fields
+}
+
+// Validate validates all properties, see `DatabasePageProperty.Validate`.
+// Properties are validated in order of name, so errors are deterministic.
+func (props DatabasePageProperties) Validate() error {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := props[name].Validate(); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// parent returns the parent type and ID, from either Parent or ParentType and
+// ParentID.
+func (p CreatePageParams) parent() (ParentType, string) {
+	if p.Parent != (Parent{}) {
+		return p.Parent.Type, p.Parent.ID()
+	}
+	return p.ParentType, p.ParentID
+}
+
 func (p CreatePageParams) Validate() error {
-	if p.ParentType == "" {
-		return errors.New("parent type is required")
+	if p.Parent != (Parent{}) {
+		if p.ParentType != "" || p.ParentID != "" {
+			return fieldError("Parent", errors.New("parent can't be combined with parent type and parent ID"))
+		}
+		if err := p.Parent.Validate(); err != nil {
+			return fieldError("Parent", err)
+		}
 	}
-	if p.ParentID == "" {
-		return errors.New("parent ID is required")
+
+	parentType, parentID := p.parent()
+
+	if parentType == "" {
+		return fieldError("ParentType", errors.New("parent type is required"))
 	}
-	if p.ParentType == ParentTypeDatabase && p.DatabasePageProperties == nil {
-		return errors.New("database page properties is required when parent type is database")
+	if parentType == ParentTypeWorkspace && parentID != "" {
+		return fieldError("ParentID", errors.New("parent ID must be empty when parent type is workspace"))
 	}
-	if p.ParentType == ParentTypePage && p.Title == nil {
-		return errors.New("title is required when parent type is page")
+	if parentType != ParentTypeWorkspace && parentID == "" {
+		return fieldError("ParentID", errors.New("parent ID is required"))
+	}
+	switch parentType {
+	case ParentTypeDatabase, ParentTypeDataSource:
+		if p.DatabasePageProperties == nil && parentType == ParentTypeDataSource {
+			return fieldError("DatabasePageProperties", errors.New("database page properties is required when parent type is data source"))
+		}
+		if p.DatabasePageProperties == nil {
+			return fieldError("DatabasePageProperties", errors.New("database page properties is required when parent type is database"))
+		}
+		if err := p.DatabasePageProperties.Validate(); err != nil {
+			return fieldError("DatabasePageProperties", err)
+		}
+	case ParentTypePage, ParentTypeBlock, ParentTypeWorkspace:
+		if p.Title == nil {
+			return fieldError("Title", fmt.Errorf("title is required when parent type is %v", strings.TrimSuffix(string(parentType), "_id")))
+		}
+		if p.DatabasePageProperties != nil {
+			return fieldError("DatabasePageProperties", errors.New("database page properties are only allowed when parent type is database"))
+		}
+	default:
+		return fieldError("ParentType", fmt.Errorf("unsupported parent type %q", parentType))
+	}
+	if err := validateRichText(p.Title); err != nil {
+		return fieldError("Title", fmt.Errorf("title: %w", err))
+	}
+	if err := validateBlocks(p.Children); err != nil {
+		return fieldError("Children", fmt.Errorf("children: %w", err))
+	}
+	if err := validateBlockDepth(p.Children, 1); err != nil {
+		return fieldError("Children", fmt.Errorf("children (see Client.CreatePageDeep): %w", err))
 	}
 	if p.Icon != nil {
 		if err := p.Icon.Validate(); err != nil {
-			return err
+			return fieldError("Icon", err)
 		}
 	}
 	if p.Cover != nil {
 		if err := p.Cover.Validate(); err != nil {
-			return err
+			return fieldError("Cover", err)
 		}
 	}
 
@@ -226,12 +515,31 @@ func (p CreatePageParams) MarshalJSON() ([]byte, error) {
 		Cover      *Cover      `json:"cover,omitempty"`
 	}
 
+	parentType, parentID := p.parent()
+	if parentType == "" {
+		// Infer the parent type for params that weren't validated.
+		if p.DatabasePageProperties != nil {
+			parentType = ParentTypeDatabase
+		} else if p.Title != nil {
+			parentType = ParentTypePage
+		}
+	}
+
 	var parent Parent
 
-	if p.DatabasePageProperties != nil {
-		parent.DatabaseID = p.ParentID
-	} else if p.Title != nil {
-		parent.PageID = p.ParentID
+	switch parentType {
+	case ParentTypeDatabase:
+		parent.DatabaseID = parentID
+	case ParentTypeDataSource:
+		parent.Type = ParentTypeDataSource
+		parent.DataSourceID = parentID
+	case ParentTypePage:
+		parent.PageID = parentID
+	case ParentTypeBlock:
+		parent.BlockID = parentID
+	case ParentTypeWorkspace:
+		parent.Type = ParentTypeWorkspace
+		parent.Workspace = true
 	}
 
 	dto := CreatePageParamsDTO{
@@ -241,8 +549,10 @@ func (p CreatePageParams) MarshalJSON() ([]byte, error) {
 		Cover:    p.Cover,
 	}
 
-	if p.DatabasePageProperties != nil {
-		dto.Properties = p.DatabasePageProperties
+	if parentType == ParentTypeDatabase || parentType == ParentTypeDataSource {
+		if p.DatabasePageProperties != nil {
+			dto.Properties = writeProps(*p.DatabasePageProperties)
+		}
 	} else if p.Title != nil {
 		dto.Properties = PageTitle{
 			Title: p.Title,
@@ -256,7 +566,8 @@ func (p CreatePageParams) MarshalJSON() ([]byte, error) {
 //
 // Pages get a different Properties type based on the parent of the page.
 // If parent type is `workspace` or `page_id`, PageProperties is used. Else if
-// parent type is `database_id`, DatabasePageProperties is used.
+// parent type is `database_id`, DatabasePageProperties is used. Partial
+// objects without parent and properties have IsPartial set.
 func (p *Page) UnmarshalJSON(b []byte) error {
 	type (
 		PageAlias Page
@@ -275,6 +586,12 @@ func (p *Page) UnmarshalJSON(b []byte) error {
 
 	page := dto.PageAlias
 
+	if dto.Parent.Type == "" && dto.Properties == nil {
+		page.IsPartial = true
+		*p = Page(page)
+		return nil
+	}
+
 	switch dto.Parent.Type {
 	case ParentTypeWorkspace:
 		fallthrough
@@ -287,7 +604,7 @@ func (p *Page) UnmarshalJSON(b []byte) error {
 			return err
 		}
 		page.Properties = props
-	case ParentTypeDatabase:
+	case ParentTypeDatabase, ParentTypeDataSource:
 		var props DatabasePageProperties
 		err := json.Unmarshal(dto.Properties, &props)
 		if err != nil {
@@ -305,13 +622,40 @@ func (p *Page) UnmarshalJSON(b []byte) error {
 
 func (p UpdatePageParams) Validate() error {
 	// At least one of the params must be set.
-	if p.DatabasePageProperties == nil && p.Archived == nil && p.Icon == nil && p.Cover == nil {
-		return errors.New("at least one of database page properties, archived, icon or cover is required")
+	if p.DatabasePageProperties == nil && p.Archived == nil && p.InTrash == nil && p.Icon == nil && p.Cover == nil {
+		return errors.New("at least one of database page properties, archived, in trash, icon or cover is required")
+	}
+	if err := p.DatabasePageProperties.Validate(); err != nil {
+		return fieldError("DatabasePageProperties", err)
 	}
 	if p.Icon != nil {
 		if err := p.Icon.Validate(); err != nil {
-			return err
+			return fieldError("Icon", err)
+		}
+	}
+	if p.Cover != nil {
+		if err := p.Cover.Validate(); err != nil {
+			return fieldError("Cover", err)
 		}
 	}
 	return nil
 }
+
+// MarshalJSON implements json.Marshaler.
+func (p UpdatePageParams) MarshalJSON() ([]byte, error) {
+	type dto struct {
+		DatabasePageProperties writeProps `json:"properties,omitempty"`
+		Archived               *bool      `json:"archived,omitempty"`
+		InTrash                *bool      `json:"in_trash,omitempty"`
+		Icon                   *Icon      `json:"icon,omitempty"`
+		Cover                  *Cover     `json:"cover,omitempty"`
+	}
+
+	return json.Marshal(dto{
+		DatabasePageProperties: writeProps(p.DatabasePageProperties),
+		Archived:               p.Archived,
+		InTrash:                p.InTrash,
+		Icon:                   p.Icon,
+		Cover:                  p.Cover,
+	})
+}
@@ -0,0 +1,143 @@
+package notion
+
+// DatabasePageProperties returns the properties of a page whose parent is a
+// database, without the need for a type assertion on `Properties`. It returns
+// false for pages with another parent type.
+func (p Page) DatabasePageProperties() (DatabasePageProperties, bool) {
+	props, ok := p.Properties.(DatabasePageProperties)
+	return props, ok
+}
+
+// PageProperties returns the properties of a page whose parent is a page,
+// block or workspace, without the need for a type assertion on `Properties`.
+// It returns false for database pages.
+func (p Page) PageProperties() (PageProperties, bool) {
+	props, ok := p.Properties.(PageProperties)
+	return props, ok
+}
+
+// AllProperties returns the properties of the page, for pages with any parent
+// type. For pages whose parent isn't a database, the only property is `title`.
+func (p Page) AllProperties() DatabasePageProperties {
+	switch props := p.Properties.(type) {
+	case DatabasePageProperties:
+		return props
+	case PageProperties:
+		return DatabasePageProperties{
+			"title": {ID: "title", Type: DBPropTypeTitle, Title: props.Title.Title},
+		}
+	}
+	return nil
+}
+
+// TitlePlainText returns the title of the page as plain text, regardless of the
+// parent type of the page.
+func (p Page) TitlePlainText() string {
+	return PlainText(pageTitle(p))
+}
+
+// Property returns the property with name `name`, for pages with any parent
+// type. For pages whose parent isn't a database, the only property is `title`.
+// When the page has no such property, the zero value is returned, so getters
+// like `AsSelect` can be chained:
+//
+//	status, ok := page.Property("Status").AsSelect()
+func (p Page) Property(name string) DatabasePageProperty {
+	return p.AllProperties()[name]
+}
+
+// AsPlainText returns the plain text of a title or rich text property value.
+func (prop DatabasePageProperty) AsPlainText() (string, bool) {
+	switch {
+	case prop.Title != nil:
+		return PlainText(prop.Title), true
+	case prop.RichText != nil:
+		return PlainText(prop.RichText), true
+	}
+	return "", false
+}
+
+// AsNumber returns the value of a number property.
+func (prop DatabasePageProperty) AsNumber() (float64, bool) {
+	if prop.Number == nil {
+		return 0, false
+	}
+	return *prop.Number, true
+}
+
+// AsSelect returns the option of a select property.
+func (prop DatabasePageProperty) AsSelect() (SelectOptions, bool) {
+	if prop.Select == nil {
+		return SelectOptions{}, false
+	}
+	return *prop.Select, true
+}
+
+// AsMultiSelect returns the options of a multi-select property.
+func (prop DatabasePageProperty) AsMultiSelect() ([]SelectOptions, bool) {
+	return prop.MultiSelect, prop.MultiSelect != nil
+}
+
+// AsStatus returns the option of a status property.
+func (prop DatabasePageProperty) AsStatus() (SelectOptions, bool) {
+	if prop.Status == nil {
+		return SelectOptions{}, false
+	}
+	return *prop.Status, true
+}
+
+// AsDate returns the value of a date property.
+func (prop DatabasePageProperty) AsDate() (Date, bool) {
+	if prop.Date == nil {
+		return Date{}, false
+	}
+	return *prop.Date, true
+}
+
+// AsCheckbox returns the value of a checkbox property.
+func (prop DatabasePageProperty) AsCheckbox() (bool, bool) {
+	if prop.Checkbox == nil {
+		return false, false
+	}
+	return *prop.Checkbox, true
+}
+
+// AsURL returns the value of a URL property.
+func (prop DatabasePageProperty) AsURL() (string, bool) {
+	if prop.URL == nil {
+		return "", false
+	}
+	return *prop.URL, true
+}
+
+// AsEmail returns the value of an email property.
+func (prop DatabasePageProperty) AsEmail() (string, bool) {
+	if prop.Email == nil {
+		return "", false
+	}
+	return *prop.Email, true
+}
+
+// AsPhoneNumber returns the value of a phone number property.
+func (prop DatabasePageProperty) AsPhoneNumber() (string, bool) {
+	if prop.PhoneNumber == nil {
+		return "", false
+	}
+	return *prop.PhoneNumber, true
+}
+
+// AsPeople returns the users of a people property.
+func (prop DatabasePageProperty) AsPeople() ([]User, bool) {
+	return prop.People, prop.People != nil
+}
+
+// AsRelation returns the related pages of a relation property. See
+// `IsTruncated` for relations with many pages.
+func (prop DatabasePageProperty) AsRelation() ([]Relation, bool) {
+	return prop.Relation, prop.Relation != nil
+}
+
+// AsFiles returns the files of a files property.
+func (prop DatabasePageProperty) AsFiles() ([]File, bool) {
+	return prop.Files, prop.Files != nil
+}
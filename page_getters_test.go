@@ -0,0 +1,114 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPageGetters(t *testing.T) {
+	t.Parallel()
+
+	var dbPage notion.Page
+	err := json.Unmarshal([]byte(`{
+		"object": "page",
+		"id": "page-id",
+		"parent": {"type": "database_id", "database_id": "db-id"},
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Foo"}, {"type": "text", "plain_text": "bar"}]},
+			"Status": {"id": "a", "type": "select", "select": {"id": "b", "name": "Done", "color": "green"}},
+			"Due": {"id": "c", "type": "date", "date": {"start": "2022-09-01"}},
+			"Estimate": {"id": "d", "type": "number", "number": null}
+		}
+	}`), &dbPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, got := "Foobar", dbPage.TitlePlainText(); exp != got {
+		t.Fatalf("title not equal (expected: %v, got: %v)", exp, got)
+	}
+
+	if _, ok := dbPage.PageProperties(); ok {
+		t.Fatal("expected no page properties")
+	}
+	dbProps, ok := dbPage.DatabasePageProperties()
+	if !ok {
+		t.Fatal("expected database page properties")
+	}
+	if exp, got := []string{"Due", "Estimate", "Name", "Status"}, propNames(dbProps); !cmp.Equal(exp, got) {
+		t.Fatalf("property names not equal (expected: %v, got: %v)", exp, got)
+	}
+
+	status, ok := dbPage.Property("Status").AsSelect()
+	if !ok {
+		t.Fatal("expected select value")
+	}
+	if diff := cmp.Diff(notion.SelectOptions{ID: "b", Name: "Done", Color: notion.ColorGreen}, status); diff != "" {
+		t.Fatalf("select not equal (-exp, +got):\n%v", diff)
+	}
+
+	due, ok := dbPage.Property("Due").AsDate()
+	if !ok {
+		t.Fatal("expected date value")
+	}
+	if exp, got := "2022-09-01", due.Start.Format("2006-01-02"); exp != got {
+		t.Fatalf("date not equal (expected: %v, got: %v)", exp, got)
+	}
+
+	if _, ok := dbPage.Property("Estimate").AsNumber(); ok {
+		t.Fatal("expected no number value")
+	}
+	if _, ok := dbPage.Property("Due").AsSelect(); ok {
+		t.Fatal("expected no select value for date property")
+	}
+	if _, ok := dbPage.Property("Missing").AsPlainText(); ok {
+		t.Fatal("expected no value for missing property")
+	}
+
+	var page notion.Page
+	err = json.Unmarshal([]byte(`{
+		"object": "page",
+		"id": "page-id",
+		"parent": {"type": "page_id", "page_id": "parent-id"},
+		"properties": {
+			"title": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Lorem ipsum"}]}
+		}
+	}`), &page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := page.DatabasePageProperties(); ok {
+		t.Fatal("expected no database page properties")
+	}
+	pageProps, ok := page.PageProperties()
+	if !ok {
+		t.Fatal("expected page properties")
+	}
+	if exp, got := "Lorem ipsum", pageProps.Title.Title[0].PlainText; exp != got {
+		t.Fatalf("title not equal (expected: %v, got: %v)", exp, got)
+	}
+	if exp, got := []string{"title"}, propNames(page.AllProperties()); !cmp.Equal(exp, got) {
+		t.Fatalf("property names not equal (expected: %v, got: %v)", exp, got)
+	}
+
+	if exp, got := "Lorem ipsum", page.TitlePlainText(); exp != got {
+		t.Fatalf("title not equal (expected: %v, got: %v)", exp, got)
+	}
+	if title, _ := page.Property("title").AsPlainText(); title != "Lorem ipsum" {
+		t.Fatalf("title property not equal (expected: Lorem ipsum, got: %v)", title)
+	}
+}
+
+func propNames(props notion.DatabasePageProperties) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,153 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PagePropsBuilder is used to build DatabasePageProperties for creating or
+// updating pages in a database. Each method sets exactly one value field for a
+// property, so the result can be sent to the API as-is.
+//
+// Example:
+//
+//	props, err := notion.NewPageProps().
+//		Title("Name", notion.RichText{Text: &notion.Text{Content: "Foobar"}}).
+//		Select("City", "Paris").
+//		Email("Email", "foo@example.com").
+//		Build()
+type PagePropsBuilder struct {
+	props DatabasePageProperties
+	err   error
+}
+
+// NewPageProps returns a new PagePropsBuilder.
+func NewPageProps() *PagePropsBuilder {
+	return &PagePropsBuilder{
+		props: DatabasePageProperties{},
+	}
+}
+
+func (b *PagePropsBuilder) set(name string, prop DatabasePageProperty) *PagePropsBuilder {
+	if b.err != nil {
+		return b
+	}
+	if name == "" {
+		b.err = errors.New("property name cannot be empty")
+		return b
+	}
+	if _, ok := b.props[name]; ok {
+		b.err = fmt.Errorf("property %q is set more than once", name)
+		return b
+	}
+
+	b.props[name] = prop
+
+	return b
+}
+
+// Title sets the value of a `title` property.
+func (b *PagePropsBuilder) Title(name string, richText ...RichText) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Title: richText})
+}
+
+// RichText sets the value of a `rich_text` property.
+func (b *PagePropsBuilder) RichText(name string, richText ...RichText) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{RichText: richText})
+}
+
+// Number sets the value of a `number` property.
+func (b *PagePropsBuilder) Number(name string, number float64) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Number: &number})
+}
+
+// Select sets the value of a `select` property, by option name.
+func (b *PagePropsBuilder) Select(name, option string) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Select: &SelectOptions{Name: option}})
+}
+
+// MultiSelect sets the value of a `multi_select` property, by option names.
+func (b *PagePropsBuilder) MultiSelect(name string, options ...string) *PagePropsBuilder {
+	multiSelect := make([]SelectOptions, len(options))
+	for i, option := range options {
+		multiSelect[i] = SelectOptions{Name: option}
+	}
+	return b.set(name, DatabasePageProperty{MultiSelect: multiSelect})
+}
+
+// Status sets the value of a `status` property, by option name.
+func (b *PagePropsBuilder) Status(name, option string) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Status: &SelectOptions{Name: option}})
+}
+
+// Date sets the value of a `date` property.
+func (b *PagePropsBuilder) Date(name string, date Date) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Date: &date})
+}
+
+// People sets the value of a `people` property, by user IDs.
+func (b *PagePropsBuilder) People(name string, userIDs ...string) *PagePropsBuilder {
+	people := make([]User, len(userIDs))
+	for i, id := range userIDs {
+		people[i] = User{BaseUser: BaseUser{ID: id}}
+	}
+	return b.set(name, DatabasePageProperty{People: people})
+}
+
+// Relation sets the value of a `relation` property, by page IDs.
+func (b *PagePropsBuilder) Relation(name string, pageIDs ...string) *PagePropsBuilder {
+	relation := make([]Relation, len(pageIDs))
+	for i, id := range pageIDs {
+		relation[i] = Relation{ID: id}
+	}
+	return b.set(name, DatabasePageProperty{Relation: relation})
+}
+
+// Files sets the value of a `files` property.
+func (b *PagePropsBuilder) Files(name string, files ...File) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Files: files})
+}
+
+// Checkbox sets the value of a `checkbox` property.
+func (b *PagePropsBuilder) Checkbox(name string, checked bool) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Checkbox: &checked})
+}
+
+// URL sets the value of a `url` property.
+func (b *PagePropsBuilder) URL(name, url string) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{URL: &url})
+}
+
+// Email sets the value of an `email` property.
+func (b *PagePropsBuilder) Email(name, email string) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Email: &email})
+}
+
+// Phone sets the value of a `phone_number` property.
+func (b *PagePropsBuilder) Phone(name, phoneNumber string) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{PhoneNumber: &phoneNumber})
+}
+
+// Clear removes the value of a property of type `propType`, when updating a
+// page. See `DatabasePageProperty.Clear`.
+func (b *PagePropsBuilder) Clear(name string, propType DatabasePropertyType) *PagePropsBuilder {
+	return b.set(name, DatabasePageProperty{Type: propType, Clear: true})
+}
+
+// Build returns the database page properties, or the first error that occurred
+// while building them.
+func (b *PagePropsBuilder) Build() (DatabasePageProperties, error) {
+	if b.err != nil {
+		return nil, invalidParams("page properties", b.err)
+	}
+	if err := b.props.Validate(); err != nil {
+		return nil, invalidParams("page properties", err)
+	}
+
+	props := make(DatabasePageProperties, len(b.props))
+	for name, prop := range b.props {
+		props[name] = prop
+	}
+
+	return props, nil
+}
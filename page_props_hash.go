@@ -0,0 +1,206 @@
+package notion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Hash returns a stable content hash (hex encoded SHA-256) of the property
+// values, e.g. for sync engines to detect changes without storing full
+// snapshots. Values are normalized first, so properties returned by the API and
+// equivalent properties built client side (e.g. via PagePropsBuilder) have the
+// same hash:
+//
+//   - Property IDs and types are ignored, as well as properties without value.
+//   - Rich text is reduced to its plain text, non-default annotations and link.
+//   - Select, status and multi-select options are reduced to their names;
+//     people and relations to their IDs.
+//   - Multi-select options, people and relations are sorted, so their order
+//     doesn't affect the hash.
+//   - Datetimes are converted to UTC. For files hosted by Notion, only names
+//     are used, as their URLs expire.
+//   - Numbers are formatted with strconv.FormatFloat, so values that JSON
+//     can't represent (NaN and ±Inf) can be hashed too.
+func (props DatabasePageProperties) Hash() string {
+	normalized := make(map[string]interface{}, len(props))
+	for name, prop := range props {
+		if value := normalizePropValue(prop); value != nil {
+			normalized[name] = value
+		}
+	}
+
+	// Maps are encoded with sorted keys, so the result is deterministic. Numbers
+	// and datetimes are normalized to strings, so normalized values only contain
+	// types that can always be encoded, and the error can be ignored.
+	b, _ := json.Marshal(normalized)
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
+
+type normalizedRichText struct {
+	Text        string       `json:"text"`
+	Annotations *Annotations `json:"annotations,omitempty"`
+	Link        string       `json:"link,omitempty"`
+}
+
+type normalizedDate struct {
+	Start    string  `json:"start"`
+	End      string  `json:"end,omitempty"`
+	TimeZone *string `json:"time_zone,omitempty"`
+}
+
+// normalizePropValue returns the normalized value of a property, keyed by its
+// value field, or nil if it has no value.
+func normalizePropValue(prop DatabasePageProperty) map[string]interface{} {
+	fields := prop.valueFields()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	value := make(map[string]interface{}, len(fields))
+
+	for _, field := range fields {
+		switch DatabasePropertyType(field) {
+		case DBPropTypeTitle:
+			value[field] = normalizeRichText(prop.Title)
+		case DBPropTypeRichText:
+			value[field] = normalizeRichText(prop.RichText)
+		case DBPropTypeNumber:
+			value[field] = normalizeNumber(prop.Number)
+		case DBPropTypeSelect:
+			value[field] = prop.Select.Name
+		case DBPropTypeStatus:
+			value[field] = prop.Status.Name
+		case DBPropTypeMultiSelect:
+			value[field] = sortedStrings(len(prop.MultiSelect), func(i int) string { return prop.MultiSelect[i].Name })
+		case DBPropTypeDate:
+			value[field] = normalizeDate(prop.Date)
+		case DBPropTypePeople:
+			value[field] = sortedStrings(len(prop.People), func(i int) string { return prop.People[i].ID })
+		case DBPropTypeRelation:
+			value[field] = sortedStrings(len(prop.Relation), func(i int) string { return prop.Relation[i].ID })
+		case DBPropTypeFiles:
+			files := make([][2]string, len(prop.Files))
+			for i, file := range prop.Files {
+				files[i][0] = file.Name
+				if file.External != nil {
+					files[i][1] = file.External.URL
+				}
+			}
+			value[field] = files
+		case DBPropTypeCheckbox:
+			value[field] = prop.Checkbox
+		case DBPropTypeURL:
+			value[field] = prop.URL
+		case DBPropTypeEmail:
+			value[field] = prop.Email
+		case DBPropTypePhoneNumber:
+			value[field] = prop.PhoneNumber
+		case DBPropTypeFormula:
+			formula := *prop.Formula
+			value[field] = map[string]interface{}{
+				"string":  formula.String,
+				"number":  normalizeNumber(formula.Number),
+				"boolean": formula.Boolean,
+				"date":    normalizeDate(formula.Date),
+			}
+		case DBPropTypeRollup:
+			rollup := *prop.Rollup
+			array := make([]interface{}, len(rollup.Array))
+			for i, item := range rollup.Array {
+				array[i] = normalizePropValue(item)
+			}
+			value[field] = map[string]interface{}{
+				"number": normalizeNumber(rollup.Number),
+				"date":   normalizeDate(rollup.Date),
+				"array":  array,
+			}
+		case DBPropTypeCreatedTime:
+			value[field] = normalizeTime(*prop.CreatedTime)
+		case DBPropTypeCreatedBy:
+			value[field] = prop.CreatedBy.ID
+		case DBPropTypeLastEditedTime:
+			value[field] = normalizeTime(*prop.LastEditedTime)
+		case DBPropTypeLastEditedBy:
+			value[field] = prop.LastEditedBy.ID
+		}
+	}
+
+	return value
+}
+
+func normalizeRichText(richText []RichText) []normalizedRichText {
+	normalized := make([]normalizedRichText, len(richText))
+
+	for i, rt := range richText {
+		normalized[i].Text = PlainText([]RichText{rt})
+		if rt.Annotations != nil {
+			annotations := *rt.Annotations
+			if annotations.Color == ColorDefault {
+				annotations.Color = ""
+			}
+			if annotations != (Annotations{}) {
+				normalized[i].Annotations = &annotations
+			}
+		}
+		switch {
+		case rt.HRef != nil:
+			normalized[i].Link = *rt.HRef
+		case rt.Text != nil && rt.Text.Link != nil:
+			normalized[i].Link = rt.Text.Link.URL
+		}
+	}
+
+	return normalized
+}
+
+func normalizeDate(date *Date) *normalizedDate {
+	if date == nil {
+		return nil
+	}
+
+	normalized := &normalizedDate{
+		Start:    normalizeDateTime(date.Start),
+		TimeZone: date.TimeZone,
+	}
+	if date.End != nil {
+		normalized.End = normalizeDateTime(*date.End)
+	}
+
+	return normalized
+}
+
+func normalizeDateTime(dt DateTime) string {
+	if dt.HasTime() {
+		return normalizeTime(dt.Time)
+	}
+	return dt.Time.Format(DateTimeFormat[:dateLength])
+}
+
+func normalizeTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// normalizeNumber formats a number as a string, or returns nil if it's nil.
+func normalizeNumber(n *float64) *string {
+	if n == nil {
+		return nil
+	}
+	s := strconv.FormatFloat(*n, 'g', -1, 64)
+	return &s
+}
+
+func sortedStrings(n int, value func(i int) string) []string {
+	strs := make([]string, n)
+	for i := range strs {
+		strs[i] = value(i)
+	}
+	sort.Strings(strs)
+	return strs
+}
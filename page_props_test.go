@@ -0,0 +1,232 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPagePropsBuilder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		builder  *notion.PagePropsBuilder
+		expJSON  map[string]interface{}
+		expError error
+	}{
+		{
+			name: "all value fields set once",
+			builder: notion.NewPageProps().
+				Title("Reason", notion.RichText{Text: &notion.Text{Content: "Foobar"}}).
+				Phone("Phone", "+31612345678").
+				Email("Email", "foo@example.com").
+				Select("City", "Paris").
+				MultiSelect("Tags", "a", "b").
+				Number("Count", 2.5).
+				Checkbox("Done", false).
+				People("Owner", "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"),
+			expJSON: map[string]interface{}{
+				"Reason": map[string]interface{}{
+					"title": []interface{}{
+						map[string]interface{}{
+							"text": map[string]interface{}{
+								"content": "Foobar",
+							},
+						},
+					},
+				},
+				"Phone": map[string]interface{}{
+					"phone_number": "+31612345678",
+				},
+				"Email": map[string]interface{}{
+					"email": "foo@example.com",
+				},
+				"City": map[string]interface{}{
+					"select": map[string]interface{}{
+						"name": "Paris",
+					},
+				},
+				"Tags": map[string]interface{}{
+					"multi_select": []interface{}{
+						map[string]interface{}{"name": "a"},
+						map[string]interface{}{"name": "b"},
+					},
+				},
+				"Count": map[string]interface{}{
+					"number": 2.5,
+				},
+				"Done": map[string]interface{}{
+					"checkbox": false,
+				},
+				"Owner": map[string]interface{}{
+					"people": []interface{}{
+						map[string]interface{}{
+							"id":         "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18",
+							"type":       "",
+							"name":       "",
+							"avatar_url": "",
+							"person":     nil,
+							"bot":        nil,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cleared properties",
+			builder: notion.NewPageProps().
+				Clear("City", notion.DBPropTypeSelect).
+				Clear("Due", notion.DBPropTypeDate).
+				Clear("Owner", notion.DBPropTypePeople).
+				Clear("Done", notion.DBPropTypeCheckbox),
+			expJSON: map[string]interface{}{
+				"City":  map[string]interface{}{"select": nil},
+				"Due":   map[string]interface{}{"date": nil},
+				"Owner": map[string]interface{}{"people": []interface{}{}},
+				"Done":  map[string]interface{}{"checkbox": false},
+			},
+		},
+		{
+			name:     "cleared read-only property",
+			builder:  notion.NewPageProps().Clear("Total", notion.DBPropTypeFormula),
+			expError: errors.New(`notion: invalid page properties: property "Total": cannot clear read-only formula property`),
+		},
+		{
+			name:     "cleared property without type",
+			builder:  notion.NewPageProps().Clear("City", ""),
+			expError: errors.New(`notion: invalid page properties: property "City": type is required when clearing a property`),
+		},
+		{
+			name:     "duplicate property name",
+			builder:  notion.NewPageProps().Email("Email", "foo@example.com").Email("Email", "bar@example.com"),
+			expError: errors.New(`notion: invalid page properties: property "Email" is set more than once`),
+		},
+		{
+			name:     "empty property name",
+			builder:  notion.NewPageProps().Select("", "Paris"),
+			expError: errors.New("notion: invalid page properties: property name cannot be empty"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			props, err := tt.builder.Build()
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && err == nil {
+				t.Fatalf("error not equal (expected: %v, got: nil)", tt.expError)
+			}
+			if tt.expError != nil && err != nil && tt.expError.Error() != err.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+			if tt.expError != nil {
+				return
+			}
+
+			b, err := json.Marshal(props)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := map[string]interface{}{}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expJSON, got); diff != "" {
+				t.Fatalf("encoded JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestDatabasePagePropertiesHash(t *testing.T) {
+	t.Parallel()
+
+	// Properties as returned by the API.
+	var fetched notion.DatabasePageProperties
+	err := json.Unmarshal([]byte(`{
+		"Name": {"id": "title", "type": "title", "title": [{
+			"type": "text",
+			"text": {"content": "Foobar", "link": null},
+			"annotations": {"bold": false, "italic": false, "strikethrough": false, "underline": false, "code": false, "color": "default"},
+			"plain_text": "Foobar",
+			"href": null
+		}]},
+		"Tags": {"id": "tags", "type": "multi_select", "multi_select": [
+			{"id": "1", "name": "b", "color": "red"},
+			{"id": "2", "name": "a", "color": "blue"}
+		]},
+		"Due": {"id": "due", "type": "date", "date": {"start": "2022-09-01T14:00:00.000+02:00", "end": null, "time_zone": null}},
+		"Count": {"id": "count", "type": "number", "number": 2.5},
+		"Notes": {"id": "notes", "type": "rich_text", "rich_text": []}
+	}`), &fetched)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	due, err := notion.ParseDateTime("2022-09-01T12:00:00.000Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	built, err := notion.NewPageProps().
+		Title("Name", notion.RichText{Text: &notion.Text{Content: "Foobar"}}).
+		MultiSelect("Tags", "a", "b").
+		Date("Due", notion.Date{Start: due}).
+		Number("Count", 2.5).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fetched.Hash() != built.Hash() {
+		t.Fatalf("expected equal hashes for fetched and built properties")
+	}
+	if fetched.Hash() != fetched.Hash() {
+		t.Fatalf("expected hash to be stable")
+	}
+
+	changed, err := notion.NewPageProps().
+		Title("Name", notion.RichText{Text: &notion.Text{Content: "Foobar"}, Annotations: &notion.Annotations{Bold: true}}).
+		MultiSelect("Tags", "a", "b").
+		Date("Due", notion.Date{Start: due}).
+		Number("Count", 2.5).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if changed.Hash() == built.Hash() {
+		t.Fatalf("expected different hashes for changed properties")
+	}
+}
+
+func TestDatabasePagePropertiesHashNonFiniteNumbers(t *testing.T) {
+	t.Parallel()
+
+	hash := func(n float64) string {
+		props := notion.DatabasePageProperties{"Count": {Number: &n}}
+		return props.Hash()
+	}
+
+	if hash(math.NaN()) != hash(math.NaN()) {
+		t.Fatalf("expected hash of NaN to be stable")
+	}
+	if hash(math.Inf(1)) == hash(math.Inf(-1)) {
+		t.Fatalf("expected different hashes for +Inf and -Inf")
+	}
+	if hash(math.NaN()) == hash(0) {
+		t.Fatalf("expected different hashes for NaN and 0")
+	}
+}
@@ -0,0 +1,547 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UnmarshalPage copies the ID and property values of a page into the struct
+// pointed to by `v`, using `notion` struct tags to map struct fields to
+// properties. The tag value is the property name, optionally followed by the
+// property type, which is checked when set:
+//
+//	type Task struct {
+//		ID     string    `notion:",id"`
+//		Name   string    `notion:"Name,title"`
+//		Done   bool      `notion:"Done,checkbox"`
+//		Tags   []string  `notion:"Tags,multi_select"`
+//		Due    time.Time `notion:"Due,date"`
+//		Points *float64  `notion:"Points"`
+//	}
+//
+// Fields without a tag, or with tag "-", are ignored. A field with the `id`
+// option is set to the page ID. Properties that are missing on the page leave
+// fields unchanged.
+//
+// Besides the matching field types of DatabasePageProperty (e.g. `[]RichText`
+// for `title`), and DatabasePageProperty itself, values are converted to these
+// field types:
+//
+//   - string, for `title` and `rich_text` (plain text), `select` and `status`
+//     (option name), `created_by` and `last_edited_by` (user ID), and string
+//     formula results.
+//   - []string, for `multi_select` (option names), `people` (user IDs) and
+//     `relation` (page IDs).
+//   - Numeric types, for `number` properties and number formula results.
+//   - bool, for `checkbox` properties and boolean formula results.
+//   - time.Time and DateTime, for `date` properties (the start value) and
+//     date formula results.
+//
+// Pointer fields are set to nil when a property has no value.
+func UnmarshalPage(page Page, v interface{}) error {
+	return UnmarshalPageWithOpts(page, v, nil)
+}
+
+// UnmarshalPageWithOpts is like UnmarshalPage, but matches property names as
+// configured by `opts` (optional), see DatabasePageProperties.Lookup.
+func UnmarshalPageWithOpts(page Page, v interface{}, opts *LookupOpts) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("notion: cannot unmarshal page into %T, must be a non-nil pointer to a struct", v)
+	}
+	rv = rv.Elem()
+
+	props := page.AllProperties()
+
+	for _, field := range structFields(rv.Type()) {
+		fv := rv.FieldByIndex(field.index)
+
+		if field.id {
+			if fv.Kind() != reflect.String {
+				return fmt.Errorf("notion: cannot unmarshal page ID into Go struct field %v.%v of type %v",
+					rv.Type().Name(), field.goName, fv.Type())
+			}
+			fv.SetString(page.ID)
+			continue
+		}
+
+		prop, ok, err := props.Lookup(field.name, opts)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		// Properties built client side (e.g. via MarshalProps) have no type.
+		if fields := prop.valueFields(); prop.Type == "" && len(fields) == 1 {
+			prop.Type = DatabasePropertyType(fields[0])
+		}
+		if field.propType != "" && prop.Type != "" && field.propType != prop.Type {
+			return fmt.Errorf("notion: cannot unmarshal %v property %q into Go struct field %v.%v, expected %v property",
+				prop.Type, field.name, rv.Type().Name(), field.goName, field.propType)
+		}
+
+		if err := unmarshalProp(prop, fv); err != nil {
+			return fmt.Errorf("notion: cannot unmarshal %v property %q into Go struct field %v.%v of type %v",
+				prop.Type, field.name, rv.Type().Name(), field.goName, fv.Type())
+		}
+	}
+
+	return nil
+}
+
+// MarshalProps returns database page properties for creating or updating a
+// page, from a struct (or pointer to a struct) with `notion` struct tags. See
+// UnmarshalPage for the tag format. When the property type is omitted from a
+// tag, it's derived from the field type: string and []RichText fields are
+// marshalled as `rich_text`, []string as `multi_select`, numeric types as
+// `number`, bool as `checkbox`, and time.Time and DateTime as `date`.
+//
+// String fields can be marshalled as `title`, `rich_text`, `select`, `status`,
+// `url`, `email` and `phone_number`, and []string fields as `multi_select`,
+// `people` (user IDs) and `relation` (page IDs). Dates from time.Time values
+// include time, use DateTime for dates without time.
+//
+// Properties without a value (e.g. empty strings and slices, nil pointers) are
+// omitted, as well as read-only properties (e.g. `formula`) and the page ID.
+func MarshalProps(v interface{}) (DatabasePageProperties, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("notion: cannot marshal %T to page properties, must be a struct", v)
+	}
+
+	props := DatabasePageProperties{}
+
+	for _, field := range structFields(rv.Type()) {
+		if field.id {
+			continue
+		}
+
+		fv := rv.FieldByIndex(field.index)
+
+		prop, err := marshalProp(field.propType, fv)
+		if err != nil {
+			return nil, fmt.Errorf("notion: cannot marshal Go struct field %v.%v of type %v into property %q",
+				rv.Type().Name(), field.goName, fv.Type(), field.name)
+		}
+		if len(prop.valueFields()) == 0 {
+			continue
+		}
+
+		props[field.name] = prop
+	}
+
+	return props, nil
+}
+
+// marshalProp returns a property with value `v`. The returned property has no
+// value field set if `v` is empty, or if the property type is read-only.
+func marshalProp(propType DatabasePropertyType, v reflect.Value) (DatabasePageProperty, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return DatabasePageProperty{}, nil
+		}
+		v = v.Elem()
+	}
+
+	if prop, ok := v.Interface().(DatabasePageProperty); ok {
+		return prop, nil
+	}
+	// Use the underlying type of custom string types, e.g. for enums.
+	if v.Kind() == reflect.String {
+		v = reflect.ValueOf(v.String())
+	}
+
+	if propType == "" {
+		propType = inferPropType(v.Type())
+	}
+
+	var prop DatabasePageProperty
+
+	switch value := v.Interface().(type) {
+	case string:
+		if value == "" {
+			return prop, nil
+		}
+		switch propType {
+		case DBPropTypeTitle:
+			prop.Title = []RichText{{Text: &Text{Content: value}}}
+		case DBPropTypeRichText:
+			prop.RichText = []RichText{{Text: &Text{Content: value}}}
+		case DBPropTypeSelect:
+			prop.Select = &SelectOptions{Name: value}
+		case DBPropTypeStatus:
+			prop.Status = &SelectOptions{Name: value}
+		case DBPropTypeURL:
+			prop.URL = &value
+		case DBPropTypeEmail:
+			prop.Email = &value
+		case DBPropTypePhoneNumber:
+			prop.PhoneNumber = &value
+		default:
+			return marshalReadOnly(propType)
+		}
+	case []RichText:
+		switch propType {
+		case DBPropTypeTitle:
+			prop.Title = value
+		case DBPropTypeRichText:
+			prop.RichText = value
+		default:
+			return marshalReadOnly(propType)
+		}
+	case []string:
+		switch propType {
+		case DBPropTypeMultiSelect:
+			for _, name := range value {
+				prop.MultiSelect = append(prop.MultiSelect, SelectOptions{Name: name})
+			}
+		case DBPropTypePeople:
+			for _, id := range value {
+				prop.People = append(prop.People, User{BaseUser: BaseUser{ID: id}})
+			}
+		case DBPropTypeRelation:
+			for _, id := range value {
+				prop.Relation = append(prop.Relation, Relation{ID: id})
+			}
+		default:
+			return marshalReadOnly(propType)
+		}
+	case SelectOptions:
+		switch propType {
+		case DBPropTypeSelect:
+			prop.Select = &value
+		case DBPropTypeStatus:
+			prop.Status = &value
+		default:
+			return marshalReadOnly(propType)
+		}
+	case []SelectOptions:
+		if propType != DBPropTypeMultiSelect {
+			return marshalReadOnly(propType)
+		}
+		prop.MultiSelect = value
+	case []User:
+		if propType != DBPropTypePeople {
+			return marshalReadOnly(propType)
+		}
+		prop.People = value
+	case []Relation:
+		if propType != DBPropTypeRelation {
+			return marshalReadOnly(propType)
+		}
+		prop.Relation = value
+	case []File:
+		if propType != DBPropTypeFiles {
+			return marshalReadOnly(propType)
+		}
+		prop.Files = value
+	case bool:
+		if propType != DBPropTypeCheckbox {
+			return marshalReadOnly(propType)
+		}
+		prop.Checkbox = &value
+	case time.Time:
+		if propType != DBPropTypeDate {
+			return marshalReadOnly(propType)
+		}
+		if !value.IsZero() {
+			prop.Date = &Date{Start: NewDateTime(value, true)}
+		}
+	case DateTime:
+		if propType != DBPropTypeDate {
+			return marshalReadOnly(propType)
+		}
+		if !value.IsZero() {
+			prop.Date = &Date{Start: value}
+		}
+	case Date:
+		if propType != DBPropTypeDate {
+			return marshalReadOnly(propType)
+		}
+		prop.Date = &value
+	default:
+		if propType != DBPropTypeNumber {
+			return marshalReadOnly(propType)
+		}
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			prop.Number = Float64Ptr(v.Float())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			prop.Number = Float64Ptr(float64(v.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			prop.Number = Float64Ptr(float64(v.Uint()))
+		default:
+			return prop, errUnsupportedFieldType
+		}
+	}
+
+	return prop, nil
+}
+
+// marshalReadOnly returns an empty property for read-only property types, so
+// they're omitted, or an error for other types.
+func marshalReadOnly(propType DatabasePropertyType) (DatabasePageProperty, error) {
+	if (DatabasePageProperty{Type: propType}).isReadOnly() {
+		return DatabasePageProperty{}, nil
+	}
+	return DatabasePageProperty{}, errUnsupportedFieldType
+}
+
+// inferPropType returns the default property type for a Go type, see
+// MarshalProps.
+func inferPropType(t reflect.Type) DatabasePropertyType {
+	switch t {
+	case reflect.TypeOf([]RichText(nil)):
+		return DBPropTypeRichText
+	case reflect.TypeOf([]string(nil)), reflect.TypeOf([]SelectOptions(nil)):
+		return DBPropTypeMultiSelect
+	case reflect.TypeOf(SelectOptions{}):
+		return DBPropTypeSelect
+	case reflect.TypeOf([]User(nil)):
+		return DBPropTypePeople
+	case reflect.TypeOf([]Relation(nil)):
+		return DBPropTypeRelation
+	case reflect.TypeOf([]File(nil)):
+		return DBPropTypeFiles
+	case timeType, dateTimeType, reflect.TypeOf(Date{}):
+		return DBPropTypeDate
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return DBPropTypeRichText
+	case reflect.Bool:
+		return DBPropTypeCheckbox
+	case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return DBPropTypeNumber
+	}
+
+	return ""
+}
+
+// structField is a struct field with a `notion` tag.
+type structField struct {
+	index    []int
+	goName   string
+	name     string
+	propType DatabasePropertyType
+	id       bool
+}
+
+var structFieldsCache sync.Map // map[reflect.Type][]structField
+
+// structFields returns the fields with a `notion` tag of struct type `t`.
+func structFields(t reflect.Type) []structField {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]structField)
+	}
+
+	var fields []structField
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("notion")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := structField{
+			index:  sf.Index,
+			goName: sf.Name,
+			name:   parts[0],
+		}
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "id":
+				field.id = true
+			default:
+				field.propType = DatabasePropertyType(opt)
+			}
+		}
+		if field.name == "" && !field.id {
+			field.name = sf.Name
+		}
+
+		fields = append(fields, field)
+	}
+
+	structFieldsCache.Store(t, fields)
+
+	return fields
+}
+
+var errUnsupportedFieldType = errors.New("unsupported field type")
+
+// unmarshalProp sets the value of property `prop` on `v`.
+func unmarshalProp(prop DatabasePageProperty, v reflect.Value) error {
+	if v.Type() == reflect.TypeOf(prop) {
+		v.Set(reflect.ValueOf(prop))
+		return nil
+	}
+
+	var value interface{}
+
+	switch prop.Type {
+	case DBPropTypeTitle:
+		value = prop.Title
+	case DBPropTypeRichText:
+		value = prop.RichText
+	case DBPropTypeNumber:
+		value = prop.Number
+	case DBPropTypeSelect:
+		value = prop.Select
+	case DBPropTypeStatus:
+		value = prop.Status
+	case DBPropTypeMultiSelect:
+		value = prop.MultiSelect
+	case DBPropTypeDate:
+		value = prop.Date
+	case DBPropTypePeople:
+		value = prop.People
+	case DBPropTypeRelation:
+		value = prop.Relation
+	case DBPropTypeFiles:
+		value = prop.Files
+	case DBPropTypeCheckbox:
+		value = prop.Checkbox
+	case DBPropTypeURL:
+		value = prop.URL
+	case DBPropTypeEmail:
+		value = prop.Email
+	case DBPropTypePhoneNumber:
+		value = prop.PhoneNumber
+	case DBPropTypeFormula:
+		value = prop.Formula
+	case DBPropTypeRollup:
+		value = prop.Rollup
+	case DBPropTypeCreatedTime:
+		value = prop.CreatedTime
+	case DBPropTypeCreatedBy:
+		value = prop.CreatedBy
+	case DBPropTypeLastEditedTime:
+		value = prop.LastEditedTime
+	case DBPropTypeLastEditedBy:
+		value = prop.LastEditedBy
+	default:
+		return errUnsupportedFieldType
+	}
+
+	return assignValue(v, reflect.ValueOf(value))
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateTimeType = reflect.TypeOf(DateTime{})
+)
+
+// assignValue sets `src` (a property value) on `dst`, converting it to the type
+// of `dst` when needed.
+func assignValue(dst, src reflect.Value) error {
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		src = src.Elem()
+		if src.Type().AssignableTo(dst.Type()) {
+			dst.Set(src)
+			return nil
+		}
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	switch s := src.Interface().(type) {
+	case []RichText:
+		if dst.Kind() == reflect.String {
+			dst.SetString(PlainText(s))
+			return nil
+		}
+	case SelectOptions:
+		if dst.Kind() == reflect.String {
+			dst.SetString(s.Name)
+			return nil
+		}
+	case User:
+		if dst.Kind() == reflect.String {
+			dst.SetString(s.ID)
+			return nil
+		}
+	case []SelectOptions:
+		return assignStrings(dst, len(s), func(i int) string { return s[i].Name })
+	case []User:
+		return assignStrings(dst, len(s), func(i int) string { return s[i].ID })
+	case []Relation:
+		return assignStrings(dst, len(s), func(i int) string { return s[i].ID })
+	case Date:
+		switch dst.Type() {
+		case timeType:
+			dst.Set(reflect.ValueOf(s.Start.Time))
+			return nil
+		case dateTimeType:
+			dst.Set(reflect.ValueOf(s.Start))
+			return nil
+		}
+	case float64:
+		switch dst.Kind() {
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(s)
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			dst.SetInt(int64(s))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			dst.SetUint(uint64(s))
+			return nil
+		}
+	case FormulaResult:
+		switch s.Type {
+		case FormulaResultTypeString:
+			return assignValue(dst, reflect.ValueOf(s.String))
+		case FormulaResultTypeNumber:
+			return assignValue(dst, reflect.ValueOf(s.Number))
+		case FormulaResultTypeBoolean:
+			return assignValue(dst, reflect.ValueOf(s.Boolean))
+		case FormulaResultTypeDate:
+			return assignValue(dst, reflect.ValueOf(s.Date))
+		}
+	}
+
+	return errUnsupportedFieldType
+}
+
+func assignStrings(dst reflect.Value, n int, value func(i int) string) error {
+	if dst.Type() != reflect.TypeOf([]string(nil)) {
+		return errUnsupportedFieldType
+	}
+
+	strs := make([]string, n)
+	for i := range strs {
+		strs[i] = value(i)
+	}
+	dst.Set(reflect.ValueOf(strs))
+
+	return nil
+}
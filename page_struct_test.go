@@ -0,0 +1,247 @@
+package notion_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+type task struct {
+	ID       string                      `notion:",id"`
+	Name     string                      `notion:"Name,title"`
+	Notes    []notion.RichText           `notion:"Notes"`
+	Done     bool                        `notion:"Done,checkbox"`
+	Points   int                         `notion:"Points,number"`
+	Estimate *float64                    `notion:"Estimate"`
+	Status   string                      `notion:"Status,status"`
+	Tags     []string                    `notion:"Tags,multi_select"`
+	Due      time.Time                   `notion:"Due,date"`
+	Owners   []string                    `notion:"Owners,people"`
+	Blocks   []string                    `notion:"Blocked by,relation"`
+	URL      *string                     `notion:"URL,url"`
+	Overdue  bool                        `notion:"Overdue,formula"`
+	Raw      notion.DatabasePageProperty `notion:"Rollup"`
+	Missing  string                      `notion:"Missing"`
+	Ignored  string                      `notion:"-"`
+	Untagged string
+}
+
+func TestUnmarshalPage(t *testing.T) {
+	t.Parallel()
+
+	due := mustParseTime(time.RFC3339, "2022-09-01T12:00:00Z")
+	rollup := notion.DatabasePageProperty{
+		Type:   notion.DBPropTypeRollup,
+		Rollup: &notion.RollupResult{Type: notion.RollupResultTypeNumber, Number: notion.Float64Ptr(3)},
+	}
+
+	page := notion.Page{
+		ID: "page-id",
+		Properties: notion.DatabasePageProperties{
+			"Name":       {Type: notion.DBPropTypeTitle, Title: []notion.RichText{{PlainText: "Foo"}, {PlainText: "bar"}}},
+			"Notes":      {Type: notion.DBPropTypeRichText, RichText: []notion.RichText{{PlainText: "Note"}}},
+			"Done":       {Type: notion.DBPropTypeCheckbox, Checkbox: notion.BoolPtr(true)},
+			"Points":     {Type: notion.DBPropTypeNumber, Number: notion.Float64Ptr(5)},
+			"Estimate":   {Type: notion.DBPropTypeNumber},
+			"Status":     {Type: notion.DBPropTypeStatus, Status: &notion.SelectOptions{Name: "In progress"}},
+			"Tags":       {Type: notion.DBPropTypeMultiSelect, MultiSelect: []notion.SelectOptions{{Name: "a"}, {Name: "b"}}},
+			"Due":        {Type: notion.DBPropTypeDate, Date: &notion.Date{Start: notion.NewDateTime(due, true)}},
+			"Owners":     {Type: notion.DBPropTypePeople, People: []notion.User{{BaseUser: notion.BaseUser{ID: "user-id"}}}},
+			"Blocked by": {Type: notion.DBPropTypeRelation, Relation: []notion.Relation{{ID: "other-id"}}},
+			"URL":        {Type: notion.DBPropTypeURL, URL: notion.StringPtr("https://example.com")},
+			"Overdue": {
+				Type:    notion.DBPropTypeFormula,
+				Formula: &notion.FormulaResult{Type: notion.FormulaResultTypeBoolean, Boolean: notion.BoolPtr(true)},
+			},
+			"Rollup": rollup,
+		},
+	}
+
+	got := task{Estimate: notion.Float64Ptr(1), Missing: "unchanged"}
+	if err := notion.UnmarshalPage(page, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := task{
+		ID:      "page-id",
+		Name:    "Foobar",
+		Notes:   []notion.RichText{{PlainText: "Note"}},
+		Done:    true,
+		Points:  5,
+		Status:  "In progress",
+		Tags:    []string{"a", "b"},
+		Due:     due,
+		Owners:  []string{"user-id"},
+		Blocks:  []string{"other-id"},
+		URL:     notion.StringPtr("https://example.com"),
+		Overdue: true,
+		Raw:     rollup,
+		Missing: "unchanged",
+	}
+
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("struct not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestUnmarshalPageErrors(t *testing.T) {
+	t.Parallel()
+
+	page := notion.Page{
+		ID: "page-id",
+		Properties: notion.DatabasePageProperties{
+			"Name": {Type: notion.DBPropTypeTitle, Title: []notion.RichText{{PlainText: "Foo"}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		v        interface{}
+		expError string
+	}{
+		{
+			name:     "not a pointer",
+			v:        task{},
+			expError: "notion: cannot unmarshal page into notion_test.task, must be a non-nil pointer to a struct",
+		},
+		{
+			name: "property type mismatch",
+			v: &struct {
+				Name string `notion:"Name,rich_text"`
+			}{},
+			expError: `notion: cannot unmarshal title property "Name" into Go struct field .Name, expected rich_text property`,
+		},
+		{
+			name: "unsupported field type",
+			v: &struct {
+				Name int `notion:"Name"`
+			}{},
+			expError: `notion: cannot unmarshal title property "Name" into Go struct field .Name of type int`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := notion.UnmarshalPage(page, tt.v)
+			if err == nil || err.Error() != tt.expError {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
+
+func TestMarshalProps(t *testing.T) {
+	t.Parallel()
+
+	type priority string
+
+	due := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	v := struct {
+		ID       string          `notion:",id"`
+		Name     string          `notion:"Name,title"`
+		Notes    string          `notion:"Notes"`
+		Done     bool            `notion:"Done"`
+		Points   int             `notion:"Points"`
+		Estimate *float64        `notion:"Estimate"`
+		Priority priority        `notion:"Priority,select"`
+		Status   string          `notion:"Status,status"`
+		Tags     []string        `notion:"Tags"`
+		Due      time.Time       `notion:"Due"`
+		Start    notion.DateTime `notion:"Start"`
+		Owners   []string        `notion:"Owners,people"`
+		Blocks   []string        `notion:"Blocked by,relation"`
+		Email    string          `notion:"Email,email"`
+		Overdue  bool            `notion:"Overdue,formula"`
+		Ignored  string          `notion:"-"`
+	}{
+		ID:       "page-id",
+		Name:     "Foobar",
+		Points:   5,
+		Priority: "High",
+		Tags:     []string{"a", "b"},
+		Due:      due,
+		Start:    notion.NewDateTime(due, false),
+		Owners:   []string{"user-id"},
+		Blocks:   []string{"other-id"},
+		Overdue:  true,
+		Ignored:  "foo",
+	}
+
+	props, err := notion.MarshalProps(&v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := notion.DatabasePageProperties{
+		"Name":       {Title: []notion.RichText{{Text: &notion.Text{Content: "Foobar"}}}},
+		"Done":       {Checkbox: notion.BoolPtr(false)},
+		"Points":     {Number: notion.Float64Ptr(5)},
+		"Priority":   {Select: &notion.SelectOptions{Name: "High"}},
+		"Tags":       {MultiSelect: []notion.SelectOptions{{Name: "a"}, {Name: "b"}}},
+		"Due":        {Date: &notion.Date{Start: notion.NewDateTime(due, true)}},
+		"Start":      {Date: &notion.Date{Start: notion.NewDateTime(due, false)}},
+		"Owners":     {People: []notion.User{{BaseUser: notion.BaseUser{ID: "user-id"}}}},
+		"Blocked by": {Relation: []notion.Relation{{ID: "other-id"}}},
+	}
+
+	if diff := cmp.Diff(exp, props); diff != "" {
+		t.Fatalf("properties not equal (-exp, +got):\n%v", diff)
+	}
+
+	// Round trip.
+	var got task
+	if err := notion.UnmarshalPage(notion.Page{Properties: props}, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != v.Name || got.Points != v.Points || !got.Due.Equal(due) {
+		t.Fatalf("unexpected round trip result: %+v", got)
+	}
+}
+
+func TestMarshalPropsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		v        interface{}
+		expError string
+	}{
+		{
+			name:     "not a struct",
+			v:        "foo",
+			expError: "notion: cannot marshal string to page properties, must be a struct",
+		},
+		{
+			name: "invalid property type",
+			v: struct {
+				Done bool `notion:"Done,title"`
+			}{},
+			expError: `notion: cannot marshal Go struct field .Done of type bool into property "Done"`,
+		},
+		{
+			name: "unsupported field type",
+			v: struct {
+				Meta map[string]string `notion:"Meta"`
+			}{Meta: map[string]string{}},
+			expError: `notion: cannot marshal Go struct field .Meta of type map[string]string into property "Meta"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := notion.MarshalProps(tt.v)
+			if err == nil || err.Error() != tt.expError {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,20 @@
 package notion
 
+import (
+	"errors"
+	"fmt"
+)
+
+// Parent is the parent of a page, database, block or comment. See the
+// constructors (e.g. PageParent) for creating parents.
 type Parent struct {
 	Type ParentType `json:"type,omitempty"`
 
-	BlockID    string `json:"block_id,omitempty"`
-	PageID     string `json:"page_id,omitempty"`
-	DatabaseID string `json:"database_id,omitempty"`
-	Workspace  bool   `json:"workspace,omitempty"`
+	BlockID      string `json:"block_id,omitempty"`
+	PageID       string `json:"page_id,omitempty"`
+	DatabaseID   string `json:"database_id,omitempty"`
+	DataSourceID string `json:"data_source_id,omitempty"`
+	Workspace    bool   `json:"workspace,omitempty"`
 }
 
 type ParentType string
@@ -16,4 +24,91 @@ const (
 	ParentTypePage      ParentType = "page_id"
 	ParentTypeBlock     ParentType = "block_id"
 	ParentTypeWorkspace ParentType = "workspace"
+
+	// ParentTypeDataSource is the parent type of pages in a data source (since
+	// API version 2025-09-03). Both DataSourceID and DatabaseID are set.
+	ParentTypeDataSource ParentType = "data_source_id"
 )
+
+// PageParent returns a parent of type page, e.g. for CreatePageParams.Parent.
+func PageParent(pageID string) Parent {
+	return Parent{Type: ParentTypePage, PageID: pageID}
+}
+
+// DatabaseParent returns a parent of type database.
+func DatabaseParent(databaseID string) Parent {
+	return Parent{Type: ParentTypeDatabase, DatabaseID: databaseID}
+}
+
+// DataSourceParent returns a parent of type data source.
+func DataSourceParent(dataSourceID string) Parent {
+	return Parent{Type: ParentTypeDataSource, DataSourceID: dataSourceID}
+}
+
+// BlockParent returns a parent of type block.
+func BlockParent(blockID string) Parent {
+	return Parent{Type: ParentTypeBlock, BlockID: blockID}
+}
+
+// WorkspaceParent returns a parent of type workspace, for top-level pages.
+func WorkspaceParent() Parent {
+	return Parent{Type: ParentTypeWorkspace, Workspace: true}
+}
+
+// ID returns the ID of the parent, for its type. It's empty for workspace
+// parents.
+func (p Parent) ID() string {
+	switch p.Type {
+	case ParentTypeDatabase:
+		return p.DatabaseID
+	case ParentTypePage:
+		return p.PageID
+	case ParentTypeBlock:
+		return p.BlockID
+	case ParentTypeDataSource:
+		return p.DataSourceID
+	}
+	return ""
+}
+
+// Validate checks that the parent has a type, and that only the ID field of
+// that type is set. For data source parents, DatabaseID may be set as well.
+func (p Parent) Validate() error {
+	switch p.Type {
+	case "":
+		return errors.New("parent type is required")
+	case ParentTypeWorkspace:
+		if !p.Workspace {
+			return errors.New("workspace must be true when parent type is workspace")
+		}
+	case ParentTypeDatabase, ParentTypePage, ParentTypeBlock, ParentTypeDataSource:
+		if p.ID() == "" {
+			return fmt.Errorf("parent ID is required when parent type is %v", p.Type)
+		}
+		if p.Workspace {
+			return fmt.Errorf("workspace can't be true when parent type is %v", p.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported parent type %q", p.Type)
+	}
+
+	for _, other := range []struct {
+		typ ParentType
+		id  string
+	}{
+		{ParentTypeDatabase, p.DatabaseID},
+		{ParentTypePage, p.PageID},
+		{ParentTypeBlock, p.BlockID},
+		{ParentTypeDataSource, p.DataSourceID},
+	} {
+		if other.typ == p.Type || other.id == "" {
+			continue
+		}
+		if p.Type == ParentTypeDataSource && other.typ == ParentTypeDatabase {
+			continue
+		}
+		return fmt.Errorf("%v can't be set when parent type is %v", other.typ, p.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParentValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		parent   notion.Parent
+		expError error
+	}{
+		{
+			name:   "page parent",
+			parent: notion.PageParent("page-id"),
+		},
+		{
+			name:   "database parent",
+			parent: notion.DatabaseParent("database-id"),
+		},
+		{
+			name:   "block parent",
+			parent: notion.BlockParent("block-id"),
+		},
+		{
+			name:   "data source parent with database ID",
+			parent: notion.Parent{Type: notion.ParentTypeDataSource, DataSourceID: "data-source-id", DatabaseID: "database-id"},
+		},
+		{
+			name:   "workspace parent",
+			parent: notion.WorkspaceParent(),
+		},
+		{
+			name:     "missing type",
+			parent:   notion.Parent{PageID: "page-id"},
+			expError: errors.New("parent type is required"),
+		},
+		{
+			name:     "missing ID",
+			parent:   notion.PageParent(""),
+			expError: errors.New("parent ID is required when parent type is page_id"),
+		},
+		{
+			name:     "ID of other type",
+			parent:   notion.Parent{Type: notion.ParentTypeDatabase, PageID: "page-id"},
+			expError: errors.New("parent ID is required when parent type is database_id"),
+		},
+		{
+			name:     "multiple IDs",
+			parent:   notion.Parent{Type: notion.ParentTypeBlock, BlockID: "block-id", PageID: "page-id"},
+			expError: errors.New("page_id can't be set when parent type is block_id"),
+		},
+		{
+			name:     "workspace not true",
+			parent:   notion.Parent{Type: notion.ParentTypeWorkspace},
+			expError: errors.New("workspace must be true when parent type is workspace"),
+		},
+		{
+			name:     "unsupported type",
+			parent:   notion.Parent{Type: "foobar"},
+			expError: errors.New(`unsupported parent type "foobar"`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.parent.Validate()
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && (err == nil || err.Error() != tt.expError.Error()) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
+
+func TestParentParams(t *testing.T) {
+	t.Parallel()
+
+	title := []notion.RichText{{Text: &notion.Text{Content: "Foobar"}}}
+
+	tests := []struct {
+		name      string
+		params    interface{ Validate() error }
+		expParent map[string]interface{}
+		expError  error
+	}{
+		{
+			name:      "page with page parent",
+			params:    notion.CreatePageParams{Parent: notion.PageParent("page-id"), Title: title},
+			expParent: map[string]interface{}{"page_id": "page-id"},
+		},
+		{
+			name:      "page with block parent",
+			params:    notion.CreatePageParams{Parent: notion.BlockParent("block-id"), Title: title},
+			expParent: map[string]interface{}{"block_id": "block-id"},
+		},
+		{
+			name: "page with database parent",
+			params: notion.CreatePageParams{
+				Parent:                 notion.DatabaseParent("database-id"),
+				DatabasePageProperties: &notion.DatabasePageProperties{},
+			},
+			expParent: map[string]interface{}{"database_id": "database-id"},
+		},
+		{
+			name: "page with parent and parent ID",
+			params: notion.CreatePageParams{
+				Parent:   notion.PageParent("page-id"),
+				ParentID: "page-id",
+				Title:    title,
+			},
+			expError: errors.New("parent can't be combined with parent type and parent ID"),
+		},
+		{
+			name:     "page with invalid parent",
+			params:   notion.CreatePageParams{Parent: notion.PageParent(""), Title: title},
+			expError: errors.New("parent ID is required when parent type is page_id"),
+		},
+		{
+			name:      "comment with block parent",
+			params:    notion.CreateCommentParams{Parent: notion.BlockParent("block-id"), RichText: title},
+			expParent: map[string]interface{}{"type": "block_id", "block_id": "block-id"},
+		},
+		{
+			name:     "comment with database parent",
+			params:   notion.CreateCommentParams{Parent: notion.DatabaseParent("database-id"), RichText: title},
+			expError: errors.New(`unsupported parent type "database_id" (expected page_id or block_id)`),
+		},
+		{
+			name: "comment with parent and discussion ID",
+			params: notion.CreateCommentParams{
+				Parent:       notion.PageParent("page-id"),
+				DiscussionID: "discussion-id",
+				RichText:     title,
+			},
+			expError: errors.New("only one of parent, parent page ID, parent block ID and discussion ID can be non-empty"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.params.Validate()
+			if tt.expError != nil {
+				if err == nil || err.Error() != tt.expError.Error() {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			b, err := json.Marshal(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body struct {
+				Parent map[string]interface{} `json:"parent"`
+			}
+			if err := json.Unmarshal(b, &body); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.expParent, body.Parent); diff != "" {
+				t.Errorf("parent not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
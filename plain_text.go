@@ -0,0 +1,140 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MentionResolver renders rich text as plain text, replacing user, page and
+// database mentions with readable names, e.g. for search indexing or
+// notifications. Names of users and titles of pages and databases are fetched
+// via the client, and cached for the lifetime of the resolver. It's safe for
+// concurrent use.
+type MentionResolver struct {
+	client *Client
+	opts   MentionResolverOpts
+
+	mu    sync.Mutex
+	names map[string]string
+}
+
+// MentionResolverOpts is used to configure how mentions are formatted.
+type MentionResolverOpts struct {
+	// FormatUser formats the name of a mentioned user. Defaults to `@Name`.
+	FormatUser func(name string) string
+	// FormatPage formats the title of a mentioned page or database. Defaults
+	// to `[Title]`.
+	FormatPage func(title string) string
+}
+
+// NewMentionResolver returns a new MentionResolver. The `opts` param is
+// optional.
+func NewMentionResolver(client *Client, opts *MentionResolverOpts) *MentionResolver {
+	r := &MentionResolver{
+		client: client,
+		names:  make(map[string]string),
+	}
+
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.FormatUser == nil {
+		r.opts.FormatUser = func(name string) string { return "@" + name }
+	}
+	if r.opts.FormatPage == nil {
+		r.opts.FormatPage = func(title string) string { return "[" + title + "]" }
+	}
+
+	return r
+}
+
+// PlainText returns the concatenated plain text of rich text, with mentions
+// resolved. When a mentioned object isn't accessible to the integration (i.e.
+// not found, or restricted) or has an empty name, the `PlainText` field of
+// the rich text is used.
+func (r *MentionResolver) PlainText(ctx context.Context, richText []RichText) (string, error) {
+	var sb strings.Builder
+
+	for _, rt := range richText {
+		if rt.Mention == nil {
+			sb.WriteString(PlainText([]RichText{rt}))
+			continue
+		}
+
+		text, err := r.mention(ctx, rt)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(text)
+	}
+
+	return sb.String(), nil
+}
+
+func (r *MentionResolver) mention(ctx context.Context, rt RichText) (string, error) {
+	m := rt.Mention
+
+	switch {
+	case m.Type == MentionTypeUser && m.User != nil:
+		if m.User.Name != "" {
+			return r.opts.FormatUser(m.User.Name), nil
+		}
+		name, ok, err := r.resolve(m.User.ID, func() (string, error) {
+			user, err := r.client.FindUserByID(ctx, m.User.ID)
+			return user.Name, err
+		})
+		if err != nil || !ok {
+			return rt.PlainText, err
+		}
+		return r.opts.FormatUser(name), nil
+	case m.Type == MentionTypePage && m.Page != nil:
+		title, ok, err := r.resolve(m.Page.ID, func() (string, error) {
+			page, err := r.client.FindPageByID(ctx, m.Page.ID)
+			return PlainText(pageTitle(page)), err
+		})
+		if err != nil || !ok {
+			return rt.PlainText, err
+		}
+		return r.opts.FormatPage(title), nil
+	case m.Type == MentionTypeDatabase && m.Database != nil:
+		title, ok, err := r.resolve(m.Database.ID, func() (string, error) {
+			db, err := r.client.FindDatabaseByID(ctx, m.Database.ID)
+			return PlainText(db.Title), err
+		})
+		if err != nil || !ok {
+			return rt.PlainText, err
+		}
+		return r.opts.FormatPage(title), nil
+	}
+
+	return rt.PlainText, nil
+}
+
+// resolve returns the cached name for an ID, or calls `find` and caches its
+// result. If the object isn't accessible, `ok` is false.
+func (r *MentionResolver) resolve(id string, find func() (string, error)) (name string, ok bool, err error) {
+	r.mu.Lock()
+	name, ok = r.names[id]
+	r.mu.Unlock()
+	if ok {
+		return name, name != "", nil
+	}
+
+	name, err = find()
+	if errors.Is(err, ErrObjectNotFound) || errors.Is(err, ErrRestrictedResource) {
+		// Cache an empty name, so inaccessible objects aren't fetched again.
+		name, err = "", nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("notion: failed to resolve mention of %v: %w", id, err)
+	}
+
+	r.mu.Lock()
+	r.names[id] = name
+	r.mu.Unlock()
+
+	return name, name != "", nil
+}
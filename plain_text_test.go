@@ -0,0 +1,102 @@
+package notion_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+)
+
+func TestMentionResolver(t *testing.T) {
+	t.Parallel()
+
+	requests := map[string]int{}
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			requests[r.URL.Path]++
+
+			status, body := http.StatusOK, ""
+			switch r.URL.Path {
+			case "/v1/users/user-id":
+				body = `{"object": "user", "id": "user-id", "type": "person", "name": "Jane"}`
+			case "/v1/pages/page-id":
+				body = `{"object": "page", "id": "page-id", "parent": {"type": "workspace", "workspace": true}, "properties": {"title": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Roadmap"}]}}}`
+			case "/v1/databases/db-id":
+				body = `{"object": "database", "id": "db-id", "title": [{"type": "text", "plain_text": "Tasks"}], "properties": {}}`
+			default:
+				status, body = http.StatusNotFound, `{"object": "error", "status": 404, "code": "object_not_found", "message": "Not found."}`
+			}
+
+			return &http.Response{
+				StatusCode: status,
+				Status:     http.StatusText(status),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	mention := func(plainText string, m notion.Mention) notion.RichText {
+		return notion.RichText{Type: notion.RichTextTypeMention, PlainText: plainText, Mention: &m}
+	}
+
+	richText := []notion.RichText{
+		{Type: notion.RichTextTypeText, PlainText: "Hi "},
+		mention("@Anonymous", notion.Mention{Type: notion.MentionTypeUser, User: &notion.User{BaseUser: notion.BaseUser{ID: "user-id"}}}),
+		{Type: notion.RichTextTypeText, PlainText: ", see "},
+		mention("Untitled", notion.Mention{Type: notion.MentionTypePage, Page: &notion.ID{ID: "page-id"}}),
+		{Type: notion.RichTextTypeText, PlainText: " in "},
+		mention("Untitled", notion.Mention{Type: notion.MentionTypeDatabase, Database: &notion.ID{ID: "db-id"}}),
+		{Type: notion.RichTextTypeText, PlainText: ", "},
+		mention("Untitled", notion.Mention{Type: notion.MentionTypePage, Page: &notion.ID{ID: "unshared-id"}}),
+		{Type: notion.RichTextTypeText, PlainText: " and "},
+		mention("@Jane", notion.Mention{Type: notion.MentionTypeUser, User: &notion.User{BaseUser: notion.BaseUser{ID: "user-id"}}}),
+	}
+
+	tests := []struct {
+		name    string
+		opts    *notion.MentionResolverOpts
+		expText string
+	}{
+		{
+			name:    "default formatting",
+			expText: "Hi @Jane, see [Roadmap] in [Tasks], Untitled and @Jane",
+		},
+		{
+			name: "custom formatting",
+			opts: &notion.MentionResolverOpts{
+				FormatUser: func(name string) string { return "<" + name + ">" },
+				FormatPage: func(title string) string { return `"` + title + `"` },
+			},
+			expText: `Hi <Jane>, see "Roadmap" in "Tasks", Untitled and <Jane>`,
+		},
+	}
+
+	// Subtests share the request counter, so aren't run in parallel.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := notion.NewMentionResolver(client, tt.opts)
+
+			for i := 0; i < 2; i++ {
+				text, err := resolver.PlainText(context.Background(), richText)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if text != tt.expText {
+					t.Fatalf("text not equal (expected: %q, got: %q)", tt.expText, text)
+				}
+			}
+		})
+	}
+
+	// Names are cached per resolver.
+	for path, n := range requests {
+		if n != len(tests) {
+			t.Errorf("expected %v requests for %v, got %v", len(tests), path, n)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// DesiredPage is the desired state of a database page, used for planning. When
+// PageID is empty, the page is created.
+type DesiredPage struct {
+	PageID     string
+	Properties DatabasePageProperties
+}
+
+// PlanAction is the type of API call of a planned change.
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "create"
+	PlanActionUpdate PlanAction = "update"
+)
+
+// PropertyChange describes a field-level change of a page property. Old is nil
+// when the current page doesn't have the property.
+type PropertyChange struct {
+	Name string                `json:"name"`
+	Old  *DatabasePageProperty `json:"old,omitempty"`
+	New  DatabasePageProperty  `json:"new"`
+}
+
+// PlannedCall is an API call that would be made to reach the desired state,
+// including the request body it would be made with.
+type PlannedCall struct {
+	Action  PlanAction       `json:"action"`
+	Method  string           `json:"method"`
+	Path    string           `json:"path"`
+	PageID  string           `json:"page_id,omitempty"`
+	Changes []PropertyChange `json:"changes"`
+	Body    json.RawMessage  `json:"body"`
+}
+
+// Plan is the set of API calls that would be made to reach the desired state of
+// pages, like `terraform plan`. Pages without changes have no calls. It can be
+// encoded to JSON as a machine-readable changelog.
+type Plan struct {
+	DatabaseID string        `json:"database_id"`
+	Calls      []PlannedCall `json:"calls"`
+}
+
+// NewPlan returns the plan to get from the current pages (e.g. returned by a
+// database query) to the desired pages of a database, without making any API
+// calls. Properties are compared like Hash does, so only properties that have
+// semantically changed are included in update calls.
+func NewPlan(databaseID string, current []Page, desired []DesiredPage) (Plan, error) {
+	currentByID := make(map[string]Page, len(current))
+	for _, page := range current {
+		currentByID[page.ID] = page
+	}
+
+	plan := Plan{DatabaseID: databaseID, Calls: []PlannedCall{}}
+
+	for i, page := range desired {
+		var (
+			call PlannedCall
+			err  error
+		)
+
+		if page.PageID == "" {
+			call, err = planCreate(databaseID, page)
+		} else {
+			curr, ok := currentByID[page.PageID]
+			if !ok {
+				return Plan{}, fmt.Errorf("notion: desired page [%v]: current page %v not found", i, page.PageID)
+			}
+			call, err = planUpdate(curr, page)
+		}
+		if err != nil {
+			return Plan{}, fmt.Errorf("notion: desired page [%v]: %w", i, err)
+		}
+
+		if call.Action != "" {
+			plan.Calls = append(plan.Calls, call)
+		}
+	}
+
+	return plan, nil
+}
+
+// Plan returns the plan to reach the desired state of pages of a database. It
+// only makes API calls to find the current state of pages, and no calls that
+// make changes. See NewPlan.
+func (c *Client) Plan(ctx context.Context, databaseID string, desired []DesiredPage) (Plan, error) {
+	var current []Page
+
+	for _, page := range desired {
+		if page.PageID == "" {
+			continue
+		}
+
+		curr, err := c.FindPageByID(ctx, page.PageID)
+		if err != nil {
+			return Plan{}, err
+		}
+		current = append(current, curr)
+	}
+
+	return NewPlan(databaseID, current, desired)
+}
+
+func planCreate(databaseID string, page DesiredPage) (PlannedCall, error) {
+	params := CreatePageParams{
+		ParentType: ParentTypeDatabase,
+		ParentID:   databaseID,
+	}
+	if page.Properties != nil {
+		params.DatabasePageProperties = &page.Properties
+	}
+	if err := params.Validate(); err != nil {
+		return PlannedCall{}, fmt.Errorf("invalid page params: %w", err)
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return PlannedCall{}, fmt.Errorf("failed to encode body params to JSON: %w", err)
+	}
+
+	changes := make([]PropertyChange, 0, len(page.Properties))
+	for _, name := range sortedPropNames(page.Properties) {
+		changes = append(changes, PropertyChange{Name: name, New: page.Properties[name]})
+	}
+
+	return PlannedCall{
+		Action:  PlanActionCreate,
+		Method:  http.MethodPost,
+		Path:    "/pages",
+		Changes: changes,
+		Body:    body,
+	}, nil
+}
+
+// planUpdate returns the update call for a page, or a zero value if the page
+// hasn't changed.
+func planUpdate(current Page, page DesiredPage) (PlannedCall, error) {
+	currentProps := current.AllProperties()
+
+	var changes []PropertyChange
+	changed := DatabasePageProperties{}
+
+	for _, name := range sortedPropNames(page.Properties) {
+		prop := page.Properties[name]
+
+		currProp, ok := currentProps[name]
+		if ok && reflect.DeepEqual(normalizePropValue(currProp), normalizePropValue(prop)) {
+			continue
+		}
+
+		change := PropertyChange{Name: name, New: prop}
+		if ok {
+			change.Old = &currProp
+		}
+		changes = append(changes, change)
+		changed[name] = prop
+	}
+
+	if len(changes) == 0 {
+		return PlannedCall{}, nil
+	}
+
+	params := UpdatePageParams{DatabasePageProperties: changed}
+	if err := params.Validate(); err != nil {
+		return PlannedCall{}, fmt.Errorf("invalid page params: %w", err)
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return PlannedCall{}, fmt.Errorf("failed to encode body params to JSON: %w", err)
+	}
+
+	return PlannedCall{
+		Action:  PlanActionUpdate,
+		Method:  http.MethodPatch,
+		Path:    "/pages/" + current.ID,
+		PageID:  current.ID,
+		Changes: changes,
+		Body:    body,
+	}, nil
+}
+
+func sortedPropNames(props DatabasePageProperties) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
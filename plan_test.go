@@ -0,0 +1,194 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewPlan(t *testing.T) {
+	t.Parallel()
+
+	current := []notion.Page{
+		{
+			ID: "page-1",
+			Properties: notion.DatabasePageProperties{
+				"Name":   {ID: "title", Type: notion.DBPropTypeTitle, Title: []notion.RichText{{PlainText: "Foo", Text: &notion.Text{Content: "Foo"}}}},
+				"Status": {ID: "a", Type: notion.DBPropTypeSelect, Select: &notion.SelectOptions{ID: "b", Name: "Todo", Color: notion.ColorRed}},
+			},
+		},
+		{
+			ID: "page-2",
+			Properties: notion.DatabasePageProperties{
+				"Status": {ID: "a", Type: notion.DBPropTypeSelect, Select: &notion.SelectOptions{ID: "c", Name: "Done", Color: notion.ColorGreen}},
+			},
+		},
+	}
+
+	updated, err := notion.NewPageProps().
+		Title("Name", notion.RichText{Text: &notion.Text{Content: "Foo"}}).
+		Select("Status", "Done").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unchanged, err := notion.NewPageProps().Select("Status", "Done").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created, err := notion.NewPageProps().Title("Name", notion.RichText{Text: &notion.Text{Content: "Bar"}}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		desired  []notion.DesiredPage
+		expCalls []notion.PlannedCall
+		expBody  []string
+		expError error
+	}{
+		{
+			name: "update, create and unchanged",
+			desired: []notion.DesiredPage{
+				{PageID: "page-1", Properties: updated},
+				{PageID: "page-2", Properties: unchanged},
+				{Properties: created},
+			},
+			expCalls: []notion.PlannedCall{
+				{
+					Action: notion.PlanActionUpdate,
+					Method: http.MethodPatch,
+					Path:   "/pages/page-1",
+					PageID: "page-1",
+					Changes: []notion.PropertyChange{
+						{
+							Name: "Status",
+							Old:  &notion.DatabasePageProperty{ID: "a", Type: notion.DBPropTypeSelect, Select: &notion.SelectOptions{ID: "b", Name: "Todo", Color: notion.ColorRed}},
+							New:  updated["Status"],
+						},
+					},
+				},
+				{
+					Action:  notion.PlanActionCreate,
+					Method:  http.MethodPost,
+					Path:    "/pages",
+					Changes: []notion.PropertyChange{{Name: "Name", New: created["Name"]}},
+				},
+			},
+			expBody: []string{
+				`{"properties":{"Status":{"select":{"name":"Done"}}}}`,
+				`{"parent":{"database_id":"db-id"},"properties":{"Name":{"title":[{"text":{"content":"Bar"}}]}}}`,
+			},
+		},
+		{
+			name:     "no changes",
+			desired:  []notion.DesiredPage{{PageID: "page-2", Properties: unchanged}},
+			expCalls: []notion.PlannedCall{},
+		},
+		{
+			name:     "unknown page",
+			desired:  []notion.DesiredPage{{PageID: "page-3", Properties: unchanged}},
+			expError: errors.New("notion: desired page [0]: current page page-3 not found"),
+		},
+		{
+			name:     "invalid create",
+			desired:  []notion.DesiredPage{{}},
+			expError: errors.New("notion: desired page [0]: invalid page params: database page properties is required when parent type is database"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := notion.NewPlan("db-id", current, tt.desired)
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && err == nil {
+				t.Fatalf("error not equal (expected: %v, got: nil)", tt.expError)
+			}
+			if tt.expError != nil && err != nil && tt.expError.Error() != err.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if plan.DatabaseID != "db-id" {
+				t.Fatalf("database ID not equal (expected: db-id, got: %v)", plan.DatabaseID)
+			}
+
+			var bodies []string
+			for i := range plan.Calls {
+				bodies = append(bodies, string(plan.Calls[i].Body))
+				plan.Calls[i].Body = nil
+			}
+
+			if diff := cmp.Diff(tt.expCalls, plan.Calls); diff != "" {
+				t.Fatalf("calls not equal (-exp, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tt.expBody, bodies); diff != "" {
+				t.Fatalf("bodies not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestClientPlan(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if r.Method != http.MethodGet {
+				t.Fatalf("unexpected %v request", r.Method)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"object": "page",
+					"id": "page-1",
+					"parent": {"type": "database_id", "database_id": "db-id"},
+					"properties": {
+						"Done": {"id": "a", "type": "checkbox", "checkbox": false}
+					}
+				}`)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	props, err := notion.NewPageProps().Checkbox("Done", true).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := client.Plan(context.Background(), "db-id", []notion.DesiredPage{{PageID: "page-1", Properties: props}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := `{"database_id":"db-id","calls":[{"action":"update","method":"PATCH","path":"/pages/page-1","page_id":"page-1",` +
+		`"changes":[{"name":"Done","old":{"id":"a","type":"checkbox","checkbox":false},"new":{"checkbox":true}}],` +
+		`"body":{"properties":{"Done":{"checkbox":true}}}}]}`
+	if exp != string(b) {
+		t.Fatalf("plan not equal (expected: %v, got: %v)", exp, string(b))
+	}
+}
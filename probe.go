@@ -0,0 +1,194 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Capability is a capability of an integration, as configured in the Notion
+// integration settings.
+// See: https://developers.notion.com/reference/capabilities
+type Capability string
+
+const (
+	CapabilityReadContent    Capability = "read_content"
+	CapabilityUpdateContent  Capability = "update_content"
+	CapabilityInsertContent  Capability = "insert_content"
+	CapabilityReadComments   Capability = "read_comments"
+	CapabilityInsertComments Capability = "insert_comments"
+	CapabilityReadUsers      Capability = "read_users"
+	CapabilityReadUserEmails Capability = "read_user_emails"
+)
+
+// CapabilityStatus is the outcome of probing a capability.
+type CapabilityStatus string
+
+const (
+	CapabilityGranted CapabilityStatus = "granted"
+	CapabilityDenied  CapabilityStatus = "denied"
+	// CapabilityUnknown is used when a capability couldn't be probed, e.g.
+	// because no page is shared with the integration.
+	CapabilityUnknown CapabilityStatus = "unknown"
+)
+
+// CapabilityReport is the result of ProbeCapabilities.
+type CapabilityReport struct {
+	// Bot is the bot user of the integration.
+	Bot          User
+	Capabilities map[Capability]CapabilityStatus
+}
+
+// Denied returns the capabilities that are denied, sorted.
+func (r CapabilityReport) Denied() []Capability {
+	var denied []Capability
+	for capability, status := range r.Capabilities {
+		if status == CapabilityDenied {
+			denied = append(denied, capability)
+		}
+	}
+	sort.Slice(denied, func(i, j int) bool { return denied[i] < denied[j] })
+	return denied
+}
+
+// ProbeCapabilities reports what the API key of the client can do, e.g. during
+// onboarding of an integration, to give users actionable setup errors. It only
+// issues cheap calls, and calls that would make changes are sent with invalid
+// request bodies: a `validation_error` means the capability is granted, while
+// `restricted_resource` means it's denied. So no content is created, updated or
+// deleted.
+//
+// Updating content and reading comments are probed on a page found via search;
+// when no page is shared with the integration, their status is unknown. The
+// status of reading user emails is unknown when the workspace has no users
+// that are people (i.e. only bots).
+//
+// An error is returned when the API key is invalid, or when a probe fails for
+// reasons other than missing capabilities.
+func (c *Client) ProbeCapabilities(ctx context.Context) (CapabilityReport, error) {
+	bot, err := c.FindCurrentUser(ctx)
+	if err != nil {
+		return CapabilityReport{}, err
+	}
+
+	report := CapabilityReport{
+		Bot:          bot,
+		Capabilities: make(map[Capability]CapabilityStatus),
+	}
+
+	var pageID string
+
+	searchResp, err := c.Search(ctx, &SearchOpts{
+		Filter:   &SearchFilter{Property: SearchFilterPropertyObject, Value: SearchFilterValuePage},
+		PageSize: 1,
+	})
+	report.Capabilities[CapabilityReadContent], err = probeStatus(err)
+	if err != nil {
+		return CapabilityReport{}, fmt.Errorf("notion: failed to probe %v: %w", CapabilityReadContent, err)
+	}
+	for _, result := range searchResp.Results {
+		if page, ok := result.(Page); ok && !page.IsPartial {
+			pageID = page.ID
+			break
+		}
+	}
+
+	probes := []struct {
+		capability Capability
+		method     string
+		path       string
+		body       string
+		skip       bool
+	}{
+		{capability: CapabilityInsertContent, method: http.MethodPost, path: "/pages", body: `{}`},
+		{capability: CapabilityUpdateContent, method: http.MethodPatch, path: "/pages/" + pageID, body: `{"properties": false}`, skip: pageID == ""},
+		{capability: CapabilityReadComments, method: http.MethodGet, path: "/comments?page_size=1&block_id=" + pageID, skip: pageID == ""},
+		{capability: CapabilityInsertComments, method: http.MethodPost, path: "/comments", body: `{}`},
+	}
+
+	for _, probe := range probes {
+		if probe.skip {
+			report.Capabilities[probe.capability] = CapabilityUnknown
+			continue
+		}
+
+		status, err := probeStatus(c.probe(ctx, probe.method, probe.path, probe.body))
+		if err != nil {
+			return CapabilityReport{}, fmt.Errorf("notion: failed to probe %v: %w", probe.capability, err)
+		}
+		report.Capabilities[probe.capability] = status
+	}
+
+	usersResp, err := c.ListUsers(ctx, &PaginationQuery{PageSize: MaxPageSize})
+	status, err := probeStatus(err)
+	if err != nil {
+		return CapabilityReport{}, fmt.Errorf("notion: failed to probe %v: %w", CapabilityReadUsers, err)
+	}
+	report.Capabilities[CapabilityReadUsers] = status
+	report.Capabilities[CapabilityReadUserEmails] = userEmailsStatus(status, usersResp.Results)
+
+	return report, nil
+}
+
+// probe sends a request with a (typically invalid) body, and returns the error
+// of the response, if any.
+func (c *Client) probe(ctx context.Context, method, path, body string) error {
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+
+	req, err := c.newRequest(ctx, method, path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return parseErrorResponse(res)
+	}
+
+	return nil
+}
+
+// probeStatus interprets the error of a probe.
+func probeStatus(err error) (CapabilityStatus, error) {
+	switch {
+	case err == nil, errors.Is(err, ErrValidation):
+		return CapabilityGranted, nil
+	case errors.Is(err, ErrRestrictedResource):
+		return CapabilityDenied, nil
+	case errors.Is(err, ErrObjectNotFound):
+		return CapabilityUnknown, nil
+	default:
+		return "", err
+	}
+}
+
+func userEmailsStatus(usersStatus CapabilityStatus, users []User) CapabilityStatus {
+	if usersStatus != CapabilityGranted {
+		return usersStatus
+	}
+
+	status := CapabilityUnknown
+	for _, user := range users {
+		if user.Person == nil {
+			continue
+		}
+		if user.Person.Email != "" {
+			return CapabilityGranted
+		}
+		status = CapabilityDenied
+	}
+
+	return status
+}
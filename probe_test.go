@@ -0,0 +1,160 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProbeCapabilities(t *testing.T) {
+	t.Parallel()
+
+	const (
+		ok         = http.StatusOK
+		invalid    = http.StatusBadRequest
+		restricted = http.StatusForbidden
+	)
+
+	errorBody := func(status int) string {
+		code := map[int]string{
+			invalid:                 "validation_error",
+			restricted:              "restricted_resource",
+			http.StatusUnauthorized: "unauthorized",
+		}[status]
+		return fmt.Sprintf(`{"object": "error", "status": %v, "code": %q, "message": "Foobar"}`, status, code)
+	}
+
+	tests := []struct {
+		name            string
+		statuses        map[string]int
+		users           string
+		expCapabilities map[notion.Capability]notion.CapabilityStatus
+		expDenied       []notion.Capability
+		expError        error
+	}{
+		{
+			name: "all capabilities",
+			statuses: map[string]int{
+				"GET /v1/users/me":   ok,
+				"POST /v1/search":    ok,
+				"POST /v1/pages":     invalid,
+				"PATCH /v1/pages/p1": invalid,
+				"GET /v1/comments":   ok,
+				"POST /v1/comments":  invalid,
+				"GET /v1/users":      ok,
+			},
+			users: `[{"object": "user", "id": "u1", "type": "person", "person": {"email": "foo@example.com"}}]`,
+			expCapabilities: map[notion.Capability]notion.CapabilityStatus{
+				notion.CapabilityReadContent:    notion.CapabilityGranted,
+				notion.CapabilityInsertContent:  notion.CapabilityGranted,
+				notion.CapabilityUpdateContent:  notion.CapabilityGranted,
+				notion.CapabilityReadComments:   notion.CapabilityGranted,
+				notion.CapabilityInsertComments: notion.CapabilityGranted,
+				notion.CapabilityReadUsers:      notion.CapabilityGranted,
+				notion.CapabilityReadUserEmails: notion.CapabilityGranted,
+			},
+		},
+		{
+			name: "restricted",
+			statuses: map[string]int{
+				"GET /v1/users/me":  ok,
+				"POST /v1/search":   restricted,
+				"POST /v1/pages":    restricted,
+				"POST /v1/comments": invalid,
+				"GET /v1/users":     ok,
+			},
+			users: `[{"object": "user", "id": "u1", "type": "person", "person": {}}]`,
+			expCapabilities: map[notion.Capability]notion.CapabilityStatus{
+				notion.CapabilityReadContent:    notion.CapabilityDenied,
+				notion.CapabilityInsertContent:  notion.CapabilityDenied,
+				notion.CapabilityUpdateContent:  notion.CapabilityUnknown,
+				notion.CapabilityReadComments:   notion.CapabilityUnknown,
+				notion.CapabilityInsertComments: notion.CapabilityGranted,
+				notion.CapabilityReadUsers:      notion.CapabilityGranted,
+				notion.CapabilityReadUserEmails: notion.CapabilityDenied,
+			},
+			expDenied: []notion.Capability{
+				notion.CapabilityInsertContent,
+				notion.CapabilityReadContent,
+				notion.CapabilityReadUserEmails,
+			},
+		},
+		{
+			name: "invalid API key",
+			statuses: map[string]int{
+				"GET /v1/users/me": http.StatusUnauthorized,
+			},
+			expError: errors.New("notion: failed to find current user: Foobar (code: unauthorized, status: 401)"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					key := r.Method + " " + r.URL.Path
+					status, found := tt.statuses[key]
+					if !found {
+						t.Fatalf("unexpected request: %v", key)
+					}
+
+					body := errorBody(status)
+					if status == ok {
+						switch key {
+						case "GET /v1/users/me":
+							body = `{"object": "user", "id": "bot-id", "type": "bot", "bot": {}}`
+						case "POST /v1/search":
+							body = `{"object": "list", "results": [{"object": "page", "id": "p1", "parent": {"type": "workspace", "workspace": true}, "properties": {}}]}`
+						case "GET /v1/users":
+							body = `{"object": "list", "results": ` + tt.users + `}`
+						default:
+							body = `{"object": "list", "results": []}`
+						}
+					}
+
+					return &http.Response{
+						StatusCode: status,
+						Status:     http.StatusText(status),
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			report, err := client.ProbeCapabilities(context.Background())
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && err == nil {
+				t.Fatalf("error not equal (expected: %v, got: nil)", tt.expError)
+			}
+			if tt.expError != nil && err != nil && tt.expError.Error() != err.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if report.Bot.ID != "bot-id" {
+				t.Fatalf("bot ID not equal (expected: bot-id, got: %v)", report.Bot.ID)
+			}
+			if diff := cmp.Diff(tt.expCapabilities, report.Capabilities); diff != "" {
+				t.Fatalf("capabilities not equal (-exp, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tt.expDenied, report.Denied()); diff != "" {
+				t.Fatalf("denied capabilities not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package notion
+
+import (
+	"context"
+	"sync"
+)
+
+type ProgressEventType string
+
+const (
+	// ProgressStarted is emitted once, when an operation starts.
+	ProgressStarted ProgressEventType = "started"
+	// ProgressFetched is emitted when the children of a block were fetched.
+	// Count is the number of children.
+	ProgressFetched ProgressEventType = "fetched"
+	// ProgressAppended is emitted when children were appended to a block. Count
+	// is the number of appended blocks, including nested blocks.
+	ProgressAppended ProgressEventType = "appended"
+	// ProgressFailed is emitted when processing an object failed. Err is set.
+	ProgressFailed ProgressEventType = "failed"
+	// ProgressCompleted is emitted once, when an operation completes without
+	// error. Count is the total number of processed blocks.
+	ProgressCompleted ProgressEventType = "completed"
+)
+
+// ProgressEvent is emitted by helpers that make multiple requests, e.g. to
+// render live status for large page trees. ObjectID is the ID of the block (or
+// page) the event is about; for `started` and `completed` events, it's the ID
+// of the block passed to the helper.
+type ProgressEvent struct {
+	Type     ProgressEventType
+	ObjectID string
+	Count    int
+	Err      error
+}
+
+// ProgressFunc receives progress events. Calls are serialized, so it doesn't
+// need to be safe for concurrent use, but it should return quickly, as it
+// blocks the operation.
+type ProgressFunc func(ProgressEvent)
+
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx that carries `fn`, which receives progress
+// events of helpers called with the returned context:
+//
+//   - FindBlockChildrenRecursive emits a `fetched` event per block whose
+//     children were fetched.
+//   - AppendBlockChildrenDeep emits an `appended` event per request.
+//
+// Both emit `started`, `failed` and `completed` events.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, &progressReporter{fn: fn})
+}
+
+// progressReporter serializes calls of a ProgressFunc.
+type progressReporter struct {
+	mu sync.Mutex
+	fn ProgressFunc
+}
+
+// progressFrom returns the progress reporter of ctx, or nil.
+func progressFrom(ctx context.Context) *progressReporter {
+	p, _ := ctx.Value(progressKey{}).(*progressReporter)
+	return p
+}
+
+// emit calls the progress func, if any. It's safe to call on a nil reporter.
+func (p *progressReporter) emit(eventType ProgressEventType, objectID string, count int, err error) {
+	if p == nil || p.fn == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fn(ProgressEvent{Type: eventType, ObjectID: objectID, Count: count, Err: err})
+}
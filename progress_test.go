@@ -0,0 +1,108 @@
+package notion_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+type progressEvent struct {
+	Type     notion.ProgressEventType
+	ObjectID string
+	Count    int
+	Err      string
+}
+
+func recordProgress(ctx context.Context, events *[]progressEvent) context.Context {
+	return notion.WithProgress(ctx, func(event notion.ProgressEvent) {
+		e := progressEvent{Type: event.Type, ObjectID: event.ObjectID, Count: event.Count}
+		if event.Err != nil {
+			e.Err = event.Err.Error()
+		}
+		*events = append(*events, e)
+	})
+}
+
+func TestFindBlockChildrenRecursiveProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		failBlockID string
+		expEvents   []progressEvent
+	}{
+		{
+			name: "completed",
+			expEvents: []progressEvent{
+				{Type: notion.ProgressStarted, ObjectID: "root"},
+				{Type: notion.ProgressFetched, ObjectID: "root", Count: 3},
+				{Type: notion.ProgressFetched, ObjectID: "p1", Count: 1},
+				{Type: notion.ProgressFetched, ObjectID: "t1", Count: 1},
+				{Type: notion.ProgressCompleted, ObjectID: "root", Count: 5},
+			},
+		},
+		{
+			name:        "failed",
+			failBlockID: "t1",
+			expEvents: []progressEvent{
+				{Type: notion.ProgressStarted, ObjectID: "root"},
+				{Type: notion.ProgressFetched, ObjectID: "root", Count: 3},
+				{Type: notion.ProgressFetched, ObjectID: "p1", Count: 1},
+				{
+					Type:     notion.ProgressFailed,
+					ObjectID: "t1",
+					Err:      "notion: failed to find children of block t1: notion: failed to find block children: Foobar (code: internal_server_error, status: 500)",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{
+				Transport: blockTreeTransport(t, tt.failBlockID),
+			}))
+
+			var events []progressEvent
+			ctx := recordProgress(context.Background(), &events)
+
+			_, _ = client.FindBlockChildrenRecursive(ctx, "root", nil)
+
+			if diff := cmp.Diff(tt.expEvents, events); diff != "" {
+				t.Fatalf("events not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestAppendBlockChildrenDeepProgress(t *testing.T) {
+	t.Parallel()
+
+	var requests []appendRequest
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{
+		Transport: appendTransport(t, &requests, -1),
+	}))
+
+	var events []progressEvent
+	ctx := recordProgress(context.Background(), &events)
+
+	blocks, _ := appendParagraphs(3)
+	if _, err := client.AppendBlockChildrenDeep(ctx, "root", blocks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expEvents := []progressEvent{
+		{Type: notion.ProgressStarted, ObjectID: "root"},
+		{Type: notion.ProgressAppended, ObjectID: "root", Count: 3},
+		{Type: notion.ProgressCompleted, ObjectID: "root", Count: 3},
+	}
+	if diff := cmp.Diff(expEvents, events); diff != "" {
+		t.Fatalf("events not equal (-exp, +got):\n%v", diff)
+	}
+}
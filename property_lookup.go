@@ -0,0 +1,79 @@
+package notion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LookupOpts configure how property names are matched by lookups, so mappings
+// don't break when editors tweak property names (e.g. `Status ` vs `status`).
+// The zero value matches names exactly.
+type LookupOpts struct {
+	// IgnoreCase matches names case insensitively.
+	IgnoreCase bool
+
+	// NormalizeSpace ignores leading and trailing whitespace, and treats runs of
+	// whitespace within names as a single space.
+	NormalizeSpace bool
+}
+
+// normalize returns the name used for comparison.
+func (opts LookupOpts) normalize(name string) string {
+	if opts.NormalizeSpace {
+		name = strings.Join(strings.Fields(name), " ")
+	}
+	if opts.IgnoreCase {
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+// AmbiguousPropertyError is returned by lookups when a name matches multiple
+// properties after normalization, and none of them exactly.
+type AmbiguousPropertyError struct {
+	Name string
+	// Matches are the names of the matching properties, sorted.
+	Matches []string
+}
+
+// Error implements `error`.
+func (err *AmbiguousPropertyError) Error() string {
+	matches := make([]string, len(err.Matches))
+	for i, match := range err.Matches {
+		matches[i] = fmt.Sprintf("%q", match)
+	}
+	return fmt.Sprintf("notion: property name %q is ambiguous, matches %v", err.Name, strings.Join(matches, ", "))
+}
+
+// Lookup returns the property with name `name`, matched as configured by `opts`
+// (optional). A property whose name matches exactly is always preferred. When
+// multiple properties match after normalization, an *AmbiguousPropertyError is
+// returned.
+func (props DatabasePageProperties) Lookup(name string, opts *LookupOpts) (DatabasePageProperty, bool, error) {
+	if prop, ok := props[name]; ok {
+		return prop, true, nil
+	}
+	if opts == nil || *opts == (LookupOpts{}) {
+		return DatabasePageProperty{}, false, nil
+	}
+
+	normalized := opts.normalize(name)
+
+	var matches []string
+	for propName := range props {
+		if opts.normalize(propName) == normalized {
+			matches = append(matches, propName)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return DatabasePageProperty{}, false, nil
+	case 1:
+		return props[matches[0]], true, nil
+	default:
+		sort.Strings(matches)
+		return DatabasePageProperty{}, false, &AmbiguousPropertyError{Name: name, Matches: matches}
+	}
+}
@@ -0,0 +1,139 @@
+package notion_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDatabasePagePropertiesLookup(t *testing.T) {
+	t.Parallel()
+
+	props := notion.DatabasePageProperties{
+		"Status ":    {ID: "a", Type: notion.DBPropTypeSelect},
+		"Due  date":  {ID: "b", Type: notion.DBPropTypeDate},
+		"Tags":       {ID: "c", Type: notion.DBPropTypeMultiSelect},
+		"tags":       {ID: "d", Type: notion.DBPropTypeMultiSelect},
+		"Estimate":   {ID: "e", Type: notion.DBPropTypeNumber},
+		" estimate ": {ID: "f", Type: notion.DBPropTypeNumber},
+	}
+
+	tests := []struct {
+		name     string
+		propName string
+		opts     *notion.LookupOpts
+		expID    string
+		expFound bool
+		expError error
+	}{
+		{
+			name:     "exact match without opts",
+			propName: "Tags",
+			expID:    "c",
+			expFound: true,
+		},
+		{
+			name:     "no match without opts",
+			propName: "status",
+			expFound: false,
+		},
+		{
+			name:     "ignore case only",
+			propName: "status",
+			opts:     &notion.LookupOpts{IgnoreCase: true},
+			expFound: false,
+		},
+		{
+			name:     "ignore case and normalize space",
+			propName: "status",
+			opts:     &notion.LookupOpts{IgnoreCase: true, NormalizeSpace: true},
+			expID:    "a",
+			expFound: true,
+		},
+		{
+			name:     "normalize inner space",
+			propName: "Due date",
+			opts:     &notion.LookupOpts{NormalizeSpace: true},
+			expID:    "b",
+			expFound: true,
+		},
+		{
+			name:     "exact match is preferred",
+			propName: "tags",
+			opts:     &notion.LookupOpts{IgnoreCase: true},
+			expID:    "d",
+			expFound: true,
+		},
+		{
+			name:     "ambiguous",
+			propName: "TAGS",
+			opts:     &notion.LookupOpts{IgnoreCase: true},
+			expError: errors.New(`notion: property name "TAGS" is ambiguous, matches "Tags", "tags"`),
+		},
+		{
+			name:     "ambiguous after normalizing space",
+			propName: "ESTIMATE",
+			opts:     &notion.LookupOpts{IgnoreCase: true, NormalizeSpace: true},
+			expError: errors.New(`notion: property name "ESTIMATE" is ambiguous, matches " estimate ", "Estimate"`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prop, found, err := props.Lookup(tt.propName, tt.opts)
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && err == nil {
+				t.Fatalf("error not equal (expected: %v, got: nil)", tt.expError)
+			}
+			if tt.expError != nil && err != nil && tt.expError.Error() != err.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+			if found != tt.expFound {
+				t.Fatalf("found not equal (expected: %v, got: %v)", tt.expFound, found)
+			}
+			if prop.ID != tt.expID {
+				t.Fatalf("property ID not equal (expected: %q, got: %q)", tt.expID, prop.ID)
+			}
+		})
+	}
+}
+
+func TestUnmarshalPageWithOpts(t *testing.T) {
+	t.Parallel()
+
+	type task struct {
+		Name string `notion:"Name,title"`
+		Done bool   `notion:"Done"`
+	}
+
+	page := notion.Page{
+		Properties: notion.DatabasePageProperties{
+			"name ": {Type: notion.DBPropTypeTitle, Title: []notion.RichText{{PlainText: "Foobar"}}},
+			"DONE":  {Type: notion.DBPropTypeCheckbox, Checkbox: notion.BoolPtr(true)},
+		},
+	}
+
+	var got task
+	if err := notion.UnmarshalPageWithOpts(page, &got, &notion.LookupOpts{IgnoreCase: true, NormalizeSpace: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(task{Name: "Foobar", Done: true}, got); diff != "" {
+		t.Fatalf("value not equal (-exp, +got):\n%v", diff)
+	}
+
+	got = task{}
+	if err := notion.UnmarshalPage(page, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(task{}, got); diff != "" {
+		t.Fatalf("value not equal (-exp, +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,195 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// QueryDatabaseStream queries a database like QueryDatabase, following
+// pagination, and calls `fn` for each page. Results are decoded incrementally,
+// so memory usage doesn't grow with the number of pages (e.g. for databases
+// with thousands of rows), as long as `fn` doesn't retain them.
+//
+// When `fn` returns an error, iteration stops and the error is returned. The
+// page size of `query` defaults to the maximum (100). See ErrCanceled for
+// cancellation; Completed is the number of pages passed to `fn`.
+func (c *Client) QueryDatabaseStream(ctx context.Context, id string, query *DatabaseQuery, fn func(Page) error) error {
+	q := DatabaseQuery{PageSize: MaxPageSize}
+	if query != nil {
+		q = *query
+		if q.PageSize == 0 {
+			q.PageSize = MaxPageSize
+		}
+	}
+
+	var count int
+
+	for {
+		if err := canceled(ctx, count, -1); err != nil {
+			return err
+		}
+
+		cursor, stop, err := c.queryDatabaseStream(ctx, id, &q, func(page Page) error {
+			count++
+			return fn(page)
+		})
+		if err != nil {
+			if cancelErr := canceled(ctx, count, -1); cancelErr != nil {
+				return cancelErr
+			}
+			return err
+		}
+		if stop {
+			return nil
+		}
+		q.StartCursor = cursor
+	}
+}
+
+// streamCallbackError wraps errors returned by stream callbacks, so they can be
+// told apart from decoding errors, and returned as-is.
+type streamCallbackError struct {
+	err error
+}
+
+func (err *streamCallbackError) Error() string {
+	return err.err.Error()
+}
+
+// queryDatabaseStream queries a single page of results, and calls `fn` for each
+// result while decoding. It returns the next cursor, or `stop` if there are no
+// more results.
+func (c *Client) queryDatabaseStream(ctx context.Context, id string, query *DatabaseQuery, fn func(Page) error) (cursor string, stop bool, err error) {
+	body := &bytes.Buffer{}
+
+	err = json.NewEncoder(body).Encode(query)
+	if err != nil {
+		return "", false, fmt.Errorf("notion: failed to encode filter to JSON: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/databases/%v/query", id), body)
+	if err != nil {
+		return "", false, fmt.Errorf("notion: invalid request: %w", err)
+	}
+
+	if len(query.FilterProperties) > 0 {
+		req.URL.RawQuery = url.Values{"filter_properties": query.FilterProperties}.Encode()
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", false, transportError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("notion: failed to query database: %w", parseErrorResponse(res))
+	}
+
+	var (
+		hasMore    bool
+		nextCursor *string
+	)
+
+	dec := json.NewDecoder(res.Body)
+
+	err = decodeObject(dec, func(key string) error {
+		switch key {
+		case "results":
+			return decodeArray(dec, func() error {
+				var page Page
+				if err := c.decodeNext(dec, &page); err != nil {
+					return err
+				}
+				c.revalidateCached("page", page.ID, page.LastEditedTime)
+				if err := fn(page); err != nil {
+					return &streamCallbackError{err: err}
+				}
+				return nil
+			})
+		case "has_more":
+			return dec.Decode(&hasMore)
+		case "next_cursor":
+			return dec.Decode(&nextCursor)
+		default:
+			var skip json.RawMessage
+			return dec.Decode(&skip)
+		}
+	})
+	if cbErr, ok := err.(*streamCallbackError); ok {
+		return "", false, cbErr.err
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("notion: failed to parse HTTP response: %w", err)
+	}
+
+	if !hasMore || nextCursor == nil {
+		return "", true, nil
+	}
+
+	return *nextCursor, false, nil
+}
+
+// decodeObject reads a JSON object from `dec`, and calls `fn` for each key, to
+// decode its value.
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("unexpected token %v", tok)
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// decodeArray reads a JSON array (or null) from `dec`, and calls `fn` to decode
+// each element.
+func decodeArray(dec *json.Decoder, fn func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("unexpected token %v (expected [)", tok)
+	}
+
+	for dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("unexpected token %v (expected %v)", tok, delim)
+	}
+	return nil
+}
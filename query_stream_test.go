@@ -0,0 +1,199 @@
+package notion_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+// queryResponse returns a database query response with `n` pages, whose IDs
+// start at `offset`.
+func queryResponse(offset, n int, nextCursor string) string {
+	pages := make([]string, n)
+	for i := range pages {
+		pages[i] = fmt.Sprintf(`{
+			"object": "page",
+			"id": "page-%v",
+			"created_time": "2021-05-19T18:34:00.000Z",
+			"last_edited_time": "2021-05-19T18:34:00.000Z",
+			"parent": {"type": "database_id", "database_id": "db-id"},
+			"archived": false,
+			"url": "https://www.notion.so/page",
+			"properties": {
+				"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Page %v"}, "plain_text": "Page %v", "annotations": {"color": "default"}}]},
+				"Notes": {"id": "a", "type": "rich_text", "rich_text": [{"type": "text", "text": {"content": "Lorem ipsum dolor sit amet"}, "plain_text": "Lorem ipsum dolor sit amet"}]},
+				"Amount": {"id": "b", "type": "number", "number": %v},
+				"Tags": {"id": "c", "type": "multi_select", "multi_select": [{"id": "t1", "name": "Foo", "color": "red"}, {"id": "t2", "name": "Bar", "color": "blue"}]},
+				"Done": {"id": "d", "type": "checkbox", "checkbox": true}
+			}
+		}`, offset+i, offset+i, offset+i, offset+i)
+	}
+
+	cursor := "null"
+	if nextCursor != "" {
+		cursor = fmt.Sprintf("%q", nextCursor)
+	}
+
+	return fmt.Sprintf(`{"object": "list", "results": [%v], "has_more": %v, "next_cursor": %v}`,
+		strings.Join(pages, ","), nextCursor != "", cursor)
+}
+
+// pagedQueryTransport serves `total` pages of query results, in responses of
+// `pageSize` pages, using the offset as cursor.
+func pagedQueryTransport(total, pageSize int) http.RoundTripper {
+	responses := make(map[string][]byte)
+	for offset := 0; offset < total; offset += pageSize {
+		n, next := pageSize, ""
+		if offset+pageSize < total {
+			next = fmt.Sprint(offset + pageSize)
+		} else {
+			n = total - offset
+		}
+		cursor := ""
+		if offset > 0 {
+			cursor = fmt.Sprint(offset)
+		}
+		responses[cursor] = []byte(queryResponse(offset, n, next))
+	}
+
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		var query notion.DatabaseQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(bytes.NewReader(responses[query.StartCursor])),
+		}, nil
+	}}
+}
+
+func TestQueryDatabaseStream(t *testing.T) {
+	t.Parallel()
+
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name     string
+		total    int
+		stopAt   int
+		expIDs   int
+		expError error
+	}{
+		{
+			name:   "all pages",
+			total:  5,
+			expIDs: 5,
+		},
+		{
+			name:   "no pages",
+			total:  0,
+			expIDs: 0,
+		},
+		{
+			name:     "callback error",
+			total:    5,
+			stopAt:   3,
+			expIDs:   3,
+			expError: errStop,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := pagedQueryTransport(tt.total, 2)
+			if tt.total == 0 {
+				transport = &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       ioutil.NopCloser(strings.NewReader(`{"object": "list", "results": null, "has_more": false, "next_cursor": null}`)),
+					}, nil
+				}}
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: transport}))
+
+			var ids []string
+			err := client.QueryDatabaseStream(context.Background(), "db-id", &notion.DatabaseQuery{PageSize: 2}, func(page notion.Page) error {
+				ids = append(ids, page.ID)
+				if len(ids) == tt.stopAt {
+					return errStop
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.expError) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			var expIDs []string
+			for i := 0; i < tt.expIDs; i++ {
+				expIDs = append(expIDs, fmt.Sprintf("page-%v", i))
+			}
+			if diff := cmp.Diff(expIDs, ids); diff != "" {
+				t.Fatalf("page IDs not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkQueryDatabase(b *testing.B) {
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: pagedQueryTransport(1000, 100)}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var pages []notion.Page
+		query := &notion.DatabaseQuery{PageSize: 100}
+		for {
+			resp, err := client.QueryDatabase(context.Background(), "db-id", query)
+			if err != nil {
+				b.Fatal(err)
+			}
+			pages = append(pages, resp.Results...)
+			if !resp.HasMore {
+				break
+			}
+			query.StartCursor = *resp.NextCursor
+		}
+		if len(pages) != 1000 {
+			b.Fatalf("expected 1000 pages, got %v", len(pages))
+		}
+	}
+}
+
+func BenchmarkQueryDatabaseStream(b *testing.B) {
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(&http.Client{Transport: pagedQueryTransport(1000, 100)}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		count := 0
+		err := client.QueryDatabaseStream(context.Background(), "db-id", nil, func(page notion.Page) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count != 1000 {
+			b.Fatalf("expected 1000 pages, got %v", count)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits requests to the Notion API to an average of `rps`
+// requests per second, with bursts of up to `burst` requests. The limit is
+// shared by all goroutines using the client, so bulk operations don't trip the
+// rate limiter of the API (an average of 3 requests per second) in the first
+// place. Requests wait for their turn, or until their context is done.
+//
+// When the API responds with `rate_limited` anyway (e.g. because other clients
+// use the same integration), all requests are paused for the duration of the
+// `Retry-After` response header. The rate limited request itself fails; the
+// client doesn't retry requests, so that's left to the caller (see
+// IsRetryable).
+//
+// A non-positive `rps` disables rate limiting. A `burst` lower than 1 is
+// treated as 1.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		if rps <= 0 {
+			c.rateLimiter = nil
+			return
+		}
+		c.rateLimiter = newRateLimiter(rps, burst)
+	}
+}
+
+// rateLimiter is a token bucket rate limiter, safe for concurrent use.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token, and returns how long to wait before it can be used.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// Tokens can go negative, so waiting requests are served in order.
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if paused := l.pausedUntil.Sub(now); paused > wait {
+		wait = paused
+	}
+
+	return wait
+}
+
+// cancel returns a token that was reserved, but not used.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+}
+
+// pause delays all requests until `d` from now.
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// wait blocks until a request can be made, or until `ctx` is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	d := l.reserve()
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport is an http.RoundTripper that waits for the rate limiter
+// before each request. It's wrapped by the compat and auth transports, and
+// wraps the debug transport, so only requests that are actually sent are
+// dumped (see wrapHTTPClient).
+type rateLimitTransport struct {
+	limiter *rateLimiter
+	next    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		if d, ok := (&APIError{Header: res.Header}).RetryAfter(); ok {
+			t.limiter.pause(d)
+		}
+	}
+
+	return res, nil
+}
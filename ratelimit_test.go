@@ -0,0 +1,115 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+)
+
+func rateLimitTransport(requests *int32, retryAfter string) http.RoundTripper {
+	return &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(requests, 1) == 1 && retryAfter != "" {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Status:     http.StatusText(http.StatusTooManyRequests),
+				Header:     http.Header{"Retry-After": []string{retryAfter}},
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{"object": "error", "status": 429, "code": "rate_limited", "message": "Foobar"}`,
+				)),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(strings.NewReader(`{"object": "user", "id": "user-id", "type": "bot"}`)),
+		}, nil
+	}}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: rateLimitTransport(&requests, "")}),
+		notion.WithRateLimit(20, 2),
+	)
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.FindCurrentUser(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A burst of 2 requests, and 4 requests at 50ms intervals.
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Fatalf("expected requests to be rate limited, took %v", elapsed)
+	}
+	if requests != 6 {
+		t.Fatalf("expected 6 requests, got %v", requests)
+	}
+}
+
+func TestWithRateLimitContext(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: rateLimitTransport(&requests, "")}),
+		notion.WithRateLimit(0.1, 1),
+	)
+
+	if _, err := client.FindCurrentUser(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.FindCurrentUser(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error not equal (expected: %v, got: %v)", context.DeadlineExceeded, err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request, got %v", requests)
+	}
+}
+
+func TestWithRateLimitRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(&http.Client{Transport: rateLimitTransport(&requests, "1")}),
+		notion.WithRateLimit(100, 10),
+	)
+
+	_, err := client.FindCurrentUser(context.Background())
+	if !errors.Is(err, notion.ErrRateLimited) {
+		t.Fatalf("error not equal (expected: %v, got: %v)", notion.ErrRateLimited, err)
+	}
+
+	start := time.Now()
+	if _, err := client.FindCurrentUser(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Fatalf("expected request to be paused for Retry-After, took %v", elapsed)
+	}
+}
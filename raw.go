@@ -0,0 +1,134 @@
+package notion
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WithRawCapture enables capturing the JSON of decoded pages, databases, users
+// and blocks, as returned by the API, in their Raw field. For blocks, use the
+// Raw method of the block types of this library (and of custom block types that
+// embed UnsupportedBlock). This gives access to fields that this library
+// doesn't support (yet), e.g. of newly launched API features. Raw capture is
+// disabled by default, as it retains a copy of every response body.
+func WithRawCapture() ClientOption {
+	return func(c *Client) {
+		c.captureRaw = true
+	}
+}
+
+// decode decodes a JSON response body into `v`. With raw capture enabled, the
+// Raw fields of the pages, databases and users in `v` are set.
+func (c *Client) decode(r io.Reader, v interface{}) error {
+	if !c.captureRaw {
+		return json.NewDecoder(r).Decode(v)
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return c.unmarshal(b, v)
+}
+
+// unmarshal is like decode, for a JSON value that's been read already.
+func (c *Client) unmarshal(b []byte, v interface{}) error {
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	if !c.captureRaw {
+		return nil
+	}
+
+	return setRaw(v, b)
+}
+
+// decodeNext is like decode, for the next JSON value of a stream.
+func (c *Client) decodeNext(dec *json.Decoder, v interface{}) error {
+	if !c.captureRaw {
+		return dec.Decode(v)
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	return c.unmarshal(raw, v)
+}
+
+// setRaw sets the Raw field of a decoded page, database, user or block, or of
+// the results of a list response, to its JSON in `b`.
+func setRaw(v interface{}, b []byte) error {
+	switch v := v.(type) {
+	case *blockDTO:
+		// The block is created from the DTO after decoding.
+		v.keepRaw = true
+	case *Page:
+		v.Raw = append(json.RawMessage(nil), b...)
+	case *Database:
+		v.Raw = append(json.RawMessage(nil), b...)
+	case *User:
+		v.Raw = append(json.RawMessage(nil), b...)
+	case *DatabaseQueryResponse:
+		results, err := rawResults(b, len(v.Results))
+		if err != nil {
+			return err
+		}
+		for i := range v.Results {
+			v.Results[i].Raw = results[i]
+		}
+	case *BlockChildrenResponse:
+		results, err := rawResults(b, len(v.Results))
+		if err != nil {
+			return err
+		}
+		for i, block := range v.Results {
+			if block, ok := block.(interface{ setRaw(json.RawMessage) }); ok {
+				block.setRaw(results[i])
+			}
+		}
+	case *ListUsersResponse:
+		results, err := rawResults(b, len(v.Results))
+		if err != nil {
+			return err
+		}
+		for i := range v.Results {
+			v.Results[i].Raw = results[i]
+		}
+	case *SearchResponse:
+		results, err := rawResults(b, len(v.Results))
+		if err != nil {
+			return err
+		}
+		for i, result := range v.Results {
+			switch result := result.(type) {
+			case Page:
+				result.Raw = results[i]
+				v.Results[i] = result
+			case Database:
+				result.Raw = results[i]
+				v.Results[i] = result
+			}
+		}
+	}
+
+	return nil
+}
+
+// rawResults returns the JSON values of the `results` of a list response,
+// which must have `n` results.
+func rawResults(b []byte, n int) ([]json.RawMessage, error) {
+	var list struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+
+	results := make([]json.RawMessage, n)
+	copy(results, list.Results)
+
+	return results, nil
+}
@@ -0,0 +1,162 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+)
+
+const rawTestPage = `{
+	"object": "page",
+	"id": "606ed832-7d79-46de-bbed-5b4896e7bc02",
+	"created_time": "2021-05-19T18:34:00.000Z",
+	"last_edited_time": "2021-05-19T18:34:00.000Z",
+	"parent": {"type": "workspace", "workspace": true},
+	"properties": {},
+	"new_feature": {"enabled": true}
+}`
+
+const rawTestBlock = `{"object": "block", "id": "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113", "type": "divider", "divider": {}, "new_feature": true}`
+
+func rawTestClient(t *testing.T, opts ...notion.ClientOption) *notion.Client {
+	t.Helper()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			var body string
+
+			switch r.URL.Path {
+			case "/v1/pages/606ed832-7d79-46de-bbed-5b4896e7bc02":
+				body = rawTestPage
+			case "/v1/databases/668d797c-76fa-4934-9b05-ad288df2d136/query":
+				body = `{"object": "list", "results": [` + rawTestPage + `], "has_more": false}`
+			case "/v1/search":
+				body = `{"object": "list", "results": [` + rawTestPage + `], "has_more": false}`
+			case "/v1/users/me":
+				body = `{"object": "user", "id": "be32e790-8292-46df-a248-b784fdf483cf", "type": "bot", "bot": {}, "new_feature": true}`
+			case "/v1/blocks/ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113":
+				body = rawTestBlock
+			case "/v1/blocks/606ed832-7d79-46de-bbed-5b4896e7bc02/children":
+				body = `{"object": "list", "results": [` + rawTestBlock + `], "has_more": false}`
+			default:
+				t.Fatalf("unexpected request: %v %v", r.Method, r.URL)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+
+	return notion.NewClient("secret-api-key", append(opts, notion.WithHTTPClient(httpClient))...)
+}
+
+// blockRaw returns the raw JSON of a block of one of the library's types.
+func blockRaw(block notion.Block) json.RawMessage {
+	return block.(interface{ Raw() json.RawMessage }).Raw()
+}
+
+func TestWithRawCapture(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := rawTestClient(t, notion.WithRawCapture())
+
+	page, err := client.FindPageByID(ctx, "606ed832-7d79-46de-bbed-5b4896e7bc02")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	queryResp, err := client.QueryDatabase(ctx, "668d797c-76fa-4934-9b05-ad288df2d136", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	searchResp, err := client.Search(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	user, err := client.FindCurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	block, err := client.FindBlockByID(ctx, "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	childrenResp, err := client.FindBlockChildrenByID(ctx, "606ed832-7d79-46de-bbed-5b4896e7bc02", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		raw  json.RawMessage
+	}{
+		{name: "page", raw: page.Raw},
+		{name: "query result", raw: queryResp.Results[0].Raw},
+		{name: "search result", raw: searchResp.Results[0].(notion.Page).Raw},
+		{name: "user", raw: user.Raw},
+		{name: "block", raw: blockRaw(block)},
+		{name: "block children result", raw: blockRaw(childrenResp.Results[0])},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(tt.raw, &obj); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := obj["new_feature"]; !ok {
+				t.Fatalf("expected `new_feature` field in raw JSON, got: %s", tt.raw)
+			}
+		})
+	}
+}
+
+func TestWithoutRawCapture(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := rawTestClient(t)
+
+	page, err := client.FindPageByID(ctx, "606ed832-7d79-46de-bbed-5b4896e7bc02")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Raw != nil {
+		t.Fatalf("expected no raw JSON, got: %s", page.Raw)
+	}
+
+	block, err := client.FindBlockByID(ctx, "ae9c9a31-1c1e-4ae2-a5ee-c539a2d43113")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw := blockRaw(block); raw != nil {
+		t.Fatalf("expected no raw JSON, got: %s", raw)
+	}
+
+	childrenResp, err := client.FindBlockChildrenByID(ctx, "606ed832-7d79-46de-bbed-5b4896e7bc02", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw := blockRaw(childrenResp.Results[0]); raw != nil {
+		t.Fatalf("expected no raw JSON, got: %s", raw)
+	}
+
+	if raw := (notion.DividerBlock{}).Raw(); raw != nil {
+		t.Fatalf("expected no raw JSON, got: %s", raw)
+	}
+}
@@ -0,0 +1,82 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EditedObject is a page or database in a feed of recently edited objects.
+// Either Page or Database is set.
+type EditedObject struct {
+	ID             string
+	Title          string
+	URL            string
+	LastEditedTime time.Time
+
+	Page     *Page
+	Database *Database
+}
+
+// RecentlyEdited returns pages and databases (visible to the integration)
+// edited since `since`, most recently edited first, e.g. for activity
+// dashboards and digest bots. At most `limit` objects are returned, or all
+// objects if `limit` is zero.
+//
+// Objects are found via search, sorted by last edited time, so no more requests
+// are made than needed. See ErrCanceled for cancellation.
+func (c *Client) RecentlyEdited(ctx context.Context, since time.Time, limit int) ([]EditedObject, error) {
+	opts := &SearchOpts{
+		Sort: &SearchSort{
+			Direction: SortDirDesc,
+			Timestamp: SearchSortTimestampLastEditedTime,
+		},
+		PageSize: MaxPageSize,
+	}
+	if limit > 0 && limit < MaxPageSize {
+		opts.PageSize = limit
+	}
+
+	var objects []EditedObject
+
+	for {
+		if err := canceled(ctx, len(objects), -1); err != nil {
+			return objects, err
+		}
+
+		resp, err := c.Search(ctx, opts)
+		if err != nil {
+			if err := canceled(ctx, len(objects), -1); err != nil {
+				return objects, err
+			}
+			return nil, fmt.Errorf("notion: failed to find recently edited objects: %w", err)
+		}
+
+		for _, result := range resp.Results {
+			var obj EditedObject
+
+			switch r := result.(type) {
+			case Page:
+				obj = EditedObject{ID: r.ID, Title: PlainText(pageTitle(r)), URL: r.URL, LastEditedTime: r.LastEditedTime, Page: &r}
+			case Database:
+				obj = EditedObject{ID: r.ID, Title: PlainText(r.Title), URL: r.URL, LastEditedTime: r.LastEditedTime, Database: &r}
+			default:
+				continue
+			}
+
+			if obj.LastEditedTime.Before(since) {
+				return objects, nil
+			}
+
+			objects = append(objects, obj)
+			if len(objects) == limit {
+				return objects, nil
+			}
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return objects, nil
+		}
+		opts.StartCursor = *resp.NextCursor
+	}
+}
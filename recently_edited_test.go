@@ -0,0 +1,109 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecentlyEdited(t *testing.T) {
+	t.Parallel()
+
+	page := func(id, lastEdited string) string {
+		return fmt.Sprintf(`{"object": "page", "id": %q, "last_edited_time": %q, "url": "https://www.notion.so/%v", `+
+			`"parent": {"type": "workspace", "workspace": true}, `+
+			`"properties": {"title": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Page %v"}]}}}`,
+			id, lastEdited, id, id)
+	}
+	db := func(id, lastEdited string) string {
+		return fmt.Sprintf(`{"object": "database", "id": %q, "last_edited_time": %q, "title": [{"type": "text", "plain_text": "DB %v"}], "properties": {}}`,
+			id, lastEdited, id)
+	}
+
+	responses := []string{
+		`{"object": "list", "results": [` + page("p1", "2022-09-03T00:00:00.000Z") + `,` + db("d1", "2022-09-02T00:00:00.000Z") + `], "has_more": true, "next_cursor": "cursor-1"}`,
+		`{"object": "list", "results": [` + page("p2", "2022-09-01T00:00:00.000Z") + `,` + page("p3", "2022-08-01T00:00:00.000Z") + `], "has_more": true, "next_cursor": "cursor-2"}`,
+	}
+
+	tests := []struct {
+		name        string
+		limit       int
+		expIDs      []string
+		expRequests []map[string]interface{}
+	}{
+		{
+			name:   "cutoff by time",
+			expIDs: []string{"p1", "d1", "p2"},
+			expRequests: []map[string]interface{}{
+				{"sort": map[string]interface{}{"direction": "descending", "timestamp": "last_edited_time"}, "page_size": float64(100)},
+				{"sort": map[string]interface{}{"direction": "descending", "timestamp": "last_edited_time"}, "page_size": float64(100), "start_cursor": "cursor-1"},
+			},
+		},
+		{
+			name:   "limit",
+			limit:  2,
+			expIDs: []string{"p1", "d1"},
+			expRequests: []map[string]interface{}{
+				{"sort": map[string]interface{}{"direction": "descending", "timestamp": "last_edited_time"}, "page_size": float64(2)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []map[string]interface{}
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					var body map[string]interface{}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Fatalf("failed to decode request body: %v", err)
+					}
+					requests = append(requests, body)
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       ioutil.NopCloser(strings.NewReader(responses[len(requests)-1])),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			since := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+			objects, err := client.RecentlyEdited(context.Background(), since, tt.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var ids []string
+			for _, obj := range objects {
+				ids = append(ids, obj.ID)
+			}
+			if diff := cmp.Diff(tt.expIDs, ids); diff != "" {
+				t.Fatalf("IDs not equal (-exp, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tt.expRequests, requests); diff != "" {
+				t.Fatalf("requests not equal (-exp, +got):\n%v", diff)
+			}
+
+			if objects[0].Title != "Page p1" || objects[0].Page == nil || objects[0].URL != "https://www.notion.so/p1" {
+				t.Fatalf("unexpected page object: %+v", objects[0])
+			}
+			if objects[1].Title != "DB d1" || objects[1].Database == nil {
+				t.Fatalf("unexpected database object: %+v", objects[1])
+			}
+		})
+	}
+}
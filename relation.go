@@ -0,0 +1,63 @@
+package notion
+
+import "encoding/json"
+
+// NewSinglePropertyRelation returns a database property for a one-way relation
+// to pages of another database, for use with CreateDatabase or UpdateDatabase.
+func NewSinglePropertyRelation(databaseID string) DatabaseProperty {
+	return DatabaseProperty{
+		Type: DBPropTypeRelation,
+		Relation: &RelationMetadata{
+			DatabaseID:     databaseID,
+			Type:           RelationTypeSingleProperty,
+			SingleProperty: &SinglePropertyRelation{},
+		},
+	}
+}
+
+// NewDualPropertyRelation returns a database property for a two-way relation
+// to pages of another database, for use with CreateDatabase or UpdateDatabase.
+// The API adds the synced property to the related database. Its name is
+// generated by the API if `syncedPropName` is empty.
+func NewDualPropertyRelation(databaseID, syncedPropName string) DatabaseProperty {
+	return DatabaseProperty{
+		Type: DBPropTypeRelation,
+		Relation: &RelationMetadata{
+			DatabaseID: databaseID,
+			Type:       RelationTypeDualProperty,
+			DualProperty: &DualPropertyRelation{
+				SyncedPropName: syncedPropName,
+			},
+		},
+	}
+}
+
+// MarshalJSON implements json.Marshaler. The API requires an object for the
+// relation type (e.g. `"single_property": {}`), so it's added when only the
+// type is set. Likewise, the type is set when only the object is set.
+func (m RelationMetadata) MarshalJSON() ([]byte, error) {
+	type dto RelationMetadata
+	rel := dto(m)
+
+	if rel.Type == "" {
+		switch {
+		case rel.DualProperty != nil:
+			rel.Type = RelationTypeDualProperty
+		case rel.SingleProperty != nil:
+			rel.Type = RelationTypeSingleProperty
+		}
+	}
+
+	switch rel.Type {
+	case RelationTypeSingleProperty:
+		if rel.SingleProperty == nil {
+			rel.SingleProperty = &SinglePropertyRelation{}
+		}
+	case RelationTypeDualProperty:
+		if rel.DualProperty == nil {
+			rel.DualProperty = &DualPropertyRelation{}
+		}
+	}
+
+	return json.Marshal(rel)
+}
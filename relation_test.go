@@ -0,0 +1,67 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRelationMetadataMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		prop    notion.DatabaseProperty
+		expJSON string
+	}{
+		{
+			name:    "single property",
+			prop:    notion.NewSinglePropertyRelation("668d797c-76fa-4934-9b05-ad288df2d136"),
+			expJSON: `{"type":"relation","relation":{"database_id":"668d797c-76fa-4934-9b05-ad288df2d136","type":"single_property","single_property":{}}}`,
+		},
+		{
+			name:    "dual property",
+			prop:    notion.NewDualPropertyRelation("668d797c-76fa-4934-9b05-ad288df2d136", "Tasks"),
+			expJSON: `{"type":"relation","relation":{"database_id":"668d797c-76fa-4934-9b05-ad288df2d136","type":"dual_property","dual_property":{"synced_property_name":"Tasks"}}}`,
+		},
+		{
+			name: "type only",
+			prop: notion.DatabaseProperty{
+				Type: notion.DBPropTypeRelation,
+				Relation: &notion.RelationMetadata{
+					DatabaseID: "668d797c-76fa-4934-9b05-ad288df2d136",
+					Type:       notion.RelationTypeDualProperty,
+				},
+			},
+			expJSON: `{"type":"relation","relation":{"database_id":"668d797c-76fa-4934-9b05-ad288df2d136","type":"dual_property","dual_property":{}}}`,
+		},
+		{
+			name: "metadata only",
+			prop: notion.DatabaseProperty{
+				Type: notion.DBPropTypeRelation,
+				Relation: &notion.RelationMetadata{
+					DatabaseID:     "668d797c-76fa-4934-9b05-ad288df2d136",
+					SingleProperty: &notion.SinglePropertyRelation{},
+				},
+			},
+			expJSON: `{"type":"relation","relation":{"database_id":"668d797c-76fa-4934-9b05-ad288df2d136","type":"single_property","single_property":{}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(tt.prop)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expJSON, string(b)); diff != "" {
+				t.Fatalf("JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
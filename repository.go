@@ -0,0 +1,176 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Repository provides CRUD operations for pages in a database, mapped to and
+// from structs of type T via `notion` struct tags. See UnmarshalPage and
+// MarshalProps for the tag format. T must be a struct type.
+//
+// Example:
+//
+//	tasks := notion.NewRepository[Task](client, databaseID)
+//	task, err := tasks.Create(ctx, Task{Name: "Foobar", Done: false})
+type Repository[T any] struct {
+	client     *Client
+	databaseID string
+	cfg        repositoryConfig
+}
+
+type repositoryConfig struct {
+	lookup *LookupOpts
+}
+
+// RepositoryOption is used to configure a Repository.
+type RepositoryOption func(*repositoryConfig)
+
+// WithPropertyLookup configures how property names of struct tags are matched
+// with property names of pages, when mapping pages to values. See
+// DatabasePageProperties.Lookup. Property names used for creating and updating
+// pages are unaffected.
+func WithPropertyLookup(opts LookupOpts) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.lookup = &opts
+	}
+}
+
+// NewRepository returns a new Repository for the database with ID `databaseID`.
+func NewRepository[T any](client *Client, databaseID string, opts ...RepositoryOption) *Repository[T] {
+	r := &Repository[T]{
+		client:     client,
+		databaseID: databaseID,
+	}
+
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
+
+	return r
+}
+
+// List returns all pages of the database matching `query` (optional), mapped
+// to values of type T. Pages are fetched with automatic pagination, so the
+// start cursor of the query is ignored. See ErrCanceled for cancellation.
+func (r *Repository[T]) List(ctx context.Context, query *DatabaseQuery) ([]T, error) {
+	q := DatabaseQuery{PageSize: MaxPageSize}
+	if query != nil {
+		q.Filter = query.Filter
+		q.Sorts = query.Sorts
+		if query.PageSize != 0 {
+			q.PageSize = query.PageSize
+		}
+	}
+
+	var values []T
+
+	for {
+		if err := canceled(ctx, len(values), -1); err != nil {
+			return values, err
+		}
+
+		resp, err := r.client.QueryDatabase(ctx, r.databaseID, &q)
+		if err != nil {
+			if err := canceled(ctx, len(values), -1); err != nil {
+				return values, err
+			}
+			return nil, err
+		}
+
+		for _, page := range resp.Results {
+			var v T
+			if err := UnmarshalPageWithOpts(page, &v, r.cfg.lookup); err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return values, nil
+		}
+		q.StartCursor = *resp.NextCursor
+	}
+}
+
+// Get returns the page with ID `pageID`, mapped to a value of type T. An error
+// is returned if the page isn't in the database.
+func (r *Repository[T]) Get(ctx context.Context, pageID string) (T, error) {
+	var v T
+
+	page, err := r.client.FindPageByID(ctx, pageID)
+	if err != nil {
+		return v, err
+	}
+
+	if !equalIDs(page.Parent.DatabaseID, r.databaseID) {
+		return v, fmt.Errorf("notion: page %v is not in database %v", pageID, r.databaseID)
+	}
+
+	if err := UnmarshalPageWithOpts(page, &v, r.cfg.lookup); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// Create creates a page in the database with the properties of `v`, and
+// returns the created page mapped to a value of type T.
+func (r *Repository[T]) Create(ctx context.Context, v T) (T, error) {
+	var created T
+
+	props, err := MarshalProps(v)
+	if err != nil {
+		return created, err
+	}
+
+	page, err := r.client.CreatePage(ctx, CreatePageParams{
+		ParentType:             ParentTypeDatabase,
+		ParentID:               r.databaseID,
+		DatabasePageProperties: &props,
+	})
+	if err != nil {
+		return created, err
+	}
+
+	if err := UnmarshalPageWithOpts(page, &created, r.cfg.lookup); err != nil {
+		return created, err
+	}
+
+	return created, nil
+}
+
+// Update updates the properties of the page with ID `pageID` to the values of
+// `v`, and returns the updated page mapped to a value of type T. Properties
+// without a value in `v` are left unchanged, see MarshalProps.
+func (r *Repository[T]) Update(ctx context.Context, pageID string, v T) (T, error) {
+	var updated T
+
+	props, err := MarshalProps(v)
+	if err != nil {
+		return updated, err
+	}
+
+	page, err := r.client.UpdatePage(ctx, pageID, UpdatePageParams{DatabasePageProperties: props})
+	if err != nil {
+		return updated, err
+	}
+
+	if err := UnmarshalPageWithOpts(page, &updated, r.cfg.lookup); err != nil {
+		return updated, err
+	}
+
+	return updated, nil
+}
+
+// Archive archives (i.e. deletes) the page with ID `pageID`.
+func (r *Repository[T]) Archive(ctx context.Context, pageID string) error {
+	_, err := r.client.UpdatePage(ctx, pageID, UpdatePageParams{Archived: BoolPtr(true)})
+	return err
+}
+
+// equalIDs returns true if both Notion IDs are equal, with or without dashes.
+func equalIDs(a, b string) bool {
+	return strings.ReplaceAll(a, "-", "") == strings.ReplaceAll(b, "-", "")
+}
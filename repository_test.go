@@ -0,0 +1,150 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+type repoTask struct {
+	ID   string `notion:",id"`
+	Name string `notion:"Name,title"`
+	Done bool   `notion:"Done"`
+}
+
+func repoPageJSON(id, databaseID, name string, done bool) string {
+	return fmt.Sprintf(`{"object": "page", "id": %q, "parent": {"type": "database_id", "database_id": %q}, "properties": {`+
+		`"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": %q}]}, `+
+		`"Done": {"id": "done", "type": "checkbox", "checkbox": %v}}}`, id, databaseID, name, done)
+}
+
+type repoRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+func repoClient(t *testing.T, requests *[]repoRequest) *notion.Client {
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			req := repoRequest{method: r.Method, path: r.URL.Path}
+			if r.Body != nil {
+				if err := json.NewDecoder(r.Body).Decode(&req.body); err != nil {
+					t.Fatalf("failed to decode request body: %v", err)
+				}
+			}
+			*requests = append(*requests, req)
+
+			var body string
+			switch {
+			case r.URL.Path == "/v1/databases/db-id/query" && req.body["start_cursor"] == nil:
+				body = `{"object": "list", "results": [` + repoPageJSON("p1", "db-id", "Foo", false) + `], "has_more": true, "next_cursor": "cursor-1"}`
+			case r.URL.Path == "/v1/databases/db-id/query":
+				body = `{"object": "list", "results": [` + repoPageJSON("p2", "db-id", "Bar", true) + `], "has_more": false, "next_cursor": null}`
+			case r.URL.Path == "/v1/pages/other-id":
+				body = repoPageJSON("other-id", "other-db-id", "Other", false)
+			case r.Method == http.MethodPost:
+				body = repoPageJSON("p3", "db-id", "Baz", false)
+			default:
+				body = repoPageJSON("p1", "db-id", "Foo", true)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		}},
+	}
+
+	return notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+}
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var requests []repoRequest
+	repo := notion.NewRepository[repoTask](repoClient(t, &requests), "db-id")
+
+	tasks, err := repo.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]repoTask{{ID: "p1", Name: "Foo"}, {ID: "p2", Name: "Bar", Done: true}}, tasks); diff != "" {
+		t.Fatalf("tasks not equal (-exp, +got):\n%v", diff)
+	}
+
+	task, err := repo.Get(ctx, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(repoTask{ID: "p1", Name: "Foo", Done: true}, task); diff != "" {
+		t.Fatalf("task not equal (-exp, +got):\n%v", diff)
+	}
+
+	created, err := repo.Create(ctx, repoTask{Name: "Baz"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.ID != "p3" {
+		t.Fatalf("expected created task ID p3, got %v", created.ID)
+	}
+
+	if _, err := repo.Update(ctx, "p1", repoTask{Done: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Archive(ctx, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expRequests := []repoRequest{
+		{method: http.MethodPost, path: "/v1/databases/db-id/query", body: map[string]interface{}{"page_size": float64(100)}},
+		{method: http.MethodPost, path: "/v1/databases/db-id/query", body: map[string]interface{}{"page_size": float64(100), "start_cursor": "cursor-1"}},
+		{method: http.MethodGet, path: "/v1/pages/p1"},
+		{
+			method: http.MethodPost,
+			path:   "/v1/pages",
+			body: map[string]interface{}{
+				"parent": map[string]interface{}{"database_id": "db-id"},
+				"properties": map[string]interface{}{
+					"Name": map[string]interface{}{"title": []interface{}{map[string]interface{}{"text": map[string]interface{}{"content": "Baz"}}}},
+					"Done": map[string]interface{}{"checkbox": false},
+				},
+			},
+		},
+		{
+			method: http.MethodPatch,
+			path:   "/v1/pages/p1",
+			body: map[string]interface{}{
+				"properties": map[string]interface{}{"Done": map[string]interface{}{"checkbox": true}},
+			},
+		},
+		{method: http.MethodPatch, path: "/v1/pages/p1", body: map[string]interface{}{"archived": true}},
+	}
+
+	if diff := cmp.Diff(expRequests, requests, cmp.AllowUnexported(repoRequest{})); diff != "" {
+		t.Fatalf("requests not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestRepositoryGetOtherDatabase(t *testing.T) {
+	t.Parallel()
+
+	var requests []repoRequest
+	repo := notion.NewRepository[repoTask](repoClient(t, &requests), "db-id")
+
+	_, err := repo.Get(context.Background(), "other-id")
+	if exp := "notion: page other-id is not in database db-id"; err == nil || err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+}
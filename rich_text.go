@@ -1,5 +1,16 @@
 package notion
 
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxRichTextLength is the maximum length (in characters) of the content of a
+// text rich text object. Use SplitRichText for longer content.
+// See: https://developers.notion.com/reference/request-limits#limits-for-property-values
+const MaxRichTextLength = 2000
+
 type RichText struct {
 	Type        RichTextType `json:"type,omitempty"`
 	Annotations *Annotations `json:"annotations,omitempty"`
@@ -11,6 +22,145 @@ type RichText struct {
 	Equation  *Equation `json:"equation,omitempty"`
 }
 
+// NewRichText returns a rich text element of type `text`, with content
+// `content`.
+func NewRichText(content string) RichText {
+	return RichText{
+		Type: RichTextTypeText,
+		Text: &Text{Content: content},
+	}
+}
+
+// NewUserMention returns a rich text element that mentions a user.
+func NewUserMention(userID string) RichText {
+	return newMention(Mention{
+		Type: MentionTypeUser,
+		User: &User{BaseUser: BaseUser{ID: userID}},
+	})
+}
+
+// NewPageMention returns a rich text element that mentions a page.
+func NewPageMention(pageID string) RichText {
+	return newMention(Mention{
+		Type: MentionTypePage,
+		Page: &ID{ID: pageID},
+	})
+}
+
+// NewDatabaseMention returns a rich text element that mentions a database.
+func NewDatabaseMention(databaseID string) RichText {
+	return newMention(Mention{
+		Type:     MentionTypeDatabase,
+		Database: &ID{ID: databaseID},
+	})
+}
+
+// NewDateMention returns a rich text element that mentions a date, e.g. created
+// with NewDateRange.
+func NewDateMention(date Date) RichText {
+	return newMention(Mention{
+		Type: MentionTypeDate,
+		Date: &date,
+	})
+}
+
+// NewTemplateDateMention returns a rich text element with a template mention of
+// a date, which is replaced by the date (or datetime) a template is duplicated.
+func NewTemplateDateMention(dateType TemplateMentionDateType) RichText {
+	return newMention(Mention{
+		Type: MentionTypeTemplateMention,
+		TemplateMention: &TemplateMention{
+			Type:                TemplateMentionTypeDate,
+			TemplateMentionDate: &dateType,
+		},
+	})
+}
+
+// NewTemplateUserMention returns a rich text element with a template mention of
+// a user, which is replaced by the user who duplicates a template.
+func NewTemplateUserMention() RichText {
+	userType := TemplateMentionUserTypeMe
+
+	return newMention(Mention{
+		Type: MentionTypeTemplateMention,
+		TemplateMention: &TemplateMention{
+			Type:                TemplateMentionTypeUser,
+			TemplateMentionUser: &userType,
+		},
+	})
+}
+
+func newMention(mention Mention) RichText {
+	return RichText{
+		Type:    RichTextTypeMention,
+		Mention: &mention,
+	}
+}
+
+// PlainText returns the concatenated plain text of rich text. The `PlainText`
+// field is used when set (i.e. for rich text returned by the API), else text
+// content is used.
+func PlainText(richText []RichText) string {
+	var sb strings.Builder
+	for _, rt := range richText {
+		switch {
+		case rt.PlainText != "":
+			sb.WriteString(rt.PlainText)
+		case rt.Text != nil:
+			sb.WriteString(rt.Text.Content)
+		case rt.Equation != nil:
+			sb.WriteString(rt.Equation.Expression)
+		}
+	}
+	return sb.String()
+}
+
+// SplitRichText returns rich text where text elements with content longer than
+// MaxRichTextLength are split into multiple elements, each with the same
+// annotations and link. Other elements are returned as is.
+func SplitRichText(richText []RichText) []RichText {
+	split := make([]RichText, 0, len(richText))
+
+	for _, rt := range richText {
+		if rt.Text == nil || utf8.RuneCountInString(rt.Text.Content) <= MaxRichTextLength {
+			split = append(split, rt)
+			continue
+		}
+
+		for _, chunk := range splitContent(rt.Text.Content, MaxRichTextLength) {
+			part := rt
+			part.Text = &Text{Content: chunk, Link: rt.Text.Link}
+			if rt.PlainText != "" {
+				part.PlainText = chunk
+			}
+			split = append(split, part)
+		}
+	}
+
+	return split
+}
+
+// splitContent splits `content` into chunks of at most `n` characters.
+func splitContent(content string, n int) []string {
+	var chunks []string
+
+	for content != "" {
+		chunk := content
+		count := 0
+		for i := range chunk {
+			if count == n {
+				chunk = chunk[:i]
+				break
+			}
+			count++
+		}
+		content = content[len(chunk):]
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
 type Equation struct {
 	Expression string `json:"expression"`
 }
@@ -32,7 +182,78 @@ type Mention struct {
 	Database        *ID              `json:"database,omitempty"`
 	Date            *Date            `json:"date,omitempty"`
 	LinkPreview     *LinkPreview     `json:"link_preview,omitempty"`
+	LinkMention     *LinkMention     `json:"link_mention,omitempty"`
 	TemplateMention *TemplateMention `json:"template_mention,omitempty"`
+
+	// Unknown contains the raw JSON value of a mention type that isn't
+	// supported (yet) by this library, e.g. `custom_emoji`. It's keyed by the
+	// value of `Type` when encoding to JSON, so data isn't lost on round trips.
+	Unknown json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Mention) UnmarshalJSON(b []byte) error {
+	type mentionAlias Mention
+
+	var alias mentionAlias
+
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return err
+	}
+
+	if !alias.Type.isKnown() {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return err
+		}
+		alias.Unknown = raw[string(alias.Type)]
+	}
+
+	*m = Mention(alias)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Mention) MarshalJSON() ([]byte, error) {
+	type mentionAlias Mention
+
+	if m.Type == MentionTypeUser && m.User != nil && m.User.isRef() {
+		// Users with only an ID (e.g. from NewUserMention) are encoded as a
+		// reference, without empty type, name and avatar URL fields.
+		type userRef struct {
+			Object string `json:"object"`
+			ID     string `json:"id"`
+		}
+		return json.Marshal(struct {
+			Type MentionType `json:"type"`
+			User userRef     `json:"user"`
+		}{m.Type, userRef{"user", m.User.ID}})
+	}
+
+	if m.Type.isKnown() || m.Unknown == nil {
+		return json.Marshal(mentionAlias(m))
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"type":         m.Type,
+		string(m.Type): m.Unknown,
+	})
+}
+
+func (t MentionType) isKnown() bool {
+	switch t {
+	case MentionTypeUser,
+		MentionTypePage,
+		MentionTypeDatabase,
+		MentionTypeDate,
+		MentionTypeLinkPreview,
+		MentionTypeLinkMention,
+		MentionTypeTemplateMention:
+		return true
+	default:
+		return false
+	}
 }
 
 type Date struct {
@@ -45,6 +266,18 @@ type LinkPreview struct {
 	URL string `json:"url"`
 }
 
+// LinkMention is a mention of a URL, with metadata of the linked page as shown
+// in Notion (e.g. for links pasted as mention).
+type LinkMention struct {
+	Href         string `json:"href"`
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	LinkAuthor   string `json:"link_author,omitempty"`
+	LinkProvider string `json:"link_provider,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
 type TemplateMention struct {
 	Type TemplateMentionType `json:"type"`
 
@@ -86,6 +319,7 @@ const (
 	MentionTypeDatabase        MentionType = "database"
 	MentionTypeDate            MentionType = "date"
 	MentionTypeLinkPreview     MentionType = "link_preview"
+	MentionTypeLinkMention     MentionType = "link_mention"
 	MentionTypeTemplateMention MentionType = "template_mention"
 
 	TemplateMentionTypeDate      TemplateMentionType     = "template_mention_date"
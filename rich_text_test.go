@@ -0,0 +1,266 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMentionUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		json       string
+		expMention notion.Mention
+	}{
+		{
+			name: "known mention type",
+			json: `{
+				"type": "page",
+				"page": {
+					"id": "b0668f48-8d66-4733-9bdb-2f82215707f7"
+				}
+			}`,
+			expMention: notion.Mention{
+				Type: notion.MentionTypePage,
+				Page: &notion.ID{
+					ID: "b0668f48-8d66-4733-9bdb-2f82215707f7",
+				},
+			},
+		},
+		{
+			name: "link mention",
+			json: `{
+				"type": "link_mention",
+				"link_mention": {
+					"href": "https://github.com/dstotijn/go-notion",
+					"title": "go-notion",
+					"link_provider": "GitHub"
+				}
+			}`,
+			expMention: notion.Mention{
+				Type: notion.MentionTypeLinkMention,
+				LinkMention: &notion.LinkMention{
+					Href:         "https://github.com/dstotijn/go-notion",
+					Title:        "go-notion",
+					LinkProvider: "GitHub",
+				},
+			},
+		},
+		{
+			name: "unknown mention type",
+			json: `{
+				"type": "custom_emoji",
+				"custom_emoji": {"id":"45ce454c-d427-4f53-9489-e5d0f3d1db6b","name":"bufo"}
+			}`,
+			expMention: notion.Mention{
+				Type:    notion.MentionType("custom_emoji"),
+				Unknown: json.RawMessage(`{"id":"45ce454c-d427-4f53-9489-e5d0f3d1db6b","name":"bufo"}`),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mention notion.Mention
+
+			if err := json.Unmarshal([]byte(tt.json), &mention); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expMention, mention); diff != "" {
+				t.Fatalf("mention not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestMentionMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	mention := notion.Mention{
+		Type:    notion.MentionType("custom_emoji"),
+		Unknown: json.RawMessage(`{"id":"45ce454c-d427-4f53-9489-e5d0f3d1db6b"}`),
+	}
+
+	got, err := json.Marshal(mention)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := `{"custom_emoji":{"id":"45ce454c-d427-4f53-9489-e5d0f3d1db6b"},"type":"custom_emoji"}`
+
+	if diff := cmp.Diff(exp, string(got)); diff != "" {
+		t.Fatalf("encoded JSON not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestRichTextConstructors(t *testing.T) {
+	t.Parallel()
+
+	date, err := notion.ParseDateTime("2022-09-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		richText notion.RichText
+		expJSON  string
+	}{
+		{
+			name:     "text",
+			richText: notion.NewRichText("Foobar"),
+			expJSON:  `{"type":"text","text":{"content":"Foobar"}}`,
+		},
+		{
+			name:     "page mention",
+			richText: notion.NewPageMention("page-id"),
+			expJSON:  `{"type":"mention","mention":{"type":"page","page":{"id":"page-id"}}}`,
+		},
+		{
+			name:     "database mention",
+			richText: notion.NewDatabaseMention("db-id"),
+			expJSON:  `{"type":"mention","mention":{"type":"database","database":{"id":"db-id"}}}`,
+		},
+		{
+			name:     "date mention",
+			richText: notion.NewDateMention(notion.Date{Start: date}),
+			expJSON:  `{"type":"mention","mention":{"type":"date","date":{"start":"2022-09-01"}}}`,
+		},
+		{
+			name:     "template date mention",
+			richText: notion.NewTemplateDateMention(notion.TemplateMentionDateTypeNow),
+			expJSON:  `{"type":"mention","mention":{"type":"template_mention","template_mention":{"type":"template_mention_date","template_mention_date":"now"}}}`,
+		},
+		{
+			name:     "template user mention",
+			richText: notion.NewTemplateUserMention(),
+			expJSON:  `{"type":"mention","mention":{"type":"template_mention","template_mention":{"type":"template_mention_user","template_mention_user":"me"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tt.richText)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expJSON, string(got)); diff != "" {
+				t.Fatalf("encoded JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+
+	user, err := json.Marshal(notion.NewUserMention("user-id"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := `{"type":"mention","mention":{"type":"user","user":{"object":"user","id":"user-id"}}}`
+	if diff := cmp.Diff(exp, string(user)); diff != "" {
+		t.Fatalf("encoded JSON not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestUserMentionRequest(t *testing.T) {
+	t.Parallel()
+
+	var body string
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body = string(b)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       io.NopCloser(strings.NewReader(`{"object": "comment", "id": "comment-id"}`)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	_, err := client.CreateComment(context.Background(), notion.CreateCommentParams{
+		ParentPageID: "page-id",
+		RichText:     []notion.RichText{notion.NewUserMention("user-id")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exp := `{"parent":{"type":"page_id","page_id":"page-id"},"rich_text":[{"type":"mention","mention":{"type":"user","user":{"object":"user","id":"user-id"}}}]}` + "\n"
+	if diff := cmp.Diff(exp, body); diff != "" {
+		t.Fatalf("request body not equal (-exp, +got):\n%v", diff)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	t.Parallel()
+
+	richText := []notion.RichText{
+		{PlainText: "Foo", Text: &notion.Text{Content: "ignored"}},
+		notion.NewRichText("bar"),
+		{Type: notion.RichTextTypeEquation, Equation: &notion.Equation{Expression: "E=mc^2"}},
+	}
+
+	if exp, got := "FoobarE=mc^2", notion.PlainText(richText); exp != got {
+		t.Fatalf("plain text not equal (expected: %v, got: %v)", exp, got)
+	}
+}
+
+func TestSplitRichText(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", notion.MaxRichTextLength-1) + "€€" + strings.Repeat("b", notion.MaxRichTextLength)
+	link := &notion.Link{URL: "https://example.com"}
+	bold := &notion.Annotations{Bold: true}
+
+	richText := []notion.RichText{
+		notion.NewRichText("Foo"),
+		{Type: notion.RichTextTypeText, Text: &notion.Text{Content: long, Link: link}, Annotations: bold},
+		notion.NewPageMention("page-id"),
+	}
+
+	exp := []notion.RichText{
+		notion.NewRichText("Foo"),
+		{
+			Type:        notion.RichTextTypeText,
+			Text:        &notion.Text{Content: strings.Repeat("a", notion.MaxRichTextLength-1) + "€", Link: link},
+			Annotations: bold,
+		},
+		{
+			Type:        notion.RichTextTypeText,
+			Text:        &notion.Text{Content: "€" + strings.Repeat("b", notion.MaxRichTextLength-1), Link: link},
+			Annotations: bold,
+		},
+		{Type: notion.RichTextTypeText, Text: &notion.Text{Content: "b", Link: link}, Annotations: bold},
+		notion.NewPageMention("page-id"),
+	}
+
+	got := notion.SplitRichText(richText)
+
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("rich text not equal (-exp, +got):\n%v", diff)
+	}
+	if exp, got := notion.PlainText(richText), notion.PlainText(got); exp != got {
+		t.Fatal("expected plain text to be unchanged")
+	}
+}
@@ -1,7 +1,9 @@
 package notion
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -13,18 +15,78 @@ type SearchOpts struct {
 	PageSize    int           `json:"page_size,omitempty"`
 }
 
+// Validate returns an error when the options are invalid, e.g. when the page
+// size is out of bounds, so callers get a descriptive error before a request
+// is made.
+func (opts SearchOpts) Validate() error {
+	if opts.PageSize < 0 || opts.PageSize > MaxPageSize {
+		return fieldError("PageSize", fmt.Errorf("page size must be between 1 and %v, got %v", MaxPageSize, opts.PageSize))
+	}
+	if opts.Sort != nil {
+		if err := opts.Sort.Validate(); err != nil {
+			return fieldError("Sort", fmt.Errorf("sort: %w", err))
+		}
+	}
+	if opts.Filter != nil {
+		if err := opts.Filter.Validate(); err != nil {
+			return fieldError("Filter", fmt.Errorf("filter: %w", err))
+		}
+	}
+	return nil
+}
+
 type SearchSort struct {
 	Direction SortDirection       `json:"direction,omitempty"`
 	Timestamp SearchSortTimestamp `json:"timestamp"`
 }
 
+// SearchSortTimestamp is the timestamp search results are sorted by. The API
+// only supports sorting by `last_edited_time` (unlike database queries, which
+// can be sorted by `created_time` too).
 type SearchSortTimestamp string
 
+// Validate returns an error when the sort direction or timestamp is invalid.
+func (sort SearchSort) Validate() error {
+	switch sort.Direction {
+	case SortDirAsc, SortDirDesc:
+	case "":
+		return errors.New("direction is required")
+	default:
+		return fmt.Errorf("invalid direction %q (expected %q or %q)", sort.Direction, SortDirAsc, SortDirDesc)
+	}
+	if sort.Timestamp != SearchSortTimestampLastEditedTime {
+		return fmt.Errorf("invalid timestamp %q (expected %q)", sort.Timestamp, SearchSortTimestampLastEditedTime)
+	}
+	return nil
+}
+
 type SearchFilter struct {
 	Value    string `json:"value"`
 	Property string `json:"property"`
 }
 
+// Validate returns an error when the filter property or value is invalid.
+func (filter SearchFilter) Validate() error {
+	if filter.Property != SearchFilterPropertyObject {
+		return fmt.Errorf("invalid property %q (expected %q)", filter.Property, SearchFilterPropertyObject)
+	}
+	switch filter.Value {
+	case SearchFilterValuePage, SearchFilterValueDatabase:
+	default:
+		return fmt.Errorf("invalid value %q (expected %q or %q)", filter.Value, SearchFilterValuePage, SearchFilterValueDatabase)
+	}
+	return nil
+}
+
+// Search filter property and values. Currently, search results can only be
+// filtered by object type.
+const (
+	SearchFilterPropertyObject = "object"
+
+	SearchFilterValuePage     = "page"
+	SearchFilterValueDatabase = "database"
+)
+
 type SearchResponse struct {
 	// Results are either pages or databases. See `SearchResponse.UnmarshalJSON`.
 	Results    SearchResults `json:"results"`
@@ -80,3 +142,112 @@ func (sr *SearchResults) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// SearchResult is a search result, with either Page or Database set, denoted
+// by Object (`page` or `database`).
+type SearchResult struct {
+	Object   string
+	Page     *Page
+	Database *Database
+}
+
+// Typed returns the results as SearchResult values, so they can be used without
+// type switches.
+func (sr SearchResults) Typed() []SearchResult {
+	results := make([]SearchResult, 0, len(sr))
+
+	for _, result := range sr {
+		switch r := result.(type) {
+		case Page:
+			results = append(results, SearchResult{Object: "page", Page: &r})
+		case Database:
+			results = append(results, SearchResult{Object: "database", Database: &r})
+		}
+	}
+
+	return results
+}
+
+// Pages returns the pages in the search results.
+func (resp SearchResponse) Pages() []Page {
+	var pages []Page
+	for _, result := range resp.Results {
+		if page, ok := result.(Page); ok {
+			pages = append(pages, page)
+		}
+	}
+	return pages
+}
+
+// Databases returns the databases in the search results.
+func (resp SearchResponse) Databases() []Database {
+	var dbs []Database
+	for _, result := range resp.Results {
+		if db, ok := result.(Database); ok {
+			dbs = append(dbs, db)
+		}
+	}
+	return dbs
+}
+
+// SearchPages returns all pages with titles matching `query` (following
+// pagination), by searching with a filter on pages. The sort and page size of
+// `opts` are used, if set; its query and filter are ignored. If a request
+// fails, the pages found so far are returned along with the error. See
+// ErrCanceled for cancellation.
+func (c *Client) SearchPages(ctx context.Context, query string, opts *SearchOpts) ([]Page, error) {
+	var pages []Page
+
+	err := c.searchAll(ctx, query, SearchFilterValuePage, opts, func(resp SearchResponse) {
+		pages = append(pages, resp.Pages()...)
+	}, func() int { return len(pages) })
+
+	return pages, err
+}
+
+// SearchDatabases returns all databases with titles matching `query`, like
+// SearchPages.
+func (c *Client) SearchDatabases(ctx context.Context, query string, opts *SearchOpts) ([]Database, error) {
+	var dbs []Database
+
+	err := c.searchAll(ctx, query, SearchFilterValueDatabase, opts, func(resp SearchResponse) {
+		dbs = append(dbs, resp.Databases()...)
+	}, func() int { return len(dbs) })
+
+	return dbs, err
+}
+
+// searchAll searches for objects of a type, calling `fn` for each response.
+// `found` returns the number of objects found, for ErrCanceled.
+func (c *Client) searchAll(ctx context.Context, query, object string, opts *SearchOpts, fn func(SearchResponse), found func() int) error {
+	searchOpts := SearchOpts{PageSize: MaxPageSize}
+	if opts != nil {
+		searchOpts.Sort = opts.Sort
+		searchOpts.StartCursor = opts.StartCursor
+		if opts.PageSize > 0 {
+			searchOpts.PageSize = opts.PageSize
+		}
+	}
+	searchOpts.Query = query
+	searchOpts.Filter = &SearchFilter{Property: SearchFilterPropertyObject, Value: object}
+
+	for {
+		if err := canceled(ctx, found(), -1); err != nil {
+			return err
+		}
+
+		resp, err := c.Search(ctx, &searchOpts)
+		if err != nil {
+			if cancelErr := canceled(ctx, found(), -1); cancelErr != nil {
+				return cancelErr
+			}
+			return err
+		}
+		fn(resp)
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return nil
+		}
+		searchOpts.StartCursor = *resp.NextCursor
+	}
+}
@@ -0,0 +1,269 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchResponseResults(t *testing.T) {
+	t.Parallel()
+
+	var resp notion.SearchResponse
+	err := json.Unmarshal([]byte(`{
+		"object": "list",
+		"results": [
+			{"object": "page", "id": "p1", "parent": {"type": "workspace", "workspace": true}, "properties": {}},
+			{"object": "database", "id": "db1", "parent": {"type": "page_id", "page_id": "p1"}, "properties": {}},
+			{"object": "page", "id": "p2", "parent": {"type": "page_id", "page_id": "p1"}, "properties": {}}
+		],
+		"has_more": false,
+		"next_cursor": null
+	}`), &resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("typed", func(t *testing.T) {
+		t.Parallel()
+
+		var got []string
+		for _, result := range resp.Results.Typed() {
+			switch {
+			case result.Page != nil:
+				got = append(got, result.Object+":"+result.Page.ID)
+			case result.Database != nil:
+				got = append(got, result.Object+":"+result.Database.ID)
+			}
+		}
+
+		if diff := cmp.Diff([]string{"page:p1", "database:db1", "page:p2"}, got); diff != "" {
+			t.Fatalf("results not equal (-exp, +got):\n%v", diff)
+		}
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		t.Parallel()
+
+		var got []string
+		for _, page := range resp.Pages() {
+			got = append(got, page.ID)
+		}
+
+		if diff := cmp.Diff([]string{"p1", "p2"}, got); diff != "" {
+			t.Fatalf("pages not equal (-exp, +got):\n%v", diff)
+		}
+	})
+
+	t.Run("databases", func(t *testing.T) {
+		t.Parallel()
+
+		var got []string
+		for _, db := range resp.Databases() {
+			got = append(got, db.ID)
+		}
+
+		if diff := cmp.Diff([]string{"db1"}, got); diff != "" {
+			t.Fatalf("databases not equal (-exp, +got):\n%v", diff)
+		}
+	})
+}
+
+func TestSearchPagesAndDatabases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		search   func(client *notion.Client) ([]string, error)
+		expValue string
+		expIDs   []string
+	}{
+		{
+			name: "pages",
+			search: func(client *notion.Client) ([]string, error) {
+				pages, err := client.SearchPages(context.Background(), "foo", &notion.SearchOpts{
+					Query:  "ignored",
+					Filter: &notion.SearchFilter{Property: "object", Value: "database"},
+				})
+				var ids []string
+				for _, page := range pages {
+					ids = append(ids, page.ID)
+				}
+				return ids, err
+			},
+			expValue: "page",
+			expIDs:   []string{"page", "page-2"},
+		},
+		{
+			name: "databases",
+			search: func(client *notion.Client) ([]string, error) {
+				dbs, err := client.SearchDatabases(context.Background(), "foo", nil)
+				var ids []string
+				for _, db := range dbs {
+					ids = append(ids, db.ID)
+				}
+				return ids, err
+			},
+			expValue: "database",
+			expIDs:   []string{"database", "database-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					var opts notion.SearchOpts
+					if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+
+					exp := notion.SearchOpts{
+						Query:    "foo",
+						Filter:   &notion.SearchFilter{Property: "object", Value: tt.expValue},
+						PageSize: 100,
+					}
+					if opts.StartCursor != "" {
+						exp.StartCursor = "cursor"
+					}
+					if diff := cmp.Diff(exp, opts); diff != "" {
+						t.Fatalf("search options not equal (-exp, +got):\n%v", diff)
+					}
+
+					id, next := tt.expValue, `"cursor"`
+					if opts.StartCursor != "" {
+						id, next = tt.expValue+"-2", "null"
+					}
+					parent := `{"type": "workspace", "workspace": true}`
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body: ioutil.NopCloser(strings.NewReader(fmt.Sprintf(
+							`{"object": "list", "results": [{"object": %q, "id": %q, "parent": %v, "properties": {}}], "has_more": %v, "next_cursor": %v}`,
+							tt.expValue, id, parent, next != "null", next,
+						))),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			ids, err := tt.search(client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expIDs, ids); diff != "" {
+				t.Fatalf("IDs not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestSearchPagesCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := notion.NewClient("secret-api-key")
+
+	_, err := client.SearchPages(ctx, "foo", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error not equal (expected: %v, got: %v)", context.Canceled, err)
+	}
+}
+
+func TestSearchOptsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     notion.SearchOpts
+		expError error
+	}{
+		{
+			name: "valid",
+			opts: notion.SearchOpts{
+				Sort:     &notion.SearchSort{Direction: notion.SortDirDesc, Timestamp: notion.SearchSortTimestampLastEditedTime},
+				Filter:   &notion.SearchFilter{Property: notion.SearchFilterPropertyObject, Value: notion.SearchFilterValuePage},
+				PageSize: 100,
+			},
+		},
+		{
+			name:     "page size too large",
+			opts:     notion.SearchOpts{PageSize: 101},
+			expError: errors.New("page size must be between 1 and 100, got 101"),
+		},
+		{
+			name:     "negative page size",
+			opts:     notion.SearchOpts{PageSize: -1},
+			expError: errors.New("page size must be between 1 and 100, got -1"),
+		},
+		{
+			name:     "missing sort direction",
+			opts:     notion.SearchOpts{Sort: &notion.SearchSort{Timestamp: notion.SearchSortTimestampLastEditedTime}},
+			expError: errors.New("sort: direction is required"),
+		},
+		{
+			name:     "invalid sort direction",
+			opts:     notion.SearchOpts{Sort: &notion.SearchSort{Direction: "desc", Timestamp: notion.SearchSortTimestampLastEditedTime}},
+			expError: errors.New(`sort: invalid direction "desc" (expected "ascending" or "descending")`),
+		},
+		{
+			name:     "invalid sort timestamp",
+			opts:     notion.SearchOpts{Sort: &notion.SearchSort{Direction: notion.SortDirAsc, Timestamp: "created_time"}},
+			expError: errors.New(`sort: invalid timestamp "created_time" (expected "last_edited_time")`),
+		},
+		{
+			name:     "invalid filter value",
+			opts:     notion.SearchOpts{Filter: &notion.SearchFilter{Property: "object", Value: "block"}},
+			expError: errors.New(`filter: invalid value "block" (expected "page" or "database")`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.opts.Validate()
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && (err == nil || tt.expError.Error() != err.Error()) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
+
+func TestSearchInvalidOpts(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	_, err := client.Search(context.Background(), &notion.SearchOpts{PageSize: 200})
+
+	exp := "notion: invalid search options: page size must be between 1 and 100, got 200"
+	if err == nil || err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+}
@@ -0,0 +1,101 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddSelectOption adds an option to a `select` or `multi_select` property of a
+// database. The existing options are kept, because the API removes options
+// that aren't sent when updating a database. If an option with the same name
+// exists, the database is returned as-is.
+func (c *Client) AddSelectOption(ctx context.Context, databaseID, propName string, option SelectOptions) (Database, error) {
+	return c.updateSelectOptions(ctx, databaseID, propName, "add", func(options []SelectOptions) ([]SelectOptions, bool, error) {
+		for _, existing := range options {
+			if existing.Name == option.Name {
+				return nil, false, nil
+			}
+		}
+		return append(options, SelectOptions{Name: option.Name, Color: option.Color}), true, nil
+	})
+}
+
+// RemoveSelectOption removes an option, by name, from a `select` or
+// `multi_select` property of a database. The option is also removed from pages
+// that have it set.
+func (c *Client) RemoveSelectOption(ctx context.Context, databaseID, propName, optionName string) (Database, error) {
+	return c.updateSelectOptions(ctx, databaseID, propName, "remove", func(options []SelectOptions) ([]SelectOptions, bool, error) {
+		for i, existing := range options {
+			if existing.Name == optionName {
+				return append(options[:i:i], options[i+1:]...), true, nil
+			}
+		}
+		return nil, false, fmt.Errorf("option %q not found", optionName)
+	})
+}
+
+// RenameSelectOption renames an option of a `select` or `multi_select` property
+// of a database. Pages that have the option set keep it.
+func (c *Client) RenameSelectOption(ctx context.Context, databaseID, propName, oldName, newName string) (Database, error) {
+	return c.updateSelectOptions(ctx, databaseID, propName, "rename", func(options []SelectOptions) ([]SelectOptions, bool, error) {
+		index := -1
+		for i, existing := range options {
+			switch existing.Name {
+			case oldName:
+				index = i
+			case newName:
+				return nil, false, fmt.Errorf("option %q already exists", newName)
+			}
+		}
+		if index == -1 {
+			return nil, false, fmt.Errorf("option %q not found", oldName)
+		}
+
+		renamed := make([]SelectOptions, len(options))
+		copy(renamed, options)
+		renamed[index].Name = newName
+
+		return renamed, true, nil
+	})
+}
+
+// updateSelectOptions fetches a database, calls `fn` with the options of a
+// `select` or `multi_select` property, and updates the database with the
+// returned options, unless `fn` returns false.
+func (c *Client) updateSelectOptions(ctx context.Context, databaseID, propName, op string, fn func([]SelectOptions) ([]SelectOptions, bool, error)) (Database, error) {
+	// Options that are missing in the update are removed, so the database is
+	// always fetched, instead of read from the object cache.
+	c.invalidateCached(databaseID)
+
+	db, err := c.FindDatabaseByID(ctx, databaseID)
+	if err != nil {
+		return Database{}, err
+	}
+
+	prop, ok := db.Properties[propName]
+	if !ok {
+		return Database{}, fmt.Errorf("notion: failed to %v select option: property %q not found", op, propName)
+	}
+	if prop.Type != DBPropTypeSelect && prop.Type != DBPropTypeMultiSelect {
+		return Database{}, fmt.Errorf("notion: failed to %v select option: property %q has type %q (expected select or multi_select)", op, propName, prop.Type)
+	}
+
+	options, update, err := fn(prop.Options())
+	if err != nil {
+		return Database{}, fmt.Errorf("notion: failed to %v select option: %w", op, err)
+	}
+	if !update {
+		return db, nil
+	}
+
+	updated := DatabaseProperty{Type: prop.Type}
+	if prop.Type == DBPropTypeSelect {
+		updated.Select = &SelectMetadata{Options: options}
+	} else {
+		updated.MultiSelect = &SelectMetadata{Options: options}
+	}
+
+	return c.UpdateDatabase(ctx, databaseID, UpdateDatabaseParams{
+		Properties: map[string]*DatabaseProperty{propName: &updated},
+	})
+}
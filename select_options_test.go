@@ -0,0 +1,159 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+const selectOptionsDatabaseJSON = `{
+	"object": "database",
+	"id": "668d797c-76fa-4934-9b05-ad288df2d136",
+	"properties": {
+		"Name": {"id": "title", "name": "Name", "type": "title", "title": {}},
+		"Tags": {
+			"id": "flsb",
+			"name": "Tags",
+			"type": "multi_select",
+			"multi_select": {
+				"options": [
+					{"id": "1", "name": "Work", "color": "blue"},
+					{"id": "2", "name": "Home", "color": "green"}
+				]
+			}
+		}
+	}
+}`
+
+func TestSelectOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		fn         func(client *notion.Client) (notion.Database, error)
+		expOptions []interface{}
+		expError   error
+	}{
+		{
+			name: "add option",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.AddSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Tags", notion.NewSelectOption("Urgent", notion.ColorRed))
+			},
+			expOptions: []interface{}{
+				map[string]interface{}{"id": "1", "name": "Work", "color": "blue"},
+				map[string]interface{}{"id": "2", "name": "Home", "color": "green"},
+				map[string]interface{}{"name": "Urgent", "color": "red"},
+			},
+		},
+		{
+			name: "add existing option",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.AddSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Tags", notion.NewSelectOption("Work", ""))
+			},
+		},
+		{
+			name: "remove option",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.RemoveSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Tags", "Work")
+			},
+			expOptions: []interface{}{
+				map[string]interface{}{"id": "2", "name": "Home", "color": "green"},
+			},
+		},
+		{
+			name: "rename option",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.RenameSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Tags", "Home", "Personal")
+			},
+			expOptions: []interface{}{
+				map[string]interface{}{"id": "1", "name": "Work", "color": "blue"},
+				map[string]interface{}{"id": "2", "name": "Personal", "color": "green"},
+			},
+		},
+		{
+			name: "rename to existing option",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.RenameSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Tags", "Home", "Work")
+			},
+			expError: errors.New(`notion: failed to rename select option: option "Work" already exists`),
+		},
+		{
+			name: "remove unknown option",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.RemoveSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Tags", "Foobar")
+			},
+			expError: errors.New(`notion: failed to remove select option: option "Foobar" not found`),
+		},
+		{
+			name: "unknown property",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.AddSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Status", notion.NewSelectOption("Done", ""))
+			},
+			expError: errors.New(`notion: failed to add select option: property "Status" not found`),
+		},
+		{
+			name: "property of other type",
+			fn: func(client *notion.Client) (notion.Database, error) {
+				return client.AddSelectOption(context.Background(), "668d797c-76fa-4934-9b05-ad288df2d136", "Name", notion.NewSelectOption("Done", ""))
+			},
+			expError: errors.New(`notion: failed to add select option: property "Name" has type "title" (expected select or multi_select)`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var updated bool
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if r.Method == http.MethodPatch {
+						updated = true
+
+						var body struct {
+							Properties map[string]struct {
+								MultiSelect map[string]interface{} `json:"multi_select"`
+							} `json:"properties"`
+						}
+						if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+							t.Fatal(err)
+						}
+						if diff := cmp.Diff(tt.expOptions, body.Properties["Tags"].MultiSelect["options"]); diff != "" {
+							t.Errorf("options not equal (-exp, +got):\n%v", diff)
+						}
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       io.NopCloser(strings.NewReader(selectOptionsDatabaseJSON)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			_, err := tt.fn(client)
+			if tt.expError != nil {
+				if err == nil || err.Error() != tt.expError.Error() {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if expUpdate := tt.expOptions != nil; updated != expUpdate {
+				t.Fatalf("database update not as expected (expected: %v, got: %v)", expUpdate, updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SyncResult is the result of SyncDatabase.
+type SyncResult struct {
+	// Pages are the pages edited since the given time, least recently edited
+	// first.
+	Pages []Page
+
+	// HighWaterMark is the latest `last_edited_time` of Pages, or the given
+	// time if there are no pages. Pass it to the next SyncDatabase call.
+	HighWaterMark time.Time
+}
+
+// SyncDatabase returns the pages of a database that were edited since `since`,
+// e.g. for syncing a database to another system. All pages are returned if
+// `since` is zero. Persist the high-water mark of the result, and use it for
+// the next sync:
+//
+//	result, err := client.SyncDatabase(ctx, dbID, lastSync)
+//	// Upsert result.Pages, then store result.HighWaterMark as lastSync.
+//
+// Notion rounds `last_edited_time` down to the minute, so pages edited on or
+// after `since` (rounded down to the minute) are returned, to not miss edits
+// made later in the same minute. As a result, pages can be returned again by
+// the next sync, so applying them should be idempotent.
+//
+// Pages are queried using a timestamp filter, sorted by last edited time, so
+// the high-water mark of partial results is safe to resume from. See
+// ErrCanceled for cancellation.
+func (c *Client) SyncDatabase(ctx context.Context, dbID string, since time.Time) (SyncResult, error) {
+	result := SyncResult{HighWaterMark: since}
+
+	query := &DatabaseQuery{
+		Sorts: []DatabaseQuerySort{
+			{Timestamp: SortTimeStampLastEditedTime, Direction: SortDirAsc},
+		},
+		PageSize: MaxPageSize,
+	}
+	if !since.IsZero() {
+		onOrAfter := NewDateTime(since.Truncate(time.Minute), true)
+		filter := NewTimestampFilter(TimestampLastEditedTime, DatePropertyFilter{OnOrAfter: &onOrAfter})
+		query.Filter = &filter
+	}
+
+	for {
+		if err := canceled(ctx, len(result.Pages), -1); err != nil {
+			return result, err
+		}
+
+		resp, err := c.QueryDatabase(ctx, dbID, query)
+		if err != nil {
+			if err := canceled(ctx, len(result.Pages), -1); err != nil {
+				return result, err
+			}
+			return result, fmt.Errorf("notion: failed to sync database: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			result.Pages = append(result.Pages, page)
+			if page.LastEditedTime.After(result.HighWaterMark) {
+				result.HighWaterMark = page.LastEditedTime
+			}
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			return result, nil
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+}
@@ -0,0 +1,146 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSyncDatabase(t *testing.T) {
+	t.Parallel()
+
+	page := func(id, lastEdited string) string {
+		return fmt.Sprintf(`{"object": "page", "id": %q, "last_edited_time": %q, `+
+			`"parent": {"type": "database_id", "database_id": "db-id"}, "properties": {}}`,
+			id, lastEdited)
+	}
+
+	responses := []string{
+		`{"object": "list", "results": [` + page("p1", "2022-09-01T10:30:00.000Z") + `,` + page("p2", "2022-09-02T08:00:00.000Z") + `], "has_more": true, "next_cursor": "cursor-1"}`,
+		`{"object": "list", "results": [` + page("p3", "2022-09-03T12:15:00.000Z") + `], "has_more": false, "next_cursor": null}`,
+	}
+	sorts := []interface{}{
+		map[string]interface{}{"timestamp": "last_edited_time", "direction": "ascending"},
+	}
+
+	tests := []struct {
+		name             string
+		since            time.Time
+		expHighWaterMark time.Time
+		expRequests      []map[string]interface{}
+	}{
+		{
+			name:             "since",
+			since:            time.Date(2022, 9, 1, 10, 30, 45, 0, time.UTC),
+			expHighWaterMark: time.Date(2022, 9, 3, 12, 15, 0, 0, time.UTC),
+			expRequests: []map[string]interface{}{
+				{
+					"filter": map[string]interface{}{
+						"timestamp":        "last_edited_time",
+						"last_edited_time": map[string]interface{}{"on_or_after": "2022-09-01T10:30:00Z"},
+					},
+					"sorts":     sorts,
+					"page_size": float64(100),
+				},
+				{
+					"filter": map[string]interface{}{
+						"timestamp":        "last_edited_time",
+						"last_edited_time": map[string]interface{}{"on_or_after": "2022-09-01T10:30:00Z"},
+					},
+					"sorts":        sorts,
+					"page_size":    float64(100),
+					"start_cursor": "cursor-1",
+				},
+			},
+		},
+		{
+			name:             "all pages",
+			expHighWaterMark: time.Date(2022, 9, 3, 12, 15, 0, 0, time.UTC),
+			expRequests: []map[string]interface{}{
+				{"sorts": sorts, "page_size": float64(100)},
+				{"sorts": sorts, "page_size": float64(100), "start_cursor": "cursor-1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []map[string]interface{}
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					var body map[string]interface{}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Fatalf("failed to decode request body: %v", err)
+					}
+					requests = append(requests, body)
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       ioutil.NopCloser(strings.NewReader(responses[len(requests)-1])),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			result, err := client.SyncDatabase(context.Background(), "db-id", tt.since)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var ids []string
+			for _, page := range result.Pages {
+				ids = append(ids, page.ID)
+			}
+			if diff := cmp.Diff([]string{"p1", "p2", "p3"}, ids); diff != "" {
+				t.Fatalf("page IDs not equal (-exp, +got):\n%v", diff)
+			}
+			if !result.HighWaterMark.Equal(tt.expHighWaterMark) {
+				t.Fatalf("high-water mark not equal (expected: %v, got: %v)", tt.expHighWaterMark, result.HighWaterMark)
+			}
+			if diff := cmp.Diff(tt.expRequests, requests); diff != "" {
+				t.Fatalf("requests not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestSyncDatabaseNoChanges(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"object": "list", "results": [], "has_more": false, "next_cursor": null}`)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	since := time.Date(2022, 9, 1, 10, 30, 0, 0, time.UTC)
+
+	result, err := client.SyncDatabase(context.Background(), "db-id", since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Pages) != 0 {
+		t.Fatalf("expected no pages, got %v", len(result.Pages))
+	}
+	if !result.HighWaterMark.Equal(since) {
+		t.Fatalf("high-water mark not equal (expected: %v, got: %v)", since, result.HighWaterMark)
+	}
+}
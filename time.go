@@ -3,6 +3,7 @@ package notion
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -13,10 +14,15 @@ const dateLength = 10
 // precision, which is what the Notion API returns in JSON response data.
 const DateTimeFormat = "2006-01-02T15:04:05.999Z07:00"
 
+// localDateTimeFormat is used for datetimes without UTC offset, which is
+// required when a time zone is set on a date.
+const localDateTimeFormat = "2006-01-02T15:04:05.999"
+
 // DateTime represents a Notion date property with optional time.
 type DateTime struct {
 	time.Time
 	hasTime bool
+	local   bool
 }
 
 // ParseDateTime parses an RFC3339 formatted string with optional time.
@@ -57,6 +63,9 @@ func (dt *DateTime) UnmarshalJSON(b []byte) error {
 // MarshalJSON implements json.Marshaler. It returns an RFC399 formatted string,
 // using microsecond precision ()
 func (dt DateTime) MarshalJSON() ([]byte, error) {
+	if dt.hasTime && dt.local {
+		return []byte(`"` + dt.Time.Format(localDateTimeFormat) + `"`), nil
+	}
 	if dt.hasTime {
 		return json.Marshal(dt.Time)
 	}
@@ -93,3 +102,60 @@ func (dt DateTime) Equal(value DateTime) bool {
 	}
 	return dt.hasTime == value.hasTime
 }
+
+// NewDateRange returns a Date for use in page properties, with correctly encoded
+// start and (optional, when not zero) end values:
+//
+//   - If `allDay` is true, only the dates are used, in time zone `tz` (if set).
+//   - Else if `tz` is set (an IANA time zone name, e.g. `Europe/Amsterdam`),
+//     times are converted to that time zone and encoded without UTC offset, as
+//     required by Notion, and the date's time zone is set.
+//   - Else, times are encoded with their UTC offset.
+func NewDateRange(start, end time.Time, tz string, allDay bool) (Date, error) {
+	if !end.IsZero() && end.Before(start) {
+		return Date{}, errors.New("notion: invalid date range: end is before start")
+	}
+
+	var loc *time.Location
+	if tz != "" {
+		var err error
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return Date{}, fmt.Errorf("notion: invalid time zone: %w", err)
+		}
+	}
+
+	newDateTime := func(t time.Time) DateTime {
+		if loc != nil {
+			t = t.In(loc)
+		}
+		if allDay {
+			return NewDateTime(t, false)
+		}
+		return DateTime{Time: t, hasTime: true, local: loc != nil}
+	}
+
+	date := Date{Start: newDateTime(start)}
+	if !end.IsZero() {
+		dt := newDateTime(end)
+		date.End = &dt
+	}
+	if loc != nil && !allDay {
+		date.TimeZone = StringPtr(tz)
+	}
+
+	return date, nil
+}
+
+// NewDatePropertyRange returns a `date` database page property, see NewDateRange.
+func NewDatePropertyRange(start, end time.Time, tz string, allDay bool) (DatabasePageProperty, error) {
+	date, err := NewDateRange(start, end, tz, allDay)
+	if err != nil {
+		return DatabasePageProperty{}, err
+	}
+
+	return DatabasePageProperty{
+		Type: DBPropTypeDate,
+		Date: &date,
+	}, nil
+}
@@ -113,3 +113,93 @@ func TestTimeUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDatePropertyRange(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2022, 9, 1, 22, 30, 0, 0, time.UTC)
+	end := time.Date(2022, 9, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		tz       string
+		allDay   bool
+		expJSON  string
+		expError string
+	}{
+		{
+			name:    "datetime",
+			start:   start,
+			expJSON: `{"type":"date","date":{"start":"2022-09-01T22:30:00Z"}}`,
+		},
+		{
+			name:    "datetime range",
+			start:   start,
+			end:     end,
+			expJSON: `{"type":"date","date":{"start":"2022-09-01T22:30:00Z","end":"2022-09-02T09:00:00Z"}}`,
+		},
+		{
+			name:    "datetime range with time zone",
+			start:   start,
+			end:     end,
+			tz:      "Europe/Amsterdam",
+			expJSON: `{"type":"date","date":{"start":"2022-09-02T00:30:00","end":"2022-09-02T11:00:00","time_zone":"Europe/Amsterdam"}}`,
+		},
+		{
+			name:    "all day",
+			start:   start,
+			end:     end,
+			allDay:  true,
+			expJSON: `{"type":"date","date":{"start":"2022-09-01","end":"2022-09-02"}}`,
+		},
+		{
+			name:    "all day with time zone",
+			start:   start,
+			tz:      "Europe/Amsterdam",
+			allDay:  true,
+			expJSON: `{"type":"date","date":{"start":"2022-09-02"}}`,
+		},
+		{
+			name:     "end before start",
+			start:    end,
+			end:      start,
+			expError: "notion: invalid date range: end is before start",
+		},
+		{
+			name:     "invalid time zone",
+			start:    start,
+			tz:       "Foo/Bar",
+			expError: "notion: invalid time zone: unknown time zone Foo/Bar",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prop, err := notion.NewDatePropertyRange(tt.start, tt.end, tt.tz, tt.allDay)
+
+			if tt.expError != "" {
+				if err == nil || err.Error() != tt.expError {
+					t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			propJSON, err := json.Marshal(prop)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expJSON, string(propJSON)); diff != "" {
+				t.Fatalf("encoded JSON not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithTimeout limits the duration of each HTTP request to the Notion API,
+// including reading the response body. The deadline and cancellation of the
+// context passed to client methods are always honored as well; whichever
+// deadline is earliest applies.
+//
+// Unlike `http.Client.Timeout`, this doesn't require configuring a custom
+// http.Client, and it doesn't affect other users of a shared http.Client.
+// Requests that time out fail with an error that wraps
+// context.DeadlineExceeded.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// timeoutTransport is an http.RoundTripper that derives a context with a
+// timeout from the context of each request.
+type timeoutTransport struct {
+	timeout time.Duration
+	next    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		err = wrapTimeout(req.Context(), ctx, err)
+		cancel()
+		return nil, err
+	}
+
+	// The context must outlive RoundTrip, as the body is read by the caller.
+	res.Body = &cancelOnClose{ReadCloser: res.Body, parent: req.Context(), ctx: ctx, cancel: cancel}
+
+	return res, nil
+}
+
+// timeoutError is returned when the timeout of WithTimeout is exceeded, as
+// opposed to the deadline of the context passed to a client method. It wraps
+// the error of the request, which wraps context.DeadlineExceeded.
+type timeoutError struct {
+	err error
+}
+
+// Error implements `error`.
+func (err *timeoutError) Error() string {
+	return err.err.Error()
+}
+
+func (err *timeoutError) Unwrap() error {
+	return err.err
+}
+
+// Timeout implements net.Error.
+func (err *timeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary implements net.Error.
+func (err *timeoutError) Temporary() bool {
+	return true
+}
+
+// wrapTimeout returns `err` as a *timeoutError if `ctx` (derived from `parent`
+// by timeoutTransport) exceeded its deadline, but `parent` didn't.
+func wrapTimeout(parent, ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil {
+		return &timeoutError{err: err}
+	}
+	return err
+}
+
+// cancelOnClose is an io.ReadCloser that cancels a context when it's closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Read implements io.Reader.
+func (rc *cancelOnClose) Read(p []byte) (int, error) {
+	n, err := rc.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = wrapTimeout(rc.parent, rc.ctx, err)
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (rc *cancelOnClose) Close() error {
+	err := rc.ReadCloser.Close()
+	rc.cancel()
+	return err
+}
@@ -0,0 +1,100 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/go-notion"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		ctx      func() (context.Context, context.CancelFunc)
+		delay    time.Duration
+		expError error
+		// expRetryable is the result of IsRetryable for the error.
+		expRetryable bool
+	}{
+		{
+			name:    "within timeout",
+			timeout: time.Second,
+			ctx:     func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+		},
+		{
+			name:         "exceeds timeout",
+			timeout:      10 * time.Millisecond,
+			ctx:          func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			delay:        time.Second,
+			expError:     context.DeadlineExceeded,
+			expRetryable: true,
+		},
+		{
+			name:    "context deadline before timeout",
+			timeout: time.Minute,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			delay:    time.Second,
+			expError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if _, ok := r.Context().Deadline(); !ok {
+						t.Fatal("expected request context to have a deadline")
+					}
+
+					select {
+					case <-time.After(tt.delay):
+					case <-r.Context().Done():
+						return nil, r.Context().Err()
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       ioutil.NopCloser(strings.NewReader(`{"object": "user", "id": "user-id", "type": "bot"}`)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithTimeout(tt.timeout))
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+			user, err := client.FindCurrentUser(ctx)
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && !errors.Is(err, tt.expError) {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+			if tt.expError != nil && notion.IsRetryable(err) != tt.expRetryable {
+				t.Fatalf("retryable not equal (expected: %v, got: %v)", tt.expRetryable, notion.IsRetryable(err))
+			}
+			if tt.expError == nil && user.ID != "user-id" {
+				t.Fatalf("user ID not equal (expected: user-id, got: %v)", user.ID)
+			}
+			if elapsed := time.Since(start); elapsed >= tt.delay && tt.delay > 0 {
+				t.Fatalf("expected request to be canceled before %v, took %v", tt.delay, elapsed)
+			}
+		})
+	}
+}
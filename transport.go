@@ -0,0 +1,168 @@
+package notion
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces the API key in errors and debug output.
+const redacted = "[REDACTED]"
+
+// ErrRedirectsDisabled is used when the client received a redirect response,
+// but following redirects is disabled via WithoutRedirects.
+var ErrRedirectsDisabled = errors.New("notion: following redirects is disabled")
+
+// wrapHTTPClient returns a copy of `hc` with its transport wrapped, so that
+// the API key is only ever sent to the Notion API, and client options that
+// operate on HTTP requests are applied. The original client is left untouched,
+// as it may be shared with other code.
+func (c *Client) wrapHTTPClient(hc *http.Client) *http.Client {
+	next := hc.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if c.tlsConfig != nil {
+		next = withTLSConfig(next, c.tlsConfig)
+	}
+
+	// Transports are wrapped from innermost (closest to the network) to
+	// outermost.
+	if c.timeout > 0 {
+		next = &timeoutTransport{timeout: c.timeout, next: next}
+	}
+	next = c.newDebugTransport(next)
+	if c.rateLimiter != nil {
+		next = &rateLimitTransport{limiter: c.rateLimiter, next: next}
+	}
+	if origin, err := url.Parse(c.baseURL); err != nil || !origin.IsAbs() || origin.Host == "" {
+		next = errorTransport{err: fmt.Errorf("notion: invalid base URL %q, expected an absolute URL", c.baseURL)}
+	} else {
+		next = &compatTransport{basePath: origin.Path, next: next}
+		next = &authTransport{
+			apiKey: c.apiKey,
+			origin: origin,
+			next:   next,
+		}
+	}
+	if len(c.requestHooks) > 0 || len(c.responseHooks) > 0 || c.logger != nil {
+		next = &hookTransport{
+			requestHooks:  c.requestHooks,
+			responseHooks: c.responseHooks,
+			logger:        c.logger,
+			secret:        c.apiKey,
+			next:          next,
+		}
+	}
+	next = c.applyMiddlewares(next)
+
+	wrapped := &http.Client{
+		Transport:     next,
+		CheckRedirect: hc.CheckRedirect,
+		Jar:           hc.Jar,
+		Timeout:       hc.Timeout,
+	}
+
+	if c.disableRedirects {
+		wrapped.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+			return ErrRedirectsDisabled
+		}
+	}
+
+	return wrapped
+}
+
+// withTLSConfig returns a clone of transport `rt` that uses `config`. If `rt`
+// isn't an *http.Transport, the TLS config can't be applied, so a transport that
+// fails all requests is returned instead of silently ignoring the config.
+func withTLSConfig(rt http.RoundTripper, config *tls.Config) http.RoundTripper {
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return errorTransport{err: fmt.Errorf("notion: cannot apply TLS config to transport of type %T, expected *http.Transport", rt)}
+	}
+
+	t = t.Clone()
+	t.TLSClientConfig = config.Clone()
+
+	return t
+}
+
+// errorTransport is an http.RoundTripper that fails all requests.
+type errorTransport struct {
+	err error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// authTransport is an http.RoundTripper that sets the `Authorization` header on
+// requests to the Notion API. Requests to any other origin (e.g. when following
+// a redirect to a file host or a misconfigured proxy) never get the header.
+type authTransport struct {
+	apiKey string
+	origin *url.URL
+	next   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Per the http.RoundTripper contract, the original request must not be
+	// modified.
+	req = req.Clone(req.Context())
+
+	if sameOrigin(req.URL, t.origin) {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	} else {
+		req.Header.Del("Authorization")
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, redactError(err, t.apiKey)
+	}
+
+	return res, nil
+}
+
+func sameOrigin(a, b *url.URL) bool {
+	return a.Scheme == b.Scheme && a.Host == b.Host
+}
+
+// redact replaces all occurrences of `secret` in `s`.
+func redact(s, secret string) string {
+	if secret == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, secret, redacted)
+}
+
+// redactedError wraps an error whose message contains a secret, e.g. a
+// transport error that echoes request headers.
+type redactedError struct {
+	err    error
+	secret string
+}
+
+func redactError(err error, secret string) error {
+	if secret == "" || !strings.Contains(err.Error(), secret) {
+		return err
+	}
+	return &redactedError{err: err, secret: secret}
+}
+
+// Error implements `error`.
+func (err *redactedError) Error() string {
+	return redact(err.err.Error(), err.secret)
+}
+
+func (err *redactedError) Unwrap() error {
+	return err.err
+}
@@ -0,0 +1,503 @@
+package notion_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+)
+
+func TestClientRedirects(t *testing.T) {
+	t.Parallel()
+
+	redirectResponse := func(r *http.Request) (*http.Response, error) {
+		if r.URL.Host == "api.notion.com" {
+			return &http.Response{
+				StatusCode: http.StatusFound,
+				Status:     http.StatusText(http.StatusFound),
+				Header:     http.Header{"Location": []string{"https://files.example.com/foobar"}},
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Body:       ioutil.NopCloser(strings.NewReader(`{"object": "user", "id": "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"}`)),
+		}, nil
+	}
+
+	t.Run("sends authorization header to Notion API only", func(t *testing.T) {
+		t.Parallel()
+
+		authHeaders := map[string]string{}
+
+		httpClient := &http.Client{
+			Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+				authHeaders[r.URL.Host] = r.Header.Get("Authorization")
+				return redirectResponse(r)
+			}},
+		}
+		client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+		_, err := client.FindUserByID(context.Background(), "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if exp, got := "Bearer secret-api-key", authHeaders["api.notion.com"]; exp != got {
+			t.Errorf("authorization header not equal (expected: %q, got: %q)", exp, got)
+		}
+		if got := authHeaders["files.example.com"]; got != "" {
+			t.Errorf("expected no authorization header for other origin, got: %q", got)
+		}
+	})
+
+	t.Run("returns error when redirects are disabled", func(t *testing.T) {
+		t.Parallel()
+
+		httpClient := &http.Client{
+			Transport: &mockRoundtripper{fn: redirectResponse},
+		}
+		client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithoutRedirects())
+
+		_, err := client.FindUserByID(context.Background(), "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18")
+		if !errors.Is(err, notion.ErrRedirectsDisabled) {
+			t.Fatalf("error not equal (expected: %v, got: %v)", notion.ErrRedirectsDisabled, err)
+		}
+	})
+}
+
+func TestClientDebugWriter(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"object": "user", "id": "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"}`)),
+			}, nil
+		}},
+	}
+	buf := &bytes.Buffer{}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithDebugWriter(buf))
+
+	_, err := client.FindUserByID(context.Background(), "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dump := buf.String()
+
+	if strings.Contains(dump, "secret-api-key") {
+		t.Errorf("expected API key to be redacted from debug output, got:\n%v", dump)
+	}
+	if !strings.Contains(dump, "Authorization: [REDACTED]") {
+		t.Errorf("expected redacted authorization header in debug output, got:\n%v", dump)
+	}
+	if !strings.Contains(dump, `"id": "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"`) {
+		t.Errorf("expected response body in debug output, got:\n%v", dump)
+	}
+}
+
+// errReadCloser is a response body that fails to read, and records if it was
+// closed.
+type errReadCloser struct {
+	closed bool
+}
+
+func (rc *errReadCloser) Read([]byte) (int, error) {
+	return 0, errors.New("read timeout")
+}
+
+func (rc *errReadCloser) Close() error {
+	rc.closed = true
+	return nil
+}
+
+func TestClientDebugWriterBodies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request body", func(t *testing.T) {
+		t.Parallel()
+
+		httpClient := &http.Client{
+			Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if exp := `{"query":"foo"}` + "\n"; string(body) != exp {
+					t.Errorf("request body not equal (expected: %q, got: %q)", exp, body)
+				}
+				if r.GetBody == nil {
+					t.Error("expected GetBody to be set")
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     http.StatusText(http.StatusOK),
+					Body:       ioutil.NopCloser(strings.NewReader(`{"object": "list", "results": []}`)),
+				}, nil
+			}},
+		}
+		buf := &bytes.Buffer{}
+		client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithDebugWriter(buf))
+
+		if _, err := client.Search(context.Background(), &notion.SearchOpts{Query: "foo"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), `"query": "foo"`) {
+			t.Errorf("expected request body in debug output, got:\n%v", buf.String())
+		}
+	})
+
+	t.Run("response body read error", func(t *testing.T) {
+		t.Parallel()
+
+		body := &errReadCloser{}
+		httpClient := &http.Client{
+			Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     http.StatusText(http.StatusOK),
+					Body:       body,
+				}, nil
+			}},
+		}
+		client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithDebugWriter(&bytes.Buffer{}))
+
+		_, err := client.FindUserByID(context.Background(), "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18")
+		if err == nil || !strings.Contains(err.Error(), "read timeout") {
+			t.Fatalf("expected read error, got: %v", err)
+		}
+		if !body.closed {
+			t.Error("expected response body to be closed")
+		}
+	})
+}
+
+func TestClientSetDumpRequests(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"object": "user", "id": "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"}`)),
+			}, nil
+		}},
+	}
+	buf := &bytes.Buffer{}
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(httpClient),
+		notion.WithDebugWriter(buf),
+		notion.WithDumpRequests(false),
+	)
+
+	if _, err := client.FindUserByID(context.Background(), "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no debug output, got:\n%v", buf.String())
+	}
+
+	client.SetDumpRequests(true)
+
+	if _, err := client.FindUserByID(context.Background(), "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "GET /v1/users/be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18") {
+		t.Fatalf("expected request dump in debug output, got:\n%v", buf.String())
+	}
+}
+
+func TestClientAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		apiVersion  string
+		expVersion  string
+		expPostBody string
+	}{
+		{
+			name:        "default version",
+			expVersion:  "2022-06-28",
+			expPostBody: `{"filter":{"or":[{"property":"Name","rich_text":{"contains":"foo"}}]}}`,
+		},
+		{
+			name:        "version before rich text rename",
+			apiVersion:  "2021-08-16",
+			expVersion:  "2021-08-16",
+			expPostBody: `{"filter":{"or":[{"property":"Name","text":{"contains":"foo"}}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if got := r.Header.Get("Notion-Version"); got != tt.expVersion {
+						t.Errorf("version not equal (expected: %q, got: %q)", tt.expVersion, got)
+					}
+
+					body, err := ioutil.ReadAll(r.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if got := strings.TrimSpace(string(body)); got != tt.expPostBody {
+						t.Errorf("post body not equal (expected: %v, got: %v)", tt.expPostBody, got)
+					}
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     http.StatusText(http.StatusOK),
+						Body:       ioutil.NopCloser(strings.NewReader(`{"object": "list", "results": []}`)),
+					}, nil
+				}},
+			}
+
+			opts := []notion.ClientOption{notion.WithHTTPClient(httpClient)}
+			if tt.apiVersion != "" {
+				opts = append(opts, notion.WithAPIVersion(tt.apiVersion))
+			}
+			client := notion.NewClient("secret-api-key", opts...)
+
+			_, err := client.QueryDatabase(context.Background(), "00000000-0000-0000-0000-000000000000", &notion.DatabaseQuery{
+				Filter: &notion.DatabaseQueryFilter{
+					Or: []notion.DatabaseQueryFilter{
+						{
+							Property: "Name",
+							DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+								RichText: &notion.TextPropertyFilter{
+									Contains: "foo",
+								},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+type mockLogger struct {
+	lines []string
+}
+
+func (l *mockLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestClientHooks(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Header:     http.Header{"X-Request-Id": []string{"e3a6a5a4-64b4-4a4f-a2a1-8b7a4e5bbd0e"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"object": "user", "id": "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"}`)),
+			}, nil
+		}},
+	}
+
+	var reqPath, resRequestID string
+	logger := &mockLogger{}
+
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(httpClient),
+		notion.WithLogger(logger),
+		notion.WithRequestHook(func(r *http.Request) {
+			reqPath = r.URL.Path
+		}),
+		notion.WithResponseHook(func(r *http.Response) {
+			resRequestID = r.Header.Get("X-Request-Id")
+		}),
+	)
+
+	if _, err := client.FindUserByID(context.Background(), "be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if exp := "/v1/users/be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18"; reqPath != exp {
+		t.Errorf("request path not equal (expected: %q, got: %q)", exp, reqPath)
+	}
+	if exp := "e3a6a5a4-64b4-4a4f-a2a1-8b7a4e5bbd0e"; resRequestID != exp {
+		t.Errorf("request ID not equal (expected: %q, got: %q)", exp, resRequestID)
+	}
+	if len(logger.lines) != 1 {
+		t.Fatalf("expected 1 log line, got: %v", len(logger.lines))
+	}
+	if exp := "notion: GET /v1/users/be32af26-4f8a-4a66-9ac4-ab8cf9e0fe18 200"; !strings.HasPrefix(logger.lines[0], exp) {
+		t.Errorf("log line has unexpected prefix (expected: %q, got: %q)", exp, logger.lines[0])
+	}
+	if !strings.Contains(logger.lines[0], "request ID: e3a6a5a4-64b4-4a4f-a2a1-8b7a4e5bbd0e") {
+		t.Errorf("expected request ID in log line, got: %q", logger.lines[0])
+	}
+}
+
+func TestClientTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"object": "user", "id": "foobar", "type": "bot"}`)
+	}))
+	srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	// The transport dials the test server for all addresses, simulating a TLS
+	// intercepting proxy with a (for the system) unknown CA.
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+		},
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(srv.Certificate())
+
+	tests := []struct {
+		name     string
+		opts     []notion.ClientOption
+		expError string
+	}{
+		{
+			name: "untrusted CA",
+			opts: []notion.ClientOption{
+				notion.WithHTTPClient(&http.Client{Transport: transport}),
+			},
+			expError: "tls: failed to verify certificate",
+		},
+		{
+			name: "trusted CA",
+			opts: []notion.ClientOption{
+				notion.WithHTTPClient(&http.Client{Transport: transport}),
+				// The test server certificate is valid for `example.com`.
+				notion.WithTLSConfig(&tls.Config{RootCAs: rootCAs, ServerName: "example.com"}),
+			},
+		},
+		{
+			name: "unsupported transport",
+			opts: []notion.ClientOption{
+				notion.WithHTTPClient(&http.Client{Transport: &mockRoundtripper{}}),
+				notion.WithTLSConfig(&tls.Config{RootCAs: rootCAs}),
+			},
+			expError: "notion: cannot apply TLS config to transport of type *notion_test.mockRoundtripper, expected *http.Transport",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := notion.NewClient("secret-api-key", tt.opts...)
+
+			user, err := client.FindCurrentUser(context.Background())
+			if tt.expError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if user.ID != "foobar" {
+					t.Fatalf("user ID not equal (expected: foobar, got: %v)", user.ID)
+				}
+				if transport.TLSClientConfig != nil {
+					t.Fatal("expected original transport to be left untouched")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expError) {
+				t.Fatalf("error does not contain %q (got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
+
+func TestClientBaseURL(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			if exp := "https://proxy.example.com/notion/v1/databases/00000000-0000-0000-0000-000000000000/query"; r.URL.String() != exp {
+				t.Errorf("URL not equal (expected: %v, got: %v)", exp, r.URL)
+			}
+			if exp := "Bearer secret-api-key"; r.Header.Get("Authorization") != exp {
+				t.Errorf("authorization header not equal (expected: %q, got: %q)", exp, r.Header.Get("Authorization"))
+			}
+
+			// Request bodies are reshaped for old API versions, regardless of the
+			// base path.
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exp := `{"filter":{"property":"Name","text":{"contains":"foo"}}}`; strings.TrimSpace(string(body)) != exp {
+				t.Errorf("post body not equal (expected: %v, got: %s)", exp, body)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"object": "list", "results": []}`)),
+			}, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key",
+		notion.WithHTTPClient(httpClient),
+		notion.WithBaseURL("https://proxy.example.com/notion/v1/"),
+		notion.WithAPIVersion("2021-08-16"),
+	)
+
+	_, err := client.QueryDatabase(context.Background(), "00000000-0000-0000-0000-000000000000", &notion.DatabaseQuery{
+		Filter: &notion.DatabaseQueryFilter{
+			Property: "Name",
+			DatabaseQueryPropertyFilter: notion.DatabaseQueryPropertyFilter{
+				RichText: &notion.TextPropertyFilter{Contains: "foo"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientInvalidBaseURL(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient), notion.WithBaseURL("localhost/v1"))
+
+	_, err := client.FindUserByID(context.Background(), "00000000-0000-0000-0000-000000000000")
+	if err == nil || !strings.Contains(err.Error(), `notion: invalid base URL "localhost/v1", expected an absolute URL`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxURLLength is the maximum length (in characters) of URLs accepted by the
+// Notion API, e.g. for embeds, bookmarks, external files, URL properties and
+// rich text links.
+// See: https://developers.notion.com/reference/request-limits
+const MaxURLLength = 2000
+
+var (
+	// webURLSchemes are accepted for URLs of embeds, bookmarks, external files
+	// and URL properties.
+	webURLSchemes = []string{"http", "https"}
+	// linkURLSchemes are accepted for rich text links.
+	linkURLSchemes = []string{"http", "https", "mailto", "tel"}
+)
+
+// validateURL returns an error if `rawURL` is empty, exceeds MaxURLLength, or
+// isn't an absolute URL with one of the given schemes.
+func validateURL(rawURL string, schemes []string) error {
+	if rawURL == "" {
+		return errors.New("URL cannot be empty")
+	}
+	if n := utf8.RuneCountInString(rawURL); n > MaxURLLength {
+		return fmt.Errorf("URL exceeds maximum length of %v characters (got: %v)", MaxURLLength, n)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if u.Scheme == "" {
+		return fmt.Errorf("invalid URL %q: must be absolute", rawURL)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	for _, s := range schemes {
+		if scheme != s {
+			continue
+		}
+		if (s == "http" || s == "https") && u.Host == "" {
+			return fmt.Errorf("invalid URL %q: host is required", rawURL)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("invalid URL %q: unsupported scheme %q (expected one of: %v)", rawURL, u.Scheme, strings.Join(schemes, ", "))
+}
+
+// Validate returns an error if the URL is empty, too long, or isn't an
+// absolute HTTP(S) URL.
+func (file FileExternal) Validate() error {
+	return validateURL(file.URL, webURLSchemes)
+}
+
+// validateFileExternal validates `file`, if not nil.
+func validateFileExternal(file *FileExternal) error {
+	if file == nil {
+		return nil
+	}
+	if err := file.Validate(); err != nil {
+		return fmt.Errorf("external file: %w", err)
+	}
+	return nil
+}
+
+// validateRichText returns an error for the first rich text item with an invalid
+// link.
+func validateRichText(richText []RichText) error {
+	for i, rt := range richText {
+		if rt.Text == nil || rt.Text.Link == nil {
+			continue
+		}
+		if err := validateURL(rt.Text.Link.URL, linkURLSchemes); err != nil {
+			return fmt.Errorf("rich text [%v]: link: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the embed URL is invalid.
+func (b EmbedBlock) Validate() error {
+	if err := validateURL(b.URL, webURLSchemes); err != nil {
+		return fmt.Errorf("embed: %w", err)
+	}
+	return nil
+}
+
+// Validate returns an error if the bookmark URL or a caption link is invalid.
+func (b BookmarkBlock) Validate() error {
+	if err := validateURL(b.URL, webURLSchemes); err != nil {
+		return fmt.Errorf("bookmark: %w", err)
+	}
+	if err := validateRichText(b.Caption); err != nil {
+		return fmt.Errorf("bookmark caption: %w", err)
+	}
+	return nil
+}
+
+// validateBlocks validates URLs of blocks (and their children) before they are
+// sent to the API, which otherwise fails with a generic validation error.
+func validateBlocks(blocks []Block) error {
+	for i, block := range blocks {
+		if err := validateBlock(block); err != nil {
+			return fmt.Errorf("block [%v]: %w", i, err)
+		}
+		if err := validateBlocks(blockChildren(block)); err != nil {
+			return fmt.Errorf("block [%v]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateBlock(block Block) error {
+	var err error
+
+	switch b := blockPtr(block).(type) {
+	case *EmbedBlock:
+		err = b.Validate()
+	case *BookmarkBlock:
+		return b.Validate()
+	case *ImageBlock:
+		err = validateFileExternal(b.External)
+	case *AudioBlock:
+		err = validateFileExternal(b.External)
+	case *VideoBlock:
+		err = validateFileExternal(b.External)
+	case *FileBlock:
+		err = validateFileExternal(b.External)
+	case *PDFBlock:
+		err = validateFileExternal(b.External)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, richText := range blockRichText(block) {
+		if err := validateRichText(richText); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
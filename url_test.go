@@ -0,0 +1,154 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+)
+
+func TestURLValidation(t *testing.T) {
+	t.Parallel()
+
+	longURL := "https://example.com/" + strings.Repeat("a", notion.MaxURLLength)
+
+	tests := []struct {
+		name     string
+		validate func() error
+		expError error
+	}{
+		{
+			name:     "valid external file",
+			validate: notion.FileExternal{URL: "https://example.com/image.png"}.Validate,
+			expError: nil,
+		},
+		{
+			name:     "external file with empty URL",
+			validate: notion.FileExternal{}.Validate,
+			expError: errors.New("URL cannot be empty"),
+		},
+		{
+			name:     "external file with relative URL",
+			validate: notion.FileExternal{URL: "/image.png"}.Validate,
+			expError: errors.New(`invalid URL "/image.png": must be absolute`),
+		},
+		{
+			name:     "external file with unsupported scheme",
+			validate: notion.FileExternal{URL: "ftp://example.com/image.png"}.Validate,
+			expError: errors.New(`invalid URL "ftp://example.com/image.png": unsupported scheme "ftp" (expected one of: http, https)`),
+		},
+		{
+			name:     "external file without host",
+			validate: notion.FileExternal{URL: "https:///image.png"}.Validate,
+			expError: errors.New(`invalid URL "https:///image.png": host is required`),
+		},
+		{
+			name:     "embed with too long URL",
+			validate: notion.EmbedBlock{URL: longURL}.Validate,
+			expError: errors.New("embed: URL exceeds maximum length of 2000 characters (got: 2020)"),
+		},
+		{
+			name: "bookmark with invalid caption link",
+			validate: notion.BookmarkBlock{
+				URL: "https://example.com",
+				Caption: []notion.RichText{
+					{Text: &notion.Text{Content: "Foo"}},
+					{Text: &notion.Text{Content: "bar", Link: &notion.Link{URL: "javascript:alert(1)"}}},
+				},
+			}.Validate,
+			expError: errors.New(`bookmark caption: rich text [1]: link: invalid URL "javascript:alert(1)": unsupported scheme "javascript" (expected one of: http, https, mailto, tel)`),
+		},
+		{
+			name: "URL property",
+			validate: notion.DatabasePageProperty{
+				URL: notion.StringPtr("example.com"),
+			}.Validate,
+			expError: errors.New(`url: invalid URL "example.com": must be absolute`),
+		},
+		{
+			name: "rich text property with mailto link",
+			validate: notion.DatabasePageProperty{
+				RichText: []notion.RichText{
+					{Text: &notion.Text{Content: "Mail", Link: &notion.Link{URL: "mailto:foo@example.com"}}},
+				},
+			}.Validate,
+			expError: nil,
+		},
+		{
+			name: "files property",
+			validate: notion.DatabasePageProperty{
+				Files: []notion.File{
+					{Name: "foo.png", Type: notion.FileTypeExternal, External: &notion.FileExternal{URL: "foo.png"}},
+				},
+			}.Validate,
+			expError: errors.New(`files [0]: external file: invalid URL "foo.png": must be absolute`),
+		},
+		{
+			name: "cover",
+			validate: notion.Cover{
+				Type:     notion.FileTypeExternal,
+				External: &notion.FileExternal{URL: "data:image/png;base64,AAAA"},
+			}.Validate,
+			expError: errors.New(`cover: external file: invalid URL "data:image/png;base64,AAAA": unsupported scheme "data" (expected one of: http, https)`),
+		},
+		{
+			name: "comment",
+			validate: notion.CreateCommentParams{
+				ParentPageID: "page-id",
+				RichText: []notion.RichText{
+					{Text: &notion.Text{Content: "Foo", Link: &notion.Link{URL: ""}}},
+				},
+			}.Validate,
+			expError: errors.New("rich text [0]: link: URL cannot be empty"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.validate()
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && err == nil {
+				t.Fatalf("error not equal (expected: %v, got: nil)", tt.expError)
+			}
+			if tt.expError != nil && err != nil && tt.expError.Error() != err.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+		})
+	}
+}
+
+func TestAppendBlockChildrenInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{
+		Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request: %v %v", r.Method, r.URL.Path)
+			return nil, nil
+		}},
+	}
+	client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+	_, err := client.AppendBlockChildren(context.Background(), "block-id", []notion.Block{
+		notion.ParagraphBlock{RichText: []notion.RichText{{Text: &notion.Text{Content: "Foo"}}}},
+		&notion.ToggleBlock{
+			RichText: []notion.RichText{{Text: &notion.Text{Content: "Bar"}}},
+			Children: []notion.Block{
+				notion.ImageBlock{Type: notion.FileTypeExternal, External: &notion.FileExternal{URL: "image.png"}},
+			},
+		},
+	})
+
+	exp := `notion: invalid block children: block [1]: block [0]: external file: invalid URL "image.png": must be absolute`
+	if err == nil || err.Error() != exp {
+		t.Fatalf("error not equal (expected: %v, got: %v)", exp, err)
+	}
+}
@@ -1,5 +1,7 @@
 package notion
 
+import "encoding/json"
+
 type UserType string
 
 const (
@@ -13,8 +15,45 @@ type Person struct {
 
 type Bot struct {
 	Owner BotOwner `json:"owner"`
+
+	// The fields below are only returned for the bot user of the integration
+	// itself, e.g. via `FindCurrentUser`.
+	WorkspaceID     string           `json:"workspace_id,omitempty"`
+	WorkspaceName   string           `json:"workspace_name,omitempty"`
+	WorkspaceLimits *WorkspaceLimits `json:"workspace_limits,omitempty"`
+	Capabilities    []BotCapability  `json:"capabilities,omitempty"`
+}
+
+// HasCapability returns true if the integration was granted `capability`.
+func (b Bot) HasCapability(capability BotCapability) bool {
+	for _, c := range b.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
 }
 
+// WorkspaceLimits contains limits of the workspace the integration is installed
+// in.
+type WorkspaceLimits struct {
+	MaxFileUploadSizeInBytes int64 `json:"max_file_upload_size_in_bytes"`
+}
+
+// BotCapability is a capability granted to an integration.
+// See: https://developers.notion.com/reference/capabilities
+type BotCapability string
+
+const (
+	BotCapabilityReadContent          BotCapability = "read_content"
+	BotCapabilityUpdateContent        BotCapability = "update_content"
+	BotCapabilityInsertContent        BotCapability = "insert_content"
+	BotCapabilityReadComments         BotCapability = "read_comments"
+	BotCapabilityInsertComments       BotCapability = "insert_comments"
+	BotCapabilityReadUserWithEmail    BotCapability = "read_user_with_email"
+	BotCapabilityReadUserWithoutEmail BotCapability = "read_user_without_email"
+)
+
 type BotOwnerType string
 
 const (
@@ -43,6 +82,22 @@ type User struct {
 
 	Person *Person `json:"person"`
 	Bot    *Bot    `json:"bot"`
+
+	// Raw contains the JSON object, as returned by the API. It's only set when
+	// the client was created with WithRawCapture.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UserRef returns a user with only an ID, e.g. for setting the value of a
+// `people` property.
+func UserRef(id string) User {
+	return User{BaseUser: BaseUser{ID: id}}
+}
+
+// isRef returns true if the user has only an ID, like users created with
+// UserRef.
+func (u User) isRef() bool {
+	return u.Type == "" && u.Name == "" && u.AvatarURL == "" && u.Person == nil && u.Bot == nil
 }
 
 // ListUsersResponse contains results (users) and pagination data returned from a list request.
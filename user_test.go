@@ -0,0 +1,97 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBotHasCapability(t *testing.T) {
+	t.Parallel()
+
+	bot := notion.Bot{
+		Capabilities: []notion.BotCapability{notion.BotCapabilityReadContent},
+	}
+
+	if !bot.HasCapability(notion.BotCapabilityReadContent) {
+		t.Fatal("expected bot to have capability `read_content`")
+	}
+	if bot.HasCapability(notion.BotCapabilityInsertContent) {
+		t.Fatal("expected bot not to have capability `insert_content`")
+	}
+}
+
+func TestPeoplePropertyWrite(t *testing.T) {
+	t.Parallel()
+
+	// E.g. a user of a fetched page, with read-only fields.
+	user := notion.User{
+		BaseUser:  notion.BaseUser{ID: "be32e790-8292-46df-a248-b784fdf483cf"},
+		Type:      notion.UserTypePerson,
+		Name:      "Jane Doe",
+		AvatarURL: "https://example.com/avatar.png",
+		Person:    &notion.Person{Email: "jane@example.com"},
+	}
+	props := notion.DatabasePageProperties{
+		"Assignees": {
+			People: []notion.User{user, notion.UserRef("71e95936-2737-4e11-b03d-f174f6f13087")},
+		},
+		"Reviewers": {
+			Type:  notion.DBPropTypePeople,
+			Clear: true,
+		},
+	}
+	expProps := map[string]interface{}{
+		"Assignees": map[string]interface{}{
+			"people": []interface{}{
+				map[string]interface{}{"id": "be32e790-8292-46df-a248-b784fdf483cf"},
+				map[string]interface{}{"id": "71e95936-2737-4e11-b03d-f174f6f13087"},
+			},
+		},
+		"Reviewers": map[string]interface{}{
+			"people": []interface{}{},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		params interface{}
+	}{
+		{
+			name: "create page",
+			params: notion.CreatePageParams{
+				ParentType:             notion.ParentTypeDatabase,
+				ParentID:               "668d797c-76fa-4934-9b05-ad288df2d136",
+				DatabasePageProperties: &props,
+			},
+		},
+		{
+			name:   "update page",
+			params: notion.UpdatePageParams{DatabasePageProperties: props},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var body struct {
+				Properties map[string]interface{} `json:"properties"`
+			}
+			if err := json.Unmarshal(b, &body); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expProps, body.Properties); diff != "" {
+				t.Fatalf("properties not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -26,3 +26,8 @@ func TimePtr(t time.Time) *time.Time {
 func Float64Ptr(f float64) *float64 {
 	return &f
 }
+
+// DateTimePtr returns the pointer of a DateTime value.
+func DateTimePtr(dt DateTime) *DateTime {
+	return &dt
+}
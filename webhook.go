@@ -0,0 +1,121 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrWebhookObjectDeleted is returned by EventObject when the object of a
+// webhook event was deleted, or isn't (or no longer) accessible to the
+// integration.
+var ErrWebhookObjectDeleted = errors.New("notion: object of webhook event is deleted or not accessible")
+
+// WebhookEvent is an event delivered to a webhook subscription.
+// See: https://developers.notion.com/reference/webhooks-events-delivery
+type WebhookEvent struct {
+	ID             string           `json:"id"`
+	Timestamp      time.Time        `json:"timestamp"`
+	WorkspaceID    string           `json:"workspace_id"`
+	WorkspaceName  string           `json:"workspace_name"`
+	SubscriptionID string           `json:"subscription_id"`
+	IntegrationID  string           `json:"integration_id"`
+	Type           WebhookEventType `json:"type"`
+	Authors        []WebhookAuthor  `json:"authors"`
+	AttemptNumber  int              `json:"attempt_number"`
+	Entity         WebhookEntity    `json:"entity"`
+
+	// Data contains the raw JSON value of the event type specific data.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// WebhookAuthor is a user or bot that caused a webhook event.
+type WebhookAuthor struct {
+	ID   string   `json:"id"`
+	Type UserType `json:"type"`
+}
+
+// WebhookEntity references the object of a webhook event.
+type WebhookEntity struct {
+	ID   string            `json:"id"`
+	Type WebhookEntityType `json:"type"`
+}
+
+type (
+	WebhookEventType  string
+	WebhookEntityType string
+)
+
+const (
+	WebhookEventPageCreated            WebhookEventType = "page.created"
+	WebhookEventPageContentUpdated     WebhookEventType = "page.content_updated"
+	WebhookEventPagePropertiesUpdated  WebhookEventType = "page.properties_updated"
+	WebhookEventPageMoved              WebhookEventType = "page.moved"
+	WebhookEventPageDeleted            WebhookEventType = "page.deleted"
+	WebhookEventPageUndeleted          WebhookEventType = "page.undeleted"
+	WebhookEventPageLocked             WebhookEventType = "page.locked"
+	WebhookEventPageUnlocked           WebhookEventType = "page.unlocked"
+	WebhookEventDatabaseCreated        WebhookEventType = "database.created"
+	WebhookEventDatabaseContentUpdated WebhookEventType = "database.content_updated"
+	WebhookEventDatabaseMoved          WebhookEventType = "database.moved"
+	WebhookEventDatabaseDeleted        WebhookEventType = "database.deleted"
+	WebhookEventDatabaseUndeleted      WebhookEventType = "database.undeleted"
+	WebhookEventDatabaseSchemaUpdated  WebhookEventType = "database.schema_updated"
+	WebhookEventCommentCreated         WebhookEventType = "comment.created"
+	WebhookEventCommentUpdated         WebhookEventType = "comment.updated"
+	WebhookEventCommentDeleted         WebhookEventType = "comment.deleted"
+
+	WebhookEntityPage     WebhookEntityType = "page"
+	WebhookEntityDatabase WebhookEntityType = "database"
+	WebhookEntityBlock    WebhookEntityType = "block"
+	WebhookEntityComment  WebhookEntityType = "comment"
+)
+
+// IsDeletion returns true for events of deleted objects, e.g. `page.deleted`.
+func (t WebhookEventType) IsDeletion() bool {
+	return strings.HasSuffix(string(t), ".deleted")
+}
+
+// EventObject fetches the object of a webhook event, so handlers go from event
+// to typed data in one call. Depending on the entity type, the returned value
+// is a Page, Database, Block or Comment; use a type switch to access it.
+//
+// For deletion events, or when the object isn't found (e.g. because it was
+// deleted after the event), ErrWebhookObjectDeleted is returned. Pages and
+// databases that were archived or moved to trash are returned as is; check
+// their `Archived` and `InTrash` fields.
+func EventObject(ctx context.Context, client *Client, event WebhookEvent) (interface{}, error) {
+	if event.Type.IsDeletion() {
+		return nil, ErrWebhookObjectDeleted
+	}
+
+	var (
+		obj interface{}
+		err error
+	)
+
+	switch event.Entity.Type {
+	case WebhookEntityPage:
+		obj, err = client.FindPageByID(ctx, event.Entity.ID)
+	case WebhookEntityDatabase:
+		obj, err = client.FindDatabaseByID(ctx, event.Entity.ID)
+	case WebhookEntityBlock:
+		obj, err = client.FindBlockByID(ctx, event.Entity.ID)
+	case WebhookEntityComment:
+		obj, err = client.FindCommentByID(ctx, event.Entity.ID)
+	default:
+		return nil, fmt.Errorf("notion: unsupported webhook entity type %q", event.Entity.Type)
+	}
+
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil, ErrWebhookObjectDeleted
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,119 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/go-notion"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEventObject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		event          string
+		expPath        string
+		respStatusCode int
+		respBody       string
+		expObject      interface{}
+		expError       error
+	}{
+		{
+			name:           "page",
+			event:          `{"id": "event-id", "type": "page.properties_updated", "entity": {"id": "page-id", "type": "page"}}`,
+			expPath:        "/v1/pages/page-id",
+			respStatusCode: http.StatusOK,
+			respBody:       `{"object": "page", "id": "page-id", "parent": {"type": "workspace", "workspace": true}, "archived": true, "properties": {}}`,
+			expObject: notion.Page{
+				ID:         "page-id",
+				Parent:     notion.Parent{Type: notion.ParentTypeWorkspace, Workspace: true},
+				Archived:   true,
+				Properties: notion.PageProperties{},
+			},
+		},
+		{
+			name:           "comment",
+			event:          `{"id": "event-id", "type": "comment.created", "entity": {"id": "comment-id", "type": "comment"}}`,
+			expPath:        "/v1/comments/comment-id",
+			respStatusCode: http.StatusOK,
+			respBody:       `{"object": "comment", "id": "comment-id", "discussion_id": "discussion-id"}`,
+			expObject:      notion.Comment{ID: "comment-id", DiscussionID: "discussion-id"},
+		},
+		{
+			name:     "deletion event",
+			event:    `{"id": "event-id", "type": "page.deleted", "entity": {"id": "page-id", "type": "page"}}`,
+			expError: notion.ErrWebhookObjectDeleted,
+		},
+		{
+			name:           "object not found",
+			event:          `{"id": "event-id", "type": "database.schema_updated", "entity": {"id": "db-id", "type": "database"}}`,
+			expPath:        "/v1/databases/db-id",
+			respStatusCode: http.StatusNotFound,
+			respBody: `{
+				"object": "error",
+				"status": 404,
+				"code": "object_not_found",
+				"message": "Could not find database with ID: db-id."
+			}`,
+			expError: notion.ErrWebhookObjectDeleted,
+		},
+		{
+			name:     "unsupported entity type",
+			event:    `{"id": "event-id", "type": "view.created", "entity": {"id": "view-id", "type": "view"}}`,
+			expError: errors.New(`notion: unsupported webhook entity type "view"`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{
+				Transport: &mockRoundtripper{fn: func(r *http.Request) (*http.Response, error) {
+					if tt.expPath == "" {
+						t.Fatalf("unexpected request: %v %v", r.Method, r.URL.Path)
+					}
+					if r.URL.Path != tt.expPath {
+						t.Fatalf("path not equal (expected: %v, got: %v)", tt.expPath, r.URL.Path)
+					}
+
+					return &http.Response{
+						StatusCode: tt.respStatusCode,
+						Status:     http.StatusText(tt.respStatusCode),
+						Body:       ioutil.NopCloser(strings.NewReader(tt.respBody)),
+					}, nil
+				}},
+			}
+			client := notion.NewClient("secret-api-key", notion.WithHTTPClient(httpClient))
+
+			var event notion.WebhookEvent
+			if err := json.Unmarshal([]byte(tt.event), &event); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			obj, err := notion.EventObject(context.Background(), client, event)
+
+			if tt.expError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expError != nil && err == nil {
+				t.Fatalf("error not equal (expected: %v, got: nil)", tt.expError)
+			}
+			if tt.expError != nil && err != nil && tt.expError.Error() != err.Error() {
+				t.Fatalf("error not equal (expected: %v, got: %v)", tt.expError, err)
+			}
+
+			if diff := cmp.Diff(tt.expObject, obj); diff != "" {
+				t.Fatalf("object not equal (-exp, +got):\n%v", diff)
+			}
+		})
+	}
+}